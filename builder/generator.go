@@ -3,9 +3,14 @@ package builder
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"go/build/constraint"
+	"go/scanner"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/dchlong/querybuilder/domain"
 	"github.com/dchlong/querybuilder/generation"
@@ -14,56 +19,194 @@ import (
 	"golang.org/x/tools/imports"
 )
 
+// GenerateOptions configures the shape of the generated code
+type GenerateOptions struct {
+	FilterStorage domain.FilterStorage // How filter types store conditions; defaults to map
+	StringFilters bool                 // Also generate <Method>String variants parsing string input
+	BuildTags     []string             // Build constraints ANDed into a //go:build line
+	HeaderComment string               // Comment placed at the top of generated files, e.g. a license banner
+
+	// OrderByDirection generates OrderBy<Field>(dir) instead of OrderBy<Field>Asc/OrderBy<Field>Desc
+	OrderByDirection bool
+
+	// Operators are registered with the method factory, for fields tagged querybuilder:"ops=<suffix>"
+	Operators []generation.CustomOperator
+
+	// MethodSuffixes override the filter method suffixes of built-in operators, keyed by the
+	// operator's keyword, e.g. {"eq": "Equals", "gt": "GreaterThan"}
+	MethodSuffixes map[string]string
+
+	// MethodNameTemplate names filter methods from generation.MethodNameData, e.g.
+	// "Where{{.Field}}{{.OpName}}"; defaults to generation.DefaultMethodNameTemplate
+	MethodNameTemplate string
+}
+
 // Generator generates querybuilder code with clean architecture
 type Generator struct {
 	methodFactory *generation.MethodFactory
 	templates     *templates.QueryBuilderTemplates
+	options       GenerateOptions
 }
 
 // NewGenerator creates a new generator instance
 func NewGenerator() *Generator {
+	return NewGeneratorWithOptions(GenerateOptions{})
+}
+
+// NewGeneratorWithOptions creates a new generator instance with the given options
+func NewGeneratorWithOptions(options GenerateOptions) *Generator {
+	if options.FilterStorage == "" {
+		options.FilterStorage = domain.FilterStorageMap
+	}
+
+	methodFactory := generation.NewMethodFactory()
+	for _, op := range options.Operators {
+		methodFactory.RegisterOperator(op.Operator, op.Suffix, op.Kind)
+	}
+
 	return &Generator{
-		methodFactory: generation.NewMethodFactory(),
+		methodFactory: methodFactory,
 		templates:     templates.NewQueryBuilderTemplates(),
+		options:       options,
 	}
 }
 
 // GenerateCode generates querybuilder code for the given structs
 func (g *Generator) GenerateCode(ctx context.Context, structs []domain.Struct, packageName string) ([]byte, error) {
+	code, _, err := g.generateCode(structs, packageName)
+	return code, err
+}
+
+// generateCode generates the formatted code for structs, also returning the unformatted code
+// so that callers can save it when formatting fails
+func (g *Generator) generateCode(structs []domain.Struct, packageName string) ([]byte, []byte, error) {
 	if len(structs) == 0 {
-		return nil, repository.ErrNoStructsProvided
+		return nil, nil, repository.ErrNoStructsProvided
+	}
+
+	if !g.options.FilterStorage.IsValid() {
+		return nil, nil, fmt.Errorf("%w: %q", repository.ErrInvalidFilterStorage, g.options.FilterStorage)
+	}
+
+	if _, err := g.buildConstraint(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := g.checkCustomOperators(structs); err != nil {
+		return nil, nil, err
+	}
+
+	if err := g.configureMethodNames(); err != nil {
+		return nil, nil, err
 	}
 
 	templateData := g.buildTemplateData(structs)
+	if err := checkFilterMethodNames(templateData); err != nil {
+		return nil, nil, err
+	}
 
 	var buf bytes.Buffer
 	if err := g.templates.Main.Execute(&buf, templateData); err != nil {
-		return nil, fmt.Errorf("%w: %w", repository.ErrTemplateExecution, err)
+		return nil, nil, fmt.Errorf("%w: %w", repository.ErrTemplateExecution, err)
 	}
 
 	// Add package header
-	result := g.buildPackageHeader(packageName) + buf.String()
+	result := g.buildPackageHeader(packageName, collectImports(structs)) + buf.String()
 
 	// Format the generated code
-	formatted, err := imports.Process("", []byte(result), nil)
+	formatted, err := formatCode([]byte(result))
 	if err != nil {
 		if os.Getenv("DEBUG_IMPORTS_ERRORS") == "1" {
-			fmt.Printf("Warning: failed to format generated code: %v\n", err)
-			return []byte(result), nil
+			fmt.Printf("Warning: %v\n", err)
+			return []byte(result), []byte(result), nil
 		}
-		return nil, fmt.Errorf("%w: %w", repository.ErrCodeFormatting, err)
+		return nil, []byte(result), err
 	}
 
-	return formatted, nil
+	return formatted, []byte(result), nil
 }
 
 // GenerateFile generates querybuilder code and writes it to a file
 func (g *Generator) GenerateFile(ctx context.Context, structs []domain.Struct, packageName, outputPath string) error {
-	code, err := g.GenerateCode(ctx, structs, packageName)
+	code, unformatted, err := g.generateCode(structs, packageName)
+	if err != nil {
+		return fmt.Errorf("failed to generate code: %w", g.writeDebugFile(outputPath, unformatted, err))
+	}
+
+	return g.writeFile(outputPath, code)
+}
+
+// GenerateFacadeCode generates a companion file for packageName that re-exports the querybuilder
+// types and constructors generated into the package at importPath (typically an internal package)
+func (g *Generator) GenerateFacadeCode(ctx context.Context, structs []domain.Struct, packageName, importPath string) ([]byte, error) {
+	if len(structs) == 0 {
+		return nil, repository.ErrNoStructsProvided
+	}
+
+	if _, err := g.buildConstraint(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := g.templates.Facade.Execute(&buf, g.buildTemplateData(structs)); err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrTemplateExecution, err)
+	}
+
+	result := g.buildFacadeHeader(packageName, importPath) + buf.String()
+	return formatCode([]byte(result))
+}
+
+// GenerateFacadeFile generates the re-exporting companion file and writes it to outputPath
+func (g *Generator) GenerateFacadeFile(ctx context.Context, structs []domain.Struct, packageName, importPath, outputPath string) error {
+	code, err := g.GenerateFacadeCode(ctx, structs, packageName, importPath)
 	if err != nil {
-		return fmt.Errorf("failed to generate code: %w", err)
+		return fmt.Errorf("failed to generate facade code: %w", err)
+	}
+
+	return g.writeFile(outputPath, code)
+}
+
+// writeDebugFile saves the unformatted code next to outputPath as <output>.debug when err is a
+// formatting error, and returns err mentioning the saved file
+func (g *Generator) writeDebugFile(outputPath string, unformatted []byte, err error) error {
+	if !errors.Is(err, repository.ErrCodeFormatting) || unformatted == nil {
+		return err
+	}
+
+	debugPath := outputPath + ".debug"
+	if mkdirErr := os.MkdirAll(filepath.Dir(debugPath), 0755); mkdirErr != nil {
+		return err
+	}
+	if writeErr := os.WriteFile(debugPath, unformatted, 0644); writeErr != nil {
+		return err
+	}
+	return fmt.Errorf("%w; unformatted code written to %s", err, debugPath)
+}
+
+// formatCode formats generated code and adds missing imports. Syntax errors are reported with
+// the position and text of the first offending line of src.
+func formatCode(src []byte) ([]byte, error) {
+	formatted, err := imports.Process("", src, nil)
+	if err == nil {
+		return formatted, nil
+	}
+
+	var errorList scanner.ErrorList
+	if !errors.As(err, &errorList) || len(errorList) == 0 {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCodeFormatting, err)
 	}
 
+	first := errorList[0]
+	lines := strings.Split(string(src), "\n")
+	if first.Pos.Line < 1 || first.Pos.Line > len(lines) {
+		return nil, fmt.Errorf("%w: line %d, column %d: %s", repository.ErrCodeFormatting, first.Pos.Line, first.Pos.Column, first.Msg)
+	}
+	return nil, fmt.Errorf("%w: line %d, column %d: %s: %q", repository.ErrCodeFormatting,
+		first.Pos.Line, first.Pos.Column, first.Msg, strings.TrimSpace(lines[first.Pos.Line-1]))
+}
+
+// writeFile writes generated code, creating the output directory if needed
+func (g *Generator) writeFile(outputPath string, code []byte) error {
 	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
 		return fmt.Errorf("%w for %s: %w", repository.ErrCreateOutputDir, outputPath, err)
 	}
@@ -81,14 +224,67 @@ func (g *Generator) GenerateFile(ctx context.Context, structs []domain.Struct, p
 	return nil
 }
 
+// checkCustomOperators checks that the operators fields opt into are registered
+func (g *Generator) checkCustomOperators(structs []domain.Struct) error {
+	for _, s := range structs {
+		for _, field := range s.FilterableFields() {
+			for _, keyword := range field.CustomOperators {
+				if _, ok := g.methodFactory.RegisteredOperator(keyword); !ok {
+					return fmt.Errorf("%s.%s: %w: %q is not registered", s.Name, field.Name, repository.ErrUnknownOperator, keyword)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// configureMethodNames applies the method suffix and name template options to the method factory
+func (g *Generator) configureMethodNames() error {
+	suffixes := make(map[repository.Operator]string, len(g.options.MethodSuffixes))
+	for keyword, suffix := range g.options.MethodSuffixes {
+		op, ok := domain.ParseOperatorKeyword(keyword)
+		if !ok {
+			return fmt.Errorf("%w: %w: %q", repository.ErrInvalidMethodName, repository.ErrUnknownOperator, keyword)
+		}
+		suffixes[op] = suffix
+	}
+	return g.methodFactory.SetMethodNames(g.options.MethodNameTemplate, suffixes)
+}
+
+// filterTypeMethods are the methods every generated filter type has besides the per-field ones
+var filterTypeMethods = []string{"ListFilters", "Clone", "Merge", "WhereRaw", "WhereColumn", "DebugSQL", "Err"}
+
+// checkFilterMethodNames checks that no two methods of a generated filter type share a name,
+// which custom method names can cause, e.g. a template without {{.Field}}
+func checkFilterMethodNames(templateData map[string]interface{}) error {
+	for _, templateStruct := range templateData["Structs"].([]map[string]interface{}) {
+		seen := make(map[string]bool)
+		for _, name := range filterTypeMethods {
+			seen[name] = true
+		}
+
+		methods := templateStruct["FilterMethods"].([]domain.Method)
+		methods = append(slices.Clip(methods), templateStruct["StringFilterMethods"].([]domain.Method)...)
+		for _, method := range methods {
+			if seen[method.Name] {
+				return fmt.Errorf("%w: %sFilters has more than one %s method", repository.ErrInvalidMethodName, templateStruct["Name"], method.Name)
+			}
+			seen[method.Name] = true
+		}
+	}
+	return nil
+}
+
 // buildTemplateData builds the data structure for template execution
 func (g *Generator) buildTemplateData(structs []domain.Struct) map[string]interface{} {
 	var templateStructs []map[string]interface{}
 
 	for _, s := range structs {
 		templateStruct := map[string]interface{}{
-			"Name":   s.Name,
-			"Fields": s.Fields,
+			"Name":       s.Name,
+			"EntityType": s.EntityTypeName(),
+			"Fields":     s.ColumnFields(),
+			"Source":     s.Source,
 		}
 
 		// Generate filter methods
@@ -97,48 +293,248 @@ func (g *Generator) buildTemplateData(structs []domain.Struct) map[string]interf
 			for _, op := range field.SupportedOperators() {
 				method := g.methodFactory.CreateFilterMethod(s.Name, field, op)
 				filterMethods = append(filterMethods, method)
+
+				// Pointer fields can also be compared with a plain value
+				if op == repository.OperatorEqual {
+					if method, ok := g.methodFactory.CreateValueFilterMethod(s.Name, field); ok {
+						filterMethods = append(filterMethods, method)
+					}
+				}
+
+				// Contains, StartsWith and EndsWith build escaped LIKE patterns
+				if op == repository.OperatorLike {
+					filterMethods = append(filterMethods, g.methodFactory.CreatePatternFilterMethods(s.Name, field)...)
+				}
+			}
+
+			for _, keyword := range field.CustomOperators {
+				op, _ := g.methodFactory.RegisteredOperator(keyword)
+				filterMethods = append(filterMethods, g.methodFactory.CreateFilterMethod(s.Name, field, op))
 			}
+
+			filterMethods = append(filterMethods, g.methodFactory.CreateClearFilterMethod(s.Name, field))
 		}
 		templateStruct["FilterMethods"] = filterMethods
 
+		// Generate string-parsing variants of the comparison methods
+		var stringFilterMethods []domain.Method
+		if g.options.StringFilters {
+			for _, field := range s.FilterableFields() {
+				for _, op := range field.SupportedOperators() {
+					if method, ok := g.methodFactory.CreateStringFilterMethod(s.Name, field, op); ok {
+						stringFilterMethods = append(stringFilterMethods, method)
+					}
+				}
+			}
+		}
+		templateStruct["StringFilterMethods"] = stringFilterMethods
+
 		// Generate updater methods
 		var updaterMethods []domain.Method
-		for _, field := range s.Fields {
+		for _, field := range s.UpdatableFields() {
 			method := g.methodFactory.CreateUpdaterMethod(s.Name, field)
 			updaterMethods = append(updaterMethods, method)
+			if method, ok := g.methodFactory.CreateValueUpdaterMethod(s.Name, field); ok {
+				updaterMethods = append(updaterMethods, method)
+			}
+		}
+		if method, ok := g.methodFactory.CreateFromStructMethod(s); ok {
+			updaterMethods = append(updaterMethods, method)
 		}
 		templateStruct["UpdaterMethods"] = updaterMethods
+		if method, ok := g.methodFactory.CreateValidateMethod(s); ok {
+			templateStruct["ValidateMethod"] = method
+		}
 
 		// Generate order methods
 		var orderMethods []domain.Method
 		for _, field := range s.FilterableFields() {
+			if !field.IsSortable() {
+				continue
+			}
+			if g.options.OrderByDirection {
+				orderMethods = append(orderMethods, g.methodFactory.CreateOrderMethod(s.Name, field, ""))
+				continue
+			}
+
 			// Ascending order method
-			ascMethod := g.methodFactory.CreateOrderMethod(s.Name, field, true)
+			ascMethod := g.methodFactory.CreateOrderMethod(s.Name, field, repository.Asc)
 			orderMethods = append(orderMethods, ascMethod)
 
 			// Descending order method
-			descMethod := g.methodFactory.CreateOrderMethod(s.Name, field, false)
+			descMethod := g.methodFactory.CreateOrderMethod(s.Name, field, repository.Desc)
 			orderMethods = append(orderMethods, descMethod)
 		}
 		templateStruct["OrderMethods"] = orderMethods
 
+		// Generate group by options
+		var groupByMethods []domain.Method
+		for _, field := range s.FilterableFields() {
+			if !field.IsSortable() {
+				continue
+			}
+			groupByMethods = append(groupByMethods, g.methodFactory.CreateGroupByMethod(s.Name, field))
+		}
+		templateStruct["GroupByMethods"] = groupByMethods
+
+		// Generate typed preload options for associations
+		var preloadMethods []domain.Method
+		for _, field := range s.AssociationFields() {
+			preloadMethods = append(preloadMethods, g.methodFactory.CreatePreloadMethod(s.Name, field))
+		}
+		templateStruct["PreloadMethods"] = preloadMethods
+
+		// Collect time columns for the count-by-month helper
+		var timeFields []domain.Field
+		for _, field := range s.Fields {
+			if field.Type == domain.FieldTypeTime {
+				timeFields = append(timeFields, field)
+			}
+		}
+		templateStruct["TimeFields"] = timeFields
+
+		// Collect numeric columns for the aggregate helpers
+		var numericFields []domain.Field
+		for _, field := range s.Fields {
+			if field.Type == domain.FieldTypeNumeric {
+				numericFields = append(numericFields, field)
+			}
+		}
+		templateStruct["NumericFields"] = numericFields
+
+		if method, ok := g.methodFactory.CreateFindByPrimaryKeyFunction(s); ok {
+			templateStruct["FindByPrimaryKey"] = method
+		}
+
+		// One lookup per unique index; indexes over the same columns share it
+		var findByUniqueIndex []domain.Method
+		for _, index := range s.UniqueIndexes() {
+			method, ok := g.methodFactory.CreateFindByUniqueIndexFunction(s, index)
+			if ok && !slices.ContainsFunc(findByUniqueIndex, func(m domain.Method) bool { return m.Name == method.Name }) {
+				findByUniqueIndex = append(findByUniqueIndex, method)
+			}
+		}
+		templateStruct["FindByUniqueIndex"] = findByUniqueIndex
+
 		templateStructs = append(templateStructs, templateStruct)
 	}
 
 	return map[string]interface{}{
-		"Structs": templateStructs,
+		"Structs":       templateStructs,
+		"FilterStorage": g.options.FilterStorage,
+		"StringFilters": g.options.StringFilters,
 	}
 }
 
+// generatedNotice marks files as generated for tools and reviewers (see `go help generate`)
+const generatedNotice = "// Code generated by querybuilder. DO NOT EDIT.\n\n"
+
+// buildPreamble returns everything above the package clause: the header comment,
+// the build constraint and the generated-code notice
+func (g *Generator) buildPreamble() string {
+	var preamble strings.Builder
+
+	if header := strings.TrimSpace(g.options.HeaderComment); header != "" {
+		for _, line := range strings.Split(header, "\n") {
+			line = strings.TrimRight(line, " \t\r")
+			switch {
+			case strings.HasPrefix(line, "//"):
+				preamble.WriteString(line)
+			case line == "":
+				preamble.WriteString("//")
+			default:
+				preamble.WriteString("// " + line)
+			}
+			preamble.WriteString("\n")
+		}
+		preamble.WriteString("\n")
+	}
+
+	// GenerateCode validated the constraint already
+	if buildLine, _ := g.buildConstraint(); buildLine != "" {
+		preamble.WriteString(buildLine + "\n\n")
+	}
+
+	preamble.WriteString(generatedNotice)
+	return preamble.String()
+}
+
+// buildConstraint returns the //go:build line for the configured build tags, or "" without tags.
+// Tags are ANDed together; a tag that is itself an expression is parenthesized.
+func (g *Generator) buildConstraint() (string, error) {
+	var terms []string
+	for _, tag := range g.options.BuildTags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if _, err := constraint.Parse("//go:build " + tag); err != nil {
+			return "", fmt.Errorf("%w %q: %w", repository.ErrInvalidBuildTag, tag, err)
+		}
+		terms = append(terms, tag)
+	}
+
+	switch len(terms) {
+	case 0:
+		return "", nil
+	case 1:
+		return "//go:build " + terms[0], nil
+	}
+
+	for i, term := range terms {
+		if strings.ContainsAny(term, " &|") {
+			terms[i] = "(" + term + ")"
+		}
+	}
+	return "//go:build " + strings.Join(terms, " && "), nil
+}
+
 // buildPackageHeader creates the package declaration and imports
-func (g *Generator) buildPackageHeader(packageName string) string {
-	return fmt.Sprintf(`// Code generated by querybuilder. DO NOT EDIT.
+func (g *Generator) buildPackageHeader(packageName string, importPaths []string) string {
+	// Standard library packages join the first group; their first path element has no dot
+	var stdImports, moduleImports strings.Builder
+	for _, path := range importPaths {
+		if first, _, _ := strings.Cut(path, "/"); strings.Contains(first, ".") {
+			fmt.Fprintf(&moduleImports, "\t%q\n", path)
+		} else {
+			fmt.Fprintf(&stdImports, "\t%q\n", path)
+		}
+	}
 
-package %s
+	// Unused imports are removed when the code is formatted
+	return g.buildPreamble() + fmt.Sprintf(`package %s
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"unicode/utf8"
+%s
 	"github.com/dchlong/querybuilder/repository"
+%s)
+
+`, packageName, stdImports.String(), moduleImports.String())
+}
+
+// collectImports returns the sorted import paths of the packages the field types of structs refer to
+func collectImports(structs []domain.Struct) []string {
+	var imports []string
+	for _, s := range structs {
+		imports = append(imports, s.Imports()...)
+	}
+	slices.Sort(imports)
+	return slices.Compact(imports)
+}
+
+// buildFacadeHeader creates the package declaration and the import of the package being re-exported
+func (g *Generator) buildFacadeHeader(packageName, importPath string) string {
+	return g.buildPreamble() + fmt.Sprintf(`package %s
+
+import (
+	internal %q
 )
 
-`, packageName)
+`, packageName, importPath)
 }
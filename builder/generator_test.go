@@ -2,6 +2,9 @@ package builder
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -9,6 +12,7 @@ import (
 	"time"
 
 	"github.com/dchlong/querybuilder/domain"
+	"github.com/dchlong/querybuilder/repository"
 )
 
 func TestNewGenerator(t *testing.T) {
@@ -173,6 +177,34 @@ func TestGenerator_GenerateCode_MultipleStructs(t *testing.T) {
 	}
 }
 
+func TestGenerator_GenerateCode_BuiltStruct(t *testing.T) {
+	account, err := domain.NewStruct("Account").
+		AddField("ID", "id", domain.FieldTypeNumeric).
+		AddField("Email", "email_address", domain.FieldTypeString).
+		AddField("CreatedAt", "created_at", domain.FieldTypeTime).
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	code, err := NewGenerator().GenerateCode(context.Background(), []domain.Struct{account}, "models")
+	if err != nil {
+		t.Fatalf("GenerateCode failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		`"time"`,
+		`AccountDBSchemaField("email_address")`,
+		"func (a *AccountFilters) EmailLike(email string) *AccountFilters",
+		"func (a *AccountFilters) CreatedAtGte(createdAt time.Time) *AccountFilters",
+		"func (a *AccountUpdater) SetID(iD int64) *AccountUpdater",
+	} {
+		if !strings.Contains(string(code), expected) {
+			t.Errorf("Generated code missing %q", expected)
+		}
+	}
+}
+
 func TestGenerator_GenerateFile(t *testing.T) {
 	generator := NewGenerator()
 	ctx := context.Background()
@@ -254,6 +286,42 @@ func TestGenerator_GenerateFile_DirectoryCreation(t *testing.T) {
 	}
 }
 
+func TestGenerator_GenerateFile_FormattingError(t *testing.T) {
+	generator := NewGenerator()
+	outputPath := filepath.Join(t.TempDir(), "generated.go")
+
+	// A type name with a space produces code that doesn't parse
+	testStruct := domain.Struct{
+		Name: "Test",
+		Fields: []domain.Field{
+			{Name: "Weird", DBName: "weird", TypeName: "weird type", Type: domain.FieldTypeString},
+		},
+	}
+
+	err := generator.GenerateFile(context.Background(), []domain.Struct{testStruct}, "test", outputPath)
+	if !errors.Is(err, repository.ErrCodeFormatting) {
+		t.Fatalf("Expected ErrCodeFormatting, got %v", err)
+	}
+
+	debugPath := outputPath + ".debug"
+	for _, part := range []string{"line ", ", column ", "weird type", debugPath} {
+		if !strings.Contains(err.Error(), part) {
+			t.Errorf("Error %q should contain %q", err, part)
+		}
+	}
+
+	unformatted, readErr := os.ReadFile(debugPath)
+	if readErr != nil {
+		t.Fatalf("Unformatted code should be written to %s: %v", debugPath, readErr)
+	}
+	if !strings.Contains(string(unformatted), "WeirdEq(weird weird type)") {
+		t.Error("Debug file should hold the unformatted code")
+	}
+	if _, statErr := os.Stat(outputPath); !os.IsNotExist(statErr) {
+		t.Error("Output file should not be written when formatting fails")
+	}
+}
+
 func TestGenerator_buildTemplateData(t *testing.T) {
 	generator := NewGenerator()
 
@@ -328,9 +396,11 @@ func TestGenerator_buildTemplateData(t *testing.T) {
 		t.Fatal("UpdaterMethods is not the expected type")
 	}
 
-	// Should have updater methods for all fields (including non-filterable)
-	if len(updaterMethods) != 3 {
-		t.Errorf("Expected 3 updater methods, got %d", len(updaterMethods))
+	// Should have setters for all fields (including non-filterable), then FromStruct
+	if len(updaterMethods) != 4 {
+		t.Errorf("Expected 4 updater methods, got %d", len(updaterMethods))
+	} else if updaterMethods[3].Name != "FromStruct" {
+		t.Errorf("Expected FromStruct last, got %s", updaterMethods[3].Name)
 	}
 
 	orderMethods, ok := productStruct["OrderMethods"].([]domain.Method)
@@ -344,15 +414,499 @@ func TestGenerator_buildTemplateData(t *testing.T) {
 	}
 }
 
+func TestGenerator_GenerateCode_CountByMonth(t *testing.T) {
+	generator := NewGenerator()
+	ctx := context.Background()
+
+	withTime := domain.Struct{
+		Name: "Order",
+		Fields: []domain.Field{
+			{Name: "ID", DBName: "id", TypeName: "int64", Type: domain.FieldTypeNumeric},
+			{Name: "PlacedAt", DBName: "placed_at", TypeName: "time.Time", Type: domain.FieldTypeTime},
+		},
+	}
+	withoutTime := domain.Struct{
+		Name: "Tag",
+		Fields: []domain.Field{
+			{Name: "Label", DBName: "label", TypeName: "string", Type: domain.FieldTypeString},
+		},
+	}
+
+	code, err := generator.GenerateCode(ctx, []domain.Struct{withTime, withoutTime}, "models")
+	if err != nil {
+		t.Fatalf("GenerateCode failed: %v", err)
+	}
+	codeStr := string(code)
+
+	expected := "func OrderCountByMonth(ctx context.Context, repo repository.MonthlyCounter[*OrderFilters], field OrderDBSchemaField, filter *OrderFilters) (map[string]int64, error)"
+	if !strings.Contains(codeStr, expected) {
+		t.Errorf("Generated code missing count-by-month helper: %s", expected)
+	}
+	if !strings.Contains(codeStr, "OrderDBSchema.PlacedAt") {
+		t.Error("Count-by-month doc should list the time columns")
+	}
+	if strings.Contains(codeStr, "TagCountByMonth") {
+		t.Error("Structs without time columns should not get a count-by-month helper")
+	}
+
+	// Distinct counts apply to any column
+	expected = "func TagCountDistinct(ctx context.Context, repo repository.DistinctCounter[*TagFilters], field TagDBSchemaField, filter *TagFilters) (int64, error)"
+	if !strings.Contains(codeStr, expected) {
+		t.Errorf("Generated code missing count-distinct helper: %s", expected)
+	}
+
+	// Numbered pages are typed by the entity
+	for _, expected := range []string{
+		"type TagPage = repository.Page[Tag]",
+		"func TagFindPage(ctx context.Context, repo repository.PageCounter[Tag, *TagFilters], filter *TagFilters, options ...repository.OptionFunc) (*TagPage, error)",
+		"func (o *TagOptions) Paginate(page, pageSize int) *TagOptions",
+	} {
+		if !strings.Contains(codeStr, expected) {
+			t.Errorf("Generated code missing page helper: %s", expected)
+		}
+	}
+}
+
+func TestGenerator_GenerateCode_Aggregates(t *testing.T) {
+	generator := NewGenerator()
+	ctx := context.Background()
+
+	withNumbers := domain.Struct{
+		Name: "Order",
+		Fields: []domain.Field{
+			{Name: "ID", DBName: "id", TypeName: "int64", Type: domain.FieldTypeNumeric},
+			{Name: "Total", DBName: "total", TypeName: "float64", Type: domain.FieldTypeNumeric},
+		},
+	}
+	withoutNumbers := domain.Struct{
+		Name: "Tag",
+		Fields: []domain.Field{
+			{Name: "Label", DBName: "label", TypeName: "string", Type: domain.FieldTypeString},
+		},
+	}
+
+	code, err := generator.GenerateCode(ctx, []domain.Struct{withNumbers, withoutNumbers}, "models")
+	if err != nil {
+		t.Fatalf("GenerateCode failed: %v", err)
+	}
+	codeStr := string(code)
+
+	for _, aggregate := range []string{"Sum", "Avg", "Min", "Max"} {
+		expected := "func Order" + aggregate + "(ctx context.Context, repo repository.Aggregator[*OrderFilters], field OrderDBSchemaField, filter *OrderFilters) (float64, bool, error)"
+		if !strings.Contains(codeStr, expected) {
+			t.Errorf("Generated code missing aggregate helper: %s", expected)
+		}
+		if !strings.Contains(codeStr, "return repo."+aggregate+"(ctx, filter, string(field))") {
+			t.Errorf("Aggregate helper %s should delegate to the repository", aggregate)
+		}
+		if strings.Contains(codeStr, "func Tag"+aggregate) {
+			t.Errorf("Structs without numeric columns should not get Tag%s", aggregate)
+		}
+	}
+	if !strings.Contains(codeStr, "OrderDBSchema.ID, OrderDBSchema.Total") {
+		t.Error("Aggregate docs should list the numeric columns")
+	}
+}
+
+func TestGenerator_GenerateCode_Select(t *testing.T) {
+	generator := NewGenerator()
+
+	structs := []domain.Struct{
+		{
+			Name: "Order",
+			Fields: []domain.Field{
+				{Name: "ID", DBName: "id", TypeName: "int64", Type: domain.FieldTypeNumeric},
+				{Name: "Number", DBName: "number", TypeName: "string", Type: domain.FieldTypeString},
+			},
+		},
+	}
+
+	code, err := generator.GenerateCode(context.Background(), structs, "models")
+	if err != nil {
+		t.Fatalf("GenerateCode failed: %v", err)
+	}
+	codeStr := string(code)
+
+	for _, expected := range []string{
+		"func (o *OrderOptions) Select(fields ...OrderDBSchemaField) *OrderOptions",
+		"options.SelectFields = append(options.SelectFields, string(field))",
+	} {
+		if !strings.Contains(codeStr, expected) {
+			t.Errorf("Generated code missing typed projection option: %s", expected)
+		}
+	}
+}
+
+func TestGenerator_GenerateCode_OrderByDirection(t *testing.T) {
+	structs := []domain.Struct{
+		{
+			Name: "Order",
+			Fields: []domain.Field{
+				{Name: "ID", DBName: "id", TypeName: "int64", Type: domain.FieldTypeNumeric},
+				{Name: "Number", DBName: "number", TypeName: "string", Type: domain.FieldTypeString},
+			},
+		},
+	}
+
+	genericOrderBy := "func (o *OrderOptions) OrderBy(field OrderDBSchemaField, dir repository.SortDirection) *OrderOptions"
+
+	code, err := NewGenerator().GenerateCode(context.Background(), structs, "models")
+	if err != nil {
+		t.Fatalf("GenerateCode failed: %v", err)
+	}
+	codeStr := string(code)
+	for _, expected := range []string{genericOrderBy, ") OrderByNumberAsc() *OrderOptions", ") OrderByNumberDesc() *OrderOptions"} {
+		if !strings.Contains(codeStr, expected) {
+			t.Errorf("Generated code missing: %s", expected)
+		}
+	}
+
+	code, err = NewGeneratorWithOptions(GenerateOptions{OrderByDirection: true}).GenerateCode(context.Background(), structs, "models")
+	if err != nil {
+		t.Fatalf("GenerateCode failed: %v", err)
+	}
+	codeStr = string(code)
+	for _, expected := range []string{genericOrderBy, ") OrderByNumber(dir repository.SortDirection) *OrderOptions"} {
+		if !strings.Contains(codeStr, expected) {
+			t.Errorf("Generated code missing: %s", expected)
+		}
+	}
+	if strings.Contains(codeStr, "OrderByNumberAsc") {
+		t.Error("OrderByDirection should replace the Asc/Desc methods")
+	}
+}
+
+func TestGenerator_GenerateCode_MethodNames(t *testing.T) {
+	structs := []domain.Struct{
+		{
+			Name: "Order",
+			Fields: []domain.Field{
+				{Name: "ID", DBName: "id", TypeName: "int64", Type: domain.FieldTypeNumeric},
+				{Name: "Number", DBName: "number", TypeName: "string", Type: domain.FieldTypeString},
+			},
+		},
+	}
+	ctx := context.Background()
+
+	code, err := NewGeneratorWithOptions(GenerateOptions{
+		MethodSuffixes:     map[string]string{"eq": "Equals"},
+		MethodNameTemplate: "Where{{.Field}}{{.Suffix}}",
+		StringFilters:      true,
+	}).GenerateCode(ctx, structs, "models")
+	if err != nil {
+		t.Fatalf("GenerateCode failed: %v", err)
+	}
+	codeStr := string(code)
+	for _, expected := range []string{
+		") WhereIDEquals(iD int64) *OrderFilters",
+		") WhereIDGt(iD int64) *OrderFilters",
+		") WhereIDEqualsString(s string) *OrderFilters",
+		") WhereNumberContains(number string) *OrderFilters",
+		") WhereNumberStartsWith(number string) *OrderFilters",
+		") ClearNumber() *OrderFilters",
+	} {
+		if !strings.Contains(codeStr, expected) {
+			t.Errorf("Generated code missing: %s", expected)
+		}
+	}
+
+	code, err = NewGeneratorWithOptions(GenerateOptions{
+		MethodNameTemplate: "{{.Field}}{{.OpName}}",
+	}).GenerateCode(ctx, structs, "models")
+	if err != nil {
+		t.Fatalf("GenerateCode failed: %v", err)
+	}
+	codeStr = string(code)
+	for _, expected := range []string{
+		") NumberEqual(number string) *OrderFilters",
+		") NumberEndsWith(number string) *OrderFilters",
+	} {
+		if !strings.Contains(codeStr, expected) {
+			t.Errorf("Generated code missing: %s", expected)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		options GenerateOptions
+	}{
+		{"unknown keyword", GenerateOptions{MethodSuffixes: map[string]string{"equal": "Equals"}}},
+		{"invalid name", GenerateOptions{MethodNameTemplate: "{{.Field}}.{{.Suffix}}"}},
+		{"same name for every operator", GenerateOptions{MethodNameTemplate: "Where{{.Field}}"}},
+		{"collides with pattern method", GenerateOptions{MethodSuffixes: map[string]string{"like": "StartsWith"}}},
+		{"template collides with pattern method", GenerateOptions{MethodNameTemplate: `{{.Field}}{{if eq .OpName "Like"}}Contains{{else}}{{.OpName}}{{end}}`}},
+		{"invalid pattern method name", GenerateOptions{MethodNameTemplate: `{{.Field}}{{if eq .OpName "EndsWith"}}-{{end}}{{.Suffix}}`}},
+		{"collides with fixed method", GenerateOptions{MethodNameTemplate: "{{.Suffix}}", MethodSuffixes: map[string]string{"eq": "Merge"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGeneratorWithOptions(tt.options).GenerateCode(ctx, structs, "models")
+			if !errors.Is(err, repository.ErrInvalidMethodName) {
+				t.Errorf("GenerateCode error = %v, want ErrInvalidMethodName", err)
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateCode_UpdateWhere(t *testing.T) {
+	generator := NewGenerator()
+
+	structs := []domain.Struct{
+		{
+			Name: "Tag",
+			Fields: []domain.Field{
+				{Name: "Label", DBName: "label", TypeName: "string", Type: domain.FieldTypeString},
+			},
+		},
+	}
+
+	code, err := generator.GenerateCode(context.Background(), structs, "models")
+	if err != nil {
+		t.Fatalf("GenerateCode failed: %v", err)
+	}
+
+	expected := "func TagUpdateWhere(ctx context.Context, repo repository.BatchUpdater[*TagFilters, *TagUpdater], where func(*TagFilters), set func(*TagUpdater)) (int64, error)"
+	if !strings.Contains(string(code), expected) {
+		t.Errorf("Generated code missing update-where helper: %s", expected)
+	}
+}
+
+func TestGenerator_GenerateCode_FilterStorage(t *testing.T) {
+	structs := []domain.Struct{
+		{
+			Name: "Tag",
+			Fields: []domain.Field{
+				{Name: "Label", DBName: "label", TypeName: "string", Type: domain.FieldTypeString},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		storage    domain.FilterStorage
+		expected   []string
+		unexpected []string
+	}{
+		{
+			name:    "default is map",
+			storage: "",
+			expected: []string{
+				"filters map[TagDBSchemaField][]*repository.Filter",
+				"order   []*repository.Filter",
+				"return slices.Clip(f.order)",
+				"func (f *TagFilters) Merge(other *TagFilters) *TagFilters",
+			},
+		},
+		{
+			name:     "map",
+			storage:  domain.FilterStorageMap,
+			expected: []string{"filters map[TagDBSchemaField][]*repository.Filter"},
+		},
+		{
+			name:    "slice",
+			storage: domain.FilterStorageSlice,
+			expected: []string{
+				"filters []*repository.Filter",
+				"func (f *TagFilters) addFilter(_ TagDBSchemaField, filter *repository.Filter) *TagFilters",
+				"return t.addFilter(TagDBSchema.Label,",
+				"f.filters = append(f.filters, other.filters...)",
+				"return slices.Clip(f.filters)",
+				"if filter.Field != string(field) {",
+				"return t.clearField(TagDBSchema.Label)",
+			},
+			unexpected: []string{"order   []*repository.Filter", "map[TagDBSchemaField]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := NewGeneratorWithOptions(GenerateOptions{FilterStorage: tt.storage})
+			code, err := generator.GenerateCode(context.Background(), structs, "models")
+			if err != nil {
+				t.Fatalf("GenerateCode failed: %v", err)
+			}
+			codeStr := string(code)
+
+			for _, expected := range tt.expected {
+				if !strings.Contains(codeStr, expected) {
+					t.Errorf("Generated code missing: %s", expected)
+				}
+			}
+			for _, unexpected := range tt.unexpected {
+				if strings.Contains(codeStr, unexpected) {
+					t.Errorf("Generated code should not contain: %s", unexpected)
+				}
+			}
+		})
+	}
+
+	generator := NewGeneratorWithOptions(GenerateOptions{FilterStorage: "list"})
+	if _, err := generator.GenerateCode(context.Background(), structs, "models"); !errors.Is(err, repository.ErrInvalidFilterStorage) {
+		t.Errorf("Expected ErrInvalidFilterStorage, got %v", err)
+	}
+}
+
+func TestGenerator_GenerateCode_StringFilters(t *testing.T) {
+	structs := []domain.Struct{
+		{
+			Name: "Tag",
+			Fields: []domain.Field{
+				{Name: "Label", DBName: "label", TypeName: "string", Type: domain.FieldTypeString},
+				{Name: "Uses", DBName: "uses", TypeName: "int", Type: domain.FieldTypeNumeric},
+			},
+		},
+	}
+
+	code, err := NewGenerator().GenerateCode(context.Background(), structs, "models")
+	if err != nil {
+		t.Fatalf("GenerateCode failed: %v", err)
+	}
+	if strings.Contains(string(code), "EqString") || strings.Contains(string(code), "errs") {
+		t.Error("String filters should only be generated when enabled")
+	}
+
+	for _, storage := range []domain.FilterStorage{domain.FilterStorageMap, domain.FilterStorageSlice} {
+		generator := NewGeneratorWithOptions(GenerateOptions{FilterStorage: storage, StringFilters: true})
+		code, err := generator.GenerateCode(context.Background(), structs, "models")
+		if err != nil {
+			t.Fatalf("GenerateCode with %s storage failed: %v", storage, err)
+		}
+		codeStr := string(code)
+
+		for _, expected := range []string{
+			"errs",
+			"func (f *TagFilters) Err() error",
+			"return errors.Join(f.errs...)",
+			"func (t *TagFilters) UsesGtString(s string) *TagFilters",
+			"func (t *TagFilters) LabelEqString(s string) *TagFilters",
+			`"strconv"`,
+		} {
+			if !strings.Contains(codeStr, expected) {
+				t.Errorf("Generated %s storage code missing: %s", storage, expected)
+			}
+		}
+	}
+}
+
+func TestGenerator_GenerateFacadeCode(t *testing.T) {
+	generator := NewGenerator()
+	ctx := context.Background()
+
+	structs := []domain.Struct{
+		{
+			Name: "Order",
+			Fields: []domain.Field{
+				{Name: "ID", DBName: "id", TypeName: "int64", Type: domain.FieldTypeNumeric},
+				{Name: "PlacedAt", DBName: "placed_at", TypeName: "time.Time", Type: domain.FieldTypeTime},
+			},
+		},
+	}
+
+	code, err := generator.GenerateFacadeCode(ctx, structs, "models", "example.com/app/internal/models")
+	if err != nil {
+		t.Fatalf("GenerateFacadeCode failed: %v", err)
+	}
+	codeStr := string(code)
+
+	for _, expected := range []string{
+		"package models",
+		`internal "example.com/app/internal/models"`,
+		"type OrderFilters = internal.OrderFilters",
+		"func NewOrderFilters() *OrderFilters",
+		"return internal.NewOrderFilters()",
+		"var OrderDBSchema = internal.OrderDBSchema",
+		"var OrderColumnToField = internal.OrderColumnToField",
+		"var OrderColumnTypes = internal.OrderColumnTypes",
+		"var OrderUpdateWhere = internal.OrderUpdateWhere",
+		"var OrderCountByMonth = internal.OrderCountByMonth",
+		"var OrderSum = internal.OrderSum",
+		"type OrderPage = internal.OrderPage",
+		"var OrderFindPage = internal.OrderFindPage",
+	} {
+		if !strings.Contains(codeStr, expected) {
+			t.Errorf("Generated facade missing: %s", expected)
+		}
+	}
+
+	if _, err := generator.GenerateFacadeCode(ctx, nil, "models", "example.com/app/internal/models"); err == nil {
+		t.Error("Expected error for empty structs")
+	}
+}
+
+func TestGenerator_GenerateJSONSchema(t *testing.T) {
+	generator := NewGenerator()
+
+	structs := []domain.Struct{
+		{
+			Name: "Profile",
+			Fields: []domain.Field{
+				{Name: "ID", DBName: "id", TypeName: "int64", Type: domain.FieldTypeNumeric},
+				{Name: "Labels", DBName: "labels", TypeName: "hstore.Hstore", Type: domain.FieldTypeHStore},
+				{Name: "Avatar", DBName: "avatar", TypeName: "Image", Type: domain.FieldTypeStruct},
+			},
+		},
+	}
+
+	data, err := generator.GenerateJSONSchema(structs)
+	if err != nil {
+		t.Fatalf("GenerateJSONSchema failed: %v", err)
+	}
+
+	var schema jsonSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("Schema is not valid JSON: %v", err)
+	}
+	if schema.Schema != jsonSchemaDraft07 {
+		t.Errorf("Expected $schema %q, got %q", jsonSchemaDraft07, schema.Schema)
+	}
+
+	filters := schema.Definitions["ProfileFilters"]
+	if filters == nil || filters.Items == nil {
+		t.Fatalf("Missing ProfileFilters definition:\n%s", data)
+	}
+
+	valueTypes := map[string]interface{}{}
+	for _, condition := range filters.Items.OneOf {
+		column := condition.Properties["field"].Const
+		if column == "avatar" {
+			t.Error("Unsupported struct field should not be described")
+		}
+		for _, op := range condition.Properties["operator"].Enum {
+			var valueType interface{}
+			if value := condition.Properties["value"]; value != nil {
+				valueType = value.Type
+			}
+			valueTypes[fmt.Sprintf("%s %s", column, op)] = valueType
+		}
+	}
+
+	for key, expected := range map[string]interface{}{
+		"id =":                  "integer",
+		"id IN":                 "array",
+		"id BETWEEN":            "object",
+		"labels HSTORE_HAS_KEY": "string",
+		"labels HSTORE_GET":     "object",
+	} {
+		if got, ok := valueTypes[key]; !ok || got != expected {
+			t.Errorf("Expected %s value type %v, got %v", key, expected, got)
+		}
+	}
+
+	if _, err := generator.GenerateJSONSchema(nil); !errors.Is(err, repository.ErrNoStructsProvided) {
+		t.Errorf("Expected ErrNoStructsProvided for empty structs, got %v", err)
+	}
+}
+
 func TestGenerator_buildPackageHeader(t *testing.T) {
 	generator := NewGenerator()
 
-	header := generator.buildPackageHeader("testpkg")
+	header := generator.buildPackageHeader("testpkg", []string{"example.com/models/enums"})
 
 	expectedElements := []string{
 		"// Code generated by querybuilder. DO NOT EDIT.",
 		"package testpkg",
 		`"github.com/dchlong/querybuilder/repository"`,
+		`"example.com/models/enums"`,
 	}
 
 	for _, element := range expectedElements {
@@ -362,6 +916,34 @@ func TestGenerator_buildPackageHeader(t *testing.T) {
 	}
 }
 
+func TestGenerator_GenerateCode_FieldImports(t *testing.T) {
+	generator := NewGenerator()
+
+	// The enums package can't be found by goimports, so it must come from the field
+	structs := []domain.Struct{{
+		Name: "Ticket",
+		Fields: []domain.Field{
+			{Name: "ID", DBName: "id", TypeName: "int64", Type: domain.FieldTypeNumeric},
+			{Name: "Status", DBName: "status", TypeName: "enums.Status", Type: domain.FieldTypeString, Imports: []string{"example.com/models/enums"}},
+			{Name: "Kind", DBName: "kind", TypeName: "enums.Kind", Type: domain.FieldTypeString, Imports: []string{"example.com/models/enums"}},
+			{Name: "Unused", DBName: "unused", TypeName: "string", Type: domain.FieldTypeString, Imports: []string{"example.com/models/unused"}},
+		},
+	}}
+
+	code, err := generator.GenerateCode(context.Background(), structs, "models")
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	codeStr := string(code)
+	if strings.Count(codeStr, `"example.com/models/enums"`) != 1 {
+		t.Error("Generated code should import the package of the field types once")
+	}
+	if strings.Contains(codeStr, "example.com/models/unused") {
+		t.Error("Unused imports should be removed")
+	}
+}
+
 // Generic type tests for builder
 func TestGenerator_GenericTypeHandling(t *testing.T) {
 	generator := NewGenerator()
@@ -488,3 +1070,61 @@ func TestGenerator_PerformanceWithLargeStruct(t *testing.T) {
 
 	t.Logf("Generated code for 50-field struct in %v", duration)
 }
+
+func TestGenerator_GenerateCode_Header(t *testing.T) {
+	structs := []domain.Struct{{
+		Name: "User",
+		Fields: []domain.Field{
+			{Name: "ID", DBName: "id", TypeName: "int64", Type: domain.FieldTypeNumeric},
+		},
+	}}
+
+	t.Run("build tags and header comment", func(t *testing.T) {
+		generator := NewGeneratorWithOptions(GenerateOptions{
+			BuildTags:     []string{"integration", "linux || darwin"},
+			HeaderComment: "Copyright 2025 Acme Inc.\n\n// SPDX-License-Identifier: MIT",
+		})
+
+		for name, generate := range map[string]func() ([]byte, error){
+			"code": func() ([]byte, error) {
+				return generator.GenerateCode(context.Background(), structs, "models")
+			},
+			"facade": func() ([]byte, error) {
+				return generator.GenerateFacadeCode(context.Background(), structs, "models", "example.com/internal/models")
+			},
+		} {
+			code, err := generate()
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", name, err)
+			}
+
+			want := "// Copyright 2025 Acme Inc.\n//\n// SPDX-License-Identifier: MIT\n\n" +
+				"//go:build integration && (linux || darwin)\n\n" +
+				"// Code generated by querybuilder. DO NOT EDIT.\n\n" +
+				"package models\n"
+			if !strings.HasPrefix(string(code), want) {
+				t.Errorf("%s: unexpected preamble:\n%s", name, code[:min(len(code), len(want))])
+			}
+		}
+	})
+
+	t.Run("single tag is not parenthesized", func(t *testing.T) {
+		generator := NewGeneratorWithOptions(GenerateOptions{BuildTags: []string{" !windows "}})
+
+		code, err := generator.GenerateCode(context.Background(), structs, "models")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.HasPrefix(string(code), "//go:build !windows\n\n// Code generated by querybuilder. DO NOT EDIT.\n") {
+			t.Errorf("unexpected preamble:\n%s", code)
+		}
+	})
+
+	t.Run("invalid build tag", func(t *testing.T) {
+		generator := NewGeneratorWithOptions(GenerateOptions{BuildTags: []string{"linux &&"}})
+
+		if _, err := generator.GenerateCode(context.Background(), structs, "models"); !errors.Is(err, repository.ErrInvalidBuildTag) {
+			t.Errorf("Expected ErrInvalidBuildTag, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,216 @@
+package builder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dchlong/querybuilder/domain"
+	"github.com/dchlong/querybuilder/repository"
+)
+
+// jsonSchemaDraft07 is the meta-schema URI of the emitted schemas
+const jsonSchemaDraft07 = "http://json-schema.org/draft-07/schema#"
+
+// jsonSchema is the subset of JSON Schema draft-07 used to describe filter payloads
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 interface{}            `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Const                interface{}            `json:"const,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	OneOf                []*jsonSchema          `json:"oneOf,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Definitions          map[string]*jsonSchema `json:"definitions,omitempty"`
+}
+
+// operatorValueShape groups operators by the shape of their filter value
+type operatorValueShape int
+
+const (
+	shapeScalar   operatorValueShape = iota // a single value of the field type
+	shapeList                               // an array of values of the field type
+	shapeNone                               // no value
+	shapeRange                              // an object with lower and upper bounds
+	shapeKey                                // a string key
+	shapeKeyValue                           // an object with key and value strings
+	shapeElement                            // a single element of an array field
+	shapeElements                           // an array of elements of an array field
+)
+
+// valueShape returns the shape of the filter value for an operator
+func valueShape(op repository.Operator) operatorValueShape {
+	switch op {
+	case repository.OperatorIn, repository.OperatorNotIn:
+		return shapeList
+	case repository.OperatorIsNull, repository.OperatorIsNotNull:
+		return shapeNone
+	case repository.OperatorBetween, repository.OperatorNotBetween:
+		return shapeRange
+	case repository.OperatorHStoreHasKey, repository.OperatorJSONHasKey:
+		return shapeKey
+	case repository.OperatorHStoreGet, repository.OperatorJSONEqual:
+		return shapeKeyValue
+	case repository.OperatorJSONContains:
+		return shapeElement
+	case repository.OperatorArrayContains, repository.OperatorArrayOverlaps:
+		return shapeElements
+	default:
+		return shapeScalar
+	}
+}
+
+// GenerateJSONSchema describes the valid filter payloads of each struct as a JSON Schema (draft-07).
+// A payload is an array of {"field", "operator", "value"} objects, mirroring repository.Filter.
+// Each struct is a definition named "<Struct>Filters".
+func (g *Generator) GenerateJSONSchema(structs []domain.Struct) ([]byte, error) {
+	if len(structs) == 0 {
+		return nil, repository.ErrNoStructsProvided
+	}
+
+	root := &jsonSchema{
+		Schema:      jsonSchemaDraft07,
+		Definitions: make(map[string]*jsonSchema, len(structs)),
+	}
+	for _, s := range structs {
+		root.Definitions[s.Name+"Filters"] = g.structFilterSchema(s)
+	}
+
+	// Keep operators such as "<" readable instead of HTML-escaped
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(root); err != nil {
+		return nil, fmt.Errorf("marshal JSON schema: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// GenerateJSONSchemaFile generates the filter payload JSON Schema and writes it to outputPath
+func (g *Generator) GenerateJSONSchemaFile(structs []domain.Struct, outputPath string) error {
+	data, err := g.GenerateJSONSchema(structs)
+	if err != nil {
+		return fmt.Errorf("failed to generate JSON schema: %w", err)
+	}
+
+	return g.writeFile(outputPath, data)
+}
+
+// structFilterSchema describes the filter payload of one struct
+func (g *Generator) structFilterSchema(s domain.Struct) *jsonSchema {
+	var conditions []*jsonSchema
+	for _, field := range s.FilterableFields() {
+		// Group the operators by value shape, keeping the first-seen shape order
+		var shapes []operatorValueShape
+		operatorsByShape := map[operatorValueShape][]string{}
+		for _, op := range field.SupportedOperators() {
+			shape := valueShape(op)
+			if _, seen := operatorsByShape[shape]; !seen {
+				shapes = append(shapes, shape)
+			}
+			operatorsByShape[shape] = append(operatorsByShape[shape], string(op))
+		}
+
+		for _, shape := range shapes {
+			conditions = append(conditions, conditionSchema(field, shape, operatorsByShape[shape]))
+		}
+	}
+
+	return &jsonSchema{
+		Title:       s.Name + " filters",
+		Description: fmt.Sprintf("Conditions accepted by %sFilters; all conditions must match", s.Name),
+		Type:        "array",
+		Items:       &jsonSchema{OneOf: conditions},
+	}
+}
+
+// conditionSchema describes a filter condition on field using one of operators with the given value shape
+func conditionSchema(field domain.Field, shape operatorValueShape, operators []string) *jsonSchema {
+	condition := &jsonSchema{
+		Type: "object",
+		Properties: map[string]*jsonSchema{
+			"field":    {Const: field.DBName},
+			"operator": {Enum: operators},
+		},
+		Required:             []string{"field", "operator"},
+		AdditionalProperties: boolPtr(false),
+	}
+
+	fieldValue := fieldValueSchema(field)
+	var value *jsonSchema
+	switch shape {
+	case shapeScalar:
+		value = fieldValue
+	case shapeList:
+		value = &jsonSchema{Type: "array", Items: fieldValue}
+	case shapeRange:
+		value = objectSchema(map[string]*jsonSchema{"lower": fieldValue, "upper": fieldValue})
+	case shapeKey:
+		value = &jsonSchema{Type: "string"}
+	case shapeKeyValue:
+		entry := &jsonSchema{Type: "string"}
+		if slices.Contains(operators, string(repository.OperatorJSONEqual)) {
+			entry = &jsonSchema{Type: []string{"string", "number", "boolean"}}
+		}
+		value = objectSchema(map[string]*jsonSchema{"key": {Type: "string"}, "value": entry})
+	case shapeElement:
+		value = fieldValueSchema(domain.Field{TypeName: field.ElemTypeName})
+	case shapeElements:
+		value = &jsonSchema{Type: "array", Items: fieldValueSchema(domain.Field{TypeName: field.ElemTypeName})}
+	case shapeNone:
+		return condition
+	}
+
+	condition.Properties["value"] = value
+	condition.Required = append(condition.Required, "value")
+	return condition
+}
+
+// fieldValueSchema describes a single value of the field's Go type
+func fieldValueSchema(field domain.Field) *jsonSchema {
+	typeName := strings.TrimPrefix(field.FilterTypeName(), "*")
+	switch {
+	case typeName == "time.Time" || field.Type == domain.FieldTypeTime:
+		return &jsonSchema{Type: "string", Format: "date-time"}
+	case typeName == "bool" || field.Type == domain.FieldTypeBool:
+		return &jsonSchema{Type: "boolean"}
+	case typeName == "string" || field.Type == domain.FieldTypeString || field.Type == domain.FieldTypeScalar:
+		return &jsonSchema{Type: "string"}
+	case strings.HasPrefix(typeName, "int") || strings.HasPrefix(typeName, "uint"):
+		return &jsonSchema{Type: "integer"}
+	case strings.HasPrefix(typeName, "float") || field.Type == domain.FieldTypeNumeric:
+		return &jsonSchema{Type: "number"}
+	default:
+		return &jsonSchema{}
+	}
+}
+
+// objectSchema describes an object with exactly the given properties, all required
+func objectSchema(properties map[string]*jsonSchema) *jsonSchema {
+	required := make([]string, 0, len(properties))
+	for _, name := range []string{"lower", "upper", "key", "value"} {
+		if _, ok := properties[name]; ok {
+			required = append(required, name)
+		}
+	}
+
+	return &jsonSchema{
+		Type:                 "object",
+		Properties:           properties,
+		Required:             required,
+		AdditionalProperties: boolPtr(false),
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/dchlong/querybuilder/field"
+	"github.com/dchlong/querybuilder/repository"
+)
+
+// fileConfig is the schema of the file passed with -config. The file is YAML; JSON works too
+// since it is valid YAML. Unknown keys are rejected, and explicit command-line flags override
+// the values set here.
+//
+//	inputs:                         # files to generate for, as filepath.Glob patterns
+//	  - models/*.go
+//	output: "{{.Dir}}/{{.Name}}_qb.go"  # output file name template, see outputName
+//	suffix: V1                      # suffix appended to struct names
+//	naming: camel                   # column naming: snake (default), camel or none
+//	acronyms: [OAuth, GraphQL]      # kept as one word in column names
+//	array_types: [pgtype.TextArray] # extra named slice types stored as Postgres arrays
+//	numeric_types: [money.Amount]   # extra named types filtered as numbers
+//	scalar_types: [ulid.ULID]       # extra named types filtered by equality only
+//	time_types:                     # extra types handled like time.Time
+//	  - type: mytime.Timestamp
+//	    numeric: true               # comparable, gets Lt/Gt/Between/...
+//	    nullable: true              # gets IsNull/IsNotNull
+//	exclude:                        # annotated structs to skip
+//	  - LegacyUser
+type fileConfig struct {
+	Inputs       []string         `yaml:"inputs"`
+	Output       string           `yaml:"output"`
+	Suffix       string           `yaml:"suffix"`
+	Naming       string           `yaml:"naming"`
+	Acronyms     []string         `yaml:"acronyms"`
+	ArrayTypes   []string         `yaml:"array_types"`
+	NumericTypes []string         `yaml:"numeric_types"`
+	ScalarTypes  []string         `yaml:"scalar_types"`
+	TimeTypes    []timeTypeConfig `yaml:"time_types"`
+	Exclude      []string         `yaml:"exclude"`
+}
+
+// timeTypeConfig is a custom time type entry of fileConfig
+type timeTypeConfig struct {
+	Type     string `yaml:"type"`
+	Numeric  bool   `yaml:"numeric"`
+	Nullable bool   `yaml:"nullable"`
+}
+
+// outputName is the data of the output file name template
+type outputName struct {
+	Dir  string // Directory of the input file
+	Name string // Input file name without extension
+	Ext  string // Input file extension, including the dot
+}
+
+// loadConfigFile reads and validates a -config file
+func loadConfigFile(path string) (*fileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var fc fileConfig
+	if err := decoder.Decode(&fc); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w %s: %w", repository.ErrInvalidConfig, path, err)
+	}
+
+	if err := fc.validate(); err != nil {
+		return nil, fmt.Errorf("%w %s: %w", repository.ErrInvalidConfig, path, err)
+	}
+	return &fc, nil
+}
+
+// validate checks the values that can be checked before any file is processed
+func (fc *fileConfig) validate() error {
+	for _, pattern := range fc.Inputs {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("inputs: %q: %w", pattern, err)
+		}
+	}
+
+	if fc.Output != "" {
+		if _, err := fc.outputTemplate(); err != nil {
+			return fmt.Errorf("output: %w", err)
+		}
+	}
+
+	if !field.Naming(fc.Naming).IsValid() {
+		return fmt.Errorf("naming: %w: %q", repository.ErrInvalidNaming, fc.Naming)
+	}
+
+	for i, timeType := range fc.TimeTypes {
+		if strings.TrimSpace(timeType.Type) == "" {
+			return fmt.Errorf("time_types[%d]: type is required", i)
+		}
+	}
+	return nil
+}
+
+// outputTemplate parses the output file name template
+func (fc *fileConfig) outputTemplate() (*template.Template, error) {
+	return template.New("output").Option("missingkey=error").Parse(fc.Output)
+}
+
+// timeTypePatterns converts the configured time types to field patterns
+func (fc *fileConfig) timeTypePatterns() []field.TimeTypePattern {
+	patterns := make([]field.TimeTypePattern, 0, len(fc.TimeTypes))
+	for _, timeType := range fc.TimeTypes {
+		patterns = append(patterns, field.TimeTypePattern{
+			Pattern:    strings.TrimSpace(timeType.Type),
+			IsNumeric:  timeType.Numeric,
+			IsNullable: timeType.Nullable,
+		})
+	}
+	return patterns
+}
+
+// applyConfigFile loads cfg.configFile and fills in every setting not given as a flag
+func applyConfigFile(cfg *config) error {
+	fc, err := loadConfigFile(cfg.configFile)
+	if err != nil {
+		return err
+	}
+
+	if !cfg.flagSet("suffix", "s") {
+		cfg.suffix = fc.Suffix
+	}
+	if !cfg.flagSet("naming") && fc.Naming != "" {
+		cfg.naming = fc.Naming
+	}
+	if !cfg.flagSet("acronyms") && len(fc.Acronyms) > 0 {
+		cfg.acronyms = strings.Join(fc.Acronyms, ",")
+	}
+	if !cfg.flagSet("array-types") && len(fc.ArrayTypes) > 0 {
+		cfg.arrayTypes = strings.Join(fc.ArrayTypes, ",")
+	}
+	if !cfg.flagSet("numeric-types") && len(fc.NumericTypes) > 0 {
+		cfg.numericTypes = strings.Join(fc.NumericTypes, ",")
+	}
+	if !cfg.flagSet("scalar-types") && len(fc.ScalarTypes) > 0 {
+		cfg.scalarTypes = strings.Join(fc.ScalarTypes, ",")
+	}
+	if !cfg.flagSet("output", "o") && fc.Output != "" {
+		// validate already parsed it once
+		cfg.outputTemplate, _ = fc.outputTemplate()
+	}
+
+	cfg.inputs = fc.Inputs
+	cfg.timeTypes = fc.timeTypePatterns()
+	cfg.exclude = fc.Exclude
+	return nil
+}
+
+// outputPackage returns the package clause of the generated code: -package, or the name of
+// -output-dir, or empty for the input file's package
+func (cfg *config) outputPackage() string {
+	if cfg.pkg != "" || cfg.outputDir == "" {
+		return cfg.pkg
+	}
+
+	absPath, err := filepath.Abs(cfg.outputDir)
+	if err != nil {
+		absPath = cfg.outputDir
+	}
+	return filepath.Base(absPath)
+}
+
+// flagSet reports whether any of the named flags was passed on the command line
+func (cfg *config) flagSet(names ...string) bool {
+	return slices.ContainsFunc(names, func(name string) bool { return cfg.setFlags[name] })
+}
+
+// outputFileName returns the output path for an input file, from the config file's output
+// template when there is one, otherwise next to the input or in -output-dir
+func (cfg *config) outputFileName(inputFile string) (string, error) {
+	if cfg.outputTemplate == nil {
+		name := generateOutputFileName(inputFile)
+		if cfg.outputDir != "" {
+			name = filepath.Join(cfg.outputDir, filepath.Base(name))
+		}
+		return name, nil
+	}
+
+	ext := filepath.Ext(inputFile)
+	var buf strings.Builder
+	err := cfg.outputTemplate.Execute(&buf, outputName{
+		Dir:  filepath.Dir(inputFile),
+		Name: strings.TrimSuffix(filepath.Base(inputFile), ext),
+		Ext:  ext,
+	})
+	if err != nil {
+		return "", fmt.Errorf("%w: output: %w", repository.ErrInvalidConfig, err)
+	}
+	return filepath.Clean(buf.String()), nil
+}
+
+// expandInputs resolves the config file's input globs to the Go files to process,
+// skipping the same test and generated files as -dir
+func expandInputs(patterns []string) ([]string, error) {
+	var files []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%w: inputs: %q: %w", repository.ErrInvalidConfig, pattern, err)
+		}
+		for _, match := range matches {
+			if isGeneratableGoFile(match) && !slices.Contains(files, match) {
+				files = append(files, match)
+			}
+		}
+	}
+	return files, nil
+}
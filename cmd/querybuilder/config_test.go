@@ -0,0 +1,175 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dchlong/querybuilder/repository"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		path := writeConfig(t, "querybuilder.yaml", `
+inputs:
+  - models/*.go
+output: "{{.Dir}}/{{.Name}}_qb{{.Ext}}"
+suffix: V1
+time_types:
+  - type: mytime.Timestamp
+    numeric: true
+    nullable: true
+exclude:
+  - LegacyUser
+`)
+
+		fc, err := loadConfigFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(fc.Inputs) != 1 || fc.Suffix != "V1" || len(fc.Exclude) != 1 {
+			t.Errorf("unexpected config: %+v", fc)
+		}
+
+		patterns := fc.timeTypePatterns()
+		if len(patterns) != 1 || patterns[0].Pattern != "mytime.Timestamp" || !patterns[0].IsNumeric || !patterns[0].IsNullable {
+			t.Errorf("unexpected time types: %+v", patterns)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		path := writeConfig(t, "querybuilder.json", `{"inputs": ["*.go"], "suffix": "V2"}`)
+
+		fc, err := loadConfigFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fc.Suffix != "V2" {
+			t.Errorf("Suffix = %q, want V2", fc.Suffix)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		if _, err := loadConfigFile(writeConfig(t, "empty.yaml", "")); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	invalid := map[string]string{
+		"unknown key":        "suffix: V1\noutput_dir: gen\n",
+		"unknown nested key": "time_types:\n  - type: mytime.Timestamp\n    numric: true\n",
+		"bad glob":           "inputs: ['models/[*.go']\n",
+		"bad template":       "output: '{{.Name'\n",
+		"missing time type":  "time_types:\n  - numeric: true\n",
+		"unknown naming":     "naming: kebab\n",
+	}
+	for name, content := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := loadConfigFile(writeConfig(t, "querybuilder.yaml", content)); !errors.Is(err, repository.ErrInvalidConfig) {
+				t.Errorf("Expected ErrInvalidConfig, got %v", err)
+			}
+		})
+	}
+}
+
+func TestApplyConfigFile(t *testing.T) {
+	path := writeConfig(t, "querybuilder.yaml", "suffix: V1\nnaming: camel\nacronyms: [OAuth, GraphQL]\narray_types: [pgtype.TextArray]\nnumeric_types: [money.Amount]\nscalar_types: [ulid.ULID]\noutput: '{{.Dir}}/gen/{{.Name}}.qb.go'\n")
+
+	t.Run("config values", func(t *testing.T) {
+		cfg := &config{configFile: path}
+		if err := applyConfigFile(cfg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.suffix != "V1" {
+			t.Errorf("suffix = %q, want V1", cfg.suffix)
+		}
+		if cfg.naming != "camel" {
+			t.Errorf("naming = %q, want camel", cfg.naming)
+		}
+		if cfg.acronyms != "OAuth,GraphQL" {
+			t.Errorf("acronyms = %q, want OAuth,GraphQL", cfg.acronyms)
+		}
+		if cfg.arrayTypes != "pgtype.TextArray" {
+			t.Errorf("arrayTypes = %q, want pgtype.TextArray", cfg.arrayTypes)
+		}
+		if cfg.numericTypes != "money.Amount" {
+			t.Errorf("numericTypes = %q, want money.Amount", cfg.numericTypes)
+		}
+		if cfg.scalarTypes != "ulid.ULID" {
+			t.Errorf("scalarTypes = %q, want ulid.ULID", cfg.scalarTypes)
+		}
+
+		output, err := cfg.outputFileName(filepath.Join("models", "user.go"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := filepath.Join("models", "gen", "user.qb.go"); output != want {
+			t.Errorf("output = %q, want %q", output, want)
+		}
+	})
+
+	t.Run("explicit flags win", func(t *testing.T) {
+		cfg := &config{configFile: path, suffix: "V9", setFlags: map[string]bool{"s": true, "output": true}}
+		if err := applyConfigFile(cfg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.suffix != "V9" {
+			t.Errorf("suffix = %q, want V9", cfg.suffix)
+		}
+		if cfg.outputTemplate != nil {
+			t.Error("output template should not be applied when -output is set")
+		}
+	})
+}
+
+func TestExpandInputs(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"user.go", "order.go", "user_test.go", "user_querybuilder.go", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("package models\n"), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	files, err := expandInputs([]string{filepath.Join(dir, "*.go"), filepath.Join(dir, "user.go")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{filepath.Join(dir, "order.go"), filepath.Join(dir, "user.go")}
+	if len(files) != len(want) || files[0] != want[0] || files[1] != want[1] {
+		t.Errorf("files = %v, want %v", files, want)
+	}
+}
+
+func TestConfig_OutputDir(t *testing.T) {
+	cfg := &config{outputDir: filepath.Join("internal", "queries")}
+
+	output, err := cfg.outputFileName(filepath.Join("models", "user.go"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := filepath.Join("internal", "queries", "user_querybuilder.go"); output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+
+	if got := cfg.outputPackage(); got != "queries" {
+		t.Errorf("outputPackage() = %q, want the -output-dir name", got)
+	}
+	cfg.pkg = "dbqueries"
+	if got := cfg.outputPackage(); got != "dbqueries" {
+		t.Errorf("outputPackage() = %q, want -package to win", got)
+	}
+	if got := (&config{}).outputPackage(); got != "" {
+		t.Errorf("outputPackage() = %q, want the input package by default", got)
+	}
+}
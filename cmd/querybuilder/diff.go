@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+
+	"github.com/dchlong/querybuilder/repository"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// checkOutput compares the generated code with outputFile without writing it. A differing file
+// is printed as a diff with -diff, or listed as stale with -check, and returns ErrOutputDiffers.
+func checkOutput(cfg *config, outputFile string, code []byte) error {
+	existing, upToDate, err := compareOutput(outputFile, code)
+	if err != nil {
+		return err
+	}
+	if upToDate {
+		if cfg.verbose {
+			fmt.Printf("Up to date: %s\n", outputFile)
+		}
+		return nil
+	}
+
+	if cfg.diff {
+		diff, err := unifiedDiff(outputFile, existing, code)
+		if err != nil {
+			return err
+		}
+		fmt.Print(diff)
+	} else {
+		fmt.Printf("Stale: %s\n", outputFile)
+	}
+	return fmt.Errorf("%w: %s", repository.ErrOutputDiffers, outputFile)
+}
+
+// compareOutput reads outputFile and reports whether it holds code, ignoring gofmt and trailing
+// newline differences. A missing output file reads as empty and is never up to date.
+func compareOutput(outputFile string, code []byte) ([]byte, bool, error) {
+	existing, err := os.ReadFile(outputFile)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read output file %s: %w", outputFile, err)
+	}
+	return existing, bytes.Equal(normalizeCode(existing), normalizeCode(code)), nil
+}
+
+// normalizeCode gofmts Go source, when it parses, and drops trailing whitespace
+func normalizeCode(code []byte) []byte {
+	if formatted, err := format.Source(code); err == nil {
+		code = formatted
+	}
+	return bytes.TrimRight(code, " \t\r\n")
+}
+
+// unifiedDiff returns a unified diff from the existing content of outputFile to the generated code
+func unifiedDiff(outputFile string, existing, code []byte) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(existing)),
+		B:        difflib.SplitLines(string(code)),
+		FromFile: outputFile,
+		ToFile:   outputFile + " (generated)",
+		Context:  3,
+	})
+}
+
+// isOutputDiff reports whether err only says that generated code differs from its output file
+func isOutputDiff(err error) bool {
+	return errors.Is(err, repository.ErrOutputDiffers)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dchlong/querybuilder/repository"
+)
+
+func TestCompareOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user_querybuilder.go")
+	if err := os.WriteFile(path, []byte("package models\n\nvar a = 1\n"), 0644); err != nil {
+		t.Fatalf("Failed to write output file: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		upToDate bool
+	}{
+		{"equal", "package models\n\nvar a = 1\n", true},
+		{"no trailing newline", "package models\n\nvar a = 1", true},
+		{"not gofmt'd", "package models\nvar a   =   1\n\n\n", true},
+		{"changed", "package models\n\nvar a = 2\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, upToDate, err := compareOutput(path, []byte(tt.code))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if upToDate != tt.upToDate {
+				t.Errorf("upToDate = %v, want %v", upToDate, tt.upToDate)
+			}
+		})
+	}
+
+	if _, upToDate, err := compareOutput(filepath.Join(t.TempDir(), "missing.go"), []byte("package models\n")); err != nil || upToDate {
+		t.Errorf("Missing output file should be stale, got %v, %v", upToDate, err)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	diff, err := unifiedDiff("user_querybuilder.go", []byte("package models\n\nvar a = 1\n"), []byte("package models\n\nvar a = 2\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, line := range []string{"--- user_querybuilder.go", "+++ user_querybuilder.go (generated)", "-var a = 1", "+var a = 2"} {
+		if !strings.Contains(diff, line) {
+			t.Errorf("Diff missing %q\n%s", line, diff)
+		}
+	}
+}
+
+func TestGenerateForFile_Check(t *testing.T) {
+	ctx := context.Background()
+	input := filepath.Join("..", "..", "examples", "product.go")
+
+	for _, cfg := range []config{{diff: true}, {check: true}} {
+		// The committed example output is up to date
+		upToDate := cfg
+		upToDate.inputFile = input
+		if err := generateForFile(ctx, &upToDate); err != nil {
+			t.Errorf("Committed output should be up to date, got %v", err)
+		}
+
+		stale := filepath.Join(t.TempDir(), "product_querybuilder.go")
+		if err := os.WriteFile(stale, []byte("package examples\n"), 0644); err != nil {
+			t.Fatalf("Failed to write stale output: %v", err)
+		}
+		staleCfg := cfg
+		staleCfg.inputFile = input
+		staleCfg.outputFile = stale
+		if err := generateForFile(ctx, &staleCfg); !errors.Is(err, repository.ErrOutputDiffers) {
+			t.Errorf("Expected ErrOutputDiffers, got %v", err)
+		}
+		if data, _ := os.ReadFile(stale); string(data) != "package examples\n" {
+			t.Error("Stale output should not be rewritten")
+		}
+	}
+}
+
+func TestGenerateForFiles_Check(t *testing.T) {
+	ctx := context.Background()
+	inputs := []string{filepath.Join("..", "..", "examples", "product.go"), filepath.Join("..", "..", "examples", "order.go")}
+
+	// order.go is generated with string filters, so its committed output is stale without them
+	err := generateForFiles(ctx, &config{check: true}, inputs)
+	if !errors.Is(err, repository.ErrOutputDiffers) || !strings.Contains(err.Error(), "1 files") {
+		t.Errorf("Expected one stale file, got %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dchlong/querybuilder/dbschema"
+	"github.com/dchlong/querybuilder/domain"
+	"github.com/dchlong/querybuilder/repository"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// openDatabase connects to the database of a -from-db URL, picking the driver by its scheme
+func openDatabase(databaseURL string) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+	switch {
+	case strings.HasPrefix(databaseURL, "postgres://"), strings.HasPrefix(databaseURL, "postgresql://"):
+		dialector = postgres.Open(databaseURL)
+	case strings.HasPrefix(databaseURL, "mysql://"):
+		dialector = mysql.Open(strings.TrimPrefix(databaseURL, "mysql://"))
+	case strings.HasPrefix(databaseURL, "sqlite://"):
+		dialector = sqlite.Open(strings.TrimPrefix(databaseURL, "sqlite://"))
+	default:
+		return nil, fmt.Errorf("%w: %s", repository.ErrUnsupportedDatabaseURL, databaseURL)
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the database: %w", err)
+	}
+	return db, nil
+}
+
+// generateFromDB writes a struct for the -table columns and its query builder
+func generateFromDB(ctx context.Context, cfg *config) error {
+	if cfg.table == "" {
+		return fmt.Errorf("%w: -from-db needs -table", repository.ErrInvalidConfig)
+	}
+
+	db, err := openDatabase(cfg.fromDB)
+	if err != nil {
+		return err
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	columns, err := dbschema.ReadColumns(db.WithContext(ctx), cfg.table)
+	if err != nil {
+		return err
+	}
+
+	s, warnings, err := dbschema.NewStruct(cfg.table, columns)
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s: %s\n", cfg.table, warning)
+	}
+
+	dir := cfg.outputDir
+	if dir == "" {
+		dir = "."
+	}
+	packageName := cfg.outputPackage()
+	if packageName == "" {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+		packageName = filepath.Base(absDir)
+	}
+	if !token.IsIdentifier(packageName) {
+		return fmt.Errorf("%w: %q, set -package", repository.ErrInvalidPackageName, packageName)
+	}
+
+	source, err := dbschema.StructSource(packageName, cfg.table, s)
+	if err != nil {
+		return fmt.Errorf("failed to render struct %s: %w", s.Name, err)
+	}
+	code, err := newGenerator(cfg).GenerateStructs(ctx, []domain.Struct{s}, packageName)
+	if err != nil {
+		return fmt.Errorf("generation failed: %w", err)
+	}
+
+	structFile := filepath.Join(dir, schema.NamingStrategy{}.ColumnName("", s.Name)+".go")
+	outputFile := cfg.outputFile
+	if outputFile == "" {
+		outputFile = generateOutputFileName(structFile)
+	}
+
+	if cfg.verbose {
+		fmt.Printf("Table:       %s (%d columns)\n", cfg.table, len(columns))
+		fmt.Printf("Struct file: %s\n", structFile)
+		fmt.Printf("Output file: %s\n", outputFile)
+	}
+
+	if cfg.dryRun {
+		fmt.Printf("Would generate struct %s in %s and %d bytes of code in %s\n", s.Name, structFile, len(code), outputFile)
+		return nil
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for file, content := range map[string][]byte{structFile: source, outputFile: code} {
+		if err := os.WriteFile(file, content, 0644); err != nil {
+			return fmt.Errorf("%w to %s: %w", repository.ErrWriteGeneratedCode, file, err)
+		}
+	}
+
+	fmt.Printf("Successfully generated struct: %s\n", structFile)
+	fmt.Printf("Successfully generated query builder: %s\n", outputFile)
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dchlong/querybuilder/repository"
+)
+
+func TestGenerateFromDB(t *testing.T) {
+	dir := t.TempDir()
+	databaseURL := "sqlite://" + filepath.Join(dir, "shop.db")
+
+	db, err := openDatabase(databaseURL)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := db.Exec("CREATE TABLE order_items (id INTEGER PRIMARY KEY, sku VARCHAR(32) NOT NULL, quantity INTEGER NOT NULL, shipped_at DATETIME NULL)").Error; err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	outputDir := filepath.Join(dir, "models")
+	cfg := &config{fromDB: databaseURL, table: "order_items", outputDir: outputDir, storage: "map", naming: "snake"}
+	if err := generateFromDB(context.Background(), cfg); err != nil {
+		t.Fatalf("generateFromDB failed: %v", err)
+	}
+
+	source, err := os.ReadFile(filepath.Join(outputDir, "order_item.go"))
+	if err != nil {
+		t.Fatalf("Failed to read struct file: %v", err)
+	}
+	for _, part := range []string{"package models", "type OrderItem struct", "ShippedAt *time.Time", `return "order_items"`} {
+		if !strings.Contains(string(source), part) {
+			t.Errorf("Struct file missing %q:\n%s", part, source)
+		}
+	}
+
+	code, err := os.ReadFile(filepath.Join(outputDir, "order_item_querybuilder.go"))
+	if err != nil {
+		t.Fatalf("Failed to read query builder file: %v", err)
+	}
+	for _, part := range []string{"func (o *OrderItemFilters) SKUEq(sKU string)", "func (o *OrderItemFilters) ShippedAtIsNull()"} {
+		if !strings.Contains(string(code), part) {
+			t.Errorf("Query builder missing %q", part)
+		}
+	}
+
+	cfg.table = "missing"
+	if err := generateFromDB(context.Background(), cfg); !errors.Is(err, repository.ErrTableNotFound) {
+		t.Errorf("Expected ErrTableNotFound, got %v", err)
+	}
+}
+
+func TestOpenDatabase_UnsupportedURL(t *testing.T) {
+	if _, err := openDatabase("mongodb://localhost/shop"); !errors.Is(err, repository.ErrUnsupportedDatabaseURL) {
+		t.Errorf("Expected ErrUnsupportedDatabaseURL, got %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
+)
+
+// watchInterval is how often -watch polls the input files for changes
+const watchInterval = 500 * time.Millisecond
+
+// fileStamp identifies one version of a watched file
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// watch runs generate once, then regenerates every input file that is added or modified until
+// SIGINT or SIGTERM. Failures are printed and watching goes on, so a half-edited file doesn't
+// end the session.
+func watch(ctx context.Context, cfg *config, generate func(context.Context, *config) error, watched func() ([]string, error)) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := generate(ctx, cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+
+	fmt.Println("Watching for changes (Ctrl-C to stop)...")
+	err := watchFiles(ctx, watchInterval, cfg.watchList(watched), func(files []string) {
+		for _, file := range files {
+			fmt.Printf("[%s] %s changed, regenerating\n", time.Now().Format(time.TimeOnly), file)
+
+			fileCfg := *cfg
+			fileCfg.inputFile = file
+			fileCfg.directory = ""
+			if err := generateForFile(ctx, &fileCfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Stopped watching")
+	return nil
+}
+
+// watchList wraps the watched file list to leave out files this run generates, since
+// regenerating them would trigger another change. Default outputs are already skipped by
+// isGeneratableGoFile; this also covers -output and config file output templates.
+func (cfg *config) watchList(watched func() ([]string, error)) func() ([]string, error) {
+	return func() ([]string, error) {
+		files, err := watched()
+		if err != nil {
+			return nil, err
+		}
+
+		outputs := make([]string, 0, len(files)+1)
+		if cfg.outputFile != "" {
+			outputs = append(outputs, cfg.outputFile)
+		}
+		for _, file := range files {
+			if output, err := cfg.outputFileName(file); err == nil {
+				outputs = append(outputs, output)
+			}
+		}
+
+		return slices.DeleteFunc(files, func(file string) bool {
+			return slices.Contains(outputs, file)
+		}), nil
+	}
+}
+
+// watchFiles polls the files returned by list every interval and calls changed with the
+// files added or modified since the previous poll, until ctx is done. Polling needs no
+// platform-specific notification API and picks up files created after watching started.
+func watchFiles(ctx context.Context, interval time.Duration, list func() ([]string, error), changed func(files []string)) error {
+	stamps, err := stampFiles(list)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current, err := stampFiles(list)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			continue
+		}
+
+		var files []string
+		for file, stamp := range current {
+			if previous, ok := stamps[file]; !ok || previous != stamp {
+				files = append(files, file)
+			}
+		}
+		stamps = current
+
+		if len(files) > 0 {
+			slices.Sort(files)
+			changed(files)
+		}
+	}
+}
+
+// stampFiles records the modification time and size of each listed file.
+// Files removed between listing and stat are left out.
+func stampFiles(list func() ([]string, error)) (map[string]fileStamp, error) {
+	files, err := list()
+	if err != nil {
+		return nil, err
+	}
+
+	stamps := make(map[string]fileStamp, len(files))
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		stamps[file] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+	}
+	return stamps, nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWatchFiles(t *testing.T) {
+	dir := t.TempDir()
+	user := filepath.Join(dir, "user.go")
+	order := filepath.Join(dir, "order.go")
+	if err := os.WriteFile(user, []byte("package models\n"), 0644); err != nil {
+		t.Fatalf("Failed to write user.go: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan []string, 10)
+	done := make(chan error, 1)
+	go func() {
+		list := func() ([]string, error) { return findGoFiles(dir) }
+		done <- watchFiles(ctx, 10*time.Millisecond, list, func(files []string) { changes <- files })
+	}()
+
+	waitForChange := func(want string) {
+		t.Helper()
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case files := <-changes:
+				if slices.Contains(files, want) {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("No change reported for %s", want)
+			}
+		}
+	}
+
+	// Let the watcher take its first snapshot
+	time.Sleep(50 * time.Millisecond)
+
+	if err := os.WriteFile(order, []byte("package models\n"), 0644); err != nil {
+		t.Fatalf("Failed to write order.go: %v", err)
+	}
+	waitForChange(order)
+
+	if err := os.WriteFile(user, []byte("package models\n\ntype User struct{}\n"), 0644); err != nil {
+		t.Fatalf("Failed to update user.go: %v", err)
+	}
+	waitForChange(user)
+
+	// Generated output is not watched
+	if err := os.WriteFile(filepath.Join(dir, "user_querybuilder.go"), []byte("package models\n"), 0644); err != nil {
+		t.Fatalf("Failed to write user_querybuilder.go: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	for len(changes) > 0 {
+		if files := <-changes; slices.Contains(files, filepath.Join(dir, "user_querybuilder.go")) {
+			t.Error("Generated file should not be reported as changed")
+		}
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("watchFiles did not stop after cancel")
+	}
+}
+
+func TestConfig_WatchList(t *testing.T) {
+	cfg := &config{configFile: writeConfig(t, "querybuilder.yaml", "output: '{{.Dir}}/{{.Name}}_qb.go'\n")}
+	if err := applyConfigFile(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	list := cfg.watchList(func() ([]string, error) {
+		return []string{"models/user.go", "models/user_qb.go", "models/order.go"}, nil
+	})
+
+	files, err := list()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"models/user.go", "models/order.go"}; !slices.Equal(files, want) {
+		t.Errorf("files = %v, want %v", files, want)
+	}
+}
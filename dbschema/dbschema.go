@@ -0,0 +1,174 @@
+package dbschema
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"slices"
+	"strings"
+
+	"github.com/dchlong/querybuilder/domain"
+	"github.com/dchlong/querybuilder/field"
+	"github.com/dchlong/querybuilder/repository"
+	"github.com/jinzhu/inflection"
+	"gorm.io/gorm"
+)
+
+// Column is a table column as reported by the database
+type Column struct {
+	Name         string
+	DatabaseType string // e.g. "varchar" or "timestamptz"
+	Nullable     bool
+	PrimaryKey   bool
+}
+
+// sqlTypes are the Go types of columns by their lowercase SQL type, without length or precision
+var sqlTypes = []struct {
+	fieldType domain.FieldType
+	typeName  string
+	names     []string
+}{
+	{domain.FieldTypeString, "string", []string{
+		"varchar", "character varying", "char", "character", "bpchar", "text",
+		"tinytext", "mediumtext", "longtext", "citext", "uuid", "enum",
+	}},
+	{domain.FieldTypeNumeric, "int64", []string{
+		"int", "integer", "int2", "int4", "int8", "smallint", "mediumint", "bigint", "tinyint",
+		"serial", "bigserial", "smallserial",
+	}},
+	{domain.FieldTypeNumeric, "float64", []string{
+		"numeric", "decimal", "real", "float", "float4", "float8", "double", "double precision",
+	}},
+	{domain.FieldTypeTime, "time.Time", []string{
+		"timestamp", "timestamptz", "timestamp without time zone", "timestamp with time zone",
+		"datetime", "date",
+	}},
+	{domain.FieldTypeBool, "bool", []string{"bool", "boolean"}},
+}
+
+// ReadColumns introspects the columns of table in declaration order. It goes through GORM's
+// migrator, which reads information_schema on Postgres and MySQL.
+func ReadColumns(db *gorm.DB, table string) ([]Column, error) {
+	if !db.Migrator().HasTable(table) {
+		return nil, fmt.Errorf("%w: %s", repository.ErrTableNotFound, table)
+	}
+
+	columnTypes, err := db.Migrator().ColumnTypes(table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+
+	columns := make([]Column, 0, len(columnTypes))
+	for _, columnType := range columnTypes {
+		column := Column{Name: columnType.Name(), DatabaseType: columnType.DatabaseTypeName()}
+		column.Nullable, _ = columnType.Nullable()
+		column.PrimaryKey, _ = columnType.PrimaryKey()
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// GoType returns the field type and Go type of a column of the SQL type. Nullable columns
+// become pointers. ok is false for SQL types without a mapping, such as json or arrays.
+func GoType(databaseType string, nullable bool) (fieldType domain.FieldType, typeName string, ok bool) {
+	name := strings.ToLower(strings.TrimSpace(databaseType))
+	if i := strings.IndexByte(name, '('); i >= 0 {
+		name = strings.TrimSpace(name[:i])
+	}
+	name = strings.TrimSuffix(name, " unsigned")
+
+	for _, sqlType := range sqlTypes {
+		if !slices.Contains(sqlType.names, name) {
+			continue
+		}
+		if nullable {
+			return domain.FieldTypePointer, "*" + sqlType.typeName, true
+		}
+		return sqlType.fieldType, sqlType.typeName, true
+	}
+	return domain.FieldTypeUnknown, "", false
+}
+
+// NewStruct converts the columns of table to a struct named after the singular table name,
+// e.g. Product for products. Columns of unmapped SQL types are left out and reported as warnings.
+func NewStruct(table string, columns []Column) (domain.Struct, []string, error) {
+	builder := domain.NewStruct(goName(inflection.Singular(tableName(table))))
+
+	var warnings, primaryKeys []string
+	for _, column := range columns {
+		// Primary keys are never NULL, whatever the driver reports
+		fieldType, typeName, ok := GoType(column.DatabaseType, column.Nullable && !column.PrimaryKey)
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("column %s: unsupported type %s, skipped", column.Name, column.DatabaseType))
+			continue
+		}
+
+		var imports []string
+		if strings.TrimPrefix(typeName, "*") == "time.Time" {
+			imports = []string{"time"}
+		}
+		name := goName(column.Name)
+		builder.AddTypedField(name, column.Name, typeName, fieldType, imports...)
+		if column.PrimaryKey {
+			primaryKeys = append(primaryKeys, name)
+		}
+	}
+
+	s, err := builder.PrimaryKey(primaryKeys...).Build()
+	if err != nil {
+		return domain.Struct{}, warnings, err
+	}
+	return s, warnings, nil
+}
+
+// StructSource renders the Go declaration of s, annotated for querybuilder generation and
+// mapped to table by a TableName method
+func StructSource(packageName, table string, s domain.Struct) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by querybuilder from table %s.\n\npackage %s\n\n", table, packageName)
+	if imports := s.Imports(); len(imports) > 0 {
+		buf.WriteString("import (\n")
+		for _, path := range imports {
+			fmt.Fprintf(&buf, "\t%q\n", path)
+		}
+		buf.WriteString(")\n\n")
+	}
+
+	fmt.Fprintf(&buf, "// %s is a row of the %s table\n//\n//gen:querybuilder\ntype %s struct {\n", s.Name, table, s.Name)
+	for _, f := range s.Fields {
+		tag := "column:" + f.DBName
+		if f.PrimaryKey {
+			tag += ";primaryKey"
+		}
+		fmt.Fprintf(&buf, "\t%s %s `gorm:%q json:%q`\n", f.Name, f.TypeName, tag, f.DBName)
+	}
+	buf.WriteString("}\n\n")
+
+	fmt.Fprintf(&buf, "// TableName maps %s to the %s table\nfunc (%s) TableName() string {\n\treturn %q\n}\n", s.Name, table, s.Name, table)
+
+	return format.Source(buf.Bytes())
+}
+
+// tableName strips a schema qualifier such as public. from table
+func tableName(table string) string {
+	return table[strings.LastIndexByte(table, '.')+1:]
+}
+
+// goName converts a snake_case column or table name to an exported Go name, keeping
+// field.DefaultAcronyms upper-case so that the names map back to the same columns
+func goName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' || r == ' ' }) {
+		if i := slices.IndexFunc(field.DefaultAcronyms, func(acronym string) bool { return strings.EqualFold(acronym, part) }); i >= 0 {
+			b.WriteString(field.DefaultAcronyms[i])
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+
+	result := b.String()
+	if result == "" || result[0] >= '0' && result[0] <= '9' {
+		result = "X" + result
+	}
+	return result
+}
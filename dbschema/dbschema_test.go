@@ -0,0 +1,150 @@
+package dbschema
+
+import (
+	"errors"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/dchlong/querybuilder/domain"
+	"github.com/dchlong/querybuilder/repository"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	err = db.Exec(`CREATE TABLE products (
+		id INTEGER PRIMARY KEY,
+		name VARCHAR(100) NOT NULL,
+		api_url TEXT NULL,
+		price NUMERIC(10,2) NOT NULL,
+		is_active BOOLEAN NOT NULL,
+		published_at DATETIME NULL,
+		payload BLOB
+	)`).Error
+	if err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	return db
+}
+
+func TestReadColumns(t *testing.T) {
+	db := setupDB(t)
+
+	columns, err := ReadColumns(db, "products")
+	if err != nil {
+		t.Fatalf("ReadColumns() error = %v", err)
+	}
+	if len(columns) != 7 {
+		t.Fatalf("ReadColumns() = %d columns, want 7", len(columns))
+	}
+	if id := columns[0]; id.Name != "id" || !id.PrimaryKey {
+		t.Errorf("columns[0] = %+v, want the id primary key", id)
+	}
+	if name := columns[1]; name.Name != "name" || name.Nullable {
+		t.Errorf("columns[1] = %+v, want non-null name", name)
+	}
+	if url := columns[2]; url.Name != "api_url" || !url.Nullable {
+		t.Errorf("columns[2] = %+v, want nullable api_url", url)
+	}
+
+	if _, err := ReadColumns(db, "missing"); !errors.Is(err, repository.ErrTableNotFound) {
+		t.Errorf("ReadColumns(missing) error = %v, want ErrTableNotFound", err)
+	}
+}
+
+func TestGoType(t *testing.T) {
+	tests := []struct {
+		databaseType string
+		nullable     bool
+		wantType     domain.FieldType
+		wantTypeName string
+		wantOK       bool
+	}{
+		{"varchar", false, domain.FieldTypeString, "string", true},
+		{"character varying(255)", false, domain.FieldTypeString, "string", true},
+		{"INT8", false, domain.FieldTypeNumeric, "int64", true},
+		{"int unsigned", false, domain.FieldTypeNumeric, "int64", true},
+		{"numeric(10,2)", false, domain.FieldTypeNumeric, "float64", true},
+		{"timestamptz", false, domain.FieldTypeTime, "time.Time", true},
+		{"bool", false, domain.FieldTypeBool, "bool", true},
+		{"text", true, domain.FieldTypePointer, "*string", true},
+		{"timestamp with time zone", true, domain.FieldTypePointer, "*time.Time", true},
+		{"jsonb", false, domain.FieldTypeUnknown, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.databaseType, func(t *testing.T) {
+			fieldType, typeName, ok := GoType(tt.databaseType, tt.nullable)
+			if fieldType != tt.wantType || typeName != tt.wantTypeName || ok != tt.wantOK {
+				t.Errorf("GoType(%q, %v) = %v, %q, %v, want %v, %q, %v", tt.databaseType, tt.nullable,
+					fieldType, typeName, ok, tt.wantType, tt.wantTypeName, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNewStruct(t *testing.T) {
+	columns, err := ReadColumns(setupDB(t), "products")
+	if err != nil {
+		t.Fatalf("ReadColumns() error = %v", err)
+	}
+
+	s, warnings, err := NewStruct("public.products", columns)
+	if err != nil {
+		t.Fatalf("NewStruct() error = %v", err)
+	}
+	if s.Name != "Product" {
+		t.Errorf("Name = %q, want Product", s.Name)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "payload") {
+		t.Errorf("warnings = %v, want the blob column skipped", warnings)
+	}
+
+	want := map[string]string{
+		"ID":          "int64",
+		"Name":        "string",
+		"APIURL":      "*string",
+		"Price":       "float64",
+		"IsActive":    "bool",
+		"PublishedAt": "*time.Time",
+	}
+	if len(s.Fields) != len(want) {
+		t.Fatalf("Fields = %+v, want %d fields", s.Fields, len(want))
+	}
+	for _, f := range s.Fields {
+		if want[f.Name] != f.TypeName {
+			t.Errorf("%s has type %q, want %q", f.Name, f.TypeName, want[f.Name])
+		}
+	}
+	if keys := s.PrimaryKeyFields(); len(keys) != 1 || keys[0].DBName != "id" {
+		t.Errorf("PrimaryKeyFields() = %v, want id", keys)
+	}
+
+	source, err := StructSource("models", "products", s)
+	if err != nil {
+		t.Fatalf("StructSource() error = %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "product.go", source, parser.ParseComments); err != nil {
+		t.Fatalf("StructSource() is not valid Go: %v\n%s", err, source)
+	}
+	for _, part := range []string{
+		"package models",
+		`"time"`,
+		"//gen:querybuilder\ntype Product struct",
+		"ID          int64      `gorm:\"column:id;primaryKey\" json:\"id\"`",
+		"APIURL      *string    `gorm:\"column:api_url\" json:\"api_url\"`",
+		`return "products"`,
+	} {
+		if !strings.Contains(string(source), part) {
+			t.Errorf("StructSource() missing %q:\n%s", part, source)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"fmt"
+	"go/token"
+	"slices"
+
+	"github.com/dchlong/querybuilder/repository"
+)
+
+// defaultTypeNames are the Go types AddField gives fields of each type classification
+var defaultTypeNames = map[FieldType]string{
+	FieldTypeString:  "string",
+	FieldTypeNumeric: "int64",
+	FieldTypeTime:    "time.Time",
+	FieldTypeBool:    "bool",
+}
+
+// StructBuilder assembles a Struct from a source other than Go code, e.g. a database schema,
+// so it can be passed to the generator. The first invalid call is reported by Build.
+type StructBuilder struct {
+	s   Struct
+	err error
+}
+
+// NewStruct starts a Struct for the Go type name, which must be an exported identifier
+func NewStruct(name string) *StructBuilder {
+	b := &StructBuilder{s: Struct{Name: name}}
+	if !token.IsIdentifier(name) || !token.IsExported(name) {
+		b.err = fmt.Errorf("%w: %q is not an exported Go identifier", repository.ErrInvalidStruct, name)
+	}
+	return b
+}
+
+// AddField adds a column of a string, numeric, time or bool type, using string, int64,
+// time.Time or bool as its Go type
+func (b *StructBuilder) AddField(name, dbName string, fieldType FieldType) *StructBuilder {
+	typeName, ok := defaultTypeNames[fieldType]
+	if !ok {
+		b.fail(fmt.Errorf("%w: field %s: type %s needs a Go type, see AddTypedField", repository.ErrInvalidStruct, name, fieldType))
+		return b
+	}
+
+	var imports []string
+	if fieldType == FieldTypeTime {
+		imports = []string{"time"}
+	}
+	return b.AddTypedField(name, dbName, typeName, fieldType, imports...)
+}
+
+// AddTypedField adds a column of the given Go type, e.g. "float64" or "uuid.UUID", importing
+// the packages the type refers to
+func (b *StructBuilder) AddTypedField(name, dbName, typeName string, fieldType FieldType, imports ...string) *StructBuilder {
+	switch {
+	case !token.IsIdentifier(name) || !token.IsExported(name):
+		b.fail(fmt.Errorf("%w: field %q is not an exported Go identifier", repository.ErrInvalidStruct, name))
+	case dbName == "":
+		b.fail(fmt.Errorf("%w: field %s has no column name", repository.ErrInvalidStruct, name))
+	case typeName == "":
+		b.fail(fmt.Errorf("%w: field %s has no Go type", repository.ErrInvalidStruct, name))
+	case fieldType == FieldTypeUnknown || fieldType.String() == "unknown":
+		b.fail(fmt.Errorf("%w: field %s has an unknown field type", repository.ErrInvalidStruct, name))
+	}
+	for _, field := range b.s.Fields {
+		switch {
+		case field.Name == name:
+			b.fail(fmt.Errorf("%w: duplicate field %s", repository.ErrInvalidStruct, name))
+		case field.DBName == dbName:
+			b.fail(fmt.Errorf("%w: fields %s and %s share column %s", repository.ErrInvalidStruct, field.Name, name, dbName))
+		}
+	}
+
+	b.s.Fields = append(b.s.Fields, Field{
+		Name:     name,
+		DBName:   dbName,
+		Type:     fieldType,
+		TypeName: typeName,
+		GoType:   typeName,
+		Imports:  imports,
+	})
+	return b
+}
+
+// PrimaryKey marks the named fields as the primary key; without it a field named ID is used
+func (b *StructBuilder) PrimaryKey(names ...string) *StructBuilder {
+	for _, name := range names {
+		i := slices.IndexFunc(b.s.Fields, func(f Field) bool { return f.Name == name })
+		if i < 0 {
+			b.fail(fmt.Errorf("%w: primary key field %s is not defined", repository.ErrInvalidStruct, name))
+			continue
+		}
+		b.s.Fields[i].PrimaryKey = true
+	}
+	return b
+}
+
+// Build returns the Struct, or the first error of the builder's calls
+func (b *StructBuilder) Build() (Struct, error) {
+	if b.err != nil {
+		return Struct{}, b.err
+	}
+	if len(b.s.Fields) == 0 {
+		return Struct{}, fmt.Errorf("%w: %s has no fields", repository.ErrInvalidStruct, b.s.Name)
+	}
+	return b.s, nil
+}
+
+// fail records err unless an earlier call already failed
+func (b *StructBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
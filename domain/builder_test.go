@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dchlong/querybuilder/repository"
+)
+
+func TestStructBuilder(t *testing.T) {
+	s, err := NewStruct("Account").
+		AddField("ID", "id", FieldTypeNumeric).
+		AddField("Email", "email", FieldTypeString).
+		AddField("CreatedAt", "created_at", FieldTypeTime).
+		AddTypedField("Balance", "balance", "float64", FieldTypeNumeric).
+		PrimaryKey("ID").
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if s.Name != "Account" || len(s.Fields) != 4 {
+		t.Fatalf("Build() = %+v, want Account with 4 fields", s)
+	}
+	if f := s.Fields[2]; f.TypeName != "time.Time" || len(f.Imports) != 1 || f.Imports[0] != "time" {
+		t.Errorf("CreatedAt = %+v, want time.Time importing time", f)
+	}
+	if f := s.Fields[3]; f.TypeName != "float64" || len(f.SupportedOperators()) == 0 {
+		t.Errorf("Balance = %+v, want a filterable float64", f)
+	}
+	if keys := s.PrimaryKeyFields(); len(keys) != 1 || !keys[0].PrimaryKey {
+		t.Errorf("PrimaryKeyFields() = %v, want the tagged ID", keys)
+	}
+}
+
+func TestStructBuilder_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *StructBuilder
+	}{
+		{"unexported struct", NewStruct("account").AddField("ID", "id", FieldTypeNumeric)},
+		{"no fields", NewStruct("Account")},
+		{"invalid field name", NewStruct("Account").AddField("first name", "first_name", FieldTypeString)},
+		{"missing column", NewStruct("Account").AddField("ID", "", FieldTypeNumeric)},
+		{"unknown type", NewStruct("Account").AddTypedField("ID", "id", "int64", FieldTypeUnknown)},
+		{"type without default", NewStruct("Account").AddField("Tags", "tags", FieldTypeArray)},
+		{"duplicate field", NewStruct("Account").AddField("ID", "id", FieldTypeNumeric).AddField("ID", "key", FieldTypeNumeric)},
+		{"duplicate column", NewStruct("Account").AddField("ID", "id", FieldTypeNumeric).AddField("Key", "id", FieldTypeNumeric)},
+		{"undefined primary key", NewStruct("Account").AddField("ID", "id", FieldTypeNumeric).PrimaryKey("Code")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.builder.Build(); !errors.Is(err, repository.ErrInvalidStruct) {
+				t.Errorf("Build() error = %v, want ErrInvalidStruct", err)
+			}
+		})
+	}
+}
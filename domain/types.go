@@ -1,6 +1,11 @@
 package domain
 
-import "github.com/dchlong/querybuilder/repository"
+import (
+	"slices"
+	"strings"
+
+	"github.com/dchlong/querybuilder/repository"
+)
 
 // FieldType represents the type classification of a struct field
 type FieldType int
@@ -15,6 +20,12 @@ const (
 	FieldTypeSlice
 	FieldTypeStruct
 	FieldTypeMap
+	FieldTypeHStore
+	FieldTypeAssociation
+	FieldTypeJSON
+	FieldTypeJSONArray
+	FieldTypeArray
+	FieldTypeScalar
 )
 
 // String returns the string representation of FieldType
@@ -36,27 +47,148 @@ func (ft FieldType) String() string {
 		return "struct"
 	case FieldTypeMap:
 		return "map"
+	case FieldTypeHStore:
+		return "hstore"
+	case FieldTypeAssociation:
+		return "association"
+	case FieldTypeJSON:
+		return "json"
+	case FieldTypeJSONArray:
+		return "json array"
+	case FieldTypeArray:
+		return "array"
+	case FieldTypeScalar:
+		return "scalar"
 	default:
 		return "unknown"
 	}
 }
 
+// IndexColumn places a field in a unique index. Name is empty for an unnamed single-column index;
+// the columns of a composite index are ordered by Priority, then by field order.
+type IndexColumn struct {
+	Name     string
+	Priority int
+}
+
+// UniqueIndex is a unique index over one or more columns of a struct, in index order
+type UniqueIndex struct {
+	Name   string
+	Fields []Field
+}
+
+// Constraint names of the querybuilder:"min=0,maxlen=255" tag options
+const (
+	ConstraintMin      = "min"      // Number at least Value
+	ConstraintMax      = "max"      // Number at most Value
+	ConstraintMinLen   = "minlen"   // String of at least Value characters
+	ConstraintMaxLen   = "maxlen"   // String of at most Value characters
+	ConstraintNotEmpty = "notempty" // Non-empty string
+)
+
+// Constraint is a validation rule the generated updater's Validate checks the staged value against.
+// Value is the bound as a Go literal, empty for ConstraintNotEmpty.
+type Constraint struct {
+	Name  string
+	Value string
+}
+
 // Field represents a struct field with its metadata
 type Field struct {
-	Name     string    // Go field name
-	DBName   string    // Database column name
-	Type     FieldType // Field type classification
-	TypeName string    // Go type name
-	GoType   string    // Full Go type (e.g., "*time.Time")
+	Name              string                // Go field name
+	DBName            string                // Database column name
+	Type              FieldType             // Field type classification
+	TypeName          string                // Go type name
+	GoType            string                // Full Go type (e.g., "*time.Time")
+	Doc               string                // Doc comment of the struct field, appended to generated method docs
+	Nullable          bool                  // Non-pointer type that can hold NULL (e.g. gorm.DeletedAt)
+	ValueTypeName     string                // Type filters take for a wrapper such as sql.NullString, empty for TypeName
+	PrimaryKey        bool                  // Tagged gorm:"primaryKey"
+	ReadOnly          bool                  // Never set by updaters
+	Version           bool                  // Optimistic locking version, checked and incremented by GormRepository.Update
+	UniqueIndexes     []IndexColumn         // Unique indexes the column belongs to
+	ElemTypeName      string                // Element type of a JSON or native array field
+	Imports           []string              // Import paths of the packages the Go type refers to
+	ExcludedOperators []repository.Operator // Operators disabled by annotation
+	CustomOperators   []string              // Method suffixes of registered operators enabled by tag
+	FullText          bool                  // Tagged for full-text search
+	FullTextConfig    string                // Postgres text search configuration, empty for the default
+	Constraints       []Constraint          // Checked by the updater's Validate
+}
+
+// FilterTypeName returns the Go type filter methods take: the held value for a database/sql
+// wrapper such as sql.NullString, since NULL is matched by IsNull, otherwise the field's type
+func (f Field) FilterTypeName() string {
+	if f.ValueTypeName != "" {
+		return f.ValueTypeName
+	}
+	return f.TypeName
 }
 
 // IsFilterable returns true if the field can be used in filters
 func (f Field) IsFilterable() bool {
-	return f.Type != FieldTypeSlice && f.Type != FieldTypeStruct && f.Type != FieldTypeMap
+	return f.Type != FieldTypeSlice && f.Type != FieldTypeStruct && f.Type != FieldTypeMap && f.IsColumn()
+}
+
+// IsSortable returns true if results can be ordered and grouped by the field.
+// Postgres has no ordering for json columns.
+func (f Field) IsSortable() bool {
+	return f.IsFilterable() && f.Type != FieldTypeJSON && f.Type != FieldTypeJSONArray
+}
+
+// IsColumn returns true if the field is stored in a column of the struct's own table.
+// Associations are loaded from related tables instead.
+func (f Field) IsColumn() bool {
+	return f.Type != FieldTypeAssociation
 }
 
-// SupportedOperators returns the operators supported by this field type
+// SupportedOperators returns the operators supported by this field type, minus the excluded ones.
+// For pointer fields Eq and Ne take the pointer, and a nil pointer compares as IS NULL and
+// IS NOT NULL; the generator adds an EqValue method taking the pointed-to value.
 func (f Field) SupportedOperators() []repository.Operator {
+	operators := f.typeOperators()
+	if len(f.ExcludedOperators) == 0 {
+		return operators
+	}
+
+	supported := make([]repository.Operator, 0, len(operators))
+	for _, op := range operators {
+		if !slices.Contains(f.ExcludedOperators, op) {
+			supported = append(supported, op)
+		}
+	}
+	return supported
+}
+
+// typeOperators returns the operators supported by the field type
+func (f Field) typeOperators() []repository.Operator {
+	if f.Type == FieldTypeJSON {
+		// JSON documents are queried by key; comparing whole documents depends on formatting
+		return []repository.Operator{
+			repository.OperatorJSONHasKey,
+			repository.OperatorJSONEqual,
+		}
+	}
+
+	if f.Type == FieldTypeJSONArray {
+		return []repository.Operator{repository.OperatorJSONContains}
+	}
+
+	if f.Type == FieldTypeArray {
+		return []repository.Operator{
+			repository.OperatorArrayContains,
+			repository.OperatorArrayOverlaps,
+		}
+	}
+
+	if f.Type == FieldTypeHStore {
+		// hstore columns are only queried by key, never compared as a whole
+		return []repository.Operator{
+			repository.OperatorHStoreHasKey,
+			repository.OperatorHStoreGet,
+		}
+	}
+
 	base := []repository.Operator{
 		repository.OperatorEqual,
 		repository.OperatorNotEqual,
@@ -64,9 +196,11 @@ func (f Field) SupportedOperators() []repository.Operator {
 
 	switch f.Type {
 	case FieldTypeString:
-		return append(base,
+		operators := append(base,
 			repository.OperatorLike,
 			repository.OperatorNotLike,
+			repository.OperatorILike,
+			repository.OperatorRegex,
 			repository.OperatorIn,
 			repository.OperatorNotIn,
 			repository.OperatorLessThan,
@@ -74,20 +208,41 @@ func (f Field) SupportedOperators() []repository.Operator {
 			repository.OperatorLessThanOrEqual,
 			repository.OperatorGreaterThanOrEqual,
 		)
+		if f.FullText {
+			operators = append(operators, repository.OperatorFullText)
+		}
+		if f.Nullable {
+			operators = append(operators, repository.OperatorIsNull, repository.OperatorIsNotNull)
+		}
+		return operators
 	case FieldTypeNumeric, FieldTypeTime:
-		return append(base,
+		operators := append(base,
 			repository.OperatorLessThan,
 			repository.OperatorGreaterThan,
 			repository.OperatorLessThanOrEqual,
 			repository.OperatorGreaterThanOrEqual,
 			repository.OperatorIn,
 			repository.OperatorNotIn,
+			repository.OperatorBetween,
+			repository.OperatorNotBetween,
 		)
+		if f.Nullable {
+			operators = append(operators, repository.OperatorIsNull, repository.OperatorIsNotNull)
+		}
+		return operators
 	case FieldTypePointer:
 		return append(base,
 			repository.OperatorIsNull,
 			repository.OperatorIsNotNull,
 		)
+	case FieldTypeScalar:
+		// Opaque values such as UUIDs have no meaningful order or pattern
+		return append(base, repository.OperatorIn, repository.OperatorNotIn)
+	case FieldTypeBool:
+		if f.Nullable {
+			return append(base, repository.OperatorIsNull, repository.OperatorIsNotNull)
+		}
+		return base
 	default:
 		return base
 	}
@@ -96,8 +251,85 @@ func (f Field) SupportedOperators() []repository.Operator {
 // Struct represents a Go struct with querybuilder generation metadata
 type Struct struct {
 	Name        string  // Go struct name
+	EntityName  string  // Go type the struct was parsed from, when Name carries a suffix
+	EntityPkg   string  // Import path of the entity's package, when the code is generated into another one
 	PackageName string  // Package name
 	Fields      []Field // Struct fields
+	Source      string  // Declaration position as "file.go:line", relative to the module root
+}
+
+// EntityTypeName returns the Go type of the entity the generated code queries, qualified by
+// PackageName when the entity lives in another package
+func (s Struct) EntityTypeName() string {
+	name := s.Name
+	if s.EntityName != "" {
+		name = s.EntityName
+	}
+	if s.EntityPkg != "" {
+		return s.PackageName + "." + name
+	}
+	return name
+}
+
+// PrimaryKeyFields returns the columns tagged gorm:"primaryKey" or, like GORM, the ID field
+// when none are tagged
+func (s Struct) PrimaryKeyFields() []Field {
+	var keys []Field
+	for _, field := range s.ColumnFields() {
+		if field.PrimaryKey {
+			keys = append(keys, field)
+		}
+	}
+	if len(keys) > 0 {
+		return keys
+	}
+
+	for _, field := range s.ColumnFields() {
+		if field.Name == "ID" {
+			return []Field{field}
+		}
+	}
+	return nil
+}
+
+// UniqueIndexes returns the unique indexes of the struct's columns in order of first declaration,
+// grouping the columns of composite indexes by name
+func (s Struct) UniqueIndexes() []UniqueIndex {
+	// Unnamed indexes cover a single column, so they are keyed by the field as well
+	type indexKey struct{ name, field string }
+	type indexed struct {
+		field    Field
+		priority int
+	}
+
+	var keys []indexKey
+	columns := make(map[indexKey][]indexed)
+	for _, field := range s.ColumnFields() {
+		for _, index := range field.UniqueIndexes {
+			key := indexKey{name: index.Name}
+			if index.Name == "" {
+				key.field = field.Name
+			}
+			if _, ok := columns[key]; !ok {
+				keys = append(keys, key)
+			}
+			columns[key] = append(columns[key], indexed{field: field, priority: index.Priority})
+		}
+	}
+
+	indexes := make([]UniqueIndex, 0, len(keys))
+	for _, key := range keys {
+		// Stable, so that columns of equal priority keep the field order
+		sorted := columns[key]
+		slices.SortStableFunc(sorted, func(a, b indexed) int { return a.priority - b.priority })
+
+		index := UniqueIndex{Name: key.name}
+		for _, column := range sorted {
+			index.Fields = append(index.Fields, column.field)
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes
 }
 
 // FilterableFields returns only the fields that can be used in filters
@@ -111,6 +343,53 @@ func (s Struct) FilterableFields() []Field {
 	return filterable
 }
 
+// UpdatableFields returns the column fields updaters can set
+func (s Struct) UpdatableFields() []Field {
+	var fields []Field
+	for _, field := range s.ColumnFields() {
+		if !field.ReadOnly {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// ColumnFields returns the fields stored in the struct's own table
+func (s Struct) ColumnFields() []Field {
+	var columns []Field
+	for _, field := range s.Fields {
+		if field.IsColumn() {
+			columns = append(columns, field)
+		}
+	}
+	return columns
+}
+
+// Imports returns the sorted import paths of the entity's package, when external, and of the
+// packages the Go types of the fields refer to
+func (s Struct) Imports() []string {
+	var imports []string
+	if s.EntityPkg != "" {
+		imports = append(imports, s.EntityPkg)
+	}
+	for _, field := range s.Fields {
+		imports = append(imports, field.Imports...)
+	}
+	slices.Sort(imports)
+	return slices.Compact(imports)
+}
+
+// AssociationFields returns the fields referencing related models
+func (s Struct) AssociationFields() []Field {
+	var associations []Field
+	for _, field := range s.Fields {
+		if !field.IsColumn() {
+			associations = append(associations, field)
+		}
+	}
+	return associations
+}
+
 // Method represents a generated method
 type Method struct {
 	Name          string // Method name
@@ -120,3 +399,56 @@ type Method struct {
 	Body          string // Method body
 	Documentation string // Method documentation
 }
+
+// FilterStorage selects how generated filter types store their conditions
+type FilterStorage string
+
+const (
+	FilterStorageMap   FilterStorage = "map"   // Call order, indexed by field
+	FilterStorageSlice FilterStorage = "slice" // Flat slice in call order
+)
+
+// IsValid returns true if the storage mode is known; empty selects the map default
+func (s FilterStorage) IsValid() bool {
+	switch s {
+	case "", FilterStorageMap, FilterStorageSlice:
+		return true
+	default:
+		return false
+	}
+}
+
+// operatorKeywords maps the lowercase method suffix of each operator to the operator
+var operatorKeywords = map[string]repository.Operator{
+	"eq":            repository.OperatorEqual,
+	"ne":            repository.OperatorNotEqual,
+	"lt":            repository.OperatorLessThan,
+	"lte":           repository.OperatorLessThanOrEqual,
+	"gt":            repository.OperatorGreaterThan,
+	"gte":           repository.OperatorGreaterThanOrEqual,
+	"like":          repository.OperatorLike,
+	"notlike":       repository.OperatorNotLike,
+	"ilike":         repository.OperatorILike,
+	"matches":       repository.OperatorRegex,
+	"search":        repository.OperatorFullText,
+	"isnull":        repository.OperatorIsNull,
+	"isnotnull":     repository.OperatorIsNotNull,
+	"in":            repository.OperatorIn,
+	"notin":         repository.OperatorNotIn,
+	"hstorehaskey":  repository.OperatorHStoreHasKey,
+	"hstoreget":     repository.OperatorHStoreGet,
+	"haskey":        repository.OperatorJSONHasKey,
+	"equals":        repository.OperatorJSONEqual,
+	"contains":      repository.OperatorJSONContains,
+	"arraycontains": repository.OperatorArrayContains,
+	"arrayoverlaps": repository.OperatorArrayOverlaps,
+	"between":       repository.OperatorBetween,
+	"notbetween":    repository.OperatorNotBetween,
+}
+
+// ParseOperatorKeyword returns the operator named by its method suffix, e.g. "notlike" for NotLike.
+// Matching is case-insensitive.
+func ParseOperatorKeyword(keyword string) (repository.Operator, bool) {
+	op, ok := operatorKeywords[strings.ToLower(keyword)]
+	return op, ok
+}
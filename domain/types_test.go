@@ -21,6 +21,10 @@ func TestFieldType_String(t *testing.T) {
 		{"slice type", FieldTypeSlice, "slice"},
 		{"struct type", FieldTypeStruct, "struct"},
 		{"map type", FieldTypeMap, "map"},
+		{"hstore type", FieldTypeHStore, "hstore"},
+		{"json type", FieldTypeJSON, "json"},
+		{"json array type", FieldTypeJSONArray, "json array"},
+		{"array type", FieldTypeArray, "array"},
 		{"unknown type", FieldTypeUnknown, "unknown"},
 	}
 
@@ -88,6 +92,14 @@ func TestField_IsFilterable(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "hstore field is filterable",
+			field: Field{
+				Name: "Attributes",
+				Type: FieldTypeHStore,
+			},
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -116,6 +128,8 @@ func TestField_SupportedOperators(t *testing.T) {
 				repository.OperatorNotEqual,
 				repository.OperatorLike,
 				repository.OperatorNotLike,
+				repository.OperatorILike,
+				repository.OperatorRegex,
 				repository.OperatorIn,
 				repository.OperatorNotIn,
 				repository.OperatorLessThan,
@@ -124,6 +138,28 @@ func TestField_SupportedOperators(t *testing.T) {
 				repository.OperatorGreaterThanOrEqual,
 			},
 		},
+		{
+			name: "full-text string field adds search",
+			field: Field{
+				Type:     FieldTypeString,
+				FullText: true,
+			},
+			expected: []repository.Operator{
+				repository.OperatorEqual,
+				repository.OperatorNotEqual,
+				repository.OperatorLike,
+				repository.OperatorNotLike,
+				repository.OperatorILike,
+				repository.OperatorRegex,
+				repository.OperatorIn,
+				repository.OperatorNotIn,
+				repository.OperatorLessThan,
+				repository.OperatorGreaterThan,
+				repository.OperatorLessThanOrEqual,
+				repository.OperatorGreaterThanOrEqual,
+				repository.OperatorFullText,
+			},
+		},
 		{
 			name: "numeric field supports numeric operators",
 			field: Field{
@@ -138,6 +174,8 @@ func TestField_SupportedOperators(t *testing.T) {
 				repository.OperatorGreaterThanOrEqual,
 				repository.OperatorIn,
 				repository.OperatorNotIn,
+				repository.OperatorBetween,
+				repository.OperatorNotBetween,
 			},
 		},
 		{
@@ -154,6 +192,29 @@ func TestField_SupportedOperators(t *testing.T) {
 				repository.OperatorGreaterThanOrEqual,
 				repository.OperatorIn,
 				repository.OperatorNotIn,
+				repository.OperatorBetween,
+				repository.OperatorNotBetween,
+			},
+		},
+		{
+			name: "nullable time field also supports null operators",
+			field: Field{
+				Type:     FieldTypeTime,
+				Nullable: true,
+			},
+			expected: []repository.Operator{
+				repository.OperatorEqual,
+				repository.OperatorNotEqual,
+				repository.OperatorLessThan,
+				repository.OperatorGreaterThan,
+				repository.OperatorLessThanOrEqual,
+				repository.OperatorGreaterThanOrEqual,
+				repository.OperatorIn,
+				repository.OperatorNotIn,
+				repository.OperatorBetween,
+				repository.OperatorNotBetween,
+				repository.OperatorIsNull,
+				repository.OperatorIsNotNull,
 			},
 		},
 		{
@@ -168,6 +229,45 @@ func TestField_SupportedOperators(t *testing.T) {
 				repository.OperatorIsNotNull,
 			},
 		},
+		{
+			name: "hstore field supports only key operators",
+			field: Field{
+				Type: FieldTypeHStore,
+			},
+			expected: []repository.Operator{
+				repository.OperatorHStoreHasKey,
+				repository.OperatorHStoreGet,
+			},
+		},
+		{
+			name: "json field supports only key operators",
+			field: Field{
+				Type: FieldTypeJSON,
+			},
+			expected: []repository.Operator{
+				repository.OperatorJSONHasKey,
+				repository.OperatorJSONEqual,
+			},
+		},
+		{
+			name: "json array field supports only contains",
+			field: Field{
+				Type: FieldTypeJSONArray,
+			},
+			expected: []repository.Operator{
+				repository.OperatorJSONContains,
+			},
+		},
+		{
+			name: "native array field supports array operators",
+			field: Field{
+				Type: FieldTypeArray,
+			},
+			expected: []repository.Operator{
+				repository.OperatorArrayContains,
+				repository.OperatorArrayOverlaps,
+			},
+		},
 		{
 			name: "bool field supports basic operators",
 			field: Field{
@@ -216,6 +316,105 @@ func TestStruct_FilterableFields(t *testing.T) {
 }
 
 // Generic type tests
+func TestStruct_PrimaryKeyFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []Field
+		want   []string
+	}{
+		{"tagged keys", []Field{{Name: "ID"}, {Name: "TenantID", PrimaryKey: true}, {Name: "Code", PrimaryKey: true}}, []string{"TenantID", "Code"}},
+		{"ID by default", []Field{{Name: "Name"}, {Name: "ID"}}, []string{"ID"}},
+		{"no key", []Field{{Name: "Name"}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, field := range (Struct{Name: "Product", Fields: tt.fields}).PrimaryKeyFields() {
+				got = append(got, field.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Struct.PrimaryKeyFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStruct_UpdatableFields(t *testing.T) {
+	s := Struct{Name: "Product", Fields: []Field{
+		{Name: "ID", ReadOnly: true},
+		{Name: "Name"},
+		{Name: "Category", Type: FieldTypeAssociation},
+		{Name: "CreatedAt", ReadOnly: true},
+	}}
+
+	var got []string
+	for _, field := range s.UpdatableFields() {
+		got = append(got, field.Name)
+	}
+	if want := []string{"Name"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Struct.UpdatableFields() = %v, want %v", got, want)
+	}
+}
+
+func TestStruct_UniqueIndexes(t *testing.T) {
+	s := Struct{
+		Name: "Product",
+		Fields: []Field{
+			{Name: "ID"},
+			{Name: "SKU", UniqueIndexes: []IndexColumn{{Priority: 10}, {Name: "idx_category_sku", Priority: 10}}},
+			{Name: "Email", UniqueIndexes: []IndexColumn{{Priority: 10}}},
+			{Name: "CategoryID", UniqueIndexes: []IndexColumn{{Name: "idx_category_sku", Priority: 1}}},
+			{Name: "Owner", Type: FieldTypeAssociation, UniqueIndexes: []IndexColumn{{Priority: 10}}},
+		},
+	}
+
+	var got [][]string
+	for _, index := range s.UniqueIndexes() {
+		names := []string{index.Name}
+		for _, field := range index.Fields {
+			names = append(names, field.Name)
+		}
+		got = append(got, names)
+	}
+
+	want := [][]string{
+		{"", "SKU"},
+		{"idx_category_sku", "CategoryID", "SKU"},
+		{"", "Email"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Struct.UniqueIndexes() = %v, want %v", got, want)
+	}
+}
+
+func TestStruct_ExternalEntity(t *testing.T) {
+	s := Struct{
+		Name:        "ProductV1",
+		EntityName:  "Product",
+		PackageName: "models",
+		Fields: []Field{
+			{Name: "CreatedAt", Imports: []string{"time"}},
+			{Name: "UpdatedAt", Imports: []string{"time"}},
+		},
+	}
+
+	if got := s.EntityTypeName(); got != "Product" {
+		t.Errorf("EntityTypeName() = %q, want Product", got)
+	}
+	if got := s.Imports(); !reflect.DeepEqual(got, []string{"time"}) {
+		t.Errorf("Imports() = %v, want [time]", got)
+	}
+
+	s.EntityPkg = "example.com/app/models"
+	if got := s.EntityTypeName(); got != "models.Product" {
+		t.Errorf("EntityTypeName() = %q, want models.Product", got)
+	}
+	if got := s.Imports(); !reflect.DeepEqual(got, []string{"example.com/app/models", "time"}) {
+		t.Errorf("Imports() = %v, want the entity package and time", got)
+	}
+}
+
 func TestGenericFieldTypes(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -385,3 +584,65 @@ func TestMethod_Struct(t *testing.T) {
 		t.Errorf("Method.Documentation = %v, want 'NameEq filters by name equal'", method.Documentation)
 	}
 }
+
+func TestFilterStorage_IsValid(t *testing.T) {
+	tests := []struct {
+		storage  FilterStorage
+		expected bool
+	}{
+		{"", true},
+		{FilterStorageMap, true},
+		{FilterStorageSlice, true},
+		{"list", false},
+		{"MAP", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.storage), func(t *testing.T) {
+			if result := tt.storage.IsValid(); result != tt.expected {
+				t.Errorf("FilterStorage(%q).IsValid() = %v, want %v", tt.storage, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestField_SupportedOperators_Excluded(t *testing.T) {
+	field := Field{
+		Name:              "Name",
+		Type:              FieldTypeString,
+		ExcludedOperators: []repository.Operator{repository.OperatorLike, repository.OperatorNotLike},
+	}
+
+	operators := field.SupportedOperators()
+	for _, op := range operators {
+		if op == repository.OperatorLike || op == repository.OperatorNotLike {
+			t.Errorf("SupportedOperators() should not include excluded operator %s", op)
+		}
+	}
+	if len(operators) != len(Field{Type: FieldTypeString}.SupportedOperators())-2 {
+		t.Errorf("SupportedOperators() = %v, expected only the two exclusions removed", operators)
+	}
+}
+
+func TestParseOperatorKeyword(t *testing.T) {
+	tests := []struct {
+		keyword  string
+		expected repository.Operator
+		ok       bool
+	}{
+		{"eq", repository.OperatorEqual, true},
+		{"NotLike", repository.OperatorNotLike, true},
+		{"ISNULL", repository.OperatorIsNull, true},
+		{"notin", repository.OperatorNotIn, true},
+		{"regex", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.keyword, func(t *testing.T) {
+			op, ok := ParseOperatorKeyword(tt.keyword)
+			if op != tt.expected || ok != tt.ok {
+				t.Errorf("ParseOperatorKeyword(%q) = %v, %v, want %v, %v", tt.keyword, op, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
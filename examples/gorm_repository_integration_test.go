@@ -329,15 +329,503 @@ func TestGormRepositoryIntegration(t *testing.T) {
 	})
 }
 
+// TestGeneratedFindByUniqueIndex exercises the lookups generated for Product's unique indexes
+func TestGeneratedFindByUniqueIndex(t *testing.T) {
+	repo := repository.NewGormRepository[Product, *ProductFilters, *ProductUpdater](setupTestDB(t))
+	ctx := context.Background()
+	products := createTestProducts()
+	require.NoError(t, repo.Create(ctx, products...))
+
+	found, exists, err := ProductFindBySKU(ctx, repo, "SGD-002")
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, products[1].ID, found.ID)
+
+	found, exists, err = ProductFindByCategoryIDAndSKU(ctx, repo, products[2].CategoryID, products[2].SKU)
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, products[2].ID, found.ID)
+
+	_, exists, err = ProductFindByCategoryIDAndSKU(ctx, repo, products[2].CategoryID+1, products[2].SKU)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// The tags also create the index, so duplicates are rejected
+	err = repo.Create(ctx, &Product{Name: "Copy", SKU: "SGD-002"})
+	assert.True(t, repository.IsConstraintViolation(err))
+}
+
+// TestGeneratedCountDistinct exercises the generated ProductCountDistinct helper
+func TestGeneratedCountDistinct(t *testing.T) {
+	repo := repository.NewGormRepository[Product, *ProductFilters, *ProductUpdater](setupTestDB(t))
+	ctx := context.Background()
+	require.NoError(t, repo.Create(ctx, createTestProducts()...))
+
+	categories, err := ProductCountDistinct(ctx, repo, ProductDBSchema.CategoryID, NewProductFilters())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), categories)
+
+	categories, err = ProductCountDistinct(ctx, repo, ProductDBSchema.CategoryID, NewProductFilters().IsActiveEq(true))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), categories)
+
+	categories, err = ProductCountDistinct(ctx, repo, ProductDBSchema.CategoryID, NewProductFilters().IsActiveEq(false))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), categories)
+}
+
+// TestGeneratedCountByMonth exercises the generated ProductCountByMonth helper over CreatedAt
+func TestGeneratedCountByMonth(t *testing.T) {
+	repo := repository.NewGormRepository[Product, *ProductFilters, *ProductUpdater](setupTestDB(t))
+	ctx := context.Background()
+
+	products := createTestProducts()
+	products[0].CreatedAt = time.Date(2024, time.November, 3, 9, 0, 0, 0, time.UTC)
+	products[1].CreatedAt = time.Date(2024, time.December, 24, 18, 0, 0, 0, time.UTC)
+	products[2].CreatedAt = time.Date(2024, time.December, 31, 23, 59, 0, 0, time.UTC)
+	products[3].CreatedAt = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.Create(ctx, products...))
+
+	counts, err := ProductCountByMonth(ctx, repo, ProductDBSchema.CreatedAt, NewProductFilters())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{
+		"2024-11": 1,
+		"2024-12": 2,
+		"2025-01": 1,
+	}, counts)
+
+	counts, err = ProductCountByMonth(ctx, repo, ProductDBSchema.CreatedAt, NewProductFilters().IsActiveEq(true))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{
+		"2024-11": 1,
+		"2024-12": 1,
+		"2025-01": 1,
+	}, counts)
+}
+
 // setupTestDB creates an in-memory SQLite database for testing
+// TestGeneratedUpdateWhere performs a conditional bulk update with inline filter and updater
+func TestGeneratedUpdateWhere(t *testing.T) {
+	repo := repository.NewGormRepository[Product, *ProductFilters, *ProductUpdater](setupTestDB(t))
+	ctx := context.Background()
+	require.NoError(t, repo.Create(ctx, createTestProducts()...))
+
+	affected, err := ProductUpdateWhere(ctx, repo,
+		func(f *ProductFilters) { f.IsActiveEq(false) },
+		func(u *ProductUpdater) { u.SetStock(0).SetPrice(1) },
+	)
+	require.NoError(t, err)
+
+	inactive, err := repo.FindAll(ctx, NewProductFilters().IsActiveEq(false))
+	require.NoError(t, err)
+	require.NotEmpty(t, inactive)
+	assert.Equal(t, int64(len(inactive)), affected)
+	for _, product := range inactive {
+		assert.Equal(t, 0, product.Stock)
+		assert.Equal(t, 1.0, product.Price)
+	}
+
+	untouched, err := repo.Count(ctx, NewProductFilters().IsActiveEq(true).PriceEq(1))
+	require.NoError(t, err)
+	assert.Zero(t, untouched)
+
+	// Without changes nothing is updated
+	affected, err = ProductUpdateWhere(ctx, repo, func(f *ProductFilters) { f.IsActiveEq(true) }, nil)
+	require.NoError(t, err)
+	assert.Zero(t, affected)
+}
+
+// TestGeneratedBetween filters numeric and time columns by inclusive ranges
+func TestGeneratedBetween(t *testing.T) {
+	repo := repository.NewGormRepository[Product, *ProductFilters, *ProductUpdater](setupTestDB(t))
+	ctx := context.Background()
+
+	products := createTestProducts()
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for i, product := range products {
+		product.CreatedAt = start.AddDate(0, i, 0)
+	}
+	repo.MustCreate(ctx, products...)
+
+	lower, upper := products[1].Price, products[2].Price
+	if lower > upper {
+		lower, upper = upper, lower
+	}
+
+	inRange, err := repo.FindAll(ctx, NewProductFilters().PriceBetween(lower, upper))
+	require.NoError(t, err)
+	require.NotEmpty(t, inRange)
+	for _, product := range inRange {
+		assert.GreaterOrEqual(t, product.Price, lower)
+		assert.LessOrEqual(t, product.Price, upper)
+	}
+
+	outside, err := repo.Count(ctx, NewProductFilters().PriceNotBetween(lower, upper))
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(products)-len(inRange)), outside)
+
+	// Both bounds are inclusive
+	firstMonths, err := repo.FindAll(ctx, NewProductFilters().CreatedAtBetween(start, start.AddDate(0, 1, 0)))
+	require.NoError(t, err)
+	assert.Len(t, firstMonths, 2)
+}
+
+// TestGeneratedEmptyIn checks that IN/NOT IN methods called without arguments are safe
+func TestGeneratedAggregates(t *testing.T) {
+	repo := repository.NewGormRepository[Product, *ProductFilters, *ProductUpdater](setupTestDB(t))
+	ctx := context.Background()
+
+	products := createTestProducts()
+	repo.MustCreate(ctx, products...)
+
+	var totalStock, maxPrice float64
+	for _, product := range products {
+		totalStock += float64(product.Stock)
+		maxPrice = max(maxPrice, product.Price)
+	}
+
+	stock, hasRows, err := ProductSum(ctx, repo, ProductDBSchema.Stock, NewProductFilters())
+	require.NoError(t, err)
+	assert.True(t, hasRows)
+	assert.Equal(t, totalStock, stock)
+
+	price, hasRows, err := ProductMax(ctx, repo, ProductDBSchema.Price, NewProductFilters())
+	require.NoError(t, err)
+	assert.True(t, hasRows)
+	assert.Equal(t, maxPrice, price)
+
+	avg, hasRows, err := ProductAvg(ctx, repo, ProductDBSchema.Price, NewProductFilters().NameEq("no such product"))
+	require.NoError(t, err)
+	assert.False(t, hasRows)
+	assert.Zero(t, avg)
+}
+
+func TestGeneratedSelect(t *testing.T) {
+	repo := repository.NewGormRepository[Product, *ProductFilters, *ProductUpdater](setupTestDB(t))
+	ctx := context.Background()
+
+	repo.MustCreate(ctx, createTestProducts()...)
+
+	products, err := repo.FindAll(ctx, NewProductFilters(),
+		NewProductOptions().Select(ProductDBSchema.Name, ProductDBSchema.Price))
+	require.NoError(t, err)
+	require.NotEmpty(t, products)
+	for _, product := range products {
+		assert.NotZero(t, product.ID)
+		assert.NotEmpty(t, product.Name)
+		assert.NotZero(t, product.Price)
+		assert.Empty(t, product.SKU)
+		assert.Zero(t, product.Stock)
+	}
+}
+
+func TestGeneratedDistinct(t *testing.T) {
+	repo := repository.NewGormRepository[Product, *ProductFilters, *ProductUpdater](setupTestDB(t))
+	ctx := context.Background()
+
+	repo.MustCreate(ctx, createTestProducts()...)
+
+	categories, err := repo.FindAll(ctx, NewProductFilters(), NewProductOptions().Distinct(ProductDBSchema.CategoryID))
+	require.NoError(t, err)
+	seen := map[int64]bool{}
+	for _, product := range categories {
+		assert.False(t, seen[product.CategoryID], "category %d repeated", product.CategoryID)
+		seen[product.CategoryID] = true
+	}
+
+	// DISTINCT ON is Postgres-only
+	_, err = repo.FindAll(ctx, NewProductFilters(), NewProductOptions().DistinctOn(ProductDBSchema.CategoryID))
+	assert.ErrorIs(t, err, repository.ErrUnsupportedDialect)
+}
+
+func TestGeneratedFindPage(t *testing.T) {
+	repo := repository.NewGormRepository[Product, *ProductFilters, *ProductUpdater](setupTestDB(t))
+	ctx := context.Background()
+
+	products := createTestProducts()
+	repo.MustCreate(ctx, products...)
+
+	page, err := ProductFindPage(ctx, repo, NewProductFilters(), NewProductOptions().OrderByIDAsc().Paginate(2, 1))
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(products)), page.Total)
+	assert.Equal(t, 2, page.Page)
+	assert.Equal(t, len(products), page.TotalPages())
+	assert.Equal(t, len(products) > 2, page.HasNext())
+	assert.True(t, page.HasPrevious())
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, products[1].ID, page.Items[0].ID)
+
+	_, err = ProductFindPage(ctx, repo, NewProductFilters(), NewProductOptions().Paginate(0, 10))
+	assert.ErrorIs(t, err, repository.ErrInvalidPage)
+}
+
+func TestGeneratedWhereColumnOnJoin(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.Exec("CREATE TABLE categories (id INTEGER PRIMARY KEY, name TEXT)").Error)
+	require.NoError(t, db.Exec("INSERT INTO categories (id, name) VALUES (1, 'Tools'), (2, 'Gadgets')").Error)
+	repo := repository.NewGormRepository[Product, *ProductFilters, *ProductUpdater](db)
+	ctx := context.Background()
+
+	repo.MustCreate(ctx, createTestProducts()...)
+
+	// Both tables have a name column: the qualified filter targets categories, the typed one products
+	filters := NewProductFilters().
+		WhereColumn("categories.name", repository.OperatorEqual, "Tools").
+		NameLike("%Widget%")
+	products, err := repo.FindAll(ctx, filters,
+		repository.WithJoin("JOIN categories ON categories.id = products.category_id"),
+		NewProductOptions().OrderBy(ProductDBSchema.Name, repository.Asc))
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	assert.Equal(t, "Awesome Widget", products[0].Name)
+	assert.Equal(t, int64(1), products[0].CategoryID)
+}
+
+func TestGeneratedOrderBy(t *testing.T) {
+	repo := repository.NewGormRepository[Product, *ProductFilters, *ProductUpdater](setupTestDB(t))
+	ctx := context.Background()
+
+	repo.MustCreate(ctx, createTestProducts()...)
+
+	// Sort column and direction as they would arrive from "?sort=price&dir=desc"
+	dir, err := repository.ParseSortDirection("desc")
+	require.NoError(t, err)
+
+	products, err := repo.FindAll(ctx, NewProductFilters(), NewProductOptions().OrderBy(ProductDBSchema.Price, dir))
+	require.NoError(t, err)
+	require.NotEmpty(t, products)
+	for i := 1; i < len(products); i++ {
+		assert.GreaterOrEqual(t, products[i-1].Price, products[i].Price)
+	}
+
+	_, err = repo.FindAll(ctx, NewProductFilters(), NewProductOptions().OrderBy(ProductDBSchema.Price, "sideways"))
+	assert.ErrorIs(t, err, repository.ErrInvalidSortDirection)
+}
+
+func TestGeneratedGroupBy(t *testing.T) {
+	repo := repository.NewGormRepository[Product, *ProductFilters, *ProductUpdater](setupTestDB(t))
+	ctx := context.Background()
+
+	repo.MustCreate(ctx, createTestProducts()...)
+
+	var groups []struct {
+		IsActive bool
+		Count    int64
+		Stock    float64
+	}
+	err := repo.FindGrouped(ctx, NewProductFilters(), &groups,
+		NewProductOptions().GroupByIsActive().OrderByIsActiveDesc(),
+		repository.WithAggregate("COUNT", "*", "count"),
+		repository.WithAggregate("SUM", "stock", "stock"),
+		repository.WithHaving(&repository.Filter{Field: "COUNT(*)", Operator: repository.OperatorGreaterThan, Value: 0}),
+	)
+	require.NoError(t, err)
+	require.NotEmpty(t, groups)
+
+	total, err := repo.Count(ctx, NewProductFilters())
+	require.NoError(t, err)
+
+	var counted int64
+	for _, group := range groups {
+		counted += group.Count
+	}
+	assert.Equal(t, total, counted)
+	assert.True(t, groups[0].IsActive)
+}
+
+func TestGeneratedFindByPrimaryKey(t *testing.T) {
+	repo := repository.NewGormRepository[Product, *ProductFilters, *ProductUpdater](setupTestDB(t))
+	ctx := context.Background()
+
+	products := createTestProducts()
+	repo.MustCreate(ctx, products...)
+
+	found, exists, err := ProductFindByPrimaryKey(ctx, repo, products[1].ID)
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, products[1].Name, found.Name)
+
+	_, exists, err = ProductFindByPrimaryKey(ctx, repo, -1)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestGeneratedJSONFilters(t *testing.T) {
+	repo := repository.NewGormRepository[Product, *ProductFilters, *ProductUpdater](setupTestDB(t))
+	ctx := context.Background()
+
+	repo.MustCreate(ctx, createTestProducts()...)
+
+	products, err := repo.FindAll(ctx, NewProductFilters().AttributesHasKey("color"))
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	assert.Equal(t, "Awesome Widget", products[0].Name)
+
+	products, err = repo.FindAll(ctx, NewProductFilters().AttributesEquals("color", "blue"))
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+
+	products, err = repo.FindAll(ctx, NewProductFilters().AttributesEquals("color", "red"))
+	require.NoError(t, err)
+	assert.Empty(t, products)
+
+	products, err = repo.FindAll(ctx, NewProductFilters().TagsContains("premium"))
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	assert.Equal(t, "Premium Device", products[0].Name)
+}
+
+func TestGeneratedForUpdate(t *testing.T) {
+	repo := repository.NewGormRepository[Product, *ProductFilters, *ProductUpdater](setupTestDB(t))
+	ctx := context.Background()
+
+	repo.MustCreate(ctx, createTestProducts()...)
+
+	// SQLite has no row locks and ignores the clause; Postgres and MySQL would hold them until commit
+	err := repo.WithTransaction(ctx, func(txRepo *repository.GormRepository[Product, *ProductFilters, *ProductUpdater]) error {
+		products, err := txRepo.FindAll(ctx, NewProductFilters().IsActiveEq(true), NewProductOptions().ForUpdateSkipLocked())
+		if err != nil {
+			return err
+		}
+		if len(products) == 0 {
+			return fmt.Errorf("no active products to lock")
+		}
+
+		_, found, err := txRepo.FindOne(ctx, NewProductFilters().IDEq(products[0].ID), NewProductOptions().ForUpdate())
+		if err == nil && !found {
+			return fmt.Errorf("locked product %d not found", products[0].ID)
+		}
+		return err
+	})
+	require.NoError(t, err)
+}
+
+func TestGeneratedILike(t *testing.T) {
+	repo := repository.NewGormRepository[Product, *ProductFilters, *ProductUpdater](setupTestDB(t))
+	ctx := context.Background()
+
+	repo.MustCreate(ctx, createTestProducts()...)
+
+	// SQLite runs the LOWER(...) LIKE LOWER(?) fallback; Postgres would use ILIKE
+	products, err := repo.FindAll(ctx, NewProductFilters().NameILike("%WIDGET%"))
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	assert.Equal(t, "Awesome Widget", products[0].Name)
+
+	count, err := repo.Count(ctx, NewProductFilters().SKUILike("prm-%"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestGeneratedPatternFilters(t *testing.T) {
+	repo := repository.NewGormRepository[Product, *ProductFilters, *ProductUpdater](setupTestDB(t))
+	ctx := context.Background()
+
+	repo.MustCreate(ctx,
+		&Product{Name: "100% Cotton Shirt", SKU: "TX_001"},
+		&Product{Name: "1000 Piece Puzzle", SKU: "TXA001"},
+		&Product{Name: "Cotton Socks", SKU: "SK-100"},
+	)
+
+	names := func(filters *ProductFilters) []string {
+		products, err := repo.FindAll(ctx, filters)
+		require.NoError(t, err)
+		result := make([]string, 0, len(products))
+		for _, product := range products {
+			result = append(result, product.Name)
+		}
+		return result
+	}
+
+	// % and _ in the input match literally instead of acting as wildcards
+	assert.Equal(t, []string{"100% Cotton Shirt"}, names(NewProductFilters().NameStartsWith("100%")))
+	assert.Equal(t, []string{"100% Cotton Shirt"}, names(NewProductFilters().SKUContains("_")))
+	assert.Equal(t, []string{"Cotton Socks"}, names(NewProductFilters().SKUEndsWith("-100")))
+	assert.ElementsMatch(t, []string{"100% Cotton Shirt", "Cotton Socks"}, names(NewProductFilters().NameContains("Cotton")))
+}
+
+func TestGeneratedEmptyIn(t *testing.T) {
+	repo := repository.NewGormRepository[Product, *ProductFilters, *ProductUpdater](setupTestDB(t))
+	ctx := context.Background()
+	require.NoError(t, repo.Create(ctx, createTestProducts()...))
+
+	filters := NewProductFilters().CategoryIDIn()
+	require.Len(t, filters.ListFilters(), 1)
+	assert.Equal(t, repository.OperatorIn, filters.ListFilters()[0].Operator)
+
+	products, err := repo.FindAll(ctx, filters)
+	require.NoError(t, err)
+	assert.Empty(t, products)
+
+	count, err := repo.Count(ctx, NewProductFilters().CategoryIDNotIn())
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(createTestProducts())), count)
+}
+
+// TestGeneratedFiltersEqual compares generated filter sets built in different orders
+func TestGeneratedFiltersEqual(t *testing.T) {
+	expected := NewProductFilters().
+		NameEq("Laptop").
+		PriceGt(100).
+		IsActiveEq(true)
+	actual := NewProductFilters().
+		IsActiveEq(true).
+		PriceGt(100).
+		NameEq("Laptop")
+
+	assert.True(t, repository.FiltersEqual(expected.ListFilters(), actual.ListFilters()))
+
+	actual.PriceLt(500)
+	assert.False(t, repository.FiltersEqual(expected.ListFilters(), actual.ListFilters()))
+}
+
+// TestGeneratedListFiltersOrder checks that filters come back in the order the methods were called
+func TestGeneratedListFiltersOrder(t *testing.T) {
+	listed := func(filters *ProductFilters) []string {
+		var calls []string
+		for _, filter := range filters.ListFilters() {
+			calls = append(calls, filter.Field+" "+string(filter.Operator))
+		}
+		return calls
+	}
+
+	for i := 0; i < 50; i++ {
+		filters := NewProductFilters().
+			PriceGte(100).
+			IsActiveEq(true).
+			PriceLte(500)
+		require.Equal(t, []string{"price >=", "is_active =", "price <="}, listed(filters), "build %d", i)
+	}
+
+	filters := NewProductFilters().NameLike("%Laptop%").PriceGte(100).IsActiveEq(true).PriceLte(500).StockGt(0)
+	assert.Equal(t, []string{"name LIKE", "price >=", "is_active =", "price <=", "stock >"}, listed(filters))
+	assert.Equal(t, []string{"name LIKE", "is_active =", "stock >"}, listed(filters.ClearPrice()))
+	assert.Equal(t, []string{"name LIKE", "is_active =", "stock >", "price <"}, listed(filters.PriceLt(10)))
+}
+
+// TestGeneratedListFiltersAppend checks that appending to the result of ListFilters and
+// adding filters afterwards don't overwrite each other
+func TestGeneratedListFiltersAppend(t *testing.T) {
+	filters := NewProductFilters().PriceGte(100).IsActiveEq(true).StockGt(0)
+	stock := &repository.Filter{Field: "stock", Operator: repository.OperatorLessThan, Value: 50}
+	extended := append(filters.ListFilters(), stock)
+	filters.NameLike("%Laptop%")
+
+	require.Len(t, extended, 4)
+	assert.Same(t, stock, extended[3])
+	listed := filters.ListFilters()
+	require.Len(t, listed, 4)
+	assert.Equal(t, "name", listed[3].Field)
+}
+
 func setupTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Silent),
 	})
 	require.NoError(t, err)
 
-	// Auto migrate the Product schema
-	err = db.AutoMigrate(&Product{})
+	// Auto migrate the example schemas
+	err = db.AutoMigrate(&Product{}, &Order{})
 	require.NoError(t, err)
 
 	return db
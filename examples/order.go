@@ -0,0 +1,36 @@
+package examples
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// OrderStatus is the fulfilment state of an order
+type OrderStatus string
+
+const (
+	OrderStatusPending   OrderStatus = "pending"
+	OrderStatusShipped   OrderStatus = "shipped"
+	OrderStatusCancelled OrderStatus = "cancelled"
+)
+
+// Order represents a customer order; its query builder also has string-parsing filters.
+// Deleting an order soft-deletes it through DeletedAt.
+//
+//gen:querybuilder
+type Order struct {
+	ID        int64           `json:"id"`
+	Number    string          `json:"number"`
+	Quantity  int             `json:"quantity"`
+	Total     float64         `json:"total"`
+	Paid      bool            `json:"paid"`
+	Status    OrderStatus     `json:"status"`
+	PlacedAt  time.Time       `json:"placed_at"`
+	ShippedAt *time.Time      `json:"shipped_at"`
+	Coupon    sql.NullString  `json:"coupon"`
+	Discount  decimal.Decimal `json:"discount" gorm:"type:decimal(10,2)"`
+	DeletedAt gorm.DeletedAt  `json:"deleted_at"`
+}
@@ -0,0 +1,2255 @@
+// Code generated by querybuilder. DO NOT EDIT.
+
+package examples
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/dchlong/querybuilder/repository"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// OrderFilters provides filtering capabilities for Order.
+// Filter methods modify it, so build it on one goroutine; a built filter can then be read
+// concurrently, e.g. by ListFilters. Derive variants from a shared filter with Clone.
+// generated from examples/order.go:24
+type OrderFilters struct {
+	filters map[OrderDBSchemaField][]*repository.Filter
+	order   []*repository.Filter // every filter in call order
+	errs    []error
+}
+
+// NewOrderFilters creates a new filter instance
+func NewOrderFilters() *OrderFilters {
+	return &OrderFilters{
+		filters: make(map[OrderDBSchemaField][]*repository.Filter),
+	}
+}
+
+// ListFilters returns all configured filters in the order they were added.
+// The result is clipped, so appending to it never writes into f.
+func (f *OrderFilters) ListFilters() []*repository.Filter {
+	return slices.Clip(f.order)
+}
+
+// addFilter appends a filter, indexing it by field
+func (f *OrderFilters) addFilter(field OrderDBSchemaField, filter *repository.Filter) *OrderFilters {
+	f.filters[field] = append(f.filters[field], filter)
+	f.order = append(f.order, filter)
+	return f
+}
+
+// clearField removes the filters on field, keeping the others in order
+func (f *OrderFilters) clearField(field OrderDBSchemaField) *OrderFilters {
+	if _, exists := f.filters[field]; !exists {
+		return f
+	}
+	delete(f.filters, field)
+	var kept []*repository.Filter
+	for _, filter := range f.order {
+		if filter.Field != string(field) {
+			kept = append(kept, filter)
+		}
+	}
+	f.order = kept
+	return f
+}
+
+// Clone returns a copy of the filters that can be extended without changing f
+func (f *OrderFilters) Clone() *OrderFilters {
+	clone := &OrderFilters{
+		filters: make(map[OrderDBSchemaField][]*repository.Filter, len(f.filters)),
+		order:   append([]*repository.Filter(nil), f.order...),
+		errs:    append([]error(nil), f.errs...),
+	}
+	for field, filters := range f.filters {
+		clone.filters[field] = append([]*repository.Filter(nil), filters...)
+	}
+	return clone
+}
+
+// Merge appends the filters of other after those of f, in other's call order, e.g. request
+// filters to a shared scope, and returns f. other is not changed; merge into f.Clone() to keep
+// f as it is.
+func (f *OrderFilters) Merge(other *OrderFilters) *OrderFilters {
+	if other == nil {
+		return f
+	}
+	for _, filter := range other.order {
+		f.addFilter(OrderDBSchemaField(filter.Field), filter)
+	}
+	f.errs = append(f.errs, other.errs...)
+	return f
+}
+
+// IDEq filters by ID eq
+func (o *OrderFilters) IDEq(iD int64) *OrderFilters {
+	return o.addFilter(OrderDBSchema.ID, &repository.Filter{
+		Field:    string(OrderDBSchema.ID),
+		Operator: repository.OperatorEqual,
+		Value:    iD,
+	})
+}
+
+// IDNe filters by ID ne
+func (o *OrderFilters) IDNe(iD int64) *OrderFilters {
+	return o.addFilter(OrderDBSchema.ID, &repository.Filter{
+		Field:    string(OrderDBSchema.ID),
+		Operator: repository.OperatorNotEqual,
+		Value:    iD,
+	})
+}
+
+// IDLt filters by ID lt
+func (o *OrderFilters) IDLt(iD int64) *OrderFilters {
+	return o.addFilter(OrderDBSchema.ID, &repository.Filter{
+		Field:    string(OrderDBSchema.ID),
+		Operator: repository.OperatorLessThan,
+		Value:    iD,
+	})
+}
+
+// IDGt filters by ID gt
+func (o *OrderFilters) IDGt(iD int64) *OrderFilters {
+	return o.addFilter(OrderDBSchema.ID, &repository.Filter{
+		Field:    string(OrderDBSchema.ID),
+		Operator: repository.OperatorGreaterThan,
+		Value:    iD,
+	})
+}
+
+// IDLte filters by ID lte
+func (o *OrderFilters) IDLte(iD int64) *OrderFilters {
+	return o.addFilter(OrderDBSchema.ID, &repository.Filter{
+		Field:    string(OrderDBSchema.ID),
+		Operator: repository.OperatorLessThanOrEqual,
+		Value:    iD,
+	})
+}
+
+// IDGte filters by ID gte
+func (o *OrderFilters) IDGte(iD int64) *OrderFilters {
+	return o.addFilter(OrderDBSchema.ID, &repository.Filter{
+		Field:    string(OrderDBSchema.ID),
+		Operator: repository.OperatorGreaterThanOrEqual,
+		Value:    iD,
+	})
+}
+
+// IDIn filters by ID in list
+// note: empty call matches nothing
+func (o *OrderFilters) IDIn(iDs ...int64) *OrderFilters {
+	return o.addFilter(OrderDBSchema.ID, &repository.Filter{
+		Field:    string(OrderDBSchema.ID),
+		Operator: repository.OperatorIn,
+		Value:    iDs,
+	})
+}
+
+// IDNotIn filters by ID not in list
+// note: empty call matches everything
+func (o *OrderFilters) IDNotIn(iDs ...int64) *OrderFilters {
+	return o.addFilter(OrderDBSchema.ID, &repository.Filter{
+		Field:    string(OrderDBSchema.ID),
+		Operator: repository.OperatorNotIn,
+		Value:    iDs,
+	})
+}
+
+// IDBetween filters by ID between lower and upper (inclusive)
+func (o *OrderFilters) IDBetween(lower, upper int64) *OrderFilters {
+	return o.addFilter(OrderDBSchema.ID, &repository.Filter{
+		Field:    string(OrderDBSchema.ID),
+		Operator: repository.OperatorBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// IDNotBetween filters by ID outside the inclusive range lower to upper
+func (o *OrderFilters) IDNotBetween(lower, upper int64) *OrderFilters {
+	return o.addFilter(OrderDBSchema.ID, &repository.Filter{
+		Field:    string(OrderDBSchema.ID),
+		Operator: repository.OperatorNotBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// ClearID removes the filters on ID, keeping the others in order
+func (o *OrderFilters) ClearID() *OrderFilters {
+	return o.clearField(OrderDBSchema.ID)
+}
+
+// NumberEq filters by Number eq
+func (o *OrderFilters) NumberEq(number string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Number, &repository.Filter{
+		Field:    string(OrderDBSchema.Number),
+		Operator: repository.OperatorEqual,
+		Value:    number,
+	})
+}
+
+// NumberNe filters by Number ne
+func (o *OrderFilters) NumberNe(number string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Number, &repository.Filter{
+		Field:    string(OrderDBSchema.Number),
+		Operator: repository.OperatorNotEqual,
+		Value:    number,
+	})
+}
+
+// NumberLike filters by Number like
+func (o *OrderFilters) NumberLike(number string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Number, &repository.Filter{
+		Field:    string(OrderDBSchema.Number),
+		Operator: repository.OperatorLike,
+		Value:    number,
+	})
+}
+
+// NumberContains filters by Number contains number; LIKE wildcards in number match literally
+func (o *OrderFilters) NumberContains(number string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Number, &repository.Filter{
+		Field:    string(OrderDBSchema.Number),
+		Operator: repository.OperatorLike,
+		Value:    "%" + repository.EscapeLike(number) + "%",
+	})
+}
+
+// NumberStartsWith filters by Number starts with number; LIKE wildcards in number match literally
+func (o *OrderFilters) NumberStartsWith(number string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Number, &repository.Filter{
+		Field:    string(OrderDBSchema.Number),
+		Operator: repository.OperatorLike,
+		Value:    repository.EscapeLike(number) + "%",
+	})
+}
+
+// NumberEndsWith filters by Number ends with number; LIKE wildcards in number match literally
+func (o *OrderFilters) NumberEndsWith(number string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Number, &repository.Filter{
+		Field:    string(OrderDBSchema.Number),
+		Operator: repository.OperatorLike,
+		Value:    "%" + repository.EscapeLike(number),
+	})
+}
+
+// NumberNotLike filters by Number notlike
+func (o *OrderFilters) NumberNotLike(number string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Number, &repository.Filter{
+		Field:    string(OrderDBSchema.Number),
+		Operator: repository.OperatorNotLike,
+		Value:    number,
+	})
+}
+
+// NumberILike filters by Number ilike
+func (o *OrderFilters) NumberILike(number string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Number, &repository.Filter{
+		Field:    string(OrderDBSchema.Number),
+		Operator: repository.OperatorILike,
+		Value:    number,
+	})
+}
+
+// NumberMatches filters by Number matching the regular expression pattern (Postgres and MySQL)
+func (o *OrderFilters) NumberMatches(pattern string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Number, &repository.Filter{
+		Field:    string(OrderDBSchema.Number),
+		Operator: repository.OperatorRegex,
+		Value:    pattern,
+	})
+}
+
+// NumberIn filters by Number in list
+// note: empty call matches nothing
+func (o *OrderFilters) NumberIn(numbers ...string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Number, &repository.Filter{
+		Field:    string(OrderDBSchema.Number),
+		Operator: repository.OperatorIn,
+		Value:    numbers,
+	})
+}
+
+// NumberNotIn filters by Number not in list
+// note: empty call matches everything
+func (o *OrderFilters) NumberNotIn(numbers ...string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Number, &repository.Filter{
+		Field:    string(OrderDBSchema.Number),
+		Operator: repository.OperatorNotIn,
+		Value:    numbers,
+	})
+}
+
+// NumberLt filters by Number lt
+func (o *OrderFilters) NumberLt(number string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Number, &repository.Filter{
+		Field:    string(OrderDBSchema.Number),
+		Operator: repository.OperatorLessThan,
+		Value:    number,
+	})
+}
+
+// NumberGt filters by Number gt
+func (o *OrderFilters) NumberGt(number string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Number, &repository.Filter{
+		Field:    string(OrderDBSchema.Number),
+		Operator: repository.OperatorGreaterThan,
+		Value:    number,
+	})
+}
+
+// NumberLte filters by Number lte
+func (o *OrderFilters) NumberLte(number string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Number, &repository.Filter{
+		Field:    string(OrderDBSchema.Number),
+		Operator: repository.OperatorLessThanOrEqual,
+		Value:    number,
+	})
+}
+
+// NumberGte filters by Number gte
+func (o *OrderFilters) NumberGte(number string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Number, &repository.Filter{
+		Field:    string(OrderDBSchema.Number),
+		Operator: repository.OperatorGreaterThanOrEqual,
+		Value:    number,
+	})
+}
+
+// ClearNumber removes the filters on Number, keeping the others in order
+func (o *OrderFilters) ClearNumber() *OrderFilters {
+	return o.clearField(OrderDBSchema.Number)
+}
+
+// QuantityEq filters by Quantity eq
+func (o *OrderFilters) QuantityEq(quantity int) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Quantity, &repository.Filter{
+		Field:    string(OrderDBSchema.Quantity),
+		Operator: repository.OperatorEqual,
+		Value:    quantity,
+	})
+}
+
+// QuantityNe filters by Quantity ne
+func (o *OrderFilters) QuantityNe(quantity int) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Quantity, &repository.Filter{
+		Field:    string(OrderDBSchema.Quantity),
+		Operator: repository.OperatorNotEqual,
+		Value:    quantity,
+	})
+}
+
+// QuantityLt filters by Quantity lt
+func (o *OrderFilters) QuantityLt(quantity int) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Quantity, &repository.Filter{
+		Field:    string(OrderDBSchema.Quantity),
+		Operator: repository.OperatorLessThan,
+		Value:    quantity,
+	})
+}
+
+// QuantityGt filters by Quantity gt
+func (o *OrderFilters) QuantityGt(quantity int) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Quantity, &repository.Filter{
+		Field:    string(OrderDBSchema.Quantity),
+		Operator: repository.OperatorGreaterThan,
+		Value:    quantity,
+	})
+}
+
+// QuantityLte filters by Quantity lte
+func (o *OrderFilters) QuantityLte(quantity int) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Quantity, &repository.Filter{
+		Field:    string(OrderDBSchema.Quantity),
+		Operator: repository.OperatorLessThanOrEqual,
+		Value:    quantity,
+	})
+}
+
+// QuantityGte filters by Quantity gte
+func (o *OrderFilters) QuantityGte(quantity int) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Quantity, &repository.Filter{
+		Field:    string(OrderDBSchema.Quantity),
+		Operator: repository.OperatorGreaterThanOrEqual,
+		Value:    quantity,
+	})
+}
+
+// QuantityIn filters by Quantity in list
+// note: empty call matches nothing
+func (o *OrderFilters) QuantityIn(quantitys ...int) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Quantity, &repository.Filter{
+		Field:    string(OrderDBSchema.Quantity),
+		Operator: repository.OperatorIn,
+		Value:    quantitys,
+	})
+}
+
+// QuantityNotIn filters by Quantity not in list
+// note: empty call matches everything
+func (o *OrderFilters) QuantityNotIn(quantitys ...int) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Quantity, &repository.Filter{
+		Field:    string(OrderDBSchema.Quantity),
+		Operator: repository.OperatorNotIn,
+		Value:    quantitys,
+	})
+}
+
+// QuantityBetween filters by Quantity between lower and upper (inclusive)
+func (o *OrderFilters) QuantityBetween(lower, upper int) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Quantity, &repository.Filter{
+		Field:    string(OrderDBSchema.Quantity),
+		Operator: repository.OperatorBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// QuantityNotBetween filters by Quantity outside the inclusive range lower to upper
+func (o *OrderFilters) QuantityNotBetween(lower, upper int) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Quantity, &repository.Filter{
+		Field:    string(OrderDBSchema.Quantity),
+		Operator: repository.OperatorNotBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// ClearQuantity removes the filters on Quantity, keeping the others in order
+func (o *OrderFilters) ClearQuantity() *OrderFilters {
+	return o.clearField(OrderDBSchema.Quantity)
+}
+
+// TotalEq filters by Total eq
+func (o *OrderFilters) TotalEq(total float64) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Total, &repository.Filter{
+		Field:    string(OrderDBSchema.Total),
+		Operator: repository.OperatorEqual,
+		Value:    total,
+	})
+}
+
+// TotalNe filters by Total ne
+func (o *OrderFilters) TotalNe(total float64) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Total, &repository.Filter{
+		Field:    string(OrderDBSchema.Total),
+		Operator: repository.OperatorNotEqual,
+		Value:    total,
+	})
+}
+
+// TotalLt filters by Total lt
+func (o *OrderFilters) TotalLt(total float64) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Total, &repository.Filter{
+		Field:    string(OrderDBSchema.Total),
+		Operator: repository.OperatorLessThan,
+		Value:    total,
+	})
+}
+
+// TotalGt filters by Total gt
+func (o *OrderFilters) TotalGt(total float64) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Total, &repository.Filter{
+		Field:    string(OrderDBSchema.Total),
+		Operator: repository.OperatorGreaterThan,
+		Value:    total,
+	})
+}
+
+// TotalLte filters by Total lte
+func (o *OrderFilters) TotalLte(total float64) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Total, &repository.Filter{
+		Field:    string(OrderDBSchema.Total),
+		Operator: repository.OperatorLessThanOrEqual,
+		Value:    total,
+	})
+}
+
+// TotalGte filters by Total gte
+func (o *OrderFilters) TotalGte(total float64) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Total, &repository.Filter{
+		Field:    string(OrderDBSchema.Total),
+		Operator: repository.OperatorGreaterThanOrEqual,
+		Value:    total,
+	})
+}
+
+// TotalIn filters by Total in list
+// note: empty call matches nothing
+func (o *OrderFilters) TotalIn(totals ...float64) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Total, &repository.Filter{
+		Field:    string(OrderDBSchema.Total),
+		Operator: repository.OperatorIn,
+		Value:    totals,
+	})
+}
+
+// TotalNotIn filters by Total not in list
+// note: empty call matches everything
+func (o *OrderFilters) TotalNotIn(totals ...float64) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Total, &repository.Filter{
+		Field:    string(OrderDBSchema.Total),
+		Operator: repository.OperatorNotIn,
+		Value:    totals,
+	})
+}
+
+// TotalBetween filters by Total between lower and upper (inclusive)
+func (o *OrderFilters) TotalBetween(lower, upper float64) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Total, &repository.Filter{
+		Field:    string(OrderDBSchema.Total),
+		Operator: repository.OperatorBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// TotalNotBetween filters by Total outside the inclusive range lower to upper
+func (o *OrderFilters) TotalNotBetween(lower, upper float64) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Total, &repository.Filter{
+		Field:    string(OrderDBSchema.Total),
+		Operator: repository.OperatorNotBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// ClearTotal removes the filters on Total, keeping the others in order
+func (o *OrderFilters) ClearTotal() *OrderFilters {
+	return o.clearField(OrderDBSchema.Total)
+}
+
+// PaidEq filters by Paid eq
+func (o *OrderFilters) PaidEq(paid bool) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Paid, &repository.Filter{
+		Field:    string(OrderDBSchema.Paid),
+		Operator: repository.OperatorEqual,
+		Value:    paid,
+	})
+}
+
+// PaidNe filters by Paid ne
+func (o *OrderFilters) PaidNe(paid bool) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Paid, &repository.Filter{
+		Field:    string(OrderDBSchema.Paid),
+		Operator: repository.OperatorNotEqual,
+		Value:    paid,
+	})
+}
+
+// ClearPaid removes the filters on Paid, keeping the others in order
+func (o *OrderFilters) ClearPaid() *OrderFilters {
+	return o.clearField(OrderDBSchema.Paid)
+}
+
+// StatusEq filters by Status eq
+func (o *OrderFilters) StatusEq(status OrderStatus) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Status, &repository.Filter{
+		Field:    string(OrderDBSchema.Status),
+		Operator: repository.OperatorEqual,
+		Value:    status,
+	})
+}
+
+// StatusNe filters by Status ne
+func (o *OrderFilters) StatusNe(status OrderStatus) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Status, &repository.Filter{
+		Field:    string(OrderDBSchema.Status),
+		Operator: repository.OperatorNotEqual,
+		Value:    status,
+	})
+}
+
+// StatusLike filters by Status like
+func (o *OrderFilters) StatusLike(status OrderStatus) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Status, &repository.Filter{
+		Field:    string(OrderDBSchema.Status),
+		Operator: repository.OperatorLike,
+		Value:    status,
+	})
+}
+
+// StatusContains filters by Status contains status; LIKE wildcards in status match literally
+func (o *OrderFilters) StatusContains(status OrderStatus) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Status, &repository.Filter{
+		Field:    string(OrderDBSchema.Status),
+		Operator: repository.OperatorLike,
+		Value:    "%" + repository.EscapeLike(string(status)) + "%",
+	})
+}
+
+// StatusStartsWith filters by Status starts with status; LIKE wildcards in status match literally
+func (o *OrderFilters) StatusStartsWith(status OrderStatus) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Status, &repository.Filter{
+		Field:    string(OrderDBSchema.Status),
+		Operator: repository.OperatorLike,
+		Value:    repository.EscapeLike(string(status)) + "%",
+	})
+}
+
+// StatusEndsWith filters by Status ends with status; LIKE wildcards in status match literally
+func (o *OrderFilters) StatusEndsWith(status OrderStatus) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Status, &repository.Filter{
+		Field:    string(OrderDBSchema.Status),
+		Operator: repository.OperatorLike,
+		Value:    "%" + repository.EscapeLike(string(status)),
+	})
+}
+
+// StatusNotLike filters by Status notlike
+func (o *OrderFilters) StatusNotLike(status OrderStatus) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Status, &repository.Filter{
+		Field:    string(OrderDBSchema.Status),
+		Operator: repository.OperatorNotLike,
+		Value:    status,
+	})
+}
+
+// StatusILike filters by Status ilike
+func (o *OrderFilters) StatusILike(status OrderStatus) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Status, &repository.Filter{
+		Field:    string(OrderDBSchema.Status),
+		Operator: repository.OperatorILike,
+		Value:    status,
+	})
+}
+
+// StatusMatches filters by Status matching the regular expression pattern (Postgres and MySQL)
+func (o *OrderFilters) StatusMatches(pattern string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Status, &repository.Filter{
+		Field:    string(OrderDBSchema.Status),
+		Operator: repository.OperatorRegex,
+		Value:    pattern,
+	})
+}
+
+// StatusIn filters by Status in list
+// note: empty call matches nothing
+func (o *OrderFilters) StatusIn(statuss ...OrderStatus) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Status, &repository.Filter{
+		Field:    string(OrderDBSchema.Status),
+		Operator: repository.OperatorIn,
+		Value:    statuss,
+	})
+}
+
+// StatusNotIn filters by Status not in list
+// note: empty call matches everything
+func (o *OrderFilters) StatusNotIn(statuss ...OrderStatus) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Status, &repository.Filter{
+		Field:    string(OrderDBSchema.Status),
+		Operator: repository.OperatorNotIn,
+		Value:    statuss,
+	})
+}
+
+// StatusLt filters by Status lt
+func (o *OrderFilters) StatusLt(status OrderStatus) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Status, &repository.Filter{
+		Field:    string(OrderDBSchema.Status),
+		Operator: repository.OperatorLessThan,
+		Value:    status,
+	})
+}
+
+// StatusGt filters by Status gt
+func (o *OrderFilters) StatusGt(status OrderStatus) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Status, &repository.Filter{
+		Field:    string(OrderDBSchema.Status),
+		Operator: repository.OperatorGreaterThan,
+		Value:    status,
+	})
+}
+
+// StatusLte filters by Status lte
+func (o *OrderFilters) StatusLte(status OrderStatus) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Status, &repository.Filter{
+		Field:    string(OrderDBSchema.Status),
+		Operator: repository.OperatorLessThanOrEqual,
+		Value:    status,
+	})
+}
+
+// StatusGte filters by Status gte
+func (o *OrderFilters) StatusGte(status OrderStatus) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Status, &repository.Filter{
+		Field:    string(OrderDBSchema.Status),
+		Operator: repository.OperatorGreaterThanOrEqual,
+		Value:    status,
+	})
+}
+
+// ClearStatus removes the filters on Status, keeping the others in order
+func (o *OrderFilters) ClearStatus() *OrderFilters {
+	return o.clearField(OrderDBSchema.Status)
+}
+
+// PlacedAtEq filters by PlacedAt eq
+func (o *OrderFilters) PlacedAtEq(placedAt time.Time) *OrderFilters {
+	return o.addFilter(OrderDBSchema.PlacedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.PlacedAt),
+		Operator: repository.OperatorEqual,
+		Value:    placedAt,
+	})
+}
+
+// PlacedAtNe filters by PlacedAt ne
+func (o *OrderFilters) PlacedAtNe(placedAt time.Time) *OrderFilters {
+	return o.addFilter(OrderDBSchema.PlacedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.PlacedAt),
+		Operator: repository.OperatorNotEqual,
+		Value:    placedAt,
+	})
+}
+
+// PlacedAtLt filters by PlacedAt lt
+func (o *OrderFilters) PlacedAtLt(placedAt time.Time) *OrderFilters {
+	return o.addFilter(OrderDBSchema.PlacedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.PlacedAt),
+		Operator: repository.OperatorLessThan,
+		Value:    placedAt,
+	})
+}
+
+// PlacedAtGt filters by PlacedAt gt
+func (o *OrderFilters) PlacedAtGt(placedAt time.Time) *OrderFilters {
+	return o.addFilter(OrderDBSchema.PlacedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.PlacedAt),
+		Operator: repository.OperatorGreaterThan,
+		Value:    placedAt,
+	})
+}
+
+// PlacedAtLte filters by PlacedAt lte
+func (o *OrderFilters) PlacedAtLte(placedAt time.Time) *OrderFilters {
+	return o.addFilter(OrderDBSchema.PlacedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.PlacedAt),
+		Operator: repository.OperatorLessThanOrEqual,
+		Value:    placedAt,
+	})
+}
+
+// PlacedAtGte filters by PlacedAt gte
+func (o *OrderFilters) PlacedAtGte(placedAt time.Time) *OrderFilters {
+	return o.addFilter(OrderDBSchema.PlacedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.PlacedAt),
+		Operator: repository.OperatorGreaterThanOrEqual,
+		Value:    placedAt,
+	})
+}
+
+// PlacedAtIn filters by PlacedAt in list
+// note: empty call matches nothing
+func (o *OrderFilters) PlacedAtIn(placedAts ...time.Time) *OrderFilters {
+	return o.addFilter(OrderDBSchema.PlacedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.PlacedAt),
+		Operator: repository.OperatorIn,
+		Value:    placedAts,
+	})
+}
+
+// PlacedAtNotIn filters by PlacedAt not in list
+// note: empty call matches everything
+func (o *OrderFilters) PlacedAtNotIn(placedAts ...time.Time) *OrderFilters {
+	return o.addFilter(OrderDBSchema.PlacedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.PlacedAt),
+		Operator: repository.OperatorNotIn,
+		Value:    placedAts,
+	})
+}
+
+// PlacedAtBetween filters by PlacedAt between lower and upper (inclusive)
+func (o *OrderFilters) PlacedAtBetween(lower, upper time.Time) *OrderFilters {
+	return o.addFilter(OrderDBSchema.PlacedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.PlacedAt),
+		Operator: repository.OperatorBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// PlacedAtNotBetween filters by PlacedAt outside the inclusive range lower to upper
+func (o *OrderFilters) PlacedAtNotBetween(lower, upper time.Time) *OrderFilters {
+	return o.addFilter(OrderDBSchema.PlacedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.PlacedAt),
+		Operator: repository.OperatorNotBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// ClearPlacedAt removes the filters on PlacedAt, keeping the others in order
+func (o *OrderFilters) ClearPlacedAt() *OrderFilters {
+	return o.clearField(OrderDBSchema.PlacedAt)
+}
+
+// ShippedAtEq filters by ShippedAt eq
+func (o *OrderFilters) ShippedAtEq(shippedAt *time.Time) *OrderFilters {
+	return o.addFilter(OrderDBSchema.ShippedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.ShippedAt),
+		Operator: repository.OperatorEqual,
+		Value:    shippedAt,
+	})
+}
+
+// ShippedAtEqValue filters by ShippedAt equal to shippedAt; rows where ShippedAt is NULL never match
+func (o *OrderFilters) ShippedAtEqValue(shippedAt time.Time) *OrderFilters {
+	return o.addFilter(OrderDBSchema.ShippedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.ShippedAt),
+		Operator: repository.OperatorEqual,
+		Value:    shippedAt,
+	})
+}
+
+// ShippedAtNe filters by ShippedAt ne
+func (o *OrderFilters) ShippedAtNe(shippedAt *time.Time) *OrderFilters {
+	return o.addFilter(OrderDBSchema.ShippedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.ShippedAt),
+		Operator: repository.OperatorNotEqual,
+		Value:    shippedAt,
+	})
+}
+
+// ShippedAtIsNull filters by ShippedAt is null check
+func (o *OrderFilters) ShippedAtIsNull() *OrderFilters {
+	return o.addFilter(OrderDBSchema.ShippedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.ShippedAt),
+		Operator: repository.OperatorIsNull,
+		Value:    nil,
+	})
+}
+
+// ShippedAtIsNotNull filters by ShippedAt is null check
+func (o *OrderFilters) ShippedAtIsNotNull() *OrderFilters {
+	return o.addFilter(OrderDBSchema.ShippedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.ShippedAt),
+		Operator: repository.OperatorIsNotNull,
+		Value:    nil,
+	})
+}
+
+// ClearShippedAt removes the filters on ShippedAt, keeping the others in order
+func (o *OrderFilters) ClearShippedAt() *OrderFilters {
+	return o.clearField(OrderDBSchema.ShippedAt)
+}
+
+// CouponEq filters by Coupon eq
+func (o *OrderFilters) CouponEq(coupon string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Coupon, &repository.Filter{
+		Field:    string(OrderDBSchema.Coupon),
+		Operator: repository.OperatorEqual,
+		Value:    coupon,
+	})
+}
+
+// CouponNe filters by Coupon ne
+func (o *OrderFilters) CouponNe(coupon string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Coupon, &repository.Filter{
+		Field:    string(OrderDBSchema.Coupon),
+		Operator: repository.OperatorNotEqual,
+		Value:    coupon,
+	})
+}
+
+// CouponLike filters by Coupon like
+func (o *OrderFilters) CouponLike(coupon string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Coupon, &repository.Filter{
+		Field:    string(OrderDBSchema.Coupon),
+		Operator: repository.OperatorLike,
+		Value:    coupon,
+	})
+}
+
+// CouponContains filters by Coupon contains coupon; LIKE wildcards in coupon match literally
+func (o *OrderFilters) CouponContains(coupon string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Coupon, &repository.Filter{
+		Field:    string(OrderDBSchema.Coupon),
+		Operator: repository.OperatorLike,
+		Value:    "%" + repository.EscapeLike(coupon) + "%",
+	})
+}
+
+// CouponStartsWith filters by Coupon starts with coupon; LIKE wildcards in coupon match literally
+func (o *OrderFilters) CouponStartsWith(coupon string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Coupon, &repository.Filter{
+		Field:    string(OrderDBSchema.Coupon),
+		Operator: repository.OperatorLike,
+		Value:    repository.EscapeLike(coupon) + "%",
+	})
+}
+
+// CouponEndsWith filters by Coupon ends with coupon; LIKE wildcards in coupon match literally
+func (o *OrderFilters) CouponEndsWith(coupon string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Coupon, &repository.Filter{
+		Field:    string(OrderDBSchema.Coupon),
+		Operator: repository.OperatorLike,
+		Value:    "%" + repository.EscapeLike(coupon),
+	})
+}
+
+// CouponNotLike filters by Coupon notlike
+func (o *OrderFilters) CouponNotLike(coupon string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Coupon, &repository.Filter{
+		Field:    string(OrderDBSchema.Coupon),
+		Operator: repository.OperatorNotLike,
+		Value:    coupon,
+	})
+}
+
+// CouponILike filters by Coupon ilike
+func (o *OrderFilters) CouponILike(coupon string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Coupon, &repository.Filter{
+		Field:    string(OrderDBSchema.Coupon),
+		Operator: repository.OperatorILike,
+		Value:    coupon,
+	})
+}
+
+// CouponMatches filters by Coupon matching the regular expression pattern (Postgres and MySQL)
+func (o *OrderFilters) CouponMatches(pattern string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Coupon, &repository.Filter{
+		Field:    string(OrderDBSchema.Coupon),
+		Operator: repository.OperatorRegex,
+		Value:    pattern,
+	})
+}
+
+// CouponIn filters by Coupon in list
+// note: empty call matches nothing
+func (o *OrderFilters) CouponIn(coupons ...string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Coupon, &repository.Filter{
+		Field:    string(OrderDBSchema.Coupon),
+		Operator: repository.OperatorIn,
+		Value:    coupons,
+	})
+}
+
+// CouponNotIn filters by Coupon not in list
+// note: empty call matches everything
+func (o *OrderFilters) CouponNotIn(coupons ...string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Coupon, &repository.Filter{
+		Field:    string(OrderDBSchema.Coupon),
+		Operator: repository.OperatorNotIn,
+		Value:    coupons,
+	})
+}
+
+// CouponLt filters by Coupon lt
+func (o *OrderFilters) CouponLt(coupon string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Coupon, &repository.Filter{
+		Field:    string(OrderDBSchema.Coupon),
+		Operator: repository.OperatorLessThan,
+		Value:    coupon,
+	})
+}
+
+// CouponGt filters by Coupon gt
+func (o *OrderFilters) CouponGt(coupon string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Coupon, &repository.Filter{
+		Field:    string(OrderDBSchema.Coupon),
+		Operator: repository.OperatorGreaterThan,
+		Value:    coupon,
+	})
+}
+
+// CouponLte filters by Coupon lte
+func (o *OrderFilters) CouponLte(coupon string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Coupon, &repository.Filter{
+		Field:    string(OrderDBSchema.Coupon),
+		Operator: repository.OperatorLessThanOrEqual,
+		Value:    coupon,
+	})
+}
+
+// CouponGte filters by Coupon gte
+func (o *OrderFilters) CouponGte(coupon string) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Coupon, &repository.Filter{
+		Field:    string(OrderDBSchema.Coupon),
+		Operator: repository.OperatorGreaterThanOrEqual,
+		Value:    coupon,
+	})
+}
+
+// CouponIsNull filters by Coupon is null check
+func (o *OrderFilters) CouponIsNull() *OrderFilters {
+	return o.addFilter(OrderDBSchema.Coupon, &repository.Filter{
+		Field:    string(OrderDBSchema.Coupon),
+		Operator: repository.OperatorIsNull,
+		Value:    nil,
+	})
+}
+
+// CouponIsNotNull filters by Coupon is null check
+func (o *OrderFilters) CouponIsNotNull() *OrderFilters {
+	return o.addFilter(OrderDBSchema.Coupon, &repository.Filter{
+		Field:    string(OrderDBSchema.Coupon),
+		Operator: repository.OperatorIsNotNull,
+		Value:    nil,
+	})
+}
+
+// ClearCoupon removes the filters on Coupon, keeping the others in order
+func (o *OrderFilters) ClearCoupon() *OrderFilters {
+	return o.clearField(OrderDBSchema.Coupon)
+}
+
+// DiscountEq filters by Discount eq
+func (o *OrderFilters) DiscountEq(discount decimal.Decimal) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Discount, &repository.Filter{
+		Field:    string(OrderDBSchema.Discount),
+		Operator: repository.OperatorEqual,
+		Value:    discount,
+	})
+}
+
+// DiscountNe filters by Discount ne
+func (o *OrderFilters) DiscountNe(discount decimal.Decimal) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Discount, &repository.Filter{
+		Field:    string(OrderDBSchema.Discount),
+		Operator: repository.OperatorNotEqual,
+		Value:    discount,
+	})
+}
+
+// DiscountLt filters by Discount lt
+func (o *OrderFilters) DiscountLt(discount decimal.Decimal) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Discount, &repository.Filter{
+		Field:    string(OrderDBSchema.Discount),
+		Operator: repository.OperatorLessThan,
+		Value:    discount,
+	})
+}
+
+// DiscountGt filters by Discount gt
+func (o *OrderFilters) DiscountGt(discount decimal.Decimal) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Discount, &repository.Filter{
+		Field:    string(OrderDBSchema.Discount),
+		Operator: repository.OperatorGreaterThan,
+		Value:    discount,
+	})
+}
+
+// DiscountLte filters by Discount lte
+func (o *OrderFilters) DiscountLte(discount decimal.Decimal) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Discount, &repository.Filter{
+		Field:    string(OrderDBSchema.Discount),
+		Operator: repository.OperatorLessThanOrEqual,
+		Value:    discount,
+	})
+}
+
+// DiscountGte filters by Discount gte
+func (o *OrderFilters) DiscountGte(discount decimal.Decimal) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Discount, &repository.Filter{
+		Field:    string(OrderDBSchema.Discount),
+		Operator: repository.OperatorGreaterThanOrEqual,
+		Value:    discount,
+	})
+}
+
+// DiscountIn filters by Discount in list
+// note: empty call matches nothing
+func (o *OrderFilters) DiscountIn(discounts ...decimal.Decimal) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Discount, &repository.Filter{
+		Field:    string(OrderDBSchema.Discount),
+		Operator: repository.OperatorIn,
+		Value:    discounts,
+	})
+}
+
+// DiscountNotIn filters by Discount not in list
+// note: empty call matches everything
+func (o *OrderFilters) DiscountNotIn(discounts ...decimal.Decimal) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Discount, &repository.Filter{
+		Field:    string(OrderDBSchema.Discount),
+		Operator: repository.OperatorNotIn,
+		Value:    discounts,
+	})
+}
+
+// DiscountBetween filters by Discount between lower and upper (inclusive)
+func (o *OrderFilters) DiscountBetween(lower, upper decimal.Decimal) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Discount, &repository.Filter{
+		Field:    string(OrderDBSchema.Discount),
+		Operator: repository.OperatorBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// DiscountNotBetween filters by Discount outside the inclusive range lower to upper
+func (o *OrderFilters) DiscountNotBetween(lower, upper decimal.Decimal) *OrderFilters {
+	return o.addFilter(OrderDBSchema.Discount, &repository.Filter{
+		Field:    string(OrderDBSchema.Discount),
+		Operator: repository.OperatorNotBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// ClearDiscount removes the filters on Discount, keeping the others in order
+func (o *OrderFilters) ClearDiscount() *OrderFilters {
+	return o.clearField(OrderDBSchema.Discount)
+}
+
+// DeletedAtEq filters by DeletedAt eq
+func (o *OrderFilters) DeletedAtEq(deletedAt gorm.DeletedAt) *OrderFilters {
+	return o.addFilter(OrderDBSchema.DeletedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.DeletedAt),
+		Operator: repository.OperatorEqual,
+		Value:    deletedAt,
+	})
+}
+
+// DeletedAtNe filters by DeletedAt ne
+func (o *OrderFilters) DeletedAtNe(deletedAt gorm.DeletedAt) *OrderFilters {
+	return o.addFilter(OrderDBSchema.DeletedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.DeletedAt),
+		Operator: repository.OperatorNotEqual,
+		Value:    deletedAt,
+	})
+}
+
+// DeletedAtLt filters by DeletedAt lt
+func (o *OrderFilters) DeletedAtLt(deletedAt gorm.DeletedAt) *OrderFilters {
+	return o.addFilter(OrderDBSchema.DeletedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.DeletedAt),
+		Operator: repository.OperatorLessThan,
+		Value:    deletedAt,
+	})
+}
+
+// DeletedAtGt filters by DeletedAt gt
+func (o *OrderFilters) DeletedAtGt(deletedAt gorm.DeletedAt) *OrderFilters {
+	return o.addFilter(OrderDBSchema.DeletedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.DeletedAt),
+		Operator: repository.OperatorGreaterThan,
+		Value:    deletedAt,
+	})
+}
+
+// DeletedAtLte filters by DeletedAt lte
+func (o *OrderFilters) DeletedAtLte(deletedAt gorm.DeletedAt) *OrderFilters {
+	return o.addFilter(OrderDBSchema.DeletedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.DeletedAt),
+		Operator: repository.OperatorLessThanOrEqual,
+		Value:    deletedAt,
+	})
+}
+
+// DeletedAtGte filters by DeletedAt gte
+func (o *OrderFilters) DeletedAtGte(deletedAt gorm.DeletedAt) *OrderFilters {
+	return o.addFilter(OrderDBSchema.DeletedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.DeletedAt),
+		Operator: repository.OperatorGreaterThanOrEqual,
+		Value:    deletedAt,
+	})
+}
+
+// DeletedAtIn filters by DeletedAt in list
+// note: empty call matches nothing
+func (o *OrderFilters) DeletedAtIn(deletedAts ...gorm.DeletedAt) *OrderFilters {
+	return o.addFilter(OrderDBSchema.DeletedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.DeletedAt),
+		Operator: repository.OperatorIn,
+		Value:    deletedAts,
+	})
+}
+
+// DeletedAtNotIn filters by DeletedAt not in list
+// note: empty call matches everything
+func (o *OrderFilters) DeletedAtNotIn(deletedAts ...gorm.DeletedAt) *OrderFilters {
+	return o.addFilter(OrderDBSchema.DeletedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.DeletedAt),
+		Operator: repository.OperatorNotIn,
+		Value:    deletedAts,
+	})
+}
+
+// DeletedAtBetween filters by DeletedAt between lower and upper (inclusive)
+func (o *OrderFilters) DeletedAtBetween(lower, upper gorm.DeletedAt) *OrderFilters {
+	return o.addFilter(OrderDBSchema.DeletedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.DeletedAt),
+		Operator: repository.OperatorBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// DeletedAtNotBetween filters by DeletedAt outside the inclusive range lower to upper
+func (o *OrderFilters) DeletedAtNotBetween(lower, upper gorm.DeletedAt) *OrderFilters {
+	return o.addFilter(OrderDBSchema.DeletedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.DeletedAt),
+		Operator: repository.OperatorNotBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// DeletedAtIsNull filters by DeletedAt is null check
+func (o *OrderFilters) DeletedAtIsNull() *OrderFilters {
+	return o.addFilter(OrderDBSchema.DeletedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.DeletedAt),
+		Operator: repository.OperatorIsNull,
+		Value:    nil,
+	})
+}
+
+// DeletedAtIsNotNull filters by DeletedAt is null check
+func (o *OrderFilters) DeletedAtIsNotNull() *OrderFilters {
+	return o.addFilter(OrderDBSchema.DeletedAt, &repository.Filter{
+		Field:    string(OrderDBSchema.DeletedAt),
+		Operator: repository.OperatorIsNotNull,
+		Value:    nil,
+	})
+}
+
+// ClearDeletedAt removes the filters on DeletedAt, keeping the others in order
+func (o *OrderFilters) ClearDeletedAt() *OrderFilters {
+	return o.clearField(OrderDBSchema.DeletedAt)
+}
+
+// WhereRaw adds a raw SQL condition for cases the typed methods don't cover, such as subqueries.
+// Pass values as args bound to ? placeholders; never format them into condition.
+func (f *OrderFilters) WhereRaw(condition string, args ...interface{}) *OrderFilters {
+	return f.addFilter("", &repository.Filter{
+		Operator: repository.OperatorRaw,
+		Value:    repository.RawSQL{SQL: condition, Args: args},
+	})
+}
+
+// WhereColumn filters on a column outside the generated fields, such as "categories.name" of a
+// table joined with repository.WithJoin. The column is quoted, not passed through as SQL.
+func (f *OrderFilters) WhereColumn(column string, operator repository.Operator, value interface{}) *OrderFilters {
+	return f.addFilter(OrderDBSchemaField(column), &repository.Filter{
+		Field:    column,
+		Operator: operator,
+		Value:    value,
+	})
+}
+
+// DebugSQL returns the WHERE condition of the filters, without the keyword, and its arguments.
+// Identifiers are double-quoted; GormRepository.ExplainFilter renders the full query of a database.
+func (f *OrderFilters) DebugSQL() (string, []interface{}, error) {
+	if err := f.Err(); err != nil {
+		return "", nil, err
+	}
+	return repository.BuildWhere(f.ListFilters())
+}
+
+// Err returns the errors collected while parsing string filter values
+func (f *OrderFilters) Err() error {
+	return errors.Join(f.errs...)
+}
+
+// IDEqString parses s as int64 and calls IDEq; parse errors are reported by Err
+func (o *OrderFilters) IDEqString(s string) *OrderFilters {
+	value, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("IDEqString: parse %q as int64: %w", s, err))
+		return o
+	}
+	return o.IDEq(value)
+}
+
+// IDNeString parses s as int64 and calls IDNe; parse errors are reported by Err
+func (o *OrderFilters) IDNeString(s string) *OrderFilters {
+	value, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("IDNeString: parse %q as int64: %w", s, err))
+		return o
+	}
+	return o.IDNe(value)
+}
+
+// IDLtString parses s as int64 and calls IDLt; parse errors are reported by Err
+func (o *OrderFilters) IDLtString(s string) *OrderFilters {
+	value, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("IDLtString: parse %q as int64: %w", s, err))
+		return o
+	}
+	return o.IDLt(value)
+}
+
+// IDGtString parses s as int64 and calls IDGt; parse errors are reported by Err
+func (o *OrderFilters) IDGtString(s string) *OrderFilters {
+	value, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("IDGtString: parse %q as int64: %w", s, err))
+		return o
+	}
+	return o.IDGt(value)
+}
+
+// IDLteString parses s as int64 and calls IDLte; parse errors are reported by Err
+func (o *OrderFilters) IDLteString(s string) *OrderFilters {
+	value, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("IDLteString: parse %q as int64: %w", s, err))
+		return o
+	}
+	return o.IDLte(value)
+}
+
+// IDGteString parses s as int64 and calls IDGte; parse errors are reported by Err
+func (o *OrderFilters) IDGteString(s string) *OrderFilters {
+	value, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("IDGteString: parse %q as int64: %w", s, err))
+		return o
+	}
+	return o.IDGte(value)
+}
+
+// NumberEqString calls NumberEq with s
+func (o *OrderFilters) NumberEqString(s string) *OrderFilters {
+	return o.NumberEq(s)
+}
+
+// NumberNeString calls NumberNe with s
+func (o *OrderFilters) NumberNeString(s string) *OrderFilters {
+	return o.NumberNe(s)
+}
+
+// NumberLtString calls NumberLt with s
+func (o *OrderFilters) NumberLtString(s string) *OrderFilters {
+	return o.NumberLt(s)
+}
+
+// NumberGtString calls NumberGt with s
+func (o *OrderFilters) NumberGtString(s string) *OrderFilters {
+	return o.NumberGt(s)
+}
+
+// NumberLteString calls NumberLte with s
+func (o *OrderFilters) NumberLteString(s string) *OrderFilters {
+	return o.NumberLte(s)
+}
+
+// NumberGteString calls NumberGte with s
+func (o *OrderFilters) NumberGteString(s string) *OrderFilters {
+	return o.NumberGte(s)
+}
+
+// QuantityEqString parses s as int and calls QuantityEq; parse errors are reported by Err
+func (o *OrderFilters) QuantityEqString(s string) *OrderFilters {
+	value, err := strconv.Atoi(s)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("QuantityEqString: parse %q as int: %w", s, err))
+		return o
+	}
+	return o.QuantityEq(value)
+}
+
+// QuantityNeString parses s as int and calls QuantityNe; parse errors are reported by Err
+func (o *OrderFilters) QuantityNeString(s string) *OrderFilters {
+	value, err := strconv.Atoi(s)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("QuantityNeString: parse %q as int: %w", s, err))
+		return o
+	}
+	return o.QuantityNe(value)
+}
+
+// QuantityLtString parses s as int and calls QuantityLt; parse errors are reported by Err
+func (o *OrderFilters) QuantityLtString(s string) *OrderFilters {
+	value, err := strconv.Atoi(s)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("QuantityLtString: parse %q as int: %w", s, err))
+		return o
+	}
+	return o.QuantityLt(value)
+}
+
+// QuantityGtString parses s as int and calls QuantityGt; parse errors are reported by Err
+func (o *OrderFilters) QuantityGtString(s string) *OrderFilters {
+	value, err := strconv.Atoi(s)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("QuantityGtString: parse %q as int: %w", s, err))
+		return o
+	}
+	return o.QuantityGt(value)
+}
+
+// QuantityLteString parses s as int and calls QuantityLte; parse errors are reported by Err
+func (o *OrderFilters) QuantityLteString(s string) *OrderFilters {
+	value, err := strconv.Atoi(s)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("QuantityLteString: parse %q as int: %w", s, err))
+		return o
+	}
+	return o.QuantityLte(value)
+}
+
+// QuantityGteString parses s as int and calls QuantityGte; parse errors are reported by Err
+func (o *OrderFilters) QuantityGteString(s string) *OrderFilters {
+	value, err := strconv.Atoi(s)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("QuantityGteString: parse %q as int: %w", s, err))
+		return o
+	}
+	return o.QuantityGte(value)
+}
+
+// TotalEqString parses s as float64 and calls TotalEq; parse errors are reported by Err
+func (o *OrderFilters) TotalEqString(s string) *OrderFilters {
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("TotalEqString: parse %q as float64: %w", s, err))
+		return o
+	}
+	return o.TotalEq(value)
+}
+
+// TotalNeString parses s as float64 and calls TotalNe; parse errors are reported by Err
+func (o *OrderFilters) TotalNeString(s string) *OrderFilters {
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("TotalNeString: parse %q as float64: %w", s, err))
+		return o
+	}
+	return o.TotalNe(value)
+}
+
+// TotalLtString parses s as float64 and calls TotalLt; parse errors are reported by Err
+func (o *OrderFilters) TotalLtString(s string) *OrderFilters {
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("TotalLtString: parse %q as float64: %w", s, err))
+		return o
+	}
+	return o.TotalLt(value)
+}
+
+// TotalGtString parses s as float64 and calls TotalGt; parse errors are reported by Err
+func (o *OrderFilters) TotalGtString(s string) *OrderFilters {
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("TotalGtString: parse %q as float64: %w", s, err))
+		return o
+	}
+	return o.TotalGt(value)
+}
+
+// TotalLteString parses s as float64 and calls TotalLte; parse errors are reported by Err
+func (o *OrderFilters) TotalLteString(s string) *OrderFilters {
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("TotalLteString: parse %q as float64: %w", s, err))
+		return o
+	}
+	return o.TotalLte(value)
+}
+
+// TotalGteString parses s as float64 and calls TotalGte; parse errors are reported by Err
+func (o *OrderFilters) TotalGteString(s string) *OrderFilters {
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("TotalGteString: parse %q as float64: %w", s, err))
+		return o
+	}
+	return o.TotalGte(value)
+}
+
+// PaidEqString parses s as bool and calls PaidEq; parse errors are reported by Err
+func (o *OrderFilters) PaidEqString(s string) *OrderFilters {
+	value, err := strconv.ParseBool(s)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("PaidEqString: parse %q as bool: %w", s, err))
+		return o
+	}
+	return o.PaidEq(value)
+}
+
+// PaidNeString parses s as bool and calls PaidNe; parse errors are reported by Err
+func (o *OrderFilters) PaidNeString(s string) *OrderFilters {
+	value, err := strconv.ParseBool(s)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("PaidNeString: parse %q as bool: %w", s, err))
+		return o
+	}
+	return o.PaidNe(value)
+}
+
+// PlacedAtEqString parses s as time.Time and calls PlacedAtEq; parse errors are reported by Err
+func (o *OrderFilters) PlacedAtEqString(s string) *OrderFilters {
+	value, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("PlacedAtEqString: parse %q as time.Time: %w", s, err))
+		return o
+	}
+	return o.PlacedAtEq(value)
+}
+
+// PlacedAtNeString parses s as time.Time and calls PlacedAtNe; parse errors are reported by Err
+func (o *OrderFilters) PlacedAtNeString(s string) *OrderFilters {
+	value, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("PlacedAtNeString: parse %q as time.Time: %w", s, err))
+		return o
+	}
+	return o.PlacedAtNe(value)
+}
+
+// PlacedAtLtString parses s as time.Time and calls PlacedAtLt; parse errors are reported by Err
+func (o *OrderFilters) PlacedAtLtString(s string) *OrderFilters {
+	value, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("PlacedAtLtString: parse %q as time.Time: %w", s, err))
+		return o
+	}
+	return o.PlacedAtLt(value)
+}
+
+// PlacedAtGtString parses s as time.Time and calls PlacedAtGt; parse errors are reported by Err
+func (o *OrderFilters) PlacedAtGtString(s string) *OrderFilters {
+	value, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("PlacedAtGtString: parse %q as time.Time: %w", s, err))
+		return o
+	}
+	return o.PlacedAtGt(value)
+}
+
+// PlacedAtLteString parses s as time.Time and calls PlacedAtLte; parse errors are reported by Err
+func (o *OrderFilters) PlacedAtLteString(s string) *OrderFilters {
+	value, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("PlacedAtLteString: parse %q as time.Time: %w", s, err))
+		return o
+	}
+	return o.PlacedAtLte(value)
+}
+
+// PlacedAtGteString parses s as time.Time and calls PlacedAtGte; parse errors are reported by Err
+func (o *OrderFilters) PlacedAtGteString(s string) *OrderFilters {
+	value, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("PlacedAtGteString: parse %q as time.Time: %w", s, err))
+		return o
+	}
+	return o.PlacedAtGte(value)
+}
+
+// ShippedAtEqString parses s as time.Time and calls ShippedAtEq; parse errors are reported by Err
+func (o *OrderFilters) ShippedAtEqString(s string) *OrderFilters {
+	value, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("ShippedAtEqString: parse %q as time.Time: %w", s, err))
+		return o
+	}
+	return o.ShippedAtEq(&value)
+}
+
+// ShippedAtNeString parses s as time.Time and calls ShippedAtNe; parse errors are reported by Err
+func (o *OrderFilters) ShippedAtNeString(s string) *OrderFilters {
+	value, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		o.errs = append(o.errs, fmt.Errorf("ShippedAtNeString: parse %q as time.Time: %w", s, err))
+		return o
+	}
+	return o.ShippedAtNe(&value)
+}
+
+// CouponEqString calls CouponEq with s
+func (o *OrderFilters) CouponEqString(s string) *OrderFilters {
+	return o.CouponEq(s)
+}
+
+// CouponNeString calls CouponNe with s
+func (o *OrderFilters) CouponNeString(s string) *OrderFilters {
+	return o.CouponNe(s)
+}
+
+// CouponLtString calls CouponLt with s
+func (o *OrderFilters) CouponLtString(s string) *OrderFilters {
+	return o.CouponLt(s)
+}
+
+// CouponGtString calls CouponGt with s
+func (o *OrderFilters) CouponGtString(s string) *OrderFilters {
+	return o.CouponGt(s)
+}
+
+// CouponLteString calls CouponLte with s
+func (o *OrderFilters) CouponLteString(s string) *OrderFilters {
+	return o.CouponLte(s)
+}
+
+// CouponGteString calls CouponGte with s
+func (o *OrderFilters) CouponGteString(s string) *OrderFilters {
+	return o.CouponGte(s)
+}
+
+// OrderUpdater provides update capabilities for Order
+// generated from examples/order.go:24
+type OrderUpdater struct {
+	fields map[string]interface{}
+}
+
+// NewOrderUpdater creates a new updater instance
+func NewOrderUpdater() *OrderUpdater {
+	return &OrderUpdater{
+		fields: make(map[string]interface{}),
+	}
+}
+
+// GetChangeSet returns the fields to update
+func (u *OrderUpdater) GetChangeSet() map[string]interface{} {
+	return u.fields
+}
+
+// Clone returns a copy of the updater that can be changed without changing u
+func (u *OrderUpdater) Clone() *OrderUpdater {
+	clone := NewOrderUpdater()
+	for field, value := range u.fields {
+		clone.fields[field] = value
+	}
+	return clone
+}
+
+// SetID sets the ID field for update
+func (o *OrderUpdater) SetID(iD int64) *OrderUpdater {
+	o.fields[string(OrderDBSchema.ID)] = iD
+	return o
+}
+
+// SetNumber sets the Number field for update
+func (o *OrderUpdater) SetNumber(number string) *OrderUpdater {
+	o.fields[string(OrderDBSchema.Number)] = number
+	return o
+}
+
+// SetQuantity sets the Quantity field for update
+func (o *OrderUpdater) SetQuantity(quantity int) *OrderUpdater {
+	o.fields[string(OrderDBSchema.Quantity)] = quantity
+	return o
+}
+
+// SetTotal sets the Total field for update
+func (o *OrderUpdater) SetTotal(total float64) *OrderUpdater {
+	o.fields[string(OrderDBSchema.Total)] = total
+	return o
+}
+
+// SetPaid sets the Paid field for update
+func (o *OrderUpdater) SetPaid(paid bool) *OrderUpdater {
+	o.fields[string(OrderDBSchema.Paid)] = paid
+	return o
+}
+
+// SetStatus sets the Status field for update
+func (o *OrderUpdater) SetStatus(status OrderStatus) *OrderUpdater {
+	o.fields[string(OrderDBSchema.Status)] = status
+	return o
+}
+
+// SetPlacedAt sets the PlacedAt field for update
+func (o *OrderUpdater) SetPlacedAt(placedAt time.Time) *OrderUpdater {
+	o.fields[string(OrderDBSchema.PlacedAt)] = placedAt
+	return o
+}
+
+// SetShippedAt sets the ShippedAt field for update
+func (o *OrderUpdater) SetShippedAt(shippedAt *time.Time) *OrderUpdater {
+	o.fields[string(OrderDBSchema.ShippedAt)] = shippedAt
+	return o
+}
+
+// SetShippedAtValue sets the ShippedAt field to shippedAt for update
+func (o *OrderUpdater) SetShippedAtValue(shippedAt time.Time) *OrderUpdater {
+	o.fields[string(OrderDBSchema.ShippedAt)] = &shippedAt
+	return o
+}
+
+// SetCoupon sets the Coupon field for update
+func (o *OrderUpdater) SetCoupon(coupon sql.NullString) *OrderUpdater {
+	o.fields[string(OrderDBSchema.Coupon)] = coupon
+	return o
+}
+
+// SetDiscount sets the Discount field for update
+func (o *OrderUpdater) SetDiscount(discount decimal.Decimal) *OrderUpdater {
+	o.fields[string(OrderDBSchema.Discount)] = discount
+	return o
+}
+
+// SetDeletedAt sets the DeletedAt field for update
+func (o *OrderUpdater) SetDeletedAt(deletedAt gorm.DeletedAt) *OrderUpdater {
+	o.fields[string(OrderDBSchema.DeletedAt)] = deletedAt
+	return o
+}
+
+// FromStruct stages the given fields of record, zero values included, or every field with a setter but the primary key when none are given; other fields are ignored
+func (o *OrderUpdater) FromStruct(record *Order, fields ...OrderDBSchemaField) *OrderUpdater {
+	if len(fields) == 0 {
+		fields = []OrderDBSchemaField{OrderDBSchema.Number, OrderDBSchema.Quantity, OrderDBSchema.Total, OrderDBSchema.Paid, OrderDBSchema.Status, OrderDBSchema.PlacedAt, OrderDBSchema.ShippedAt, OrderDBSchema.Coupon, OrderDBSchema.Discount, OrderDBSchema.DeletedAt}
+	}
+	for _, field := range fields {
+		switch field {
+		case OrderDBSchema.ID:
+			o.SetID(record.ID)
+		case OrderDBSchema.Number:
+			o.SetNumber(record.Number)
+		case OrderDBSchema.Quantity:
+			o.SetQuantity(record.Quantity)
+		case OrderDBSchema.Total:
+			o.SetTotal(record.Total)
+		case OrderDBSchema.Paid:
+			o.SetPaid(record.Paid)
+		case OrderDBSchema.Status:
+			o.SetStatus(record.Status)
+		case OrderDBSchema.PlacedAt:
+			o.SetPlacedAt(record.PlacedAt)
+		case OrderDBSchema.ShippedAt:
+			o.SetShippedAt(record.ShippedAt)
+		case OrderDBSchema.Coupon:
+			o.SetCoupon(record.Coupon)
+		case OrderDBSchema.Discount:
+			o.SetDiscount(record.Discount)
+		case OrderDBSchema.DeletedAt:
+			o.SetDeletedAt(record.DeletedAt)
+		}
+	}
+	return o
+}
+
+// OrderOptions provides query options for Order
+// generated from examples/order.go:24
+type OrderOptions struct {
+	options []func(*repository.Options)
+}
+
+// NewOrderOptions creates a new options instance
+func NewOrderOptions() *OrderOptions {
+	return &OrderOptions{}
+}
+
+// Apply applies all configured options to repository options
+func (o *OrderOptions) Apply(repoOpts *repository.Options) {
+	for _, option := range o.options {
+		option(repoOpts)
+	}
+}
+
+// Select only queries the given fields; the primary key is always selected
+func (o *OrderOptions) Select(fields ...OrderDBSchemaField) *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		for _, field := range fields {
+			options.SelectFields = append(options.SelectFields, string(field))
+		}
+	})
+	return o
+}
+
+// Distinct removes duplicate rows; with fields only those are selected and compared
+func (o *OrderOptions) Distinct(fields ...OrderDBSchemaField) *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.Distinct = true
+		for _, field := range fields {
+			options.DistinctFields = append(options.DistinctFields, string(field))
+		}
+	})
+	return o
+}
+
+// DistinctOn keeps the first row of each group of equal fields (Postgres); order by them first
+func (o *OrderOptions) DistinctOn(fields ...OrderDBSchemaField) *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		for _, field := range fields {
+			options.DistinctOn = append(options.DistinctOn, string(field))
+		}
+	})
+	return o
+}
+
+// OrderBy orders results by field in direction dir, e.g. when both come from API parameters
+func (o *OrderOptions) OrderBy(field OrderDBSchemaField, dir repository.SortDirection) *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(field),
+			Direction: dir,
+		})
+	})
+	return o
+}
+
+// Paginate limits results to the 1-based page of pageSize rows; OrderFindPage reports the page metadata
+func (o *OrderOptions) Paginate(page, pageSize int) *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		repository.WithPage(page, pageSize).Apply(options)
+	})
+	return o
+}
+
+// ForUpdate locks the selected rows until the transaction ends (SELECT ... FOR UPDATE); use it inside WithTransaction
+func (o *OrderOptions) ForUpdate() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.Lock = &repository.Lock{Strength: repository.LockUpdate}
+	})
+	return o
+}
+
+// ForUpdateSkipLocked locks the selected rows like ForUpdate, skipping rows other transactions have locked
+func (o *OrderOptions) ForUpdateSkipLocked() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.Lock = &repository.Lock{Strength: repository.LockUpdate, SkipLocked: true}
+	})
+	return o
+}
+
+// OrderByIDAsc orders results by ID asc
+func (o *OrderOptions) OrderByIDAsc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.ID),
+			Direction: "asc",
+		})
+	})
+	return o
+}
+
+// OrderByIDDesc orders results by ID desc
+func (o *OrderOptions) OrderByIDDesc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.ID),
+			Direction: "desc",
+		})
+	})
+	return o
+}
+
+// OrderByNumberAsc orders results by Number asc
+func (o *OrderOptions) OrderByNumberAsc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.Number),
+			Direction: "asc",
+		})
+	})
+	return o
+}
+
+// OrderByNumberDesc orders results by Number desc
+func (o *OrderOptions) OrderByNumberDesc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.Number),
+			Direction: "desc",
+		})
+	})
+	return o
+}
+
+// OrderByQuantityAsc orders results by Quantity asc
+func (o *OrderOptions) OrderByQuantityAsc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.Quantity),
+			Direction: "asc",
+		})
+	})
+	return o
+}
+
+// OrderByQuantityDesc orders results by Quantity desc
+func (o *OrderOptions) OrderByQuantityDesc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.Quantity),
+			Direction: "desc",
+		})
+	})
+	return o
+}
+
+// OrderByTotalAsc orders results by Total asc
+func (o *OrderOptions) OrderByTotalAsc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.Total),
+			Direction: "asc",
+		})
+	})
+	return o
+}
+
+// OrderByTotalDesc orders results by Total desc
+func (o *OrderOptions) OrderByTotalDesc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.Total),
+			Direction: "desc",
+		})
+	})
+	return o
+}
+
+// OrderByPaidAsc orders results by Paid asc
+func (o *OrderOptions) OrderByPaidAsc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.Paid),
+			Direction: "asc",
+		})
+	})
+	return o
+}
+
+// OrderByPaidDesc orders results by Paid desc
+func (o *OrderOptions) OrderByPaidDesc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.Paid),
+			Direction: "desc",
+		})
+	})
+	return o
+}
+
+// OrderByStatusAsc orders results by Status asc
+func (o *OrderOptions) OrderByStatusAsc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.Status),
+			Direction: "asc",
+		})
+	})
+	return o
+}
+
+// OrderByStatusDesc orders results by Status desc
+func (o *OrderOptions) OrderByStatusDesc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.Status),
+			Direction: "desc",
+		})
+	})
+	return o
+}
+
+// OrderByPlacedAtAsc orders results by PlacedAt asc
+func (o *OrderOptions) OrderByPlacedAtAsc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.PlacedAt),
+			Direction: "asc",
+		})
+	})
+	return o
+}
+
+// OrderByPlacedAtDesc orders results by PlacedAt desc
+func (o *OrderOptions) OrderByPlacedAtDesc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.PlacedAt),
+			Direction: "desc",
+		})
+	})
+	return o
+}
+
+// OrderByShippedAtAsc orders results by ShippedAt asc
+func (o *OrderOptions) OrderByShippedAtAsc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.ShippedAt),
+			Direction: "asc",
+		})
+	})
+	return o
+}
+
+// OrderByShippedAtDesc orders results by ShippedAt desc
+func (o *OrderOptions) OrderByShippedAtDesc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.ShippedAt),
+			Direction: "desc",
+		})
+	})
+	return o
+}
+
+// OrderByCouponAsc orders results by Coupon asc
+func (o *OrderOptions) OrderByCouponAsc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.Coupon),
+			Direction: "asc",
+		})
+	})
+	return o
+}
+
+// OrderByCouponDesc orders results by Coupon desc
+func (o *OrderOptions) OrderByCouponDesc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.Coupon),
+			Direction: "desc",
+		})
+	})
+	return o
+}
+
+// OrderByDiscountAsc orders results by Discount asc
+func (o *OrderOptions) OrderByDiscountAsc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.Discount),
+			Direction: "asc",
+		})
+	})
+	return o
+}
+
+// OrderByDiscountDesc orders results by Discount desc
+func (o *OrderOptions) OrderByDiscountDesc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.Discount),
+			Direction: "desc",
+		})
+	})
+	return o
+}
+
+// OrderByDeletedAtAsc orders results by DeletedAt asc
+func (o *OrderOptions) OrderByDeletedAtAsc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.DeletedAt),
+			Direction: "asc",
+		})
+	})
+	return o
+}
+
+// OrderByDeletedAtDesc orders results by DeletedAt desc
+func (o *OrderOptions) OrderByDeletedAtDesc() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(OrderDBSchema.DeletedAt),
+			Direction: "desc",
+		})
+	})
+	return o
+}
+
+// GroupByID groups results by ID
+func (o *OrderOptions) GroupByID() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(OrderDBSchema.ID))
+	})
+	return o
+}
+
+// GroupByNumber groups results by Number
+func (o *OrderOptions) GroupByNumber() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(OrderDBSchema.Number))
+	})
+	return o
+}
+
+// GroupByQuantity groups results by Quantity
+func (o *OrderOptions) GroupByQuantity() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(OrderDBSchema.Quantity))
+	})
+	return o
+}
+
+// GroupByTotal groups results by Total
+func (o *OrderOptions) GroupByTotal() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(OrderDBSchema.Total))
+	})
+	return o
+}
+
+// GroupByPaid groups results by Paid
+func (o *OrderOptions) GroupByPaid() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(OrderDBSchema.Paid))
+	})
+	return o
+}
+
+// GroupByStatus groups results by Status
+func (o *OrderOptions) GroupByStatus() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(OrderDBSchema.Status))
+	})
+	return o
+}
+
+// GroupByPlacedAt groups results by PlacedAt
+func (o *OrderOptions) GroupByPlacedAt() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(OrderDBSchema.PlacedAt))
+	})
+	return o
+}
+
+// GroupByShippedAt groups results by ShippedAt
+func (o *OrderOptions) GroupByShippedAt() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(OrderDBSchema.ShippedAt))
+	})
+	return o
+}
+
+// GroupByCoupon groups results by Coupon
+func (o *OrderOptions) GroupByCoupon() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(OrderDBSchema.Coupon))
+	})
+	return o
+}
+
+// GroupByDiscount groups results by Discount
+func (o *OrderOptions) GroupByDiscount() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(OrderDBSchema.Discount))
+	})
+	return o
+}
+
+// GroupByDeletedAt groups results by DeletedAt
+func (o *OrderOptions) GroupByDeletedAt() *OrderOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(OrderDBSchema.DeletedAt))
+	})
+	return o
+}
+
+// OrderUpdateWhere applies the changes configured by set to every Order row matching the filters configured by where
+func OrderUpdateWhere(ctx context.Context, repo repository.BatchUpdater[*OrderFilters, *OrderUpdater], where func(*OrderFilters), set func(*OrderUpdater)) (int64, error) {
+	filters := NewOrderFilters()
+	if where != nil {
+		where(filters)
+	}
+	updater := NewOrderUpdater()
+	if set != nil {
+		set(updater)
+	}
+	return repo.UpdateWithFilter(ctx, filters, updater)
+}
+
+// OrderCountDistinct counts the distinct non-NULL values of field among the Order rows matching filter
+func OrderCountDistinct(ctx context.Context, repo repository.DistinctCounter[*OrderFilters], field OrderDBSchemaField, filter *OrderFilters) (int64, error) {
+	return repo.CountDistinct(ctx, filter, string(field))
+}
+
+// OrderPage is one page of Order rows with the total count and page metadata
+type OrderPage = repository.Page[Order]
+
+// OrderFindPage returns the page of Order rows matching filter selected by Paginate, with the total count
+func OrderFindPage(ctx context.Context, repo repository.PageCounter[Order, *OrderFilters], filter *OrderFilters, options ...repository.OptionFunc) (*OrderPage, error) {
+	return repository.FindNumberedPage(ctx, repo, filter, options...)
+}
+
+// OrderFindByPrimaryKey returns the Order with the given primary key, reporting false when there is none
+func OrderFindByPrimaryKey(ctx context.Context, repo repository.PrimaryKeyFinder[Order], iD int64) (*Order, bool, error) {
+	return repo.FindByPrimaryKey(ctx, iD)
+}
+
+// OrderCountByMonth counts Order rows matching filter grouped by the "YYYY-MM" month of field.
+// field should be a time column: OrderDBSchema.PlacedAt, OrderDBSchema.DeletedAt
+func OrderCountByMonth(ctx context.Context, repo repository.MonthlyCounter[*OrderFilters], field OrderDBSchemaField, filter *OrderFilters) (map[string]int64, error) {
+	return repo.CountByMonth(ctx, filter, string(field))
+}
+
+// OrderSum returns the sum of field over the Order rows matching filter; hasRows is false when no non-NULL values match.
+// field should be a numeric column: OrderDBSchema.ID, OrderDBSchema.Quantity, OrderDBSchema.Total, OrderDBSchema.Discount
+func OrderSum(ctx context.Context, repo repository.Aggregator[*OrderFilters], field OrderDBSchemaField, filter *OrderFilters) (float64, bool, error) {
+	return repo.Sum(ctx, filter, string(field))
+}
+
+// OrderAvg returns the average of field over the Order rows matching filter; hasRows is false when no non-NULL values match.
+// field should be a numeric column: OrderDBSchema.ID, OrderDBSchema.Quantity, OrderDBSchema.Total, OrderDBSchema.Discount
+func OrderAvg(ctx context.Context, repo repository.Aggregator[*OrderFilters], field OrderDBSchemaField, filter *OrderFilters) (float64, bool, error) {
+	return repo.Avg(ctx, filter, string(field))
+}
+
+// OrderMin returns the smallest value of field over the Order rows matching filter; hasRows is false when no non-NULL values match.
+// field should be a numeric column: OrderDBSchema.ID, OrderDBSchema.Quantity, OrderDBSchema.Total, OrderDBSchema.Discount
+func OrderMin(ctx context.Context, repo repository.Aggregator[*OrderFilters], field OrderDBSchemaField, filter *OrderFilters) (float64, bool, error) {
+	return repo.Min(ctx, filter, string(field))
+}
+
+// OrderMax returns the largest value of field over the Order rows matching filter; hasRows is false when no non-NULL values match.
+// field should be a numeric column: OrderDBSchema.ID, OrderDBSchema.Quantity, OrderDBSchema.Total, OrderDBSchema.Discount
+func OrderMax(ctx context.Context, repo repository.Aggregator[*OrderFilters], field OrderDBSchemaField, filter *OrderFilters) (float64, bool, error) {
+	return repo.Max(ctx, filter, string(field))
+}
+
+// OrderDBSchemaField represents database field names
+// generated from examples/order.go:24
+type OrderDBSchemaField string
+
+// String returns the string representation of the field
+func (f OrderDBSchemaField) String() string {
+	return string(f)
+}
+
+// OrderDBSchema contains database field mappings for Order
+var OrderDBSchema = struct {
+	ID        OrderDBSchemaField
+	Number    OrderDBSchemaField
+	Quantity  OrderDBSchemaField
+	Total     OrderDBSchemaField
+	Paid      OrderDBSchemaField
+	Status    OrderDBSchemaField
+	PlacedAt  OrderDBSchemaField
+	ShippedAt OrderDBSchemaField
+	Coupon    OrderDBSchemaField
+	Discount  OrderDBSchemaField
+	DeletedAt OrderDBSchemaField
+}{
+	ID:        OrderDBSchemaField("id"),
+	Number:    OrderDBSchemaField("number"),
+	Quantity:  OrderDBSchemaField("quantity"),
+	Total:     OrderDBSchemaField("total"),
+	Paid:      OrderDBSchemaField("paid"),
+	Status:    OrderDBSchemaField("status"),
+	PlacedAt:  OrderDBSchemaField("placed_at"),
+	ShippedAt: OrderDBSchemaField("shipped_at"),
+	Coupon:    OrderDBSchemaField("coupon"),
+	Discount:  OrderDBSchemaField("discount"),
+	DeletedAt: OrderDBSchemaField("deleted_at"),
+}
+
+// OrderColumnToField maps the database columns of Order to their struct fields, for
+// repositories that read and write entities through reflection
+var OrderColumnToField = map[string]string{
+	"id":         "ID",
+	"number":     "Number",
+	"quantity":   "Quantity",
+	"total":      "Total",
+	"paid":       "Paid",
+	"status":     "Status",
+	"placed_at":  "PlacedAt",
+	"shipped_at": "ShippedAt",
+	"coupon":     "Coupon",
+	"discount":   "Discount",
+	"deleted_at": "DeletedAt",
+}
+
+// OrderColumnTypes maps the database columns of Order to the Go types of their struct fields
+var OrderColumnTypes = map[string]string{
+	"id":         "int64",
+	"number":     "string",
+	"quantity":   "int",
+	"total":      "float64",
+	"paid":       "bool",
+	"status":     "OrderStatus",
+	"placed_at":  "time.Time",
+	"shipped_at": "*time.Time",
+	"coupon":     "sql.NullString",
+	"discount":   "decimal.Decimal",
+	"deleted_at": "gorm.DeletedAt",
+}
@@ -0,0 +1,367 @@
+package examples
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dchlong/querybuilder"
+	"github.com/dchlong/querybuilder/parser"
+	"github.com/dchlong/querybuilder/repository"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExampleOrder generates the Order query builder with string filters enabled
+func TestExampleOrder(t *testing.T) {
+	ctx := context.Background()
+
+	generator := querybuilder.NewQueryBuilderGeneratorWithOptions(&parser.Structs{}, querybuilder.Options{
+		StringFilters: true,
+	})
+
+	err := generator.Generate(ctx, "./order.go", "order_querybuilder.go", "")
+	if err != nil {
+		panic(err)
+	}
+}
+
+// TestGeneratedStringFilters filters orders with values parsed from strings
+func TestGeneratedStringFilters(t *testing.T) {
+	repo := repository.NewGormRepository[Order, *OrderFilters, *OrderUpdater](setupTestDB(t))
+	ctx := context.Background()
+
+	placedAt := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	shippedAt := placedAt.Add(48 * time.Hour)
+	repo.MustCreate(ctx,
+		&Order{Number: "A-1", Quantity: 1, Total: 9.5, Paid: true, PlacedAt: placedAt, ShippedAt: &shippedAt},
+		&Order{Number: "A-2", Quantity: 3, Total: 30, Paid: false, PlacedAt: placedAt.AddDate(0, 1, 0)},
+		&Order{Number: "A-3", Quantity: 5, Total: 75.25, Paid: true, PlacedAt: placedAt.AddDate(0, 2, 0)},
+	)
+
+	t.Run("successful coercion", func(t *testing.T) {
+		filters := NewOrderFilters().
+			QuantityGteString("3").
+			PaidEqString("true").
+			TotalLtString("100").
+			PlacedAtGtString("2025-04-01T00:00:00Z")
+		require.NoError(t, filters.Err())
+
+		orders, err := repo.FindAll(ctx, filters)
+		require.NoError(t, err)
+		require.Len(t, orders, 1)
+		assert.Equal(t, "A-3", orders[0].Number)
+
+		orders, err = repo.FindAll(ctx, NewOrderFilters().NumberEqString("A-1").ShippedAtEqString(shippedAt.Format(time.RFC3339)))
+		require.NoError(t, err)
+		require.Len(t, orders, 1)
+		assert.Equal(t, "A-1", orders[0].Number)
+	})
+
+	t.Run("failing coercion", func(t *testing.T) {
+		filters := NewOrderFilters().
+			QuantityEqString("three").
+			PaidEqString("yes").
+			NumberEqString("A-1")
+
+		err := filters.Err()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `QuantityEqString: parse "three" as int`)
+		assert.Contains(t, err.Error(), `PaidEqString: parse "yes" as bool`)
+		assert.ErrorIs(t, err, strconv.ErrSyntax)
+
+		// Only the successfully parsed condition was recorded
+		assert.Len(t, filters.ListFilters(), 1)
+
+		_, err = repo.FindAll(ctx, filters)
+		assert.ErrorIs(t, err, repository.ErrInvalidFilterValue)
+	})
+}
+
+// TestGeneratedSoftDelete soft-deletes, finds and restores orders through their gorm.DeletedAt field
+func TestGeneratedSoftDelete(t *testing.T) {
+	repo := repository.NewGormRepository[Order, *OrderFilters, *OrderUpdater](setupTestDB(t))
+	ctx := context.Background()
+
+	placedAt := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	repo.MustCreate(ctx,
+		&Order{Number: "B-1", Quantity: 1, PlacedAt: placedAt},
+		&Order{Number: "B-2", Quantity: 2, PlacedAt: placedAt},
+	)
+
+	deleted, err := repo.DeleteWithFilter(ctx, NewOrderFilters().NumberEq("B-1"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	active, err := repo.Count(ctx, NewOrderFilters())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), active)
+
+	trashed, err := repo.FindAll(ctx, NewOrderFilters().DeletedAtIsNotNull(), repository.WithUnscoped())
+	require.NoError(t, err)
+	require.Len(t, trashed, 1)
+	assert.Equal(t, "B-1", trashed[0].Number)
+	assert.True(t, trashed[0].DeletedAt.Valid)
+
+	restored, err := repo.Restore(ctx, NewOrderFilters().NumberEq("B-1"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), restored)
+
+	live, err := repo.Count(ctx, NewOrderFilters().DeletedAtIsNull())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), live)
+}
+
+// TestGeneratedEnumFilters filters orders by a named string type, which keeps its type in the filters
+func TestGeneratedEnumFilters(t *testing.T) {
+	repo := repository.NewGormRepository[Order, *OrderFilters, *OrderUpdater](setupTestDB(t))
+	ctx := context.Background()
+
+	placedAt := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	repo.MustCreate(ctx,
+		&Order{Number: "C-1", Status: OrderStatusPending, PlacedAt: placedAt},
+		&Order{Number: "C-2", Status: OrderStatusShipped, PlacedAt: placedAt},
+		&Order{Number: "C-3", Status: OrderStatusCancelled, PlacedAt: placedAt},
+	)
+
+	orders, err := repo.FindAll(ctx, NewOrderFilters().StatusEq(OrderStatusShipped))
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, "C-2", orders[0].Number)
+
+	open, err := repo.Count(ctx, NewOrderFilters().StatusIn(OrderStatusPending, OrderStatusShipped))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), open)
+
+	updated, err := repo.UpdateWithFilter(ctx, NewOrderFilters().NumberEq("C-1"), NewOrderUpdater().SetStatus(OrderStatusCancelled))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), updated)
+
+	cancelled, err := repo.Count(ctx, NewOrderFilters().StatusEq(OrderStatusCancelled))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), cancelled)
+}
+
+// TestGeneratedPointerValues filters and updates the nullable ShippedAt by value and by nil pointer
+func TestGeneratedPointerValues(t *testing.T) {
+	repo := repository.NewGormRepository[Order, *OrderFilters, *OrderUpdater](setupTestDB(t))
+	ctx := context.Background()
+
+	placedAt := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	repo.MustCreate(ctx,
+		&Order{Number: "D-1", Quantity: 1, PlacedAt: placedAt},
+		&Order{Number: "D-2", Quantity: 2, PlacedAt: placedAt},
+	)
+
+	shippedAt := placedAt.Add(24 * time.Hour)
+	updated, err := repo.UpdateWithFilter(ctx, NewOrderFilters().NumberEq("D-1"), NewOrderUpdater().SetShippedAtValue(shippedAt))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), updated)
+
+	shipped, err := repo.FindAll(ctx, NewOrderFilters().ShippedAtEqValue(shippedAt))
+	require.NoError(t, err)
+	require.Len(t, shipped, 1)
+	assert.Equal(t, "D-1", shipped[0].Number)
+
+	pending, err := repo.FindAll(ctx, NewOrderFilters().ShippedAtEq(nil))
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "D-2", pending[0].Number)
+}
+
+// TestGeneratedSQLNullFilters filters a sql.NullString by its value and by NULL, and sets it whole
+func TestGeneratedSQLNullFilters(t *testing.T) {
+	repo := repository.NewGormRepository[Order, *OrderFilters, *OrderUpdater](setupTestDB(t))
+	ctx := context.Background()
+
+	placedAt := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	repo.MustCreate(ctx,
+		&Order{Number: "N-1", PlacedAt: placedAt, Coupon: sql.NullString{String: "SPRING10", Valid: true}},
+		&Order{Number: "N-2", PlacedAt: placedAt},
+	)
+
+	withCoupon, err := repo.FindAll(ctx, NewOrderFilters().CouponStartsWith("SPRING"))
+	require.NoError(t, err)
+	require.Len(t, withCoupon, 1)
+	assert.Equal(t, "N-1", withCoupon[0].Number)
+
+	updated, err := repo.UpdateWithFilter(ctx, NewOrderFilters().CouponIsNull(),
+		NewOrderUpdater().SetCoupon(sql.NullString{String: "WELCOME", Valid: true}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), updated)
+
+	welcome, err := repo.FindAll(ctx, NewOrderFilters().CouponIn("WELCOME", "SUMMER"))
+	require.NoError(t, err)
+	require.Len(t, welcome, 1)
+	assert.Equal(t, "N-2", welcome[0].Number)
+
+	_, err = repo.UpdateWithFilter(ctx, NewOrderFilters().CouponEq("SPRING10"), NewOrderUpdater().SetCoupon(sql.NullString{}))
+	require.NoError(t, err)
+	count, err := repo.Count(ctx, NewOrderFilters().CouponIsNotNull())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+// TestGeneratedDecimalFilters compares decimal.Decimal columns as numbers
+func TestGeneratedDecimalFilters(t *testing.T) {
+	repo := repository.NewGormRepository[Order, *OrderFilters, *OrderUpdater](setupTestDB(t))
+	ctx := context.Background()
+
+	placedAt := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	repo.MustCreate(ctx,
+		&Order{Number: "D-1", PlacedAt: placedAt, Discount: decimal.NewFromFloat(2.5)},
+		&Order{Number: "D-2", PlacedAt: placedAt, Discount: decimal.NewFromFloat(10)},
+		&Order{Number: "D-3", PlacedAt: placedAt, Discount: decimal.NewFromFloat(25.75)},
+	)
+
+	large, err := repo.FindAll(ctx, NewOrderFilters().DiscountGt(decimal.NewFromFloat(9.99)), NewOrderOptions().OrderByDiscountAsc())
+	require.NoError(t, err)
+	require.Len(t, large, 2)
+	assert.Equal(t, "D-2", large[0].Number)
+	assert.True(t, large[1].Discount.Equal(decimal.NewFromFloat(25.75)))
+
+	count, err := repo.Count(ctx, NewOrderFilters().DiscountBetween(decimal.NewFromInt(1), decimal.NewFromInt(10)))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+// TestGeneratedWhereRaw combines typed filters with a raw subquery condition
+func TestGeneratedWhereRaw(t *testing.T) {
+	repo := repository.NewGormRepository[Order, *OrderFilters, *OrderUpdater](setupTestDB(t))
+	ctx := context.Background()
+
+	placedAt := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	repo.MustCreate(ctx,
+		&Order{Number: "E-1", Quantity: 1, Total: 10, PlacedAt: placedAt},
+		&Order{Number: "E-2", Quantity: 2, Total: 50, PlacedAt: placedAt},
+		&Order{Number: "E-3", Quantity: 3, Total: 90, PlacedAt: placedAt},
+	)
+
+	filters := NewOrderFilters().
+		QuantityGt(1).
+		WhereRaw("total > (SELECT AVG(total) FROM orders WHERE quantity >= ?)", 1)
+
+	orders, err := repo.FindAll(ctx, filters)
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, "E-3", orders[0].Number)
+}
+
+// TestGeneratedDebugSQL checks the condition rendered by the generated DebugSQL helper
+func TestGeneratedDebugSQL(t *testing.T) {
+	condition, args, err := NewOrderFilters().
+		NumberEq("A-1").
+		QuantityIn(1, 2).
+		ShippedAtIsNull().
+		DebugSQL()
+	require.NoError(t, err)
+	assert.Equal(t, `"number" = ? AND "quantity" IN (?,?) AND "shipped_at" IS NULL`, condition)
+	assert.Equal(t, []interface{}{"A-1", 1, 2}, args)
+
+	_, _, err = NewOrderFilters().QuantityGteString("three").DebugSQL()
+	assert.Error(t, err)
+
+	// The full query of a database comes from the repository
+	repo := repository.NewGormRepository[Order, *OrderFilters, *OrderUpdater](setupTestDB(t))
+	sql, vars, err := repo.ExplainFilter(NewOrderFilters().NumberEq("A-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM `orders` WHERE `number` = ? AND `orders`.`deleted_at` IS NULL", sql)
+	assert.Equal(t, []interface{}{"A-1"}, vars)
+}
+
+func TestGeneratedClone(t *testing.T) {
+	base := NewOrderFilters().NumberEq("A-1").QuantityGte(2)
+	baseSQL, baseArgs, err := base.DebugSQL()
+	require.NoError(t, err)
+
+	// Extending a clone, also on a field base already filters, leaves base alone
+	variant := base.Clone().QuantityLte(5).ShippedAtIsNull()
+	other := base.Clone().QuantityLte(9)
+	base.QuantityGteString("many")
+
+	variantSQL, variantArgs, err := variant.DebugSQL()
+	require.NoError(t, err)
+	assert.Equal(t, `"number" = ? AND "quantity" >= ? AND "quantity" <= ? AND "shipped_at" IS NULL`, variantSQL)
+	assert.Equal(t, []interface{}{"A-1", 2, 5}, variantArgs)
+
+	_, otherArgs, err := other.DebugSQL()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"A-1", 2, 9}, otherArgs)
+
+	assert.Error(t, base.Err(), "base keeps its own parse errors")
+	assert.NoError(t, variant.Err())
+	assert.Len(t, base.ListFilters(), 2)
+	assert.Equal(t, `"number" = ? AND "quantity" >= ?`, baseSQL)
+	assert.Equal(t, []interface{}{"A-1", 2}, baseArgs)
+
+	updater := NewOrderUpdater().SetNumber("A-2")
+	changed := updater.Clone().SetQuantity(3)
+	assert.Equal(t, map[string]interface{}{"number": "A-2"}, updater.GetChangeSet())
+	assert.Equal(t, map[string]interface{}{"number": "A-2", "quantity": 3}, changed.GetChangeSet())
+}
+
+func TestGeneratedMerge(t *testing.T) {
+	scope := NewOrderFilters().PaidEq(true).QuantityGte(1)
+	request := NewOrderFilters().NumberEq("A-1").QuantityLte(5).QuantityGteString("many")
+
+	merged := scope.Clone().Merge(request)
+	sql, args, err := repository.BuildWhere(merged.ListFilters())
+	require.NoError(t, err)
+	assert.Equal(t, `"paid" = ? AND "quantity" >= ? AND "number" = ? AND "quantity" <= ?`, sql, "filters keep call order, scope first")
+	assert.Equal(t, []interface{}{true, 1, "A-1", 5}, args)
+	assert.Error(t, merged.Err(), "parse errors are merged too")
+
+	assert.Len(t, scope.ListFilters(), 2, "the clone kept scope unchanged")
+	assert.Len(t, request.ListFilters(), 2)
+	assert.Same(t, scope, scope.Merge(nil))
+}
+
+func TestGeneratedClearField(t *testing.T) {
+	filters := NewOrderFilters().QuantityGte(2).NumberEq("A-1").QuantityLte(5).PaidEq(true)
+
+	sql, args, err := filters.ClearQuantity().DebugSQL()
+	require.NoError(t, err)
+	assert.Equal(t, `"number" = ? AND "paid" = ?`, sql)
+	assert.Equal(t, []interface{}{"A-1", true}, args)
+
+	// A cleared field filtered on again goes last
+	sql, _, err = filters.ClearCoupon().QuantityGte(1).DebugSQL()
+	require.NoError(t, err)
+	assert.Equal(t, `"number" = ? AND "paid" = ? AND "quantity" >= ?`, sql)
+}
+
+func TestGeneratedFiltersConcurrentReads(t *testing.T) {
+	filters := NewOrderFilters().QuantityGte(2).NumberEq("A-1").QuantityLte(5)
+	expected, _, err := filters.DebugSQL()
+	require.NoError(t, err)
+	assert.Equal(t, `"quantity" >= ? AND "number" = ? AND "quantity" <= ?`, expected)
+
+	results := make(chan string, 8)
+	for range cap(results) {
+		go func() {
+			condition, _, _ := filters.DebugSQL()
+			results <- condition
+		}()
+	}
+	for range cap(results) {
+		assert.Equal(t, expected, <-results)
+	}
+}
+
+func TestGeneratedColumnToField(t *testing.T) {
+	orderType := reflect.TypeOf(Order{})
+	require.Len(t, OrderColumnToField, len(OrderColumnTypes))
+
+	for column, fieldName := range OrderColumnToField {
+		field, ok := orderType.FieldByName(fieldName)
+		require.True(t, ok, "%s maps to missing field %s", column, fieldName)
+		assert.Contains(t, field.Type.String(), OrderColumnTypes[column])
+	}
+
+	assert.Equal(t, "ShippedAt", OrderColumnToField[OrderDBSchema.ShippedAt.String()])
+	assert.Equal(t, "decimal.Decimal", OrderColumnTypes[OrderDBSchema.Discount.String()])
+}
@@ -11,12 +11,12 @@ import (
 //gen:querybuilder
 type Product struct {
 	ID          int64                           `json:"id"`
-	Name        string                          `json:"name"`
-	SKU         string                          `json:"sku"`
+	Name        string                          `json:"name" qb:"notempty,maxlen=255"`
+	SKU         string                          `json:"sku" gorm:"uniqueIndex;uniqueIndex:idx_products_category_sku"`
 	Description *string                         `json:"description"`
-	Price       float64                         `json:"price"`
-	Stock       int                             `json:"stock"`
-	CategoryID  int64                           `json:"category_id"`
+	Price       float64                         `json:"price" qb:"min=0"`
+	Stock       int                             `json:"stock" qb:"min=0"`
+	CategoryID  int64                           `json:"category_id" gorm:"uniqueIndex:idx_products_category_sku,priority:1"`
 	IsActive    bool                            `json:"is_active"`
 	Tags        datatypes.JSONSlice[string]     `json:"tags"`       // JSON array
 	Attributes  datatypes.JSONType[*Attributes] `json:"attributes"` // JSON object
@@ -3,15 +3,24 @@
 package examples
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"time"
+	"unicode/utf8"
 
 	"github.com/dchlong/querybuilder/repository"
 	"gorm.io/datatypes"
 )
 
-// ProductFilters provides filtering capabilities for Product
+// ProductFilters provides filtering capabilities for Product.
+// Filter methods modify it, so build it on one goroutine; a built filter can then be read
+// concurrently, e.g. by ListFilters. Derive variants from a shared filter with Clone.
+// generated from examples/product.go:12
 type ProductFilters struct {
 	filters map[ProductDBSchemaField][]*repository.Filter
+	order   []*repository.Filter // every filter in call order
 }
 
 // NewProductFilters creates a new filter instance
@@ -21,786 +30,1016 @@ func NewProductFilters() *ProductFilters {
 	}
 }
 
-// ListFilters returns all configured filters
+// ListFilters returns all configured filters in the order they were added.
+// The result is clipped, so appending to it never writes into f.
 func (f *ProductFilters) ListFilters() []*repository.Filter {
-	var result []*repository.Filter
-	for _, filterList := range f.filters {
-		result = append(result, filterList...)
+	return slices.Clip(f.order)
+}
+
+// addFilter appends a filter, indexing it by field
+func (f *ProductFilters) addFilter(field ProductDBSchemaField, filter *repository.Filter) *ProductFilters {
+	f.filters[field] = append(f.filters[field], filter)
+	f.order = append(f.order, filter)
+	return f
+}
+
+// clearField removes the filters on field, keeping the others in order
+func (f *ProductFilters) clearField(field ProductDBSchemaField) *ProductFilters {
+	if _, exists := f.filters[field]; !exists {
+		return f
 	}
-	return result
+	delete(f.filters, field)
+	var kept []*repository.Filter
+	for _, filter := range f.order {
+		if filter.Field != string(field) {
+			kept = append(kept, filter)
+		}
+	}
+	f.order = kept
+	return f
+}
+
+// Clone returns a copy of the filters that can be extended without changing f
+func (f *ProductFilters) Clone() *ProductFilters {
+	clone := &ProductFilters{
+		filters: make(map[ProductDBSchemaField][]*repository.Filter, len(f.filters)),
+		order:   append([]*repository.Filter(nil), f.order...),
+	}
+	for field, filters := range f.filters {
+		clone.filters[field] = append([]*repository.Filter(nil), filters...)
+	}
+	return clone
+}
+
+// Merge appends the filters of other after those of f, in other's call order, e.g. request
+// filters to a shared scope, and returns f. other is not changed; merge into f.Clone() to keep
+// f as it is.
+func (f *ProductFilters) Merge(other *ProductFilters) *ProductFilters {
+	if other == nil {
+		return f
+	}
+	for _, filter := range other.order {
+		f.addFilter(ProductDBSchemaField(filter.Field), filter)
+	}
+	return f
 }
 
 // IDEq filters by ID eq
 func (p *ProductFilters) IDEq(iD int64) *ProductFilters {
-	p.filters[ProductDBSchema.ID] = append(p.filters[ProductDBSchema.ID],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.ID),
-			Operator: repository.OperatorEqual,
-			Value:    iD,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.ID, &repository.Filter{
+		Field:    string(ProductDBSchema.ID),
+		Operator: repository.OperatorEqual,
+		Value:    iD,
+	})
 }
 
 // IDNe filters by ID ne
 func (p *ProductFilters) IDNe(iD int64) *ProductFilters {
-	p.filters[ProductDBSchema.ID] = append(p.filters[ProductDBSchema.ID],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.ID),
-			Operator: repository.OperatorNotEqual,
-			Value:    iD,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.ID, &repository.Filter{
+		Field:    string(ProductDBSchema.ID),
+		Operator: repository.OperatorNotEqual,
+		Value:    iD,
+	})
 }
 
 // IDLt filters by ID lt
 func (p *ProductFilters) IDLt(iD int64) *ProductFilters {
-	p.filters[ProductDBSchema.ID] = append(p.filters[ProductDBSchema.ID],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.ID),
-			Operator: repository.OperatorLessThan,
-			Value:    iD,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.ID, &repository.Filter{
+		Field:    string(ProductDBSchema.ID),
+		Operator: repository.OperatorLessThan,
+		Value:    iD,
+	})
 }
 
 // IDGt filters by ID gt
 func (p *ProductFilters) IDGt(iD int64) *ProductFilters {
-	p.filters[ProductDBSchema.ID] = append(p.filters[ProductDBSchema.ID],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.ID),
-			Operator: repository.OperatorGreaterThan,
-			Value:    iD,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.ID, &repository.Filter{
+		Field:    string(ProductDBSchema.ID),
+		Operator: repository.OperatorGreaterThan,
+		Value:    iD,
+	})
 }
 
 // IDLte filters by ID lte
 func (p *ProductFilters) IDLte(iD int64) *ProductFilters {
-	p.filters[ProductDBSchema.ID] = append(p.filters[ProductDBSchema.ID],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.ID),
-			Operator: repository.OperatorLessThanOrEqual,
-			Value:    iD,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.ID, &repository.Filter{
+		Field:    string(ProductDBSchema.ID),
+		Operator: repository.OperatorLessThanOrEqual,
+		Value:    iD,
+	})
 }
 
 // IDGte filters by ID gte
 func (p *ProductFilters) IDGte(iD int64) *ProductFilters {
-	p.filters[ProductDBSchema.ID] = append(p.filters[ProductDBSchema.ID],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.ID),
-			Operator: repository.OperatorGreaterThanOrEqual,
-			Value:    iD,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.ID, &repository.Filter{
+		Field:    string(ProductDBSchema.ID),
+		Operator: repository.OperatorGreaterThanOrEqual,
+		Value:    iD,
+	})
 }
 
 // IDIn filters by ID in list
+// note: empty call matches nothing
 func (p *ProductFilters) IDIn(iDs ...int64) *ProductFilters {
-	p.filters[ProductDBSchema.ID] = append(p.filters[ProductDBSchema.ID],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.ID),
-			Operator: repository.OperatorIn,
-			Value:    iDs,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.ID, &repository.Filter{
+		Field:    string(ProductDBSchema.ID),
+		Operator: repository.OperatorIn,
+		Value:    iDs,
+	})
 }
 
-// IDNotIn filters by ID in list
+// IDNotIn filters by ID not in list
+// note: empty call matches everything
 func (p *ProductFilters) IDNotIn(iDs ...int64) *ProductFilters {
-	p.filters[ProductDBSchema.ID] = append(p.filters[ProductDBSchema.ID],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.ID),
-			Operator: repository.OperatorNotIn,
-			Value:    iDs,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.ID, &repository.Filter{
+		Field:    string(ProductDBSchema.ID),
+		Operator: repository.OperatorNotIn,
+		Value:    iDs,
+	})
+}
+
+// IDBetween filters by ID between lower and upper (inclusive)
+func (p *ProductFilters) IDBetween(lower, upper int64) *ProductFilters {
+	return p.addFilter(ProductDBSchema.ID, &repository.Filter{
+		Field:    string(ProductDBSchema.ID),
+		Operator: repository.OperatorBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// IDNotBetween filters by ID outside the inclusive range lower to upper
+func (p *ProductFilters) IDNotBetween(lower, upper int64) *ProductFilters {
+	return p.addFilter(ProductDBSchema.ID, &repository.Filter{
+		Field:    string(ProductDBSchema.ID),
+		Operator: repository.OperatorNotBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// ClearID removes the filters on ID, keeping the others in order
+func (p *ProductFilters) ClearID() *ProductFilters {
+	return p.clearField(ProductDBSchema.ID)
 }
 
 // NameEq filters by Name eq
 func (p *ProductFilters) NameEq(name string) *ProductFilters {
-	p.filters[ProductDBSchema.Name] = append(p.filters[ProductDBSchema.Name],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Name),
-			Operator: repository.OperatorEqual,
-			Value:    name,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Name, &repository.Filter{
+		Field:    string(ProductDBSchema.Name),
+		Operator: repository.OperatorEqual,
+		Value:    name,
+	})
 }
 
 // NameNe filters by Name ne
 func (p *ProductFilters) NameNe(name string) *ProductFilters {
-	p.filters[ProductDBSchema.Name] = append(p.filters[ProductDBSchema.Name],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Name),
-			Operator: repository.OperatorNotEqual,
-			Value:    name,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Name, &repository.Filter{
+		Field:    string(ProductDBSchema.Name),
+		Operator: repository.OperatorNotEqual,
+		Value:    name,
+	})
 }
 
 // NameLike filters by Name like
 func (p *ProductFilters) NameLike(name string) *ProductFilters {
-	p.filters[ProductDBSchema.Name] = append(p.filters[ProductDBSchema.Name],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Name),
-			Operator: repository.OperatorLike,
-			Value:    name,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Name, &repository.Filter{
+		Field:    string(ProductDBSchema.Name),
+		Operator: repository.OperatorLike,
+		Value:    name,
+	})
+}
+
+// NameContains filters by Name contains name; LIKE wildcards in name match literally
+func (p *ProductFilters) NameContains(name string) *ProductFilters {
+	return p.addFilter(ProductDBSchema.Name, &repository.Filter{
+		Field:    string(ProductDBSchema.Name),
+		Operator: repository.OperatorLike,
+		Value:    "%" + repository.EscapeLike(name) + "%",
+	})
+}
+
+// NameStartsWith filters by Name starts with name; LIKE wildcards in name match literally
+func (p *ProductFilters) NameStartsWith(name string) *ProductFilters {
+	return p.addFilter(ProductDBSchema.Name, &repository.Filter{
+		Field:    string(ProductDBSchema.Name),
+		Operator: repository.OperatorLike,
+		Value:    repository.EscapeLike(name) + "%",
+	})
+}
+
+// NameEndsWith filters by Name ends with name; LIKE wildcards in name match literally
+func (p *ProductFilters) NameEndsWith(name string) *ProductFilters {
+	return p.addFilter(ProductDBSchema.Name, &repository.Filter{
+		Field:    string(ProductDBSchema.Name),
+		Operator: repository.OperatorLike,
+		Value:    "%" + repository.EscapeLike(name),
+	})
 }
 
 // NameNotLike filters by Name notlike
 func (p *ProductFilters) NameNotLike(name string) *ProductFilters {
-	p.filters[ProductDBSchema.Name] = append(p.filters[ProductDBSchema.Name],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Name),
-			Operator: repository.OperatorNotLike,
-			Value:    name,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Name, &repository.Filter{
+		Field:    string(ProductDBSchema.Name),
+		Operator: repository.OperatorNotLike,
+		Value:    name,
+	})
+}
+
+// NameILike filters by Name ilike
+func (p *ProductFilters) NameILike(name string) *ProductFilters {
+	return p.addFilter(ProductDBSchema.Name, &repository.Filter{
+		Field:    string(ProductDBSchema.Name),
+		Operator: repository.OperatorILike,
+		Value:    name,
+	})
+}
+
+// NameMatches filters by Name matching the regular expression pattern (Postgres and MySQL)
+func (p *ProductFilters) NameMatches(pattern string) *ProductFilters {
+	return p.addFilter(ProductDBSchema.Name, &repository.Filter{
+		Field:    string(ProductDBSchema.Name),
+		Operator: repository.OperatorRegex,
+		Value:    pattern,
+	})
 }
 
 // NameIn filters by Name in list
+// note: empty call matches nothing
 func (p *ProductFilters) NameIn(names ...string) *ProductFilters {
-	p.filters[ProductDBSchema.Name] = append(p.filters[ProductDBSchema.Name],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Name),
-			Operator: repository.OperatorIn,
-			Value:    names,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Name, &repository.Filter{
+		Field:    string(ProductDBSchema.Name),
+		Operator: repository.OperatorIn,
+		Value:    names,
+	})
 }
 
-// NameNotIn filters by Name in list
+// NameNotIn filters by Name not in list
+// note: empty call matches everything
 func (p *ProductFilters) NameNotIn(names ...string) *ProductFilters {
-	p.filters[ProductDBSchema.Name] = append(p.filters[ProductDBSchema.Name],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Name),
-			Operator: repository.OperatorNotIn,
-			Value:    names,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Name, &repository.Filter{
+		Field:    string(ProductDBSchema.Name),
+		Operator: repository.OperatorNotIn,
+		Value:    names,
+	})
 }
 
 // NameLt filters by Name lt
 func (p *ProductFilters) NameLt(name string) *ProductFilters {
-	p.filters[ProductDBSchema.Name] = append(p.filters[ProductDBSchema.Name],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Name),
-			Operator: repository.OperatorLessThan,
-			Value:    name,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Name, &repository.Filter{
+		Field:    string(ProductDBSchema.Name),
+		Operator: repository.OperatorLessThan,
+		Value:    name,
+	})
 }
 
 // NameGt filters by Name gt
 func (p *ProductFilters) NameGt(name string) *ProductFilters {
-	p.filters[ProductDBSchema.Name] = append(p.filters[ProductDBSchema.Name],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Name),
-			Operator: repository.OperatorGreaterThan,
-			Value:    name,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Name, &repository.Filter{
+		Field:    string(ProductDBSchema.Name),
+		Operator: repository.OperatorGreaterThan,
+		Value:    name,
+	})
 }
 
 // NameLte filters by Name lte
 func (p *ProductFilters) NameLte(name string) *ProductFilters {
-	p.filters[ProductDBSchema.Name] = append(p.filters[ProductDBSchema.Name],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Name),
-			Operator: repository.OperatorLessThanOrEqual,
-			Value:    name,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Name, &repository.Filter{
+		Field:    string(ProductDBSchema.Name),
+		Operator: repository.OperatorLessThanOrEqual,
+		Value:    name,
+	})
 }
 
 // NameGte filters by Name gte
 func (p *ProductFilters) NameGte(name string) *ProductFilters {
-	p.filters[ProductDBSchema.Name] = append(p.filters[ProductDBSchema.Name],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Name),
-			Operator: repository.OperatorGreaterThanOrEqual,
-			Value:    name,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Name, &repository.Filter{
+		Field:    string(ProductDBSchema.Name),
+		Operator: repository.OperatorGreaterThanOrEqual,
+		Value:    name,
+	})
+}
+
+// ClearName removes the filters on Name, keeping the others in order
+func (p *ProductFilters) ClearName() *ProductFilters {
+	return p.clearField(ProductDBSchema.Name)
 }
 
 // SKUEq filters by SKU eq
 func (p *ProductFilters) SKUEq(sKU string) *ProductFilters {
-	p.filters[ProductDBSchema.SKU] = append(p.filters[ProductDBSchema.SKU],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.SKU),
-			Operator: repository.OperatorEqual,
-			Value:    sKU,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.SKU, &repository.Filter{
+		Field:    string(ProductDBSchema.SKU),
+		Operator: repository.OperatorEqual,
+		Value:    sKU,
+	})
 }
 
 // SKUNe filters by SKU ne
 func (p *ProductFilters) SKUNe(sKU string) *ProductFilters {
-	p.filters[ProductDBSchema.SKU] = append(p.filters[ProductDBSchema.SKU],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.SKU),
-			Operator: repository.OperatorNotEqual,
-			Value:    sKU,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.SKU, &repository.Filter{
+		Field:    string(ProductDBSchema.SKU),
+		Operator: repository.OperatorNotEqual,
+		Value:    sKU,
+	})
 }
 
 // SKULike filters by SKU like
 func (p *ProductFilters) SKULike(sKU string) *ProductFilters {
-	p.filters[ProductDBSchema.SKU] = append(p.filters[ProductDBSchema.SKU],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.SKU),
-			Operator: repository.OperatorLike,
-			Value:    sKU,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.SKU, &repository.Filter{
+		Field:    string(ProductDBSchema.SKU),
+		Operator: repository.OperatorLike,
+		Value:    sKU,
+	})
+}
+
+// SKUContains filters by SKU contains sKU; LIKE wildcards in sKU match literally
+func (p *ProductFilters) SKUContains(sKU string) *ProductFilters {
+	return p.addFilter(ProductDBSchema.SKU, &repository.Filter{
+		Field:    string(ProductDBSchema.SKU),
+		Operator: repository.OperatorLike,
+		Value:    "%" + repository.EscapeLike(sKU) + "%",
+	})
+}
+
+// SKUStartsWith filters by SKU starts with sKU; LIKE wildcards in sKU match literally
+func (p *ProductFilters) SKUStartsWith(sKU string) *ProductFilters {
+	return p.addFilter(ProductDBSchema.SKU, &repository.Filter{
+		Field:    string(ProductDBSchema.SKU),
+		Operator: repository.OperatorLike,
+		Value:    repository.EscapeLike(sKU) + "%",
+	})
+}
+
+// SKUEndsWith filters by SKU ends with sKU; LIKE wildcards in sKU match literally
+func (p *ProductFilters) SKUEndsWith(sKU string) *ProductFilters {
+	return p.addFilter(ProductDBSchema.SKU, &repository.Filter{
+		Field:    string(ProductDBSchema.SKU),
+		Operator: repository.OperatorLike,
+		Value:    "%" + repository.EscapeLike(sKU),
+	})
 }
 
 // SKUNotLike filters by SKU notlike
 func (p *ProductFilters) SKUNotLike(sKU string) *ProductFilters {
-	p.filters[ProductDBSchema.SKU] = append(p.filters[ProductDBSchema.SKU],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.SKU),
-			Operator: repository.OperatorNotLike,
-			Value:    sKU,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.SKU, &repository.Filter{
+		Field:    string(ProductDBSchema.SKU),
+		Operator: repository.OperatorNotLike,
+		Value:    sKU,
+	})
+}
+
+// SKUILike filters by SKU ilike
+func (p *ProductFilters) SKUILike(sKU string) *ProductFilters {
+	return p.addFilter(ProductDBSchema.SKU, &repository.Filter{
+		Field:    string(ProductDBSchema.SKU),
+		Operator: repository.OperatorILike,
+		Value:    sKU,
+	})
+}
+
+// SKUMatches filters by SKU matching the regular expression pattern (Postgres and MySQL)
+func (p *ProductFilters) SKUMatches(pattern string) *ProductFilters {
+	return p.addFilter(ProductDBSchema.SKU, &repository.Filter{
+		Field:    string(ProductDBSchema.SKU),
+		Operator: repository.OperatorRegex,
+		Value:    pattern,
+	})
 }
 
 // SKUIn filters by SKU in list
+// note: empty call matches nothing
 func (p *ProductFilters) SKUIn(sKUs ...string) *ProductFilters {
-	p.filters[ProductDBSchema.SKU] = append(p.filters[ProductDBSchema.SKU],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.SKU),
-			Operator: repository.OperatorIn,
-			Value:    sKUs,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.SKU, &repository.Filter{
+		Field:    string(ProductDBSchema.SKU),
+		Operator: repository.OperatorIn,
+		Value:    sKUs,
+	})
 }
 
-// SKUNotIn filters by SKU in list
+// SKUNotIn filters by SKU not in list
+// note: empty call matches everything
 func (p *ProductFilters) SKUNotIn(sKUs ...string) *ProductFilters {
-	p.filters[ProductDBSchema.SKU] = append(p.filters[ProductDBSchema.SKU],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.SKU),
-			Operator: repository.OperatorNotIn,
-			Value:    sKUs,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.SKU, &repository.Filter{
+		Field:    string(ProductDBSchema.SKU),
+		Operator: repository.OperatorNotIn,
+		Value:    sKUs,
+	})
 }
 
 // SKULt filters by SKU lt
 func (p *ProductFilters) SKULt(sKU string) *ProductFilters {
-	p.filters[ProductDBSchema.SKU] = append(p.filters[ProductDBSchema.SKU],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.SKU),
-			Operator: repository.OperatorLessThan,
-			Value:    sKU,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.SKU, &repository.Filter{
+		Field:    string(ProductDBSchema.SKU),
+		Operator: repository.OperatorLessThan,
+		Value:    sKU,
+	})
 }
 
 // SKUGt filters by SKU gt
 func (p *ProductFilters) SKUGt(sKU string) *ProductFilters {
-	p.filters[ProductDBSchema.SKU] = append(p.filters[ProductDBSchema.SKU],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.SKU),
-			Operator: repository.OperatorGreaterThan,
-			Value:    sKU,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.SKU, &repository.Filter{
+		Field:    string(ProductDBSchema.SKU),
+		Operator: repository.OperatorGreaterThan,
+		Value:    sKU,
+	})
 }
 
 // SKULte filters by SKU lte
 func (p *ProductFilters) SKULte(sKU string) *ProductFilters {
-	p.filters[ProductDBSchema.SKU] = append(p.filters[ProductDBSchema.SKU],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.SKU),
-			Operator: repository.OperatorLessThanOrEqual,
-			Value:    sKU,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.SKU, &repository.Filter{
+		Field:    string(ProductDBSchema.SKU),
+		Operator: repository.OperatorLessThanOrEqual,
+		Value:    sKU,
+	})
 }
 
 // SKUGte filters by SKU gte
 func (p *ProductFilters) SKUGte(sKU string) *ProductFilters {
-	p.filters[ProductDBSchema.SKU] = append(p.filters[ProductDBSchema.SKU],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.SKU),
-			Operator: repository.OperatorGreaterThanOrEqual,
-			Value:    sKU,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.SKU, &repository.Filter{
+		Field:    string(ProductDBSchema.SKU),
+		Operator: repository.OperatorGreaterThanOrEqual,
+		Value:    sKU,
+	})
+}
+
+// ClearSKU removes the filters on SKU, keeping the others in order
+func (p *ProductFilters) ClearSKU() *ProductFilters {
+	return p.clearField(ProductDBSchema.SKU)
 }
 
 // DescriptionEq filters by Description eq
 func (p *ProductFilters) DescriptionEq(description *string) *ProductFilters {
-	p.filters[ProductDBSchema.Description] = append(p.filters[ProductDBSchema.Description],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Description),
-			Operator: repository.OperatorEqual,
-			Value:    description,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Description, &repository.Filter{
+		Field:    string(ProductDBSchema.Description),
+		Operator: repository.OperatorEqual,
+		Value:    description,
+	})
+}
+
+// DescriptionEqValue filters by Description equal to description; rows where Description is NULL never match
+func (p *ProductFilters) DescriptionEqValue(description string) *ProductFilters {
+	return p.addFilter(ProductDBSchema.Description, &repository.Filter{
+		Field:    string(ProductDBSchema.Description),
+		Operator: repository.OperatorEqual,
+		Value:    description,
+	})
 }
 
 // DescriptionNe filters by Description ne
 func (p *ProductFilters) DescriptionNe(description *string) *ProductFilters {
-	p.filters[ProductDBSchema.Description] = append(p.filters[ProductDBSchema.Description],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Description),
-			Operator: repository.OperatorNotEqual,
-			Value:    description,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Description, &repository.Filter{
+		Field:    string(ProductDBSchema.Description),
+		Operator: repository.OperatorNotEqual,
+		Value:    description,
+	})
 }
 
 // DescriptionIsNull filters by Description is null check
 func (p *ProductFilters) DescriptionIsNull() *ProductFilters {
-	p.filters[ProductDBSchema.Description] = append(p.filters[ProductDBSchema.Description],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Description),
-			Operator: repository.OperatorIsNull,
-			Value:    nil,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Description, &repository.Filter{
+		Field:    string(ProductDBSchema.Description),
+		Operator: repository.OperatorIsNull,
+		Value:    nil,
+	})
 }
 
 // DescriptionIsNotNull filters by Description is null check
 func (p *ProductFilters) DescriptionIsNotNull() *ProductFilters {
-	p.filters[ProductDBSchema.Description] = append(p.filters[ProductDBSchema.Description],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Description),
-			Operator: repository.OperatorIsNotNull,
-			Value:    nil,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Description, &repository.Filter{
+		Field:    string(ProductDBSchema.Description),
+		Operator: repository.OperatorIsNotNull,
+		Value:    nil,
+	})
+}
+
+// ClearDescription removes the filters on Description, keeping the others in order
+func (p *ProductFilters) ClearDescription() *ProductFilters {
+	return p.clearField(ProductDBSchema.Description)
 }
 
 // PriceEq filters by Price eq
 func (p *ProductFilters) PriceEq(price float64) *ProductFilters {
-	p.filters[ProductDBSchema.Price] = append(p.filters[ProductDBSchema.Price],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Price),
-			Operator: repository.OperatorEqual,
-			Value:    price,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Price, &repository.Filter{
+		Field:    string(ProductDBSchema.Price),
+		Operator: repository.OperatorEqual,
+		Value:    price,
+	})
 }
 
 // PriceNe filters by Price ne
 func (p *ProductFilters) PriceNe(price float64) *ProductFilters {
-	p.filters[ProductDBSchema.Price] = append(p.filters[ProductDBSchema.Price],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Price),
-			Operator: repository.OperatorNotEqual,
-			Value:    price,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Price, &repository.Filter{
+		Field:    string(ProductDBSchema.Price),
+		Operator: repository.OperatorNotEqual,
+		Value:    price,
+	})
 }
 
 // PriceLt filters by Price lt
 func (p *ProductFilters) PriceLt(price float64) *ProductFilters {
-	p.filters[ProductDBSchema.Price] = append(p.filters[ProductDBSchema.Price],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Price),
-			Operator: repository.OperatorLessThan,
-			Value:    price,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Price, &repository.Filter{
+		Field:    string(ProductDBSchema.Price),
+		Operator: repository.OperatorLessThan,
+		Value:    price,
+	})
 }
 
 // PriceGt filters by Price gt
 func (p *ProductFilters) PriceGt(price float64) *ProductFilters {
-	p.filters[ProductDBSchema.Price] = append(p.filters[ProductDBSchema.Price],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Price),
-			Operator: repository.OperatorGreaterThan,
-			Value:    price,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Price, &repository.Filter{
+		Field:    string(ProductDBSchema.Price),
+		Operator: repository.OperatorGreaterThan,
+		Value:    price,
+	})
 }
 
 // PriceLte filters by Price lte
 func (p *ProductFilters) PriceLte(price float64) *ProductFilters {
-	p.filters[ProductDBSchema.Price] = append(p.filters[ProductDBSchema.Price],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Price),
-			Operator: repository.OperatorLessThanOrEqual,
-			Value:    price,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Price, &repository.Filter{
+		Field:    string(ProductDBSchema.Price),
+		Operator: repository.OperatorLessThanOrEqual,
+		Value:    price,
+	})
 }
 
 // PriceGte filters by Price gte
 func (p *ProductFilters) PriceGte(price float64) *ProductFilters {
-	p.filters[ProductDBSchema.Price] = append(p.filters[ProductDBSchema.Price],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Price),
-			Operator: repository.OperatorGreaterThanOrEqual,
-			Value:    price,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Price, &repository.Filter{
+		Field:    string(ProductDBSchema.Price),
+		Operator: repository.OperatorGreaterThanOrEqual,
+		Value:    price,
+	})
 }
 
 // PriceIn filters by Price in list
+// note: empty call matches nothing
 func (p *ProductFilters) PriceIn(prices ...float64) *ProductFilters {
-	p.filters[ProductDBSchema.Price] = append(p.filters[ProductDBSchema.Price],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Price),
-			Operator: repository.OperatorIn,
-			Value:    prices,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Price, &repository.Filter{
+		Field:    string(ProductDBSchema.Price),
+		Operator: repository.OperatorIn,
+		Value:    prices,
+	})
 }
 
-// PriceNotIn filters by Price in list
+// PriceNotIn filters by Price not in list
+// note: empty call matches everything
 func (p *ProductFilters) PriceNotIn(prices ...float64) *ProductFilters {
-	p.filters[ProductDBSchema.Price] = append(p.filters[ProductDBSchema.Price],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Price),
-			Operator: repository.OperatorNotIn,
-			Value:    prices,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Price, &repository.Filter{
+		Field:    string(ProductDBSchema.Price),
+		Operator: repository.OperatorNotIn,
+		Value:    prices,
+	})
+}
+
+// PriceBetween filters by Price between lower and upper (inclusive)
+func (p *ProductFilters) PriceBetween(lower, upper float64) *ProductFilters {
+	return p.addFilter(ProductDBSchema.Price, &repository.Filter{
+		Field:    string(ProductDBSchema.Price),
+		Operator: repository.OperatorBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// PriceNotBetween filters by Price outside the inclusive range lower to upper
+func (p *ProductFilters) PriceNotBetween(lower, upper float64) *ProductFilters {
+	return p.addFilter(ProductDBSchema.Price, &repository.Filter{
+		Field:    string(ProductDBSchema.Price),
+		Operator: repository.OperatorNotBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// ClearPrice removes the filters on Price, keeping the others in order
+func (p *ProductFilters) ClearPrice() *ProductFilters {
+	return p.clearField(ProductDBSchema.Price)
 }
 
 // StockEq filters by Stock eq
 func (p *ProductFilters) StockEq(stock int) *ProductFilters {
-	p.filters[ProductDBSchema.Stock] = append(p.filters[ProductDBSchema.Stock],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Stock),
-			Operator: repository.OperatorEqual,
-			Value:    stock,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Stock, &repository.Filter{
+		Field:    string(ProductDBSchema.Stock),
+		Operator: repository.OperatorEqual,
+		Value:    stock,
+	})
 }
 
 // StockNe filters by Stock ne
 func (p *ProductFilters) StockNe(stock int) *ProductFilters {
-	p.filters[ProductDBSchema.Stock] = append(p.filters[ProductDBSchema.Stock],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Stock),
-			Operator: repository.OperatorNotEqual,
-			Value:    stock,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Stock, &repository.Filter{
+		Field:    string(ProductDBSchema.Stock),
+		Operator: repository.OperatorNotEqual,
+		Value:    stock,
+	})
 }
 
 // StockLt filters by Stock lt
 func (p *ProductFilters) StockLt(stock int) *ProductFilters {
-	p.filters[ProductDBSchema.Stock] = append(p.filters[ProductDBSchema.Stock],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Stock),
-			Operator: repository.OperatorLessThan,
-			Value:    stock,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Stock, &repository.Filter{
+		Field:    string(ProductDBSchema.Stock),
+		Operator: repository.OperatorLessThan,
+		Value:    stock,
+	})
 }
 
 // StockGt filters by Stock gt
 func (p *ProductFilters) StockGt(stock int) *ProductFilters {
-	p.filters[ProductDBSchema.Stock] = append(p.filters[ProductDBSchema.Stock],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Stock),
-			Operator: repository.OperatorGreaterThan,
-			Value:    stock,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Stock, &repository.Filter{
+		Field:    string(ProductDBSchema.Stock),
+		Operator: repository.OperatorGreaterThan,
+		Value:    stock,
+	})
 }
 
 // StockLte filters by Stock lte
 func (p *ProductFilters) StockLte(stock int) *ProductFilters {
-	p.filters[ProductDBSchema.Stock] = append(p.filters[ProductDBSchema.Stock],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Stock),
-			Operator: repository.OperatorLessThanOrEqual,
-			Value:    stock,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Stock, &repository.Filter{
+		Field:    string(ProductDBSchema.Stock),
+		Operator: repository.OperatorLessThanOrEqual,
+		Value:    stock,
+	})
 }
 
 // StockGte filters by Stock gte
 func (p *ProductFilters) StockGte(stock int) *ProductFilters {
-	p.filters[ProductDBSchema.Stock] = append(p.filters[ProductDBSchema.Stock],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Stock),
-			Operator: repository.OperatorGreaterThanOrEqual,
-			Value:    stock,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Stock, &repository.Filter{
+		Field:    string(ProductDBSchema.Stock),
+		Operator: repository.OperatorGreaterThanOrEqual,
+		Value:    stock,
+	})
 }
 
 // StockIn filters by Stock in list
+// note: empty call matches nothing
 func (p *ProductFilters) StockIn(stocks ...int) *ProductFilters {
-	p.filters[ProductDBSchema.Stock] = append(p.filters[ProductDBSchema.Stock],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Stock),
-			Operator: repository.OperatorIn,
-			Value:    stocks,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Stock, &repository.Filter{
+		Field:    string(ProductDBSchema.Stock),
+		Operator: repository.OperatorIn,
+		Value:    stocks,
+	})
 }
 
-// StockNotIn filters by Stock in list
+// StockNotIn filters by Stock not in list
+// note: empty call matches everything
 func (p *ProductFilters) StockNotIn(stocks ...int) *ProductFilters {
-	p.filters[ProductDBSchema.Stock] = append(p.filters[ProductDBSchema.Stock],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.Stock),
-			Operator: repository.OperatorNotIn,
-			Value:    stocks,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.Stock, &repository.Filter{
+		Field:    string(ProductDBSchema.Stock),
+		Operator: repository.OperatorNotIn,
+		Value:    stocks,
+	})
+}
+
+// StockBetween filters by Stock between lower and upper (inclusive)
+func (p *ProductFilters) StockBetween(lower, upper int) *ProductFilters {
+	return p.addFilter(ProductDBSchema.Stock, &repository.Filter{
+		Field:    string(ProductDBSchema.Stock),
+		Operator: repository.OperatorBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// StockNotBetween filters by Stock outside the inclusive range lower to upper
+func (p *ProductFilters) StockNotBetween(lower, upper int) *ProductFilters {
+	return p.addFilter(ProductDBSchema.Stock, &repository.Filter{
+		Field:    string(ProductDBSchema.Stock),
+		Operator: repository.OperatorNotBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// ClearStock removes the filters on Stock, keeping the others in order
+func (p *ProductFilters) ClearStock() *ProductFilters {
+	return p.clearField(ProductDBSchema.Stock)
 }
 
 // CategoryIDEq filters by CategoryID eq
 func (p *ProductFilters) CategoryIDEq(categoryID int64) *ProductFilters {
-	p.filters[ProductDBSchema.CategoryID] = append(p.filters[ProductDBSchema.CategoryID],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.CategoryID),
-			Operator: repository.OperatorEqual,
-			Value:    categoryID,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.CategoryID, &repository.Filter{
+		Field:    string(ProductDBSchema.CategoryID),
+		Operator: repository.OperatorEqual,
+		Value:    categoryID,
+	})
 }
 
 // CategoryIDNe filters by CategoryID ne
 func (p *ProductFilters) CategoryIDNe(categoryID int64) *ProductFilters {
-	p.filters[ProductDBSchema.CategoryID] = append(p.filters[ProductDBSchema.CategoryID],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.CategoryID),
-			Operator: repository.OperatorNotEqual,
-			Value:    categoryID,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.CategoryID, &repository.Filter{
+		Field:    string(ProductDBSchema.CategoryID),
+		Operator: repository.OperatorNotEqual,
+		Value:    categoryID,
+	})
 }
 
 // CategoryIDLt filters by CategoryID lt
 func (p *ProductFilters) CategoryIDLt(categoryID int64) *ProductFilters {
-	p.filters[ProductDBSchema.CategoryID] = append(p.filters[ProductDBSchema.CategoryID],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.CategoryID),
-			Operator: repository.OperatorLessThan,
-			Value:    categoryID,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.CategoryID, &repository.Filter{
+		Field:    string(ProductDBSchema.CategoryID),
+		Operator: repository.OperatorLessThan,
+		Value:    categoryID,
+	})
 }
 
 // CategoryIDGt filters by CategoryID gt
 func (p *ProductFilters) CategoryIDGt(categoryID int64) *ProductFilters {
-	p.filters[ProductDBSchema.CategoryID] = append(p.filters[ProductDBSchema.CategoryID],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.CategoryID),
-			Operator: repository.OperatorGreaterThan,
-			Value:    categoryID,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.CategoryID, &repository.Filter{
+		Field:    string(ProductDBSchema.CategoryID),
+		Operator: repository.OperatorGreaterThan,
+		Value:    categoryID,
+	})
 }
 
 // CategoryIDLte filters by CategoryID lte
 func (p *ProductFilters) CategoryIDLte(categoryID int64) *ProductFilters {
-	p.filters[ProductDBSchema.CategoryID] = append(p.filters[ProductDBSchema.CategoryID],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.CategoryID),
-			Operator: repository.OperatorLessThanOrEqual,
-			Value:    categoryID,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.CategoryID, &repository.Filter{
+		Field:    string(ProductDBSchema.CategoryID),
+		Operator: repository.OperatorLessThanOrEqual,
+		Value:    categoryID,
+	})
 }
 
 // CategoryIDGte filters by CategoryID gte
 func (p *ProductFilters) CategoryIDGte(categoryID int64) *ProductFilters {
-	p.filters[ProductDBSchema.CategoryID] = append(p.filters[ProductDBSchema.CategoryID],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.CategoryID),
-			Operator: repository.OperatorGreaterThanOrEqual,
-			Value:    categoryID,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.CategoryID, &repository.Filter{
+		Field:    string(ProductDBSchema.CategoryID),
+		Operator: repository.OperatorGreaterThanOrEqual,
+		Value:    categoryID,
+	})
 }
 
 // CategoryIDIn filters by CategoryID in list
+// note: empty call matches nothing
 func (p *ProductFilters) CategoryIDIn(categoryIDs ...int64) *ProductFilters {
-	p.filters[ProductDBSchema.CategoryID] = append(p.filters[ProductDBSchema.CategoryID],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.CategoryID),
-			Operator: repository.OperatorIn,
-			Value:    categoryIDs,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.CategoryID, &repository.Filter{
+		Field:    string(ProductDBSchema.CategoryID),
+		Operator: repository.OperatorIn,
+		Value:    categoryIDs,
+	})
 }
 
-// CategoryIDNotIn filters by CategoryID in list
+// CategoryIDNotIn filters by CategoryID not in list
+// note: empty call matches everything
 func (p *ProductFilters) CategoryIDNotIn(categoryIDs ...int64) *ProductFilters {
-	p.filters[ProductDBSchema.CategoryID] = append(p.filters[ProductDBSchema.CategoryID],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.CategoryID),
-			Operator: repository.OperatorNotIn,
-			Value:    categoryIDs,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.CategoryID, &repository.Filter{
+		Field:    string(ProductDBSchema.CategoryID),
+		Operator: repository.OperatorNotIn,
+		Value:    categoryIDs,
+	})
+}
+
+// CategoryIDBetween filters by CategoryID between lower and upper (inclusive)
+func (p *ProductFilters) CategoryIDBetween(lower, upper int64) *ProductFilters {
+	return p.addFilter(ProductDBSchema.CategoryID, &repository.Filter{
+		Field:    string(ProductDBSchema.CategoryID),
+		Operator: repository.OperatorBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// CategoryIDNotBetween filters by CategoryID outside the inclusive range lower to upper
+func (p *ProductFilters) CategoryIDNotBetween(lower, upper int64) *ProductFilters {
+	return p.addFilter(ProductDBSchema.CategoryID, &repository.Filter{
+		Field:    string(ProductDBSchema.CategoryID),
+		Operator: repository.OperatorNotBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// ClearCategoryID removes the filters on CategoryID, keeping the others in order
+func (p *ProductFilters) ClearCategoryID() *ProductFilters {
+	return p.clearField(ProductDBSchema.CategoryID)
 }
 
 // IsActiveEq filters by IsActive eq
 func (p *ProductFilters) IsActiveEq(isActive bool) *ProductFilters {
-	p.filters[ProductDBSchema.IsActive] = append(p.filters[ProductDBSchema.IsActive],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.IsActive),
-			Operator: repository.OperatorEqual,
-			Value:    isActive,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.IsActive, &repository.Filter{
+		Field:    string(ProductDBSchema.IsActive),
+		Operator: repository.OperatorEqual,
+		Value:    isActive,
+	})
 }
 
 // IsActiveNe filters by IsActive ne
 func (p *ProductFilters) IsActiveNe(isActive bool) *ProductFilters {
-	p.filters[ProductDBSchema.IsActive] = append(p.filters[ProductDBSchema.IsActive],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.IsActive),
-			Operator: repository.OperatorNotEqual,
-			Value:    isActive,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.IsActive, &repository.Filter{
+		Field:    string(ProductDBSchema.IsActive),
+		Operator: repository.OperatorNotEqual,
+		Value:    isActive,
+	})
+}
+
+// ClearIsActive removes the filters on IsActive, keeping the others in order
+func (p *ProductFilters) ClearIsActive() *ProductFilters {
+	return p.clearField(ProductDBSchema.IsActive)
+}
+
+// TagsContains filters by Tags containing element. JSON array.
+func (p *ProductFilters) TagsContains(element string) *ProductFilters {
+	return p.addFilter(ProductDBSchema.Tags, &repository.Filter{
+		Field:    string(ProductDBSchema.Tags),
+		Operator: repository.OperatorJSONContains,
+		Value:    element,
+	})
+}
+
+// ClearTags removes the filters on Tags, keeping the others in order
+func (p *ProductFilters) ClearTags() *ProductFilters {
+	return p.clearField(ProductDBSchema.Tags)
+}
+
+// AttributesHasKey filters by Attributes containing key. JSON object.
+func (p *ProductFilters) AttributesHasKey(key string) *ProductFilters {
+	return p.addFilter(ProductDBSchema.Attributes, &repository.Filter{
+		Field:    string(ProductDBSchema.Attributes),
+		Operator: repository.OperatorJSONHasKey,
+		Value:    key,
+	})
+}
+
+// AttributesEquals filters by Attributes value under key equal to value, compared as a typed JSON value. JSON object.
+func (p *ProductFilters) AttributesEquals(key string, value interface{}) *ProductFilters {
+	return p.addFilter(ProductDBSchema.Attributes, &repository.Filter{
+		Field:    string(ProductDBSchema.Attributes),
+		Operator: repository.OperatorJSONEqual,
+		Value:    repository.KeyValue{Key: key, Value: value},
+	})
+}
+
+// ClearAttributes removes the filters on Attributes, keeping the others in order
+func (p *ProductFilters) ClearAttributes() *ProductFilters {
+	return p.clearField(ProductDBSchema.Attributes)
 }
 
 // CreatedAtEq filters by CreatedAt eq
 func (p *ProductFilters) CreatedAtEq(createdAt time.Time) *ProductFilters {
-	p.filters[ProductDBSchema.CreatedAt] = append(p.filters[ProductDBSchema.CreatedAt],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.CreatedAt),
-			Operator: repository.OperatorEqual,
-			Value:    createdAt,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.CreatedAt, &repository.Filter{
+		Field:    string(ProductDBSchema.CreatedAt),
+		Operator: repository.OperatorEqual,
+		Value:    createdAt,
+	})
 }
 
 // CreatedAtNe filters by CreatedAt ne
 func (p *ProductFilters) CreatedAtNe(createdAt time.Time) *ProductFilters {
-	p.filters[ProductDBSchema.CreatedAt] = append(p.filters[ProductDBSchema.CreatedAt],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.CreatedAt),
-			Operator: repository.OperatorNotEqual,
-			Value:    createdAt,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.CreatedAt, &repository.Filter{
+		Field:    string(ProductDBSchema.CreatedAt),
+		Operator: repository.OperatorNotEqual,
+		Value:    createdAt,
+	})
 }
 
 // CreatedAtLt filters by CreatedAt lt
 func (p *ProductFilters) CreatedAtLt(createdAt time.Time) *ProductFilters {
-	p.filters[ProductDBSchema.CreatedAt] = append(p.filters[ProductDBSchema.CreatedAt],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.CreatedAt),
-			Operator: repository.OperatorLessThan,
-			Value:    createdAt,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.CreatedAt, &repository.Filter{
+		Field:    string(ProductDBSchema.CreatedAt),
+		Operator: repository.OperatorLessThan,
+		Value:    createdAt,
+	})
 }
 
 // CreatedAtGt filters by CreatedAt gt
 func (p *ProductFilters) CreatedAtGt(createdAt time.Time) *ProductFilters {
-	p.filters[ProductDBSchema.CreatedAt] = append(p.filters[ProductDBSchema.CreatedAt],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.CreatedAt),
-			Operator: repository.OperatorGreaterThan,
-			Value:    createdAt,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.CreatedAt, &repository.Filter{
+		Field:    string(ProductDBSchema.CreatedAt),
+		Operator: repository.OperatorGreaterThan,
+		Value:    createdAt,
+	})
 }
 
 // CreatedAtLte filters by CreatedAt lte
 func (p *ProductFilters) CreatedAtLte(createdAt time.Time) *ProductFilters {
-	p.filters[ProductDBSchema.CreatedAt] = append(p.filters[ProductDBSchema.CreatedAt],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.CreatedAt),
-			Operator: repository.OperatorLessThanOrEqual,
-			Value:    createdAt,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.CreatedAt, &repository.Filter{
+		Field:    string(ProductDBSchema.CreatedAt),
+		Operator: repository.OperatorLessThanOrEqual,
+		Value:    createdAt,
+	})
 }
 
 // CreatedAtGte filters by CreatedAt gte
 func (p *ProductFilters) CreatedAtGte(createdAt time.Time) *ProductFilters {
-	p.filters[ProductDBSchema.CreatedAt] = append(p.filters[ProductDBSchema.CreatedAt],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.CreatedAt),
-			Operator: repository.OperatorGreaterThanOrEqual,
-			Value:    createdAt,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.CreatedAt, &repository.Filter{
+		Field:    string(ProductDBSchema.CreatedAt),
+		Operator: repository.OperatorGreaterThanOrEqual,
+		Value:    createdAt,
+	})
 }
 
 // CreatedAtIn filters by CreatedAt in list
+// note: empty call matches nothing
 func (p *ProductFilters) CreatedAtIn(createdAts ...time.Time) *ProductFilters {
-	p.filters[ProductDBSchema.CreatedAt] = append(p.filters[ProductDBSchema.CreatedAt],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.CreatedAt),
-			Operator: repository.OperatorIn,
-			Value:    createdAts,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.CreatedAt, &repository.Filter{
+		Field:    string(ProductDBSchema.CreatedAt),
+		Operator: repository.OperatorIn,
+		Value:    createdAts,
+	})
 }
 
-// CreatedAtNotIn filters by CreatedAt in list
+// CreatedAtNotIn filters by CreatedAt not in list
+// note: empty call matches everything
 func (p *ProductFilters) CreatedAtNotIn(createdAts ...time.Time) *ProductFilters {
-	p.filters[ProductDBSchema.CreatedAt] = append(p.filters[ProductDBSchema.CreatedAt],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.CreatedAt),
-			Operator: repository.OperatorNotIn,
-			Value:    createdAts,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.CreatedAt, &repository.Filter{
+		Field:    string(ProductDBSchema.CreatedAt),
+		Operator: repository.OperatorNotIn,
+		Value:    createdAts,
+	})
+}
+
+// CreatedAtBetween filters by CreatedAt between lower and upper (inclusive)
+func (p *ProductFilters) CreatedAtBetween(lower, upper time.Time) *ProductFilters {
+	return p.addFilter(ProductDBSchema.CreatedAt, &repository.Filter{
+		Field:    string(ProductDBSchema.CreatedAt),
+		Operator: repository.OperatorBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// CreatedAtNotBetween filters by CreatedAt outside the inclusive range lower to upper
+func (p *ProductFilters) CreatedAtNotBetween(lower, upper time.Time) *ProductFilters {
+	return p.addFilter(ProductDBSchema.CreatedAt, &repository.Filter{
+		Field:    string(ProductDBSchema.CreatedAt),
+		Operator: repository.OperatorNotBetween,
+		Value:    repository.Range{Lower: lower, Upper: upper},
+	})
+}
+
+// ClearCreatedAt removes the filters on CreatedAt, keeping the others in order
+func (p *ProductFilters) ClearCreatedAt() *ProductFilters {
+	return p.clearField(ProductDBSchema.CreatedAt)
 }
 
 // UpdatedAtEq filters by UpdatedAt eq
 func (p *ProductFilters) UpdatedAtEq(updatedAt *time.Time) *ProductFilters {
-	p.filters[ProductDBSchema.UpdatedAt] = append(p.filters[ProductDBSchema.UpdatedAt],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.UpdatedAt),
-			Operator: repository.OperatorEqual,
-			Value:    updatedAt,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.UpdatedAt, &repository.Filter{
+		Field:    string(ProductDBSchema.UpdatedAt),
+		Operator: repository.OperatorEqual,
+		Value:    updatedAt,
+	})
+}
+
+// UpdatedAtEqValue filters by UpdatedAt equal to updatedAt; rows where UpdatedAt is NULL never match
+func (p *ProductFilters) UpdatedAtEqValue(updatedAt time.Time) *ProductFilters {
+	return p.addFilter(ProductDBSchema.UpdatedAt, &repository.Filter{
+		Field:    string(ProductDBSchema.UpdatedAt),
+		Operator: repository.OperatorEqual,
+		Value:    updatedAt,
+	})
 }
 
 // UpdatedAtNe filters by UpdatedAt ne
 func (p *ProductFilters) UpdatedAtNe(updatedAt *time.Time) *ProductFilters {
-	p.filters[ProductDBSchema.UpdatedAt] = append(p.filters[ProductDBSchema.UpdatedAt],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.UpdatedAt),
-			Operator: repository.OperatorNotEqual,
-			Value:    updatedAt,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.UpdatedAt, &repository.Filter{
+		Field:    string(ProductDBSchema.UpdatedAt),
+		Operator: repository.OperatorNotEqual,
+		Value:    updatedAt,
+	})
 }
 
 // UpdatedAtIsNull filters by UpdatedAt is null check
 func (p *ProductFilters) UpdatedAtIsNull() *ProductFilters {
-	p.filters[ProductDBSchema.UpdatedAt] = append(p.filters[ProductDBSchema.UpdatedAt],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.UpdatedAt),
-			Operator: repository.OperatorIsNull,
-			Value:    nil,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.UpdatedAt, &repository.Filter{
+		Field:    string(ProductDBSchema.UpdatedAt),
+		Operator: repository.OperatorIsNull,
+		Value:    nil,
+	})
 }
 
 // UpdatedAtIsNotNull filters by UpdatedAt is null check
 func (p *ProductFilters) UpdatedAtIsNotNull() *ProductFilters {
-	p.filters[ProductDBSchema.UpdatedAt] = append(p.filters[ProductDBSchema.UpdatedAt],
-		&repository.Filter{
-			Field:    string(ProductDBSchema.UpdatedAt),
-			Operator: repository.OperatorIsNotNull,
-			Value:    nil,
-		})
-	return p
+	return p.addFilter(ProductDBSchema.UpdatedAt, &repository.Filter{
+		Field:    string(ProductDBSchema.UpdatedAt),
+		Operator: repository.OperatorIsNotNull,
+		Value:    nil,
+	})
+}
+
+// ClearUpdatedAt removes the filters on UpdatedAt, keeping the others in order
+func (p *ProductFilters) ClearUpdatedAt() *ProductFilters {
+	return p.clearField(ProductDBSchema.UpdatedAt)
+}
+
+// WhereRaw adds a raw SQL condition for cases the typed methods don't cover, such as subqueries.
+// Pass values as args bound to ? placeholders; never format them into condition.
+func (f *ProductFilters) WhereRaw(condition string, args ...interface{}) *ProductFilters {
+	return f.addFilter("", &repository.Filter{
+		Operator: repository.OperatorRaw,
+		Value:    repository.RawSQL{SQL: condition, Args: args},
+	})
+}
+
+// WhereColumn filters on a column outside the generated fields, such as "categories.name" of a
+// table joined with repository.WithJoin. The column is quoted, not passed through as SQL.
+func (f *ProductFilters) WhereColumn(column string, operator repository.Operator, value interface{}) *ProductFilters {
+	return f.addFilter(ProductDBSchemaField(column), &repository.Filter{
+		Field:    column,
+		Operator: operator,
+		Value:    value,
+	})
+}
+
+// DebugSQL returns the WHERE condition of the filters, without the keyword, and its arguments.
+// Identifiers are double-quoted; GormRepository.ExplainFilter renders the full query of a database.
+func (f *ProductFilters) DebugSQL() (string, []interface{}, error) {
+	return repository.BuildWhere(f.ListFilters())
 }
 
 // ProductUpdater provides update capabilities for Product
+// generated from examples/product.go:12
 type ProductUpdater struct {
 	fields map[string]interface{}
 }
@@ -817,6 +1056,15 @@ func (u *ProductUpdater) GetChangeSet() map[string]interface{} {
 	return u.fields
 }
 
+// Clone returns a copy of the updater that can be changed without changing u
+func (u *ProductUpdater) Clone() *ProductUpdater {
+	clone := NewProductUpdater()
+	for field, value := range u.fields {
+		clone.fields[field] = value
+	}
+	return clone
+}
+
 // SetID sets the ID field for update
 func (p *ProductUpdater) SetID(iD int64) *ProductUpdater {
 	p.fields[string(ProductDBSchema.ID)] = iD
@@ -841,6 +1089,12 @@ func (p *ProductUpdater) SetDescription(description *string) *ProductUpdater {
 	return p
 }
 
+// SetDescriptionValue sets the Description field to description for update
+func (p *ProductUpdater) SetDescriptionValue(description string) *ProductUpdater {
+	p.fields[string(ProductDBSchema.Description)] = &description
+	return p
+}
+
 // SetPrice sets the Price field for update
 func (p *ProductUpdater) SetPrice(price float64) *ProductUpdater {
 	p.fields[string(ProductDBSchema.Price)] = price
@@ -865,13 +1119,13 @@ func (p *ProductUpdater) SetIsActive(isActive bool) *ProductUpdater {
 	return p
 }
 
-// SetTags sets the Tags field for update
+// SetTags sets the Tags field for update. JSON array.
 func (p *ProductUpdater) SetTags(tags datatypes.JSONSlice[string]) *ProductUpdater {
 	p.fields[string(ProductDBSchema.Tags)] = tags
 	return p
 }
 
-// SetAttributes sets the Attributes field for update
+// SetAttributes sets the Attributes field for update. JSON object.
 func (p *ProductUpdater) SetAttributes(attributes datatypes.JSONType[*Attributes]) *ProductUpdater {
 	p.fields[string(ProductDBSchema.Attributes)] = attributes
 	return p
@@ -889,7 +1143,74 @@ func (p *ProductUpdater) SetUpdatedAt(updatedAt *time.Time) *ProductUpdater {
 	return p
 }
 
+// SetUpdatedAtValue sets the UpdatedAt field to updatedAt for update
+func (p *ProductUpdater) SetUpdatedAtValue(updatedAt time.Time) *ProductUpdater {
+	p.fields[string(ProductDBSchema.UpdatedAt)] = &updatedAt
+	return p
+}
+
+// FromStruct stages the given fields of record, zero values included, or every field with a setter but the primary key when none are given; other fields are ignored
+func (p *ProductUpdater) FromStruct(record *Product, fields ...ProductDBSchemaField) *ProductUpdater {
+	if len(fields) == 0 {
+		fields = []ProductDBSchemaField{ProductDBSchema.Name, ProductDBSchema.SKU, ProductDBSchema.Description, ProductDBSchema.Price, ProductDBSchema.Stock, ProductDBSchema.CategoryID, ProductDBSchema.IsActive, ProductDBSchema.Tags, ProductDBSchema.Attributes, ProductDBSchema.CreatedAt, ProductDBSchema.UpdatedAt}
+	}
+	for _, field := range fields {
+		switch field {
+		case ProductDBSchema.ID:
+			p.SetID(record.ID)
+		case ProductDBSchema.Name:
+			p.SetName(record.Name)
+		case ProductDBSchema.SKU:
+			p.SetSKU(record.SKU)
+		case ProductDBSchema.Description:
+			p.SetDescription(record.Description)
+		case ProductDBSchema.Price:
+			p.SetPrice(record.Price)
+		case ProductDBSchema.Stock:
+			p.SetStock(record.Stock)
+		case ProductDBSchema.CategoryID:
+			p.SetCategoryID(record.CategoryID)
+		case ProductDBSchema.IsActive:
+			p.SetIsActive(record.IsActive)
+		case ProductDBSchema.Tags:
+			p.SetTags(record.Tags)
+		case ProductDBSchema.Attributes:
+			p.SetAttributes(record.Attributes)
+		case ProductDBSchema.CreatedAt:
+			p.SetCreatedAt(record.CreatedAt)
+		case ProductDBSchema.UpdatedAt:
+			p.SetUpdatedAt(record.UpdatedAt)
+		}
+	}
+	return p
+}
+
+// Validate checks the staged values against the field constraints, returning every violation joined
+func (p *ProductUpdater) Validate() error {
+	var errs []error
+	if value, ok := p.fields[string(ProductDBSchema.Name)].(string); ok {
+		if value == "" {
+			errs = append(errs, fmt.Errorf("%w: Name must not be empty", repository.ErrConstraintViolation))
+		}
+		if n := utf8.RuneCountInString(value); n > 255 {
+			errs = append(errs, fmt.Errorf("%w: Name must be at most 255 characters long, got %d", repository.ErrConstraintViolation, n))
+		}
+	}
+	if value, ok := p.fields[string(ProductDBSchema.Price)].(float64); ok {
+		if value < 0 {
+			errs = append(errs, fmt.Errorf("%w: Price must be at least 0, got %v", repository.ErrConstraintViolation, value))
+		}
+	}
+	if value, ok := p.fields[string(ProductDBSchema.Stock)].(int); ok {
+		if value < 0 {
+			errs = append(errs, fmt.Errorf("%w: Stock must be at least 0, got %v", repository.ErrConstraintViolation, value))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // ProductOptions provides query options for Product
+// generated from examples/product.go:12
 type ProductOptions struct {
 	options []func(*repository.Options)
 }
@@ -906,6 +1227,72 @@ func (o *ProductOptions) Apply(repoOpts *repository.Options) {
 	}
 }
 
+// Select only queries the given fields; the primary key is always selected
+func (o *ProductOptions) Select(fields ...ProductDBSchemaField) *ProductOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		for _, field := range fields {
+			options.SelectFields = append(options.SelectFields, string(field))
+		}
+	})
+	return o
+}
+
+// Distinct removes duplicate rows; with fields only those are selected and compared
+func (o *ProductOptions) Distinct(fields ...ProductDBSchemaField) *ProductOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.Distinct = true
+		for _, field := range fields {
+			options.DistinctFields = append(options.DistinctFields, string(field))
+		}
+	})
+	return o
+}
+
+// DistinctOn keeps the first row of each group of equal fields (Postgres); order by them first
+func (o *ProductOptions) DistinctOn(fields ...ProductDBSchemaField) *ProductOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		for _, field := range fields {
+			options.DistinctOn = append(options.DistinctOn, string(field))
+		}
+	})
+	return o
+}
+
+// OrderBy orders results by field in direction dir, e.g. when both come from API parameters
+func (o *ProductOptions) OrderBy(field ProductDBSchemaField, dir repository.SortDirection) *ProductOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(field),
+			Direction: dir,
+		})
+	})
+	return o
+}
+
+// Paginate limits results to the 1-based page of pageSize rows; ProductFindPage reports the page metadata
+func (o *ProductOptions) Paginate(page, pageSize int) *ProductOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		repository.WithPage(page, pageSize).Apply(options)
+	})
+	return o
+}
+
+// ForUpdate locks the selected rows until the transaction ends (SELECT ... FOR UPDATE); use it inside WithTransaction
+func (o *ProductOptions) ForUpdate() *ProductOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.Lock = &repository.Lock{Strength: repository.LockUpdate}
+	})
+	return o
+}
+
+// ForUpdateSkipLocked locks the selected rows like ForUpdate, skipping rows other transactions have locked
+func (o *ProductOptions) ForUpdateSkipLocked() *ProductOptions {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.Lock = &repository.Lock{Strength: repository.LockUpdate, SkipLocked: true}
+	})
+	return o
+}
+
 // OrderByIDAsc orders results by ID asc
 func (p *ProductOptions) OrderByIDAsc() *ProductOptions {
 	p.options = append(p.options, func(options *repository.Options) {
@@ -1126,7 +1513,159 @@ func (p *ProductOptions) OrderByUpdatedAtDesc() *ProductOptions {
 	return p
 }
 
+// GroupByID groups results by ID
+func (p *ProductOptions) GroupByID() *ProductOptions {
+	p.options = append(p.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(ProductDBSchema.ID))
+	})
+	return p
+}
+
+// GroupByName groups results by Name
+func (p *ProductOptions) GroupByName() *ProductOptions {
+	p.options = append(p.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(ProductDBSchema.Name))
+	})
+	return p
+}
+
+// GroupBySKU groups results by SKU
+func (p *ProductOptions) GroupBySKU() *ProductOptions {
+	p.options = append(p.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(ProductDBSchema.SKU))
+	})
+	return p
+}
+
+// GroupByDescription groups results by Description
+func (p *ProductOptions) GroupByDescription() *ProductOptions {
+	p.options = append(p.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(ProductDBSchema.Description))
+	})
+	return p
+}
+
+// GroupByPrice groups results by Price
+func (p *ProductOptions) GroupByPrice() *ProductOptions {
+	p.options = append(p.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(ProductDBSchema.Price))
+	})
+	return p
+}
+
+// GroupByStock groups results by Stock
+func (p *ProductOptions) GroupByStock() *ProductOptions {
+	p.options = append(p.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(ProductDBSchema.Stock))
+	})
+	return p
+}
+
+// GroupByCategoryID groups results by CategoryID
+func (p *ProductOptions) GroupByCategoryID() *ProductOptions {
+	p.options = append(p.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(ProductDBSchema.CategoryID))
+	})
+	return p
+}
+
+// GroupByIsActive groups results by IsActive
+func (p *ProductOptions) GroupByIsActive() *ProductOptions {
+	p.options = append(p.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(ProductDBSchema.IsActive))
+	})
+	return p
+}
+
+// GroupByCreatedAt groups results by CreatedAt
+func (p *ProductOptions) GroupByCreatedAt() *ProductOptions {
+	p.options = append(p.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(ProductDBSchema.CreatedAt))
+	})
+	return p
+}
+
+// GroupByUpdatedAt groups results by UpdatedAt
+func (p *ProductOptions) GroupByUpdatedAt() *ProductOptions {
+	p.options = append(p.options, func(options *repository.Options) {
+		options.GroupBy = append(options.GroupBy, string(ProductDBSchema.UpdatedAt))
+	})
+	return p
+}
+
+// ProductUpdateWhere applies the changes configured by set to every Product row matching the filters configured by where
+func ProductUpdateWhere(ctx context.Context, repo repository.BatchUpdater[*ProductFilters, *ProductUpdater], where func(*ProductFilters), set func(*ProductUpdater)) (int64, error) {
+	filters := NewProductFilters()
+	if where != nil {
+		where(filters)
+	}
+	updater := NewProductUpdater()
+	if set != nil {
+		set(updater)
+	}
+	return repo.UpdateWithFilter(ctx, filters, updater)
+}
+
+// ProductCountDistinct counts the distinct non-NULL values of field among the Product rows matching filter
+func ProductCountDistinct(ctx context.Context, repo repository.DistinctCounter[*ProductFilters], field ProductDBSchemaField, filter *ProductFilters) (int64, error) {
+	return repo.CountDistinct(ctx, filter, string(field))
+}
+
+// ProductPage is one page of Product rows with the total count and page metadata
+type ProductPage = repository.Page[Product]
+
+// ProductFindPage returns the page of Product rows matching filter selected by Paginate, with the total count
+func ProductFindPage(ctx context.Context, repo repository.PageCounter[Product, *ProductFilters], filter *ProductFilters, options ...repository.OptionFunc) (*ProductPage, error) {
+	return repository.FindNumberedPage(ctx, repo, filter, options...)
+}
+
+// ProductFindByPrimaryKey returns the Product with the given primary key, reporting false when there is none
+func ProductFindByPrimaryKey(ctx context.Context, repo repository.PrimaryKeyFinder[Product], iD int64) (*Product, bool, error) {
+	return repo.FindByPrimaryKey(ctx, iD)
+}
+
+// ProductFindBySKU returns the Product with the given SKU, reporting false when there is none
+func ProductFindBySKU(ctx context.Context, repo repository.OneFinder[Product, *ProductFilters], sKU string) (*Product, bool, error) {
+	return repo.FindOne(ctx, NewProductFilters().SKUEq(sKU))
+}
+
+// ProductFindByCategoryIDAndSKU returns the Product with the given CategoryID and SKU, reporting false when there is none
+func ProductFindByCategoryIDAndSKU(ctx context.Context, repo repository.OneFinder[Product, *ProductFilters], categoryID int64, sKU string) (*Product, bool, error) {
+	return repo.FindOne(ctx, NewProductFilters().CategoryIDEq(categoryID).SKUEq(sKU))
+}
+
+// ProductCountByMonth counts Product rows matching filter grouped by the "YYYY-MM" month of field.
+// field should be a time column: ProductDBSchema.CreatedAt
+func ProductCountByMonth(ctx context.Context, repo repository.MonthlyCounter[*ProductFilters], field ProductDBSchemaField, filter *ProductFilters) (map[string]int64, error) {
+	return repo.CountByMonth(ctx, filter, string(field))
+}
+
+// ProductSum returns the sum of field over the Product rows matching filter; hasRows is false when no non-NULL values match.
+// field should be a numeric column: ProductDBSchema.ID, ProductDBSchema.Price, ProductDBSchema.Stock, ProductDBSchema.CategoryID
+func ProductSum(ctx context.Context, repo repository.Aggregator[*ProductFilters], field ProductDBSchemaField, filter *ProductFilters) (float64, bool, error) {
+	return repo.Sum(ctx, filter, string(field))
+}
+
+// ProductAvg returns the average of field over the Product rows matching filter; hasRows is false when no non-NULL values match.
+// field should be a numeric column: ProductDBSchema.ID, ProductDBSchema.Price, ProductDBSchema.Stock, ProductDBSchema.CategoryID
+func ProductAvg(ctx context.Context, repo repository.Aggregator[*ProductFilters], field ProductDBSchemaField, filter *ProductFilters) (float64, bool, error) {
+	return repo.Avg(ctx, filter, string(field))
+}
+
+// ProductMin returns the smallest value of field over the Product rows matching filter; hasRows is false when no non-NULL values match.
+// field should be a numeric column: ProductDBSchema.ID, ProductDBSchema.Price, ProductDBSchema.Stock, ProductDBSchema.CategoryID
+func ProductMin(ctx context.Context, repo repository.Aggregator[*ProductFilters], field ProductDBSchemaField, filter *ProductFilters) (float64, bool, error) {
+	return repo.Min(ctx, filter, string(field))
+}
+
+// ProductMax returns the largest value of field over the Product rows matching filter; hasRows is false when no non-NULL values match.
+// field should be a numeric column: ProductDBSchema.ID, ProductDBSchema.Price, ProductDBSchema.Stock, ProductDBSchema.CategoryID
+func ProductMax(ctx context.Context, repo repository.Aggregator[*ProductFilters], field ProductDBSchemaField, filter *ProductFilters) (float64, bool, error) {
+	return repo.Max(ctx, filter, string(field))
+}
+
 // ProductDBSchemaField represents database field names
+// generated from examples/product.go:12
 type ProductDBSchemaField string
 
 // String returns the string representation of the field
@@ -1162,3 +1701,36 @@ var ProductDBSchema = struct {
 	CreatedAt:   ProductDBSchemaField("created_at"),
 	UpdatedAt:   ProductDBSchemaField("updated_at"),
 }
+
+// ProductColumnToField maps the database columns of Product to their struct fields, for
+// repositories that read and write entities through reflection
+var ProductColumnToField = map[string]string{
+	"id":          "ID",
+	"name":        "Name",
+	"sku":         "SKU",
+	"description": "Description",
+	"price":       "Price",
+	"stock":       "Stock",
+	"category_id": "CategoryID",
+	"is_active":   "IsActive",
+	"tags":        "Tags",
+	"attributes":  "Attributes",
+	"created_at":  "CreatedAt",
+	"updated_at":  "UpdatedAt",
+}
+
+// ProductColumnTypes maps the database columns of Product to the Go types of their struct fields
+var ProductColumnTypes = map[string]string{
+	"id":          "int64",
+	"name":        "string",
+	"sku":         "string",
+	"description": "*string",
+	"price":       "float64",
+	"stock":       "int",
+	"category_id": "int64",
+	"is_active":   "bool",
+	"tags":        "datatypes.JSONSlice[string]",
+	"attributes":  "datatypes.JSONType[*Attributes]",
+	"created_at":  "time.Time",
+	"updated_at":  "*time.Time",
+}
@@ -2,10 +2,16 @@ package examples
 
 import (
 	"context"
+	"encoding/json"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/dchlong/querybuilder"
 	"github.com/dchlong/querybuilder/parser"
+	"github.com/dchlong/querybuilder/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // ExampleUsage demonstrates how to use the clean querybuilder
@@ -14,7 +20,9 @@ func TestExampleProduct(t *testing.T) {
 
 	// Initialize the generator with a cleaner API
 	structsParser := &parser.Structs{} // Initialize with your parser
-	generator := querybuilder.NewQueryBuilderGenerator(structsParser)
+	generator := querybuilder.NewQueryBuilderGeneratorWithOptions(structsParser, querybuilder.Options{
+		EmitJSONSchema: true,
+	})
 
 	// Generate code with simple, clear method call
 	err := generator.Generate(ctx, "./product.go", "product_querybuilder.go", "")
@@ -22,3 +30,103 @@ func TestExampleProduct(t *testing.T) {
 		panic(err)
 	}
 }
+
+// TestProductJSONSchema checks the filter payload schema generated next to product_querybuilder.go
+func TestProductJSONSchema(t *testing.T) {
+	data, err := os.ReadFile(querybuilder.JSONSchemaPath("product_querybuilder.go"))
+	require.NoError(t, err)
+
+	var schema struct {
+		Schema      string `json:"$schema"`
+		Definitions map[string]struct {
+			Type  string `json:"type"`
+			Items struct {
+				OneOf []struct {
+					Properties struct {
+						Field struct {
+							Const string `json:"const"`
+						} `json:"field"`
+						Operator struct {
+							Enum []string `json:"enum"`
+						} `json:"operator"`
+						Value map[string]interface{} `json:"value"`
+					} `json:"properties"`
+					Required []string `json:"required"`
+				} `json:"oneOf"`
+			} `json:"items"`
+		} `json:"definitions"`
+	}
+	require.NoError(t, json.Unmarshal(data, &schema))
+	assert.Equal(t, "http://json-schema.org/draft-07/schema#", schema.Schema)
+
+	filters, ok := schema.Definitions["ProductFilters"]
+	require.True(t, ok, "missing ProductFilters definition")
+	assert.Equal(t, "array", filters.Type)
+
+	// Collect the permitted operators and value schemas per column
+	operators := map[string][]string{}
+	values := map[string]map[string]interface{}{}
+	for _, condition := range filters.Items.OneOf {
+		column := condition.Properties.Field.Const
+		require.NotEmpty(t, column)
+		for _, op := range condition.Properties.Operator.Enum {
+			operators[column] = append(operators[column], op)
+			values[column+" "+op] = condition.Properties.Value
+		}
+		assert.Equal(t, condition.Properties.Value != nil, len(condition.Required) == 3,
+			"value must be required exactly when the operator takes one")
+	}
+
+	assert.ElementsMatch(t, []string{"id", "name", "sku", "description", "price", "stock", "category_id", "is_active", "tags", "attributes", "created_at", "updated_at"},
+		keys(operators))
+	assert.ElementsMatch(t, []string{"JSON_CONTAINS"}, operators["tags"])
+	assert.Equal(t, "string", values["tags JSON_CONTAINS"]["type"])
+	assert.ElementsMatch(t, []string{"JSON_HAS_KEY", "JSON_EQ"}, operators["attributes"])
+	assert.ElementsMatch(t, []string{"=", "!="}, operators["is_active"])
+	assert.Contains(t, operators["name"], "LIKE")
+	assert.NotContains(t, operators["price"], "LIKE")
+	assert.Contains(t, operators["description"], "IS_NULL")
+
+	assert.Equal(t, "integer", values["stock ="]["type"])
+	assert.Equal(t, "number", values["price <"]["type"])
+	assert.Equal(t, "boolean", values["is_active ="]["type"])
+	assert.Equal(t, "date-time", values["created_at >="]["format"])
+	assert.Equal(t, "array", values["id IN"]["type"])
+	assert.Equal(t, "object", values["price BETWEEN"]["type"])
+	assert.Nil(t, values["description IS_NULL"])
+}
+
+func keys[V any](m map[string]V) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
+
+func TestGeneratedUpdaterValidate(t *testing.T) {
+	assert.NoError(t, NewProductUpdater().SetName("Laptop").SetPrice(999).Validate())
+	assert.NoError(t, NewProductUpdater().SetIsActive(false).Validate(), "unset fields aren't checked")
+
+	err := NewProductUpdater().SetName("").SetPrice(-1).SetStock(-5).Validate()
+	require.ErrorIs(t, err, repository.ErrConstraintViolation)
+	assert.Equal(t, "value violates field constraint: Name must not be empty\n"+
+		"value violates field constraint: Price must be at least 0, got -1\n"+
+		"value violates field constraint: Stock must be at least 0, got -5", err.Error())
+
+	err = NewProductUpdater().SetName(strings.Repeat("é", 256)).Validate()
+	assert.ErrorContains(t, err, "Name must be at most 255 characters long, got 256")
+	assert.NoError(t, NewProductUpdater().SetName(strings.Repeat("é", 255)).Validate(), "length counts characters, not bytes")
+}
+
+func TestGeneratedUpdaterFromStruct(t *testing.T) {
+	product := &Product{ID: 7, Name: "Laptop", Price: 999, Stock: 0}
+
+	changes := NewProductUpdater().FromStruct(product, ProductDBSchema.Name, ProductDBSchema.Stock).GetChangeSet()
+	assert.Equal(t, map[string]interface{}{"name": "Laptop", "stock": 0}, changes, "zero values are staged")
+
+	changes = NewProductUpdater().FromStruct(product).GetChangeSet()
+	assert.NotContains(t, changes, "id", "the primary key needs to be listed")
+	assert.Equal(t, 999.0, changes["price"])
+	assert.Len(t, changes, 11)
+}
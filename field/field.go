@@ -3,7 +3,10 @@ package field
 import (
 	"fmt"
 	"go/types"
+	"maps"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 
 	"gorm.io/gorm/schema"
@@ -11,8 +14,9 @@ import (
 
 // TimeTypePattern represents a pattern for detecting time-related types.
 type TimeTypePattern struct {
-	Pattern   string // Type name pattern (exact match)
-	IsNumeric bool   // Whether this time type behaves like numeric for filtering
+	Pattern    string // Type name pattern (exact match)
+	IsNumeric  bool   // Whether this time type behaves like numeric for filtering
+	IsNullable bool   // Whether this time type can hold NULL, like sql.NullTime
 }
 
 // DefaultTimeTypes contains the built-in time type patterns.
@@ -21,8 +25,29 @@ var DefaultTimeTypes = []TimeTypePattern{
 	{Pattern: "datatypes.Date", IsNumeric: true},
 	{Pattern: "datatypes.Time", IsNumeric: true},
 	{Pattern: "datatypes.DateTime", IsNumeric: true},
-	{Pattern: "sql.NullTime", IsNumeric: true},
-	{Pattern: "pq.NullTime", IsNumeric: true},
+	{Pattern: "sql.NullTime", IsNumeric: true, IsNullable: true},
+	{Pattern: "pq.NullTime", IsNumeric: true, IsNullable: true},
+	{Pattern: "gorm.DeletedAt", IsNumeric: true, IsNullable: true},
+}
+
+// DefaultArrayTypes are the named slice types stored as native Postgres arrays
+var DefaultArrayTypes = []string{
+	"pq.StringArray",
+	"pq.Int64Array",
+	"pq.Int32Array",
+	"pq.Float64Array",
+	"pq.Float32Array",
+	"pq.BoolArray",
+}
+
+// DefaultNumericTypes are the named types outside the Go numeric kinds filtered as numbers
+var DefaultNumericTypes = []string{
+	"decimal.Decimal",
+}
+
+// DefaultScalarTypes are the named types filtered as opaque values, by equality and membership
+var DefaultScalarTypes = []string{
+	"uuid.UUID",
 }
 
 // BaseInfo contains basic information about a struct field.
@@ -30,16 +55,65 @@ type BaseInfo struct {
 	Name     string // Go field name
 	DBName   string // Database column name
 	TypeName string // Go type name
+	Doc      string // Doc comment of the field, copied into the generated method docs
 
 	// Type classification flags
 	IsStruct  bool // Is a struct type
 	IsNumeric bool // Is a numeric type (int, float, etc.)
 	IsTime    bool // Is a time-related type
 	IsString  bool // Is a string type
+	IsBool    bool // Is a boolean type
 	IsSlice   bool // Is a slice type
 	IsMap     bool // Is a map type
+	IsHStore  bool // Is a map type stored as a Postgres hstore column
+	IsJSON    bool // Is a JSON object column (datatypes.JSON, datatypes.JSONMap, datatypes.JSONType[T])
+	IsScalar  bool // Is an opaque value such as uuid.UUID, compared only for equality
+
+	IsJSONArray  bool   // Is a JSON array column (datatypes.JSONSlice[T])
+	IsArray      bool   // Is a native Postgres array column (e.g. pq.StringArray)
+	ElemTypeName string // Element type of a JSON or native array column
+
+	IsNullable    bool   // Can hold NULL without being a pointer (e.g. sql.NullTime, gorm.DeletedAt)
+	ValueTypeName string // Go type of the value a database/sql wrapper such as sql.NullString holds
+
+	IsPrimaryKey bool // Tagged gorm:"primaryKey"
+	IsReadOnly   bool // Tagged querybuilder:"readonly": filtered and sorted but never set by updaters
+	IsVersion    bool // Tagged gorm:"version" or querybuilder:"version": the optimistic locking version GormRepository.Update manages
+
+	Operators         []string // Method suffixes of registered operators from querybuilder:"ops=regex|search"
+	ExcludedOperators []string // Method suffixes of built-in operators removed with querybuilder:"ops=-lt|-gt"
+
+	IsFullText     bool   // Tagged querybuilder:"fulltext": searchable with a full-text index
+	FullTextConfig string // Postgres text search configuration of querybuilder:"fulltext=english"
+
+	UniqueIndexes []IndexTag // Unique indexes the column belongs to
+
+	Constraints []ConstraintTag // Validation constraints from querybuilder:"min=0,maxlen=255"
+	BasicInfo   types.BasicInfo // Properties of the basic type underlying the field or its pointed-to type, 0 for other types
+
+	IsAssociation bool // Is a related model (struct, pointer or slice of structs) rather than a column
+}
+
+// ConstraintTag is a validation constraint of a querybuilder tag, such as min=0 or notempty.
+// Value is empty for notempty.
+type ConstraintTag struct {
+	Name  string
+	Value string
 }
 
+// constraintNames are the querybuilder tag options that declare validation constraints
+var constraintNames = []string{"notempty", "minlen", "maxlen", "min", "max"}
+
+// IndexTag places a column in an index declared by a gorm tag. Name is empty for an unnamed
+// single-column index; columns of a composite index are ordered by Priority, then declaration.
+type IndexTag struct {
+	Name     string
+	Priority int
+}
+
+// defaultIndexPriority is GORM's priority for index columns without one
+const defaultIndexPriority = 10
+
 // Info contains comprehensive field information including type metadata.
 type Info struct {
 	BaseInfo           // Embedded base information
@@ -49,6 +123,8 @@ type Info struct {
 	// Enhanced type flags
 	IsPointer bool // Is a pointer type
 	IsGeneric bool // Has generic type parameters
+
+	Imports []string // Import paths of the packages the type refers to, sorted
 }
 
 func (fi Info) TypeArgs() []*Info {
@@ -90,6 +166,13 @@ func (fi Info) GetPointed() Info {
 type InfoGenerator struct {
 	pkg       *types.Package    // Package context for type resolution
 	timeTypes []TimeTypePattern // Configurable time type patterns
+	namer     schema.Namer      // Derives column names; nil uses GORM's snake_case
+	jsonTags  bool              // Use json tag names as column names before the namer
+	acronyms  []string          // Kept as one word by the namer, see NewAcronymNamer
+	arrays    []string          // Named slice types stored as native arrays, see DefaultArrayTypes
+	numerics  []string          // Named types filtered as numbers, see DefaultNumericTypes
+	scalars   []string          // Named types filtered as opaque values, see DefaultScalarTypes
+	external  bool              // Code is generated into another package, so pkg's types are qualified
 }
 
 // Field interface defines the contract for struct field information.
@@ -100,6 +183,18 @@ type Field interface {
 	Tag() reflect.StructTag // Struct tags (db:"", json:"", etc.)
 }
 
+// PrefixedField is a Field promoted from an embedded struct whose column names GORM prefixes
+type PrefixedField interface {
+	Field
+	ColumnPrefix() string // prepended to the column name, see EmbeddedPrefix
+}
+
+// DocumentedField is a Field declared with a doc comment
+type DocumentedField interface {
+	Field
+	Doc() string // comment text without the comment markers
+}
+
 type field struct {
 	name string
 	typ  types.Type
@@ -123,6 +218,10 @@ func NewInfoGenerator(pkg *types.Package) *InfoGenerator {
 	return &InfoGenerator{
 		pkg:       pkg,
 		timeTypes: DefaultTimeTypes,
+		acronyms:  DefaultAcronyms,
+		arrays:    DefaultArrayTypes,
+		numerics:  DefaultNumericTypes,
+		scalars:   DefaultScalarTypes,
 	}
 }
 
@@ -131,6 +230,10 @@ func NewInfoGeneratorWithTimeTypes(pkg *types.Package, timeTypes []TimeTypePatte
 	return &InfoGenerator{
 		pkg:       pkg,
 		timeTypes: timeTypes,
+		acronyms:  DefaultAcronyms,
+		arrays:    DefaultArrayTypes,
+		numerics:  DefaultNumericTypes,
+		scalars:   DefaultScalarTypes,
 	}
 }
 
@@ -142,6 +245,44 @@ func (g *InfoGenerator) AddTimeType(pattern string, isNumeric bool) {
 	})
 }
 
+// SetNamer sets how column names are derived from field names without a column tag.
+// nil restores GORM's snake_case naming.
+func (g *InfoGenerator) SetNamer(namer schema.Namer) {
+	g.namer = namer
+}
+
+// SetAcronyms replaces the acronyms kept as one word in derived column names
+func (g *InfoGenerator) SetAcronyms(acronyms []string) {
+	g.acronyms = acronyms
+}
+
+// SetArrayTypes replaces the named slice types (e.g. "pq.StringArray") stored as native arrays
+func (g *InfoGenerator) SetArrayTypes(arrayTypes []string) {
+	g.arrays = arrayTypes
+}
+
+// SetNumericTypes replaces the named types (e.g. "decimal.Decimal") filtered as numbers
+func (g *InfoGenerator) SetNumericTypes(numericTypes []string) {
+	g.numerics = numericTypes
+}
+
+// SetScalarTypes replaces the named types (e.g. "uuid.UUID") filtered as opaque values
+func (g *InfoGenerator) SetScalarTypes(scalarTypes []string) {
+	g.scalars = scalarTypes
+}
+
+// SetJSONTagColumns makes fields without a column tag use their json tag name as column name,
+// falling back to the namer when there is no json name
+func (g *InfoGenerator) SetJSONTagColumns(enabled bool) {
+	g.jsonTags = enabled
+}
+
+// SetExternalPackage makes the types declared in the generator's package qualified and imported
+// like any other package's, for code generated into a different package
+func (g *InfoGenerator) SetExternalPackage(external bool) {
+	g.external = external
+}
+
 // matchTimeType checks if a type name matches any configured time type patterns.
 // Returns the matching pattern or nil if no match is found.
 func (g *InfoGenerator) matchTimeType(typeName string) *TimeTypePattern {
@@ -153,11 +294,23 @@ func (g *InfoGenerator) matchTimeType(typeName string) *TimeTypePattern {
 	return nil
 }
 
+// matchNamedTimeType matches a named type against the time type patterns. Types of the
+// generator's package also match by their unqualified name when they are qualified.
+func (g InfoGenerator) matchNamedTimeType(t *types.Named) *TimeTypePattern {
+	if pattern := g.matchTimeType(g.getOriginalTypeName(t)); pattern != nil {
+		return pattern
+	}
+	if g.external && t.Obj().Pkg() == g.pkg {
+		return g.matchTimeType(t.Obj().Name())
+	}
+	return nil
+}
+
 // getOriginalTypeName returns the properly qualified type name.
 // Returns unqualified name for types in the same package, qualified name for imports.
 func (g InfoGenerator) getOriginalTypeName(t *types.Named) string {
 	obj := t.Obj()
-	if obj.Pkg() == g.pkg {
+	if obj.Pkg() == g.pkg && !g.external {
 		// Same package - use unqualified name
 		return obj.Name()
 	}
@@ -201,6 +354,85 @@ func parseTagSetting(tags reflect.StructTag) map[string]string {
 	return setting
 }
 
+// parseUniqueIndexes returns the unique indexes declared by the gorm tag: unique,
+// uniqueIndex[:name[,priority:n]] and index:name,unique or class:UNIQUE. Partial and expression
+// indexes are left out, as they don't make the column values themselves unique.
+func parseUniqueIndexes(tags reflect.StructTag) []IndexTag {
+	var indexes []IndexTag
+	for _, tagPart := range strings.Split(tags.Get("gorm"), ";") {
+		key, value, _ := strings.Cut(tagPart, ":")
+		key = strings.TrimSpace(strings.ToUpper(key))
+
+		switch key {
+		case "UNIQUE":
+			indexes = append(indexes, IndexTag{Priority: defaultIndexPriority})
+			continue
+		case "UNIQUEINDEX", "INDEX":
+		default:
+			continue
+		}
+
+		settings := strings.Split(value, ",")
+		index := IndexTag{Name: strings.TrimSpace(settings[0]), Priority: defaultIndexPriority}
+		unique := key == "UNIQUEINDEX"
+		partial := false
+		for _, setting := range settings[1:] {
+			name, option, _ := strings.Cut(setting, ":")
+			switch strings.TrimSpace(strings.ToUpper(name)) {
+			case "UNIQUE":
+				unique = true
+			case "CLASS":
+				unique = unique || strings.EqualFold(strings.TrimSpace(option), "UNIQUE")
+			case "PRIORITY":
+				if priority, err := strconv.Atoi(strings.TrimSpace(option)); err == nil {
+					index.Priority = priority
+				}
+			case "WHERE", "EXPRESSION":
+				partial = true
+			}
+		}
+
+		if unique && !partial {
+			indexes = append(indexes, index)
+		}
+	}
+	return indexes
+}
+
+// jsonTagName returns the name of the json struct tag, or "" when it has none or is "-"
+func jsonTagName(tags reflect.StructTag) string {
+	name, _, _ := strings.Cut(tags.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// parseQueryBuilderTag parses the querybuilder struct tag, or its short form qb.
+// Options are comma separated and may carry a value, e.g. `querybuilder:"hstore,op=eq"`.
+// Flags without a value map to themselves.
+func parseQueryBuilderTag(tags reflect.StructTag) map[string]string {
+	setting := make(map[string]string)
+
+	options := strings.Split(tags.Get("querybuilder"), ",")
+	options = append(options, strings.Split(tags.Get("qb"), ",")...)
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+
+		key, value, found := strings.Cut(option, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if !found {
+			value = key
+		}
+		setting[key] = strings.TrimSpace(value)
+	}
+
+	return setting
+}
+
 // GenFieldInfo generates field information for code generation.
 // Returns nil if the field should be skipped (e.g., tagged with "-").
 func (g InfoGenerator) GenFieldInfo(f Field) *Info {
@@ -212,41 +444,178 @@ func (g InfoGenerator) GenFieldInfo(f Field) *Info {
 	// Create base field information
 	baseInfo := g.createBaseInfo(f)
 
-	// Handle time types using configurable patterns
+	// Handle time types using configurable patterns, otherwise process field based on its type
+	var info *Info
 	if timePattern := g.matchTimeType(baseInfo.TypeName); timePattern != nil {
-		return g.createTimeFieldInfo(baseInfo, *timePattern)
+		info = g.createTimeFieldInfo(baseInfo, *timePattern)
+	} else {
+		info = g.processFieldType(f, baseInfo)
 	}
 
-	// Process field based on its type
-	return g.processFieldType(f, baseInfo)
+	if info != nil {
+		info.Imports = g.typeImports(f.Type())
+		if pf, ok := f.(PrefixedField); ok {
+			info.DBName = pf.ColumnPrefix() + info.DBName
+		}
+		if df, ok := f.(DocumentedField); ok {
+			info.Doc = df.Doc()
+		}
+	}
+	return info
 }
 
-// shouldSkipField checks if a field should be skipped based on its tags.
+// IsEmbedded reports whether a named struct field is tagged embedded, making GORM flatten its
+// fields into the columns of the outer struct
+func IsEmbedded(tags reflect.StructTag) bool {
+	_, ok := parseTagSetting(tags)["EMBEDDED"]
+	return ok
+}
+
+// EmbeddedPrefix returns the embeddedPrefix tag setting of an embedded struct field
+func EmbeddedPrefix(tags reflect.StructTag) string {
+	return parseTagSetting(tags)["EMBEDDEDPREFIX"]
+}
+
+// typeImports returns the sorted import paths of the packages t refers to, including g.pkg only
+// for an external package
+func (g InfoGenerator) typeImports(t types.Type) []string {
+	paths := make(map[string]bool)
+	g.collectImports(t, paths)
+	return slices.Sorted(maps.Keys(paths))
+}
+
+// collectImports adds the packages of the named types in t, including type arguments, to paths
+func (g InfoGenerator) collectImports(t types.Type, paths map[string]bool) {
+	switch t := types.Unalias(t).(type) {
+	case *types.Named:
+		if pkg := t.Obj().Pkg(); pkg != nil && (g.pkg == nil || pkg.Path() != g.pkg.Path() || g.external) {
+			paths[pkg.Path()] = true
+		}
+		for i := 0; i < t.TypeArgs().Len(); i++ {
+			g.collectImports(t.TypeArgs().At(i), paths)
+		}
+	case *types.Pointer:
+		g.collectImports(t.Elem(), paths)
+	case *types.Slice:
+		g.collectImports(t.Elem(), paths)
+	case *types.Array:
+		g.collectImports(t.Elem(), paths)
+	case *types.Map:
+		g.collectImports(t.Key(), paths)
+		g.collectImports(t.Elem(), paths)
+	}
+}
+
+// shouldSkipField checks if a field should be skipped based on its tags: gorm:"-" ignores the
+// field for GORM too, querybuilder:"-" only for the generated code.
 func (g InfoGenerator) shouldSkipField(f Field) bool {
 	tagSetting := parseTagSetting(f.Tag())
-	return tagSetting["-"] != ""
+	return tagSetting["-"] != "" || parseQueryBuilderTag(f.Tag())["-"] != ""
 }
 
 // createBaseInfo creates the base field information structure.
 func (g InfoGenerator) createBaseInfo(f Field) BaseInfo {
 	tagSetting := parseTagSetting(f.Tag())
+	isFullText, fullTextConfig := tagFullText(f.Tag())
+	operators, excluded := tagOperators(f.Tag())
 
-	dbName := schema.NamingStrategy{}.ColumnName("", f.Name())
+	var namer schema.Namer = schema.NamingStrategy{}
+	if g.namer != nil {
+		namer = g.namer
+	}
+	// Field names used verbatim have no words to join
+	if _, verbatim := namer.(fieldNameNamer); !verbatim && len(g.acronyms) > 0 {
+		namer = NewAcronymNamer(namer, g.acronyms)
+	}
+
+	dbName := namer.ColumnName("", f.Name())
 	if dbColName := tagSetting["COLUMN"]; dbColName != "" {
 		dbName = dbColName
+	} else if jsonName := jsonTagName(f.Tag()); g.jsonTags && jsonName != "" {
+		dbName = jsonName
 	}
 
 	return BaseInfo{
-		Name:     f.Name(),
-		TypeName: f.Type().String(),
-		DBName:   dbName,
+		Name:         f.Name(),
+		TypeName:     f.Type().String(),
+		DBName:       dbName,
+		IsPrimaryKey: tagSetting["PRIMARYKEY"] != "" || tagSetting["PRIMARY_KEY"] != "",
+		IsReadOnly:   parseQueryBuilderTag(f.Tag())["readonly"] != "",
+		IsVersion:    tagSetting["VERSION"] != "" || parseQueryBuilderTag(f.Tag())["version"] != "",
+		Operators:    operators,
+
+		ExcludedOperators: excluded,
+
+		IsFullText:     isFullText,
+		FullTextConfig: fullTextConfig,
+
+		UniqueIndexes: parseUniqueIndexes(f.Tag()),
+
+		Constraints: tagConstraints(f.Tag()),
+		BasicInfo:   basicInfo(f.Type()),
 	}
 }
 
+// tagConstraints returns the validation constraints of a querybuilder tag in a fixed order
+func tagConstraints(tags reflect.StructTag) []ConstraintTag {
+	setting := parseQueryBuilderTag(tags)
+
+	var constraints []ConstraintTag
+	for _, name := range constraintNames {
+		value, ok := setting[name]
+		if !ok {
+			continue
+		}
+		if value == name {
+			value = ""
+		}
+		constraints = append(constraints, ConstraintTag{Name: name, Value: value})
+	}
+	return constraints
+}
+
+// basicInfo returns the properties of the basic type underlying t, or of the type t points to
+func basicInfo(t types.Type) types.BasicInfo {
+	if pointer, ok := t.Underlying().(*types.Pointer); ok {
+		t = pointer.Elem()
+	}
+	if basic, ok := t.Underlying().(*types.Basic); ok {
+		return basic.Info()
+	}
+	return 0
+}
+
+// tagFullText reports whether a field is tagged querybuilder:"fulltext", and returns the
+// configuration of querybuilder:"fulltext=english"
+func tagFullText(tags reflect.StructTag) (bool, string) {
+	value, ok := parseQueryBuilderTag(tags)["fulltext"]
+	if !ok || value == "fulltext" {
+		return ok, ""
+	}
+	return true, value
+}
+
+// tagOperators returns the |-separated operator suffixes of a querybuilder:"ops=..." tag: those
+// of registered operators to add, and those prefixed with "-" of built-in operators to remove
+func tagOperators(tags reflect.StructTag) (operators, excluded []string) {
+	for _, keyword := range strings.Split(parseQueryBuilderTag(tags)["ops"], "|") {
+		keyword = strings.TrimSpace(keyword)
+		if removed, ok := strings.CutPrefix(keyword, "-"); ok {
+			if removed = strings.TrimSpace(removed); removed != "" {
+				excluded = append(excluded, removed)
+			}
+		} else if keyword != "" {
+			operators = append(operators, keyword)
+		}
+	}
+	return operators, excluded
+}
+
 // createTimeFieldInfo creates field info for time-related fields using the matched pattern.
 func (g InfoGenerator) createTimeFieldInfo(baseInfo BaseInfo, pattern TimeTypePattern) *Info {
 	baseInfo.IsTime = true
 	baseInfo.IsNumeric = pattern.IsNumeric
+	baseInfo.IsNullable = pattern.IsNullable
 	return &Info{BaseInfo: baseInfo}
 }
 
@@ -256,7 +625,7 @@ func (g InfoGenerator) processFieldType(f Field, baseInfo BaseInfo) *Info {
 	case *types.Basic:
 		return g.processBasicType(t, baseInfo)
 	case *types.Slice:
-		return g.processSliceType(baseInfo)
+		return g.processSliceType(t, baseInfo)
 	case *types.Named:
 		return g.processNamedType(f, t)
 	case *types.Struct:
@@ -264,7 +633,7 @@ func (g InfoGenerator) processFieldType(f Field, baseInfo BaseInfo) *Info {
 	case *types.Pointer:
 		return g.processPointerType(f, t, baseInfo)
 	case *types.Map:
-		return g.processMapType(baseInfo)
+		return g.processMapType(f, baseInfo)
 	default:
 		// Unknown type - no filtering needed
 		return nil
@@ -275,12 +644,23 @@ func (g InfoGenerator) processFieldType(f Field, baseInfo BaseInfo) *Info {
 func (g InfoGenerator) processBasicType(t *types.Basic, baseInfo BaseInfo) *Info {
 	baseInfo.IsString = t.Info()&types.IsString != 0
 	baseInfo.IsNumeric = t.Info()&types.IsNumeric != 0
+	baseInfo.IsBool = t.Info()&types.IsBoolean != 0
 	return &Info{BaseInfo: baseInfo}
 }
 
 // processSliceType handles slice types.
-func (g InfoGenerator) processSliceType(baseInfo BaseInfo) *Info {
+// A slice of models (e.g. []Item or []*Item) is a has-many association.
+func (g InfoGenerator) processSliceType(t *types.Slice, baseInfo BaseInfo) *Info {
 	baseInfo.IsSlice = true
+
+	elem := t.Elem()
+	if pointer, ok := elem.(*types.Pointer); ok {
+		elem = pointer.Elem()
+	}
+	if named, ok := elem.(*types.Named); ok {
+		baseInfo.IsAssociation = g.isAssociationType(named)
+	}
+
 	return &Info{BaseInfo: baseInfo}
 }
 
@@ -291,13 +671,31 @@ func (g InfoGenerator) processStructType(baseInfo BaseInfo) *Info {
 }
 
 // processMapType handles map types.
-func (g InfoGenerator) processMapType(baseInfo BaseInfo) *Info {
+// Maps are opaque to the generator unless tagged as a Postgres hstore column,
+// since the Go type alone does not reveal how the column is stored.
+func (g InfoGenerator) processMapType(f Field, baseInfo BaseInfo) *Info {
 	baseInfo.IsMap = true
+	baseInfo.IsHStore = parseQueryBuilderTag(f.Tag())["hstore"] != ""
 	return &Info{BaseInfo: baseInfo}
 }
 
 // processNamedType handles named types (custom types, generics).
 func (g InfoGenerator) processNamedType(f Field, t *types.Named) *Info {
+	// Opaque scalars such as uuid.UUID are arrays or structs the database compares as one value
+	if typeName := g.getOriginalTypeName(t); slices.Contains(g.scalars, typeName) {
+		baseInfo := g.createBaseInfo(f)
+		baseInfo.TypeName = typeName
+		baseInfo.IsScalar = true
+		return &Info{BaseInfo: baseInfo}
+	}
+	// Structs such as decimal.Decimal that hold a number compare like one
+	if typeName := g.getOriginalTypeName(t); slices.Contains(g.numerics, typeName) {
+		baseInfo := g.createBaseInfo(f)
+		baseInfo.TypeName = typeName
+		baseInfo.IsNumeric = true
+		return &Info{BaseInfo: baseInfo}
+	}
+
 	// Recursively process the underlying type
 	r := g.GenFieldInfo(field{
 		name: f.Name(),
@@ -313,10 +711,48 @@ func (g InfoGenerator) processNamedType(f Field, t *types.Named) *Info {
 	r.TypeName = g.getOriginalTypeName(t)
 
 	// Handle time types using configurable patterns
-	if timePattern := g.matchTimeType(r.TypeName); timePattern != nil {
+	if timePattern := g.matchNamedTimeType(t); timePattern != nil {
 		r.IsTime = true
 		r.IsStruct = false
 		r.IsNumeric = timePattern.IsNumeric
+		r.IsNullable = timePattern.IsNullable
+	}
+
+	// database/sql wrappers such as sql.NullString are filtered like the value they hold
+	if value, ok := g.sqlNullValue(t); ok && !r.IsTime {
+		if valueInfo := g.GenFieldInfo(field{name: f.Name(), typ: value.Type()}); valueInfo != nil &&
+			(valueInfo.IsString || valueInfo.IsNumeric || valueInfo.IsBool || valueInfo.IsTime) {
+			r.IsStruct = false
+			r.IsString = valueInfo.IsString
+			r.IsNumeric = valueInfo.IsNumeric
+			r.IsBool = valueInfo.IsBool
+			r.IsTime = valueInfo.IsTime
+			r.IsNullable = true
+			r.ValueTypeName = valueInfo.TypeName
+		}
+	}
+
+	if g.isJSONType(t) {
+		r.IsJSON = true
+	}
+	// Arrays of scalars can be searched for an element; arrays of objects stay opaque
+	if g.isDatatype(t, "JSONSlice") && t.TypeArgs().Len() == 1 {
+		elemType := t.TypeArgs().At(0)
+		if _, ok := elemType.Underlying().(*types.Basic); ok {
+			r.IsJSONArray = true
+			r.ElemTypeName = g.GenFieldInfo(field{name: f.Name(), typ: elemType}).TypeName
+		}
+	}
+
+	// Native arrays can be searched for elements; other slices stay opaque
+	if s, ok := t.Underlying().(*types.Slice); ok && slices.Contains(g.arrays, r.TypeName) {
+		r.IsArray = true
+		r.ElemTypeName = g.GenFieldInfo(field{name: f.Name(), typ: s.Elem()}).TypeName
+	}
+
+	// Structs that can't be scanned from a single column are related models
+	if r.IsStruct && g.isAssociationType(t) && !g.isSerializedField(f) {
+		r.IsAssociation = true
 	}
 
 	// Handle generic types
@@ -328,6 +764,55 @@ func (g InfoGenerator) processNamedType(f Field, t *types.Named) *Info {
 	return r
 }
 
+// isAssociationType reports whether a named type is a model that GORM loads as an association.
+// Time types and types implementing sql.Scanner (e.g. datatypes.JSONType) are column values instead.
+func (g InfoGenerator) isAssociationType(t *types.Named) bool {
+	if _, ok := t.Underlying().(*types.Struct); !ok {
+		return false
+	}
+
+	if g.matchNamedTimeType(t) != nil {
+		return false
+	}
+
+	methods := types.NewMethodSet(types.NewPointer(t))
+	return methods.Lookup(nil, "Scan") == nil
+}
+
+// jsonTypes are the gorm.io/datatypes types holding a JSON object that can be queried by key
+var jsonTypes = []string{"JSON", "JSONMap", "JSONType"}
+
+// isJSONType reports whether a named type is one of the JSON object types of gorm.io/datatypes
+func (g InfoGenerator) isJSONType(t *types.Named) bool {
+	return slices.ContainsFunc(jsonTypes, func(name string) bool { return g.isDatatype(t, name) })
+}
+
+// isDatatype reports whether a named type is the gorm.io/datatypes type with the given name
+func (g InfoGenerator) isDatatype(t *types.Named, name string) bool {
+	obj := t.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == "gorm.io/datatypes" && obj.Name() == name
+}
+
+// sqlNullValue returns the value field of a database/sql nullable wrapper, such as String of
+// sql.NullString or V of sql.Null[T]: a struct of the value and a Valid flag
+func (g InfoGenerator) sqlNullValue(t *types.Named) (*types.Var, bool) {
+	obj := t.Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() != "database/sql" || !strings.HasPrefix(obj.Name(), "Null") {
+		return nil, false
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok || st.NumFields() != 2 || st.Field(1).Name() != "Valid" {
+		return nil, false
+	}
+	return st.Field(0), true
+}
+
+// isSerializedField reports whether GORM stores the field in a single column via a serializer or explicit type.
+func (g InfoGenerator) isSerializedField(f Field) bool {
+	tagSetting := parseTagSetting(f.Tag())
+	return tagSetting["SERIALIZER"] != "" || tagSetting["TYPE"] != ""
+}
+
 // processGenericType handles generic type arguments.
 func (g InfoGenerator) processGenericType(f Field, t *types.Named, baseName string) string {
 	var typeArgs []string
@@ -361,9 +846,23 @@ func (g InfoGenerator) processPointerType(f Field, t *types.Pointer, baseInfo Ba
 
 	return &Info{
 		BaseInfo: BaseInfo{
-			Name:     baseInfo.Name,
-			TypeName: fmt.Sprintf("*%s", pointedField.TypeName),
-			DBName:   baseInfo.DBName,
+			Name:         baseInfo.Name,
+			TypeName:     fmt.Sprintf("*%s", pointedField.TypeName),
+			DBName:       baseInfo.DBName,
+			IsPrimaryKey: baseInfo.IsPrimaryKey,
+			IsReadOnly:   baseInfo.IsReadOnly,
+			IsVersion:    baseInfo.IsVersion,
+			Operators:    baseInfo.Operators,
+
+			ExcludedOperators: baseInfo.ExcludedOperators,
+
+			IsFullText:     baseInfo.IsFullText,
+			FullTextConfig: baseInfo.FullTextConfig,
+
+			UniqueIndexes: baseInfo.UniqueIndexes,
+
+			Constraints: baseInfo.Constraints,
+			BasicInfo:   baseInfo.BasicInfo,
 		},
 		IsPointer: true,
 		pointed:   &pointedField.BaseInfo,
@@ -50,6 +50,14 @@ func TestTimeTypeDetection_Integration(t *testing.T) {
 			expectedNumeric:  true,
 			description:      "PostgreSQL pq.NullTime should be detected as numeric time type",
 		},
+		{
+			name:             "gorm_deleted_at",
+			fieldName:        "DeletedAt",
+			typeName:         "gorm.DeletedAt",
+			expectedDetected: true,
+			expectedNumeric:  true,
+			description:      "GORM DeletedAt should be detected as numeric time type",
+		},
 		{
 			name:             "custom_non_time",
 			fieldName:        "Name",
@@ -0,0 +1,538 @@
+package field
+
+import (
+	"go/types"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestInfoGenerator_GenFieldInfo_HStore(t *testing.T) {
+	pkg := types.NewPackage("models", "models")
+	generator := NewInfoGenerator(pkg)
+	mapType := types.NewMap(types.Typ[types.String], types.Typ[types.String])
+
+	tests := []struct {
+		name       string
+		tag        reflect.StructTag
+		wantHStore bool
+	}{
+		{"tagged map is hstore", `querybuilder:"hstore"`, true},
+		{"tag option among others", `gorm:"type:hstore" querybuilder:"readonly, hstore"`, true},
+		{"untagged map stays opaque", `gorm:"type:hstore"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := generator.GenFieldInfo(field{name: "Attributes", typ: mapType, tag: tt.tag})
+			if info == nil {
+				t.Fatal("GenFieldInfo returned nil for map field")
+			}
+			if !info.IsMap {
+				t.Error("map field should have IsMap=true")
+			}
+			if info.IsHStore != tt.wantHStore {
+				t.Errorf("IsHStore = %v, want %v", info.IsHStore, tt.wantHStore)
+			}
+		})
+	}
+
+	t.Run("named map type keeps tag", func(t *testing.T) {
+		named := types.NewNamed(types.NewTypeName(0, pkg, "Labels", nil), mapType, nil)
+		info := generator.GenFieldInfo(field{name: "Labels", typ: named, tag: `querybuilder:"hstore"`})
+		if info == nil || !info.IsHStore {
+			t.Fatalf("named map type should be detected as hstore, got %+v", info)
+		}
+		if info.TypeName != "Labels" {
+			t.Errorf("TypeName = %q, want Labels", info.TypeName)
+		}
+	})
+}
+
+func TestInfoGenerator_GenFieldInfo_PrimaryKey(t *testing.T) {
+	generator := NewInfoGenerator(types.NewPackage("models", "models"))
+	stringType := types.Typ[types.String]
+
+	tests := []struct {
+		name   string
+		typ    types.Type
+		tag    reflect.StructTag
+		wantPK bool
+	}{
+		{"primaryKey tag", stringType, `gorm:"primaryKey"`, true},
+		{"legacy primary_key tag", stringType, `gorm:"column:uuid;primary_key"`, true},
+		{"pointer keeps tag", types.NewPointer(stringType), `gorm:"primaryKey"`, true},
+		{"untagged", stringType, `gorm:"column:uuid"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := generator.GenFieldInfo(field{name: "UUID", typ: tt.typ, tag: tt.tag})
+			if info == nil {
+				t.Fatal("GenFieldInfo returned nil")
+			}
+			if info.IsPrimaryKey != tt.wantPK {
+				t.Errorf("IsPrimaryKey = %v, want %v", info.IsPrimaryKey, tt.wantPK)
+			}
+		})
+	}
+}
+
+func TestInfoGenerator_GenFieldInfo_QueryBuilderTag(t *testing.T) {
+	generator := NewInfoGenerator(types.NewPackage("models", "models"))
+	intType := types.Typ[types.Int64]
+
+	tests := []struct {
+		name         string
+		typ          types.Type
+		tag          reflect.StructTag
+		wantSkipped  bool
+		wantReadOnly bool
+	}{
+		{"skipped", intType, `querybuilder:"-"`, true, false},
+		{"skipped by short tag", intType, `gorm:"column:row_version" qb:"-"`, true, false},
+		{"readonly", intType, `querybuilder:"readonly"`, false, true},
+		{"readonly by short tag", intType, `qb:"readonly"`, false, true},
+		{"pointer keeps readonly", types.NewPointer(intType), `qb:"readonly"`, false, true},
+		{"untagged", intType, `gorm:"column:row_version"`, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := generator.GenFieldInfo(field{name: "RowVersion", typ: tt.typ, tag: tt.tag})
+			if (info == nil) != tt.wantSkipped {
+				t.Fatalf("GenFieldInfo skipped = %v, want %v", info == nil, tt.wantSkipped)
+			}
+			if info != nil && info.IsReadOnly != tt.wantReadOnly {
+				t.Errorf("IsReadOnly = %v, want %v", info.IsReadOnly, tt.wantReadOnly)
+			}
+		})
+	}
+}
+
+func TestInfoGenerator_GenFieldInfo_Bool(t *testing.T) {
+	pkg := types.NewPackage("models", "models")
+	generator := NewInfoGenerator(pkg)
+	flag := types.NewNamed(types.NewTypeName(0, pkg, "Flag", nil), types.Typ[types.Bool], nil)
+
+	tests := []struct {
+		name     string
+		typ      types.Type
+		wantBool bool
+		wantType string
+	}{
+		{"bool", types.Typ[types.Bool], true, "bool"},
+		{"named bool", flag, true, "Flag"},
+		{"string", types.Typ[types.String], false, "string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := generator.GenFieldInfo(field{name: "Visible", typ: tt.typ})
+			if info == nil {
+				t.Fatal("GenFieldInfo returned nil")
+			}
+			if info.IsBool != tt.wantBool || info.TypeName != tt.wantType {
+				t.Errorf("IsBool = %v, TypeName = %s, want %v, %s", info.IsBool, info.TypeName, tt.wantBool, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestInfoGenerator_GenFieldInfo_Unsigned(t *testing.T) {
+	pkg := types.NewPackage("models", "models")
+	generator := NewInfoGenerator(pkg)
+	code := types.NewNamed(types.NewTypeName(0, pkg, "Code", nil), types.Typ[types.Uint64], nil)
+
+	tests := []struct {
+		typ      types.Type
+		wantType string
+	}{
+		{types.Typ[types.Uint], "uint"},
+		{types.Typ[types.Uint8], "uint8"},
+		{types.Typ[types.Uint16], "uint16"},
+		{types.Typ[types.Uint32], "uint32"},
+		{types.Typ[types.Uint64], "uint64"},
+		{code, "Code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantType, func(t *testing.T) {
+			info := generator.GenFieldInfo(field{name: "ID", typ: tt.typ})
+			if info == nil {
+				t.Fatal("GenFieldInfo returned nil")
+			}
+			if !info.IsNumeric || info.IsString || info.IsBool {
+				t.Errorf("%s should be numeric only, got %+v", tt.wantType, info.BaseInfo)
+			}
+			if info.TypeName != tt.wantType {
+				t.Errorf("TypeName = %s, want %s", info.TypeName, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestInfoGenerator_GenFieldInfo_UniqueIndexes(t *testing.T) {
+	generator := NewInfoGenerator(types.NewPackage("models", "models"))
+	stringType := types.Typ[types.String]
+
+	tests := []struct {
+		name string
+		typ  types.Type
+		tag  reflect.StructTag
+		want []IndexTag
+	}{
+		{"unique", stringType, `gorm:"unique"`, []IndexTag{{Priority: 10}}},
+		{"unnamed uniqueIndex", stringType, `gorm:"uniqueIndex"`, []IndexTag{{Priority: 10}}},
+		{"named with priority", stringType, `gorm:"uniqueIndex:idx_sku,priority:2"`, []IndexTag{{Name: "idx_sku", Priority: 2}}},
+		{"index with unique option", stringType, `gorm:"index:idx_sku,unique"`, []IndexTag{{Name: "idx_sku", Priority: 10}}},
+		{"index with unique class", stringType, `gorm:"index:idx_sku,class:UNIQUE"`, []IndexTag{{Name: "idx_sku", Priority: 10}}},
+		{"several indexes", stringType, `gorm:"uniqueIndex;uniqueIndex:idx_a"`, []IndexTag{{Priority: 10}, {Name: "idx_a", Priority: 10}}},
+		{"pointer keeps indexes", types.NewPointer(stringType), `gorm:"uniqueIndex"`, []IndexTag{{Priority: 10}}},
+		{"plain index", stringType, `gorm:"index:idx_sku"`, nil},
+		{"partial index", stringType, `gorm:"uniqueIndex:idx_sku,where:deleted_at IS NULL"`, nil},
+		{"untagged", stringType, `json:"sku"`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := generator.GenFieldInfo(field{name: "SKU", typ: tt.typ, tag: tt.tag})
+			if info == nil {
+				t.Fatal("GenFieldInfo returned nil")
+			}
+			if !reflect.DeepEqual(info.UniqueIndexes, tt.want) {
+				t.Errorf("UniqueIndexes = %v, want %v", info.UniqueIndexes, tt.want)
+			}
+		})
+	}
+}
+
+func TestInfoGenerator_GenFieldInfo_JSON(t *testing.T) {
+	generator := NewInfoGenerator(types.NewPackage("models", "models"))
+	datatypes := types.NewPackage("gorm.io/datatypes", "datatypes")
+	other := types.NewPackage("example.com/other", "other")
+
+	jsonType := func(pkg *types.Package, name string, underlying types.Type) *types.Named {
+		return types.NewNamed(types.NewTypeName(0, pkg, name, nil), underlying, nil)
+	}
+	object := types.NewStruct(nil, nil)
+	stringMap := types.NewMap(types.Typ[types.String], types.NewInterfaceType(nil, nil))
+
+	tests := []struct {
+		name     string
+		typ      types.Type
+		wantJSON bool
+	}{
+		{"JSONType", jsonType(datatypes, "JSONType", object), true},
+		{"JSONMap", jsonType(datatypes, "JSONMap", stringMap), true},
+		{"JSON", jsonType(datatypes, "JSON", types.NewSlice(types.Typ[types.Byte])), true},
+		{"other package", jsonType(other, "JSONType", object), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := generator.GenFieldInfo(field{name: "Attributes", typ: tt.typ})
+			if info == nil {
+				t.Fatal("GenFieldInfo returned nil")
+			}
+			if info.IsJSON != tt.wantJSON {
+				t.Errorf("IsJSON = %v, want %v", info.IsJSON, tt.wantJSON)
+			}
+		})
+	}
+}
+
+func TestInfoGenerator_GenFieldInfo_SQLNull(t *testing.T) {
+	generator := NewInfoGenerator(types.NewPackage("models", "models"))
+	sqlPkg := types.NewPackage("database/sql", "sql")
+	other := types.NewPackage("example.com/other", "other")
+
+	nullType := func(pkg *types.Package, name, valueName string, value types.Type) *types.Named {
+		underlying := types.NewStruct([]*types.Var{
+			types.NewField(0, pkg, valueName, value, false),
+			types.NewField(0, pkg, "Valid", types.Typ[types.Bool], false),
+		}, nil)
+		return types.NewNamed(types.NewTypeName(0, pkg, name, nil), underlying, nil)
+	}
+
+	tests := []struct {
+		name      string
+		typ       types.Type
+		wantValue string
+		check     func(*Info) bool
+	}{
+		{"NullString", nullType(sqlPkg, "NullString", "String", types.Typ[types.String]), "string", func(i *Info) bool { return i.IsString }},
+		{"NullInt64", nullType(sqlPkg, "NullInt64", "Int64", types.Typ[types.Int64]), "int64", func(i *Info) bool { return i.IsNumeric }},
+		{"NullBool", nullType(sqlPkg, "NullBool", "Bool", types.Typ[types.Bool]), "bool", func(i *Info) bool { return i.IsBool }},
+		{"other package", nullType(other, "NullString", "String", types.Typ[types.String]), "", func(i *Info) bool { return i.IsStruct }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := generator.GenFieldInfo(field{name: "Value", typ: tt.typ})
+			if info == nil {
+				t.Fatal("GenFieldInfo returned nil")
+			}
+			if info.ValueTypeName != tt.wantValue {
+				t.Errorf("ValueTypeName = %q, want %q", info.ValueTypeName, tt.wantValue)
+			}
+			if !tt.check(info) {
+				t.Errorf("unexpected classification: %+v", info.BaseInfo)
+			}
+			if wantNullable := tt.wantValue != ""; info.IsNullable != wantNullable || info.IsStruct == wantNullable {
+				t.Errorf("IsNullable = %v, IsStruct = %v, want nullable %v", info.IsNullable, info.IsStruct, wantNullable)
+			}
+		})
+	}
+}
+
+func TestInfoGenerator_GenFieldInfo_JSONArray(t *testing.T) {
+	pkg := types.NewPackage("models", "models")
+	generator := NewInfoGenerator(pkg)
+	datatypes := types.NewPackage("gorm.io/datatypes", "datatypes")
+
+	typeParam := types.NewTypeParam(types.NewTypeName(0, datatypes, "T", nil), types.NewInterfaceType(nil, nil))
+	jsonSlice := types.NewNamed(types.NewTypeName(0, datatypes, "JSONSlice", nil), types.NewSlice(typeParam), nil)
+	jsonSlice.SetTypeParams([]*types.TypeParam{typeParam})
+
+	instantiate := func(elem types.Type) types.Type {
+		typ, err := types.Instantiate(nil, jsonSlice, []types.Type{elem}, false)
+		if err != nil {
+			t.Fatalf("Instantiate failed: %v", err)
+		}
+		return typ
+	}
+	item := types.NewNamed(types.NewTypeName(0, pkg, "Item", nil), types.NewStruct(nil, nil), nil)
+
+	info := generator.GenFieldInfo(field{name: "Tags", typ: instantiate(types.Typ[types.String])})
+	if info == nil || !info.IsJSONArray {
+		t.Fatalf("JSONSlice[string] should be a JSON array, got %+v", info)
+	}
+	if info.ElemTypeName != "string" {
+		t.Errorf("ElemTypeName = %q, want string", info.ElemTypeName)
+	}
+
+	info = generator.GenFieldInfo(field{name: "Items", typ: instantiate(item)})
+	if info == nil || info.IsJSONArray {
+		t.Errorf("JSONSlice of structs should not be searchable, got %+v", info)
+	}
+
+	info = generator.GenFieldInfo(field{name: "Names", typ: types.NewSlice(types.Typ[types.String])})
+	if info == nil || info.IsJSONArray {
+		t.Errorf("plain []string should not be a JSON array, got %+v", info)
+	}
+}
+
+func TestInfoGenerator_GenFieldInfo_Array(t *testing.T) {
+	pkg := types.NewPackage("models", "models")
+	pq := types.NewPackage("github.com/lib/pq", "pq")
+	named := func(pkg *types.Package, name string, underlying types.Type) *types.Named {
+		return types.NewNamed(types.NewTypeName(0, pkg, name, nil), underlying, nil)
+	}
+	stringSlice := types.NewSlice(types.Typ[types.String])
+
+	tests := []struct {
+		name      string
+		typ       types.Type
+		arrays    []string
+		wantArray bool
+		wantElem  string
+	}{
+		{"pq.StringArray", named(pq, "StringArray", stringSlice), nil, true, "string"},
+		{"pq.Int64Array", named(pq, "Int64Array", types.NewSlice(types.Typ[types.Int64])), nil, true, "int64"},
+		{"configured type", named(pkg, "Tags", stringSlice), []string{"Tags"}, true, "string"},
+		{"unconfigured type", named(pkg, "Tags", stringSlice), nil, false, ""},
+		{"plain slice", stringSlice, nil, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := NewInfoGenerator(pkg)
+			if tt.arrays != nil {
+				generator.SetArrayTypes(append(slices.Clone(DefaultArrayTypes), tt.arrays...))
+			}
+			info := generator.GenFieldInfo(field{name: "Tags", typ: tt.typ})
+			if info == nil {
+				t.Fatal("GenFieldInfo returned nil")
+			}
+			if info.IsArray != tt.wantArray || info.ElemTypeName != tt.wantElem {
+				t.Errorf("IsArray = %v, ElemTypeName = %q, want %v, %q", info.IsArray, info.ElemTypeName, tt.wantArray, tt.wantElem)
+			}
+		})
+	}
+}
+
+func TestInfoGenerator_GenFieldInfo_Decimal(t *testing.T) {
+	pkg := types.NewPackage("models", "models")
+	decimal := types.NewPackage("github.com/shopspring/decimal", "decimal")
+	money := types.NewPackage("example.com/money", "money")
+	opaque := types.NewStruct([]*types.Var{types.NewField(0, decimal, "value", types.NewPointer(types.Typ[types.Int]), false)}, nil)
+
+	generator := NewInfoGenerator(pkg)
+	generator.SetNumericTypes(append(slices.Clone(DefaultNumericTypes), "money.Amount"))
+
+	for _, typ := range []*types.Named{
+		types.NewNamed(types.NewTypeName(0, decimal, "Decimal", nil), opaque, nil),
+		types.NewNamed(types.NewTypeName(0, money, "Amount", nil), opaque, nil),
+	} {
+		info := generator.GenFieldInfo(field{name: "Price", typ: typ})
+		if info == nil {
+			t.Fatalf("GenFieldInfo(%s) returned nil", typ)
+		}
+		want := typ.Obj().Pkg().Name() + "." + typ.Obj().Name()
+		if !info.IsNumeric || info.IsStruct || info.TypeName != want {
+			t.Errorf("%s: IsNumeric = %v, IsStruct = %v, TypeName = %q", want, info.IsNumeric, info.IsStruct, info.TypeName)
+		}
+		if len(info.Imports) != 1 || info.Imports[0] != typ.Obj().Pkg().Path() {
+			t.Errorf("%s: Imports = %v, want [%s]", want, info.Imports, typ.Obj().Pkg().Path())
+		}
+	}
+}
+
+func TestInfoGenerator_GenFieldInfo_Scalar(t *testing.T) {
+	pkg := types.NewPackage("models", "models")
+	uuid := types.NewPackage("github.com/google/uuid", "uuid")
+	ulid := types.NewPackage("github.com/oklog/ulid/v2", "ulid")
+	bytes16 := types.NewArray(types.Typ[types.Byte], 16)
+
+	tests := []struct {
+		name       string
+		typ        types.Type
+		scalars    []string
+		wantScalar bool
+	}{
+		{"uuid.UUID", types.NewNamed(types.NewTypeName(0, uuid, "UUID", nil), bytes16, nil), nil, true},
+		{"configured type", types.NewNamed(types.NewTypeName(0, ulid, "ULID", nil), bytes16, nil), []string{"ulid.ULID"}, true},
+		{"unconfigured type", types.NewNamed(types.NewTypeName(0, ulid, "ULID", nil), bytes16, nil), nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := NewInfoGenerator(pkg)
+			if tt.scalars != nil {
+				generator.SetScalarTypes(append(slices.Clone(DefaultScalarTypes), tt.scalars...))
+			}
+			info := generator.GenFieldInfo(field{name: "ID", typ: tt.typ})
+			if !tt.wantScalar {
+				if info != nil && info.IsScalar {
+					t.Error("unregistered array type should not be a scalar")
+				}
+				return
+			}
+			if info == nil {
+				t.Fatal("GenFieldInfo returned nil")
+			}
+			if !info.IsScalar {
+				t.Error("IsScalar = false, want true")
+			}
+		})
+	}
+}
+
+func TestInfoGenerator_GenFieldInfo_Imports(t *testing.T) {
+	pkg := types.NewPackage("example.com/models", "models")
+	generator := NewInfoGenerator(pkg)
+	enums := types.NewPackage("example.com/models/enums", "enums")
+	datatypes := types.NewPackage("gorm.io/datatypes", "datatypes")
+
+	status := types.NewNamed(types.NewTypeName(0, enums, "Status", nil), types.Typ[types.String], nil)
+	local := types.NewNamed(types.NewTypeName(0, pkg, "Kind", nil), types.Typ[types.String], nil)
+
+	typeParam := types.NewTypeParam(types.NewTypeName(0, datatypes, "T", nil), types.NewInterfaceType(nil, nil))
+	jsonSlice := types.NewNamed(types.NewTypeName(0, datatypes, "JSONSlice", nil), types.NewSlice(typeParam), nil)
+	jsonSlice.SetTypeParams([]*types.TypeParam{typeParam})
+	statuses, err := types.Instantiate(nil, jsonSlice, []types.Type{status}, false)
+	if err != nil {
+		t.Fatalf("Instantiate failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		typ  types.Type
+		want []string
+	}{
+		{"basic", types.Typ[types.String], nil},
+		{"same package", local, nil},
+		{"imported", status, []string{"example.com/models/enums"}},
+		{"pointer", types.NewPointer(status), []string{"example.com/models/enums"}},
+		{"map", types.NewMap(types.Typ[types.String], status), []string{"example.com/models/enums"}},
+		{"type argument", statuses, []string{"example.com/models/enums", "gorm.io/datatypes"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := generator.GenFieldInfo(field{name: "Status", typ: tt.typ})
+			if info == nil {
+				t.Fatal("GenFieldInfo returned nil")
+			}
+			if !slices.Equal(info.Imports, tt.want) {
+				t.Errorf("Imports = %v, want %v", info.Imports, tt.want)
+			}
+		})
+	}
+}
+
+func TestInfoGenerator_SetExternalPackage(t *testing.T) {
+	pkg := types.NewPackage("example.com/models", "models")
+	timestamp := types.NewNamed(types.NewTypeName(0, pkg, "Timestamp", nil), types.NewStruct(nil, nil), nil)
+	status := types.NewNamed(types.NewTypeName(0, pkg, "Status", nil), types.Typ[types.String], nil)
+
+	generator := NewInfoGeneratorWithTimeTypes(pkg, append(slices.Clone(DefaultTimeTypes), TimeTypePattern{Pattern: "Timestamp", IsNumeric: true}))
+	generator.SetExternalPackage(true)
+
+	info := generator.GenFieldInfo(field{name: "Status", typ: types.NewPointer(status)})
+	if info.TypeName != "*models.Status" {
+		t.Errorf("TypeName = %q, want *models.Status", info.TypeName)
+	}
+	if !slices.Equal(info.Imports, []string{"example.com/models"}) {
+		t.Errorf("Imports = %v, want the models package", info.Imports)
+	}
+
+	info = generator.GenFieldInfo(field{name: "CreatedAt", typ: timestamp})
+	if info.TypeName != "models.Timestamp" || !info.IsTime {
+		t.Errorf("Local time type should still match its pattern, got %+v", info.BaseInfo)
+	}
+}
+
+type prefixedField struct {
+	field
+	prefix string
+}
+
+func (f prefixedField) ColumnPrefix() string {
+	return f.prefix
+}
+
+func TestEmbeddedTags(t *testing.T) {
+	tests := []struct {
+		tag          reflect.StructTag
+		wantEmbedded bool
+		wantPrefix   string
+	}{
+		{`gorm:"embedded"`, true, ""},
+		{`gorm:"embedded;embeddedPrefix:addr_"`, true, "addr_"},
+		{`gorm:"embeddedPrefix:addr_"`, false, "addr_"},
+		{`gorm:"column:address"`, false, ""},
+	}
+
+	for _, tt := range tests {
+		if got := IsEmbedded(tt.tag); got != tt.wantEmbedded {
+			t.Errorf("IsEmbedded(%s) = %v, want %v", tt.tag, got, tt.wantEmbedded)
+		}
+		if got := EmbeddedPrefix(tt.tag); got != tt.wantPrefix {
+			t.Errorf("EmbeddedPrefix(%s) = %q, want %q", tt.tag, got, tt.wantPrefix)
+		}
+	}
+
+	generator := NewInfoGenerator(types.NewPackage("models", "models"))
+	str := types.Typ[types.String]
+	for tag, want := range map[reflect.StructTag]string{
+		"":                    "addr_street",
+		`gorm:"column:line1"`: "addr_line1",
+	} {
+		info := generator.GenFieldInfo(prefixedField{field{name: "Street", typ: str, tag: tag}, "addr_"})
+		if info == nil || info.DBName != want {
+			t.Errorf("DBName of prefixed field tagged %q = %+v, want %q", tag, info, want)
+		}
+	}
+}
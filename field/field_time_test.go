@@ -14,6 +14,7 @@ func TestTimeTypePattern_DefaultConfiguration(t *testing.T) {
 		"datatypes.DateTime": true,
 		"sql.NullTime":       true,
 		"pq.NullTime":        true,
+		"gorm.DeletedAt":     true,
 	}
 
 	// Check all expected defaults are present
@@ -157,9 +158,10 @@ func TestInfoGenerator_CreateTimeFieldInfo(t *testing.T) {
 	}
 
 	tests := []struct {
-		name            string
-		pattern         TimeTypePattern
-		expectedNumeric bool
+		name             string
+		pattern          TimeTypePattern
+		expectedNumeric  bool
+		expectedNullable bool
 	}{
 		{
 			name:            "numeric time type",
@@ -171,6 +173,12 @@ func TestInfoGenerator_CreateTimeFieldInfo(t *testing.T) {
 			pattern:         TimeTypePattern{Pattern: "custom.Date", IsNumeric: false},
 			expectedNumeric: false,
 		},
+		{
+			name:             "nullable time type",
+			pattern:          TimeTypePattern{Pattern: "gorm.DeletedAt", IsNumeric: true, IsNullable: true},
+			expectedNumeric:  true,
+			expectedNullable: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -189,6 +197,10 @@ func TestInfoGenerator_CreateTimeFieldInfo(t *testing.T) {
 				t.Errorf("Expected IsNumeric=%v, got %v", tt.expectedNumeric, info.IsNumeric)
 			}
 
+			if info.IsNullable != tt.expectedNullable {
+				t.Errorf("Expected IsNullable=%v, got %v", tt.expectedNullable, info.IsNullable)
+			}
+
 			if info.Name != baseInfo.Name {
 				t.Errorf("Expected Name=%s, got %s", baseInfo.Name, info.Name)
 			}
@@ -0,0 +1,131 @@
+package field
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm/schema"
+)
+
+// Naming selects how column names are derived from field names that have no column tag
+type Naming string
+
+const (
+	NamingSnake Naming = "snake" // CreatedAt -> created_at, GORM's default
+	NamingCamel Naming = "camel" // CreatedAt -> createdAt
+	NamingNone  Naming = "none"  // CreatedAt -> CreatedAt
+)
+
+// IsValid reports whether n is a known naming; empty means snake
+func (n Naming) IsValid() bool {
+	switch n {
+	case "", NamingSnake, NamingCamel, NamingNone:
+		return true
+	default:
+		return false
+	}
+}
+
+// Namer returns the schema.Namer implementing n. Pass the same namer to gorm.Config.NamingStrategy
+// so that GORM queries the columns the generated code refers to.
+func (n Naming) Namer() schema.Namer {
+	switch n {
+	case NamingCamel:
+		return camelCaseNamer{}
+	case NamingNone:
+		return fieldNameNamer{}
+	default:
+		return schema.NamingStrategy{}
+	}
+}
+
+// camelCaseNamer names columns in lowerCamelCase, splitting words like GORM's snake_case
+type camelCaseNamer struct {
+	schema.NamingStrategy
+}
+
+func (n camelCaseNamer) ColumnName(table, column string) string {
+	words := strings.Split(n.NamingStrategy.ColumnName(table, column), "_")
+	for i := 1; i < len(words); i++ {
+		if words[i] != "" {
+			words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// fieldNameNamer uses field names as column names unchanged
+type fieldNameNamer struct {
+	schema.NamingStrategy
+}
+
+func (fieldNameNamer) ColumnName(_, column string) string {
+	return column
+}
+
+// DefaultAcronyms are the acronyms kept as one word in column names by default
+var DefaultAcronyms = []string{"ID", "URL", "API", "HTTP", "SKU"}
+
+// NewAcronymNamer wraps namer so that each acronym counts as a single word, e.g. with "OAuth",
+// OAuthToken becomes oauth_token instead of o_auth_token. Use the same namer in
+// gorm.Config.NamingStrategy when registering acronyms GORM would split.
+func NewAcronymNamer(namer schema.Namer, acronyms []string) schema.Namer {
+	// Longest first, so that "HTTPS" is tried before "HTTP"
+	sorted := slices.Clone(acronyms)
+	slices.SortStableFunc(sorted, func(a, b string) int { return len(b) - len(a) })
+	return acronymNamer{Namer: namer, acronyms: sorted}
+}
+
+// acronymNamer rewrites acronyms in title case, e.g. OAuth to Oauth, before naming a column
+type acronymNamer struct {
+	schema.Namer
+	acronyms []string
+}
+
+func (n acronymNamer) ColumnName(table, column string) string {
+	return n.Namer.ColumnName(table, joinAcronyms(column, n.acronyms))
+}
+
+// joinAcronyms rewrites the acronyms that form whole words of a Go identifier in title case
+func joinAcronyms(name string, acronyms []string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		acronym := matchAcronym(runes, i, acronyms)
+		if acronym == nil {
+			b.WriteRune(runes[i])
+			i++
+			continue
+		}
+		b.WriteRune(unicode.ToUpper(acronym[0]))
+		b.WriteString(strings.ToLower(string(acronym[1:])))
+		i += len(acronym)
+	}
+	return b.String()
+}
+
+// matchAcronym returns the acronym starting a word at runes[i], or nil. An acronym starts a word
+// after a lowercase letter or digit, and ends one before an uppercase letter starting a new
+// word, a digit, or the end of the identifier.
+func matchAcronym(runes []rune, i int, acronyms []string) []rune {
+	if i > 0 && unicode.IsUpper(runes[i-1]) {
+		return nil
+	}
+
+	for _, acronym := range acronyms {
+		candidate := []rune(acronym)
+		end := i + len(candidate)
+		if len(candidate) == 0 || end > len(runes) || string(runes[i:end]) != acronym {
+			continue
+		}
+
+		switch {
+		case end == len(runes), unicode.IsDigit(runes[end]):
+			return candidate
+		case unicode.IsUpper(runes[end]) && end+1 < len(runes) && unicode.IsLower(runes[end+1]):
+			return candidate
+		}
+	}
+	return nil
+}
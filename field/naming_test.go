@@ -0,0 +1,131 @@
+package field
+
+import (
+	"go/types"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestNaming_Namer(t *testing.T) {
+	tests := []struct {
+		naming Naming
+		field  string
+		want   string
+	}{
+		{"", "CreatedAt", "created_at"},
+		{NamingSnake, "CategoryID", "category_id"},
+		{NamingCamel, "CreatedAt", "createdAt"},
+		{NamingCamel, "CategoryID", "categoryId"},
+		{NamingCamel, "ID", "id"},
+		{NamingNone, "CategoryID", "CategoryID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.naming)+" "+tt.field, func(t *testing.T) {
+			if got := tt.naming.Namer().ColumnName("", tt.field); got != tt.want {
+				t.Errorf("ColumnName(%q) = %q, want %q", tt.field, got, tt.want)
+			}
+		})
+	}
+
+	if Naming("kebab").IsValid() {
+		t.Error("unknown naming should be invalid")
+	}
+}
+
+func TestInfoGenerator_SetNamer(t *testing.T) {
+	generator := NewInfoGenerator(types.NewPackage("models", "models"))
+	generator.SetNamer(NamingCamel.Namer())
+
+	info := generator.GenFieldInfo(field{name: "CreatedAt", typ: types.Typ[types.String]})
+	if info.DBName != "createdAt" {
+		t.Errorf("DBName = %q, want createdAt", info.DBName)
+	}
+
+	info = generator.GenFieldInfo(field{name: "CreatedAt", typ: types.Typ[types.String], tag: `gorm:"column:created"`})
+	if info.DBName != "created" {
+		t.Errorf("column tag should win over the namer, got DBName %q", info.DBName)
+	}
+}
+
+func TestInfoGenerator_JSONTagColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		field    string
+		tag      string
+		want     string
+		wantJSON string
+	}{
+		{"acronym", "SKU", `json:"sku"`, "sku", "sku"},
+		{"id", "ID", `json:"id"`, "id", "id"},
+		{"trailing acronym", "ImageURL", `json:"image_url,omitempty"`, "image_url", "image_url"},
+		{"json name differs", "URL", `json:"link"`, "url", "link"},
+		{"mixed acronyms", "APIKeyID", `json:"apiKeyId"`, "api_key_id", "apiKeyId"},
+		{"json skipped", "SKU", `json:"-"`, "sku", "sku"},
+		{"json options only", "SKU", `json:",omitempty"`, "sku", "sku"},
+		{"column tag wins", "SKU", `json:"sku_code" gorm:"column:code"`, "code", "code"},
+	}
+
+	snake := NewInfoGenerator(types.NewPackage("models", "models"))
+	withJSON := NewInfoGenerator(types.NewPackage("models", "models"))
+	withJSON.SetJSONTagColumns(true)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := field{name: tt.field, typ: types.Typ[types.String], tag: reflect.StructTag(tt.tag)}
+			if got := snake.GenFieldInfo(f).DBName; got != tt.want {
+				t.Errorf("DBName = %q, want %q", got, tt.want)
+			}
+			if got := withJSON.GenFieldInfo(f).DBName; got != tt.wantJSON {
+				t.Errorf("DBName with json tag columns = %q, want %q", got, tt.wantJSON)
+			}
+		})
+	}
+}
+
+func TestInfoGenerator_Acronyms(t *testing.T) {
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"SKU", "sku"},
+		{"ProductSKU", "product_sku"},
+		{"SKUCode", "sku_code"},
+		{"HTTPStatus", "http_status"},
+		{"MyHTTPServerURL", "my_http_server_url"},
+		{"UserIDs", "user_ids"},
+		{"APIKeyID", "api_key_id"},
+		{"HTTPS", "https"},
+		{"HTTPSPort", "https_port"},
+		{"OAuthToken", "oauth_token"},
+		{"UserOAuth", "user_oauth"},
+		{"GraphQLQuery", "graphql_query"},
+		{"OAuth2Token", "oauth2_token"},
+	}
+
+	generator := NewInfoGenerator(types.NewPackage("models", "models"))
+	generator.SetAcronyms(append(slices.Clone(DefaultAcronyms), "OAuth", "GraphQL", "HTTPS"))
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			info := generator.GenFieldInfo(field{name: tt.field, typ: types.Typ[types.String]})
+			if info.DBName != tt.want {
+				t.Errorf("DBName = %q, want %q", info.DBName, tt.want)
+			}
+		})
+	}
+
+	camel := NewInfoGenerator(types.NewPackage("models", "models"))
+	camel.SetNamer(NamingCamel.Namer())
+	camel.SetAcronyms([]string{"OAuth"})
+	if got := camel.GenFieldInfo(field{name: "OAuthToken", typ: types.Typ[types.String]}).DBName; got != "oauthToken" {
+		t.Errorf("camel DBName = %q, want oauthToken", got)
+	}
+
+	none := NewInfoGenerator(types.NewPackage("models", "models"))
+	none.SetNamer(NamingNone.Namer())
+	if got := none.GenFieldInfo(field{name: "ProductSKU", typ: types.Typ[types.String]}).DBName; got != "ProductSKU" {
+		t.Errorf("verbatim DBName = %q, want ProductSKU", got)
+	}
+}
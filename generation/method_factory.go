@@ -2,16 +2,51 @@ package generation
 
 import (
 	"fmt"
+	"go/token"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
+	"text/template"
 
 	"github.com/dchlong/querybuilder/domain"
 	"github.com/dchlong/querybuilder/repository"
 )
 
+// OperatorKind is the parameter list of the filter methods of a registered operator
+type OperatorKind int
+
+// Parameter lists of registered operators
+const (
+	OperatorKindBinary   OperatorKind = iota // One value of the field's type
+	OperatorKindUnary                        // No parameters
+	OperatorKindVariadic                     // Any number of values of the field's type
+	OperatorKindRange                        // Inclusive lower and upper bounds
+)
+
+// CustomOperator is an operator registered with MethodFactory.RegisterOperator
+type CustomOperator struct {
+	Operator repository.Operator
+	Suffix   string // Method name suffix, e.g. "SimilarTo" for NameSimilarTo
+	Kind     OperatorKind
+}
+
+// DefaultMethodNameTemplate names filter methods <Field><Suffix>, e.g. NameEq
+const DefaultMethodNameTemplate = "{{.Field}}{{.Suffix}}"
+
+// MethodNameData is the data of a filter method name template
+type MethodNameData struct {
+	Field  string // Go field name, e.g. "Name"
+	Suffix string // Method suffix of the operator, e.g. "Eq"
+	OpName string // Name of the operator, e.g. "Equal"; the suffix for registered operators and for Contains, StartsWith and EndsWith
+}
+
 // MethodFactory creates methods for querybuilder generation
 type MethodFactory struct {
-	operatorNames  map[repository.Operator]string
-	methodSuffixes map[repository.Operator]string
+	operatorNames   map[repository.Operator]string
+	methodSuffixes  map[repository.Operator]string
+	customOperators map[repository.Operator]OperatorKind
+	methodName      *template.Template // Names filter methods; nil for DefaultMethodNameTemplate
 }
 
 // NewMethodFactory creates a new method factory
@@ -26,10 +61,22 @@ func NewMethodFactory() *MethodFactory {
 			repository.OperatorGreaterThanOrEqual: "OperatorGreaterThanOrEqual",
 			repository.OperatorLike:               "OperatorLike",
 			repository.OperatorNotLike:            "OperatorNotLike",
+			repository.OperatorILike:              "OperatorILike",
+			repository.OperatorRegex:              "OperatorRegex",
+			repository.OperatorFullText:           "OperatorFullText",
 			repository.OperatorIsNull:             "OperatorIsNull",
 			repository.OperatorIsNotNull:          "OperatorIsNotNull",
 			repository.OperatorIn:                 "OperatorIn",
 			repository.OperatorNotIn:              "OperatorNotIn",
+			repository.OperatorHStoreHasKey:       "OperatorHStoreHasKey",
+			repository.OperatorHStoreGet:          "OperatorHStoreGet",
+			repository.OperatorJSONHasKey:         "OperatorJSONHasKey",
+			repository.OperatorJSONEqual:          "OperatorJSONEqual",
+			repository.OperatorJSONContains:       "OperatorJSONContains",
+			repository.OperatorArrayContains:      "OperatorArrayContains",
+			repository.OperatorArrayOverlaps:      "OperatorArrayOverlaps",
+			repository.OperatorBetween:            "OperatorBetween",
+			repository.OperatorNotBetween:         "OperatorNotBetween",
 		},
 		methodSuffixes: map[repository.Operator]string{
 			repository.OperatorEqual:              "Eq",
@@ -40,20 +87,160 @@ func NewMethodFactory() *MethodFactory {
 			repository.OperatorGreaterThanOrEqual: "Gte",
 			repository.OperatorLike:               "Like",
 			repository.OperatorNotLike:            "NotLike",
+			repository.OperatorILike:              "ILike",
+			repository.OperatorRegex:              "Matches",
+			repository.OperatorFullText:           "Search",
 			repository.OperatorIsNull:             "IsNull",
 			repository.OperatorIsNotNull:          "IsNotNull",
 			repository.OperatorIn:                 "In",
 			repository.OperatorNotIn:              "NotIn",
+			repository.OperatorHStoreHasKey:       "HStoreHasKey",
+			repository.OperatorHStoreGet:          "HStoreGet",
+			repository.OperatorJSONHasKey:         "HasKey",
+			repository.OperatorJSONEqual:          "Equals",
+			repository.OperatorJSONContains:       "Contains",
+			repository.OperatorArrayContains:      "ArrayContains",
+			repository.OperatorArrayOverlaps:      "ArrayOverlaps",
+			repository.OperatorBetween:            "Between",
+			repository.OperatorNotBetween:         "NotBetween",
 		},
+		customOperators: map[repository.Operator]OperatorKind{},
+	}
+}
+
+// RegisterOperator adds an operator outside the built-in set, such as a dialect-specific
+// match. Fields opt in with a querybuilder:"ops=<suffix>" tag and get a <Field><suffix> filter
+// method taking the parameters of kind. Register the operator's SQL with
+// repository.RegisterOperator as well. Panics if op is a built-in operator.
+func (f *MethodFactory) RegisterOperator(op repository.Operator, suffix string, kind OperatorKind) {
+	if _, builtin := f.operatorNames[op]; builtin {
+		panic(fmt.Sprintf("generation: RegisterOperator of built-in operator %s", op))
+	}
+	f.methodSuffixes[op] = suffix
+	f.customOperators[op] = kind
+}
+
+// SetMethodNames overrides the method suffixes of built-in operators and sets the template naming
+// filter methods from MethodNameData, e.g. "Where{{.Field}}{{.OpName}}"; an empty nameTemplate
+// keeps DefaultMethodNameTemplate. Register custom operators first: the names of every operator
+// must be exported identifiers, or nothing changes and ErrInvalidMethodName is returned. Operators
+// may share a name when no field type supports both; the generator reports actual collisions.
+func (f *MethodFactory) SetMethodNames(nameTemplate string, suffixes map[repository.Operator]string) error {
+	for op, suffix := range suffixes {
+		if _, builtin := f.operatorNames[op]; !builtin {
+			return fmt.Errorf("%w: suffix %q of %s, which is not a built-in operator", repository.ErrInvalidMethodName, suffix, op)
+		}
+	}
+
+	candidate := &MethodFactory{
+		operatorNames:   f.operatorNames,
+		methodSuffixes:  maps.Clone(f.methodSuffixes),
+		customOperators: f.customOperators,
+	}
+	maps.Copy(candidate.methodSuffixes, suffixes)
+	if nameTemplate != "" {
+		tmpl, err := template.New("method name").Parse(nameTemplate)
+		if err != nil {
+			return fmt.Errorf("%w: %w", repository.ErrInvalidMethodName, err)
+		}
+		candidate.methodName = tmpl
+	}
+
+	for _, op := range slices.Sorted(maps.Keys(candidate.methodSuffixes)) {
+		name, err := candidate.executeMethodName("Field", op)
+		if err != nil {
+			return fmt.Errorf("%w: %w", repository.ErrInvalidMethodName, err)
+		}
+		if !token.IsIdentifier(name) || !token.IsExported(name) {
+			return fmt.Errorf("%w: %q of %s is not an exported Go identifier", repository.ErrInvalidMethodName, name, op)
+		}
+	}
+	for _, pattern := range patternMethods {
+		name, err := candidate.renderMethodName(MethodNameData{Field: "Field", Suffix: pattern.name, OpName: pattern.name})
+		if err != nil {
+			return fmt.Errorf("%w: %w", repository.ErrInvalidMethodName, err)
+		}
+		if !token.IsIdentifier(name) || !token.IsExported(name) {
+			return fmt.Errorf("%w: %q of %s is not an exported Go identifier", repository.ErrInvalidMethodName, name, pattern.name)
+		}
+	}
+
+	f.methodSuffixes = candidate.methodSuffixes
+	f.methodName = candidate.methodName
+	return nil
+}
+
+// FilterMethodName returns the name of the filter method of op for the field fieldName
+func (f *MethodFactory) FilterMethodName(fieldName string, op repository.Operator) string {
+	name, err := f.executeMethodName(fieldName, op)
+	if err != nil {
+		// SetMethodNames checked the template; only templates branching on the field name get here
+		return fieldName + f.methodSuffixes[op]
+	}
+	return name
+}
+
+// executeMethodName applies the method name template for a field and operator
+func (f *MethodFactory) executeMethodName(fieldName string, op repository.Operator) (string, error) {
+	opName, ok := f.operatorNames[op]
+	if !ok {
+		opName = f.methodSuffixes[op]
+	}
+	return f.renderMethodName(MethodNameData{
+		Field:  fieldName,
+		Suffix: f.methodSuffixes[op],
+		OpName: strings.TrimPrefix(opName, "Operator"),
+	})
+}
+
+// patternMethodName returns the name of a pattern method, e.g. Contains, for the field fieldName
+func (f *MethodFactory) patternMethodName(fieldName, pattern string) string {
+	name, err := f.renderMethodName(MethodNameData{Field: fieldName, Suffix: pattern, OpName: pattern})
+	if err != nil {
+		// SetMethodNames checked the template; only templates branching on the field name get here
+		return fieldName + pattern
+	}
+	return name
+}
+
+// renderMethodName applies the method name template to data
+func (f *MethodFactory) renderMethodName(data MethodNameData) (string, error) {
+	if f.methodName == nil {
+		return data.Field + data.Suffix, nil
+	}
+
+	var name strings.Builder
+	err := f.methodName.Execute(&name, data)
+	return name.String(), err
+}
+
+// RegisteredOperator returns the registered operator whose method suffix is keyword, ignoring case
+func (f *MethodFactory) RegisteredOperator(keyword string) (repository.Operator, bool) {
+	for op := range f.customOperators {
+		if strings.EqualFold(f.methodSuffixes[op], keyword) {
+			return op, true
+		}
 	}
+	return "", false
 }
 
 // CreateFilterMethod creates a filter method for a field and operator
 func (f *MethodFactory) CreateFilterMethod(structName string, field domain.Field, op repository.Operator) domain.Method {
-	methodName := field.Name + f.methodSuffixes[op]
+	method := f.createFilterMethod(structName, field, op)
+	method.Documentation = f.withFieldDoc(method.Documentation, field)
+	return method
+}
+
+// createFilterMethod creates the filter method of the operator's kind
+func (f *MethodFactory) createFilterMethod(structName string, field domain.Field, op repository.Operator) domain.Method {
+	methodName := f.FilterMethodName(field.Name, op)
 	filterTypeName := structName + "Filters"
 	receiverName := strings.ToLower(string(filterTypeName[0]))
 
+	if kind, ok := f.customOperators[op]; ok {
+		return f.createCustomFilterMethod(methodName, filterTypeName, receiverName, structName, field, op, kind)
+	}
+
 	if f.isUnaryOperator(op) {
 		return f.createUnaryFilterMethod(methodName, filterTypeName, receiverName, structName, field, op)
 	}
@@ -62,6 +249,30 @@ func (f *MethodFactory) CreateFilterMethod(structName string, field domain.Field
 		return f.createVariadicFilterMethod(methodName, filterTypeName, receiverName, structName, field, op)
 	}
 
+	if f.isKeyOperator(op) {
+		return f.createKeyFilterMethod(methodName, filterTypeName, receiverName, structName, field, op)
+	}
+
+	if f.isKeyValueOperator(op) {
+		return f.createKeyValueFilterMethod(methodName, filterTypeName, receiverName, structName, field, op)
+	}
+
+	if f.isRangeOperator(op) {
+		return f.createRangeFilterMethod(methodName, filterTypeName, receiverName, structName, field, op)
+	}
+
+	if op == repository.OperatorRegex {
+		return f.createRegexFilterMethod(methodName, filterTypeName, receiverName, structName, field, op)
+	}
+
+	if op == repository.OperatorFullText {
+		return f.createFullTextFilterMethod(methodName, filterTypeName, receiverName, structName, field, op)
+	}
+
+	if op == repository.OperatorJSONContains {
+		return f.createElementFilterMethod(methodName, filterTypeName, receiverName, structName, field, op)
+	}
+
 	return f.createBinaryFilterMethod(methodName, filterTypeName, receiverName, structName, field, op)
 }
 
@@ -70,21 +281,11 @@ func (f *MethodFactory) createBinaryFilterMethod(methodName, filterTypeName, rec
 	paramName := f.fieldNameToParamName(field.Name)
 
 	return domain.Method{
-		Name:       methodName,
-		Receiver:   fmt.Sprintf("%s *%s", receiverName, filterTypeName),
-		Parameters: fmt.Sprintf("%s %s", paramName, field.TypeName),
-		ReturnType: "*" + filterTypeName,
-		Body: fmt.Sprintf(`%s.filters[%sDBSchema.%s] = append(%s.filters[%sDBSchema.%s], 
-	&repository.Filter{
-		Field:    string(%sDBSchema.%s),
-		Operator: repository.%s,
-		Value:    %s,
-	})
-return %s`,
-			receiverName, structName, field.Name,
-			receiverName, structName, field.Name,
-			structName, field.Name,
-			f.operatorNames[op], paramName, receiverName),
+		Name:          methodName,
+		Receiver:      fmt.Sprintf("%s *%s", receiverName, filterTypeName),
+		Parameters:    fmt.Sprintf("%s %s", paramName, field.FilterTypeName()),
+		ReturnType:    "*" + filterTypeName,
+		Body:          f.filterBody(receiverName, structName, field, op, paramName),
 		Documentation: fmt.Sprintf("%s filters by %s %s", methodName, field.Name, strings.ToLower(f.methodSuffixes[op])),
 	}
 }
@@ -93,48 +294,339 @@ return %s`,
 func (f *MethodFactory) createVariadicFilterMethod(methodName, filterTypeName, receiverName, structName string, field domain.Field, op repository.Operator) domain.Method {
 	paramName := f.fieldNameToParamName(field.Name) + "s"
 
+	// Empty lists are passed through; the repository turns them into a match-nothing (IN)
+	// or match-everything (NOT IN) condition instead of invalid SQL
+	documentation := fmt.Sprintf("%s filters by %s in list\n// note: empty call matches nothing", methodName, field.Name)
+	if op == repository.OperatorNotIn {
+		documentation = fmt.Sprintf("%s filters by %s not in list\n// note: empty call matches everything", methodName, field.Name)
+	}
+
 	return domain.Method{
-		Name:       methodName,
-		Receiver:   fmt.Sprintf("%s *%s", receiverName, filterTypeName),
-		Parameters: fmt.Sprintf("%s ...%s", paramName, field.TypeName),
-		ReturnType: "*" + filterTypeName,
-		Body: fmt.Sprintf(`%s.filters[%sDBSchema.%s] = append(%s.filters[%sDBSchema.%s], 
-	&repository.Filter{
-		Field:    string(%sDBSchema.%s),
-		Operator: repository.%s,
-		Value:    %s,
-	})
-return %s`,
-			receiverName, structName, field.Name,
-			receiverName, structName, field.Name,
-			structName, field.Name,
-			f.operatorNames[op], paramName, receiverName),
-		Documentation: fmt.Sprintf("%s filters by %s in list", methodName, field.Name),
+		Name:          methodName,
+		Receiver:      fmt.Sprintf("%s *%s", receiverName, filterTypeName),
+		Parameters:    fmt.Sprintf("%s ...%s", paramName, field.FilterTypeName()),
+		ReturnType:    "*" + filterTypeName,
+		Body:          f.filterBody(receiverName, structName, field, op, paramName),
+		Documentation: documentation,
 	}
 }
 
 // createUnaryFilterMethod creates a method that takes no parameters (for IS NULL/IS NOT NULL)
 func (f *MethodFactory) createUnaryFilterMethod(methodName, filterTypeName, receiverName, structName string, field domain.Field, op repository.Operator) domain.Method {
 	return domain.Method{
-		Name:       methodName,
-		Receiver:   fmt.Sprintf("%s *%s", receiverName, filterTypeName),
-		Parameters: "",
-		ReturnType: "*" + filterTypeName,
-		Body: fmt.Sprintf(`%s.filters[%sDBSchema.%s] = append(%s.filters[%sDBSchema.%s], 
-	&repository.Filter{
-		Field:    string(%sDBSchema.%s),
-		Operator: repository.%s,
-		Value:    nil,
-	})
-return %s`,
-			receiverName, structName, field.Name,
-			receiverName, structName, field.Name,
-			structName, field.Name,
-			f.operatorNames[op], receiverName),
+		Name:          methodName,
+		Receiver:      fmt.Sprintf("%s *%s", receiverName, filterTypeName),
+		Parameters:    "",
+		ReturnType:    "*" + filterTypeName,
+		Body:          f.filterBody(receiverName, structName, field, op, "nil"),
 		Documentation: fmt.Sprintf("%s filters by %s is null check", methodName, field.Name),
 	}
 }
 
+// createKeyFilterMethod creates a method that takes a key inside the column (for hstore and JSON key checks)
+func (f *MethodFactory) createKeyFilterMethod(methodName, filterTypeName, receiverName, structName string, field domain.Field, op repository.Operator) domain.Method {
+	documentation := fmt.Sprintf("%s filters by %s containing key", methodName, field.Name)
+	if op == repository.OperatorHStoreHasKey {
+		documentation += " (Postgres only)"
+	}
+
+	return domain.Method{
+		Name:          methodName,
+		Receiver:      fmt.Sprintf("%s *%s", receiverName, filterTypeName),
+		Parameters:    "key string",
+		ReturnType:    "*" + filterTypeName,
+		Body:          f.filterBody(receiverName, structName, field, op, "key"),
+		Documentation: documentation,
+	}
+}
+
+// createKeyValueFilterMethod creates a method that compares the value stored under a key inside the column
+func (f *MethodFactory) createKeyValueFilterMethod(methodName, filterTypeName, receiverName, structName string, field domain.Field, op repository.Operator) domain.Method {
+	documentation := fmt.Sprintf("%s filters by %s value under key equal to value", methodName, field.Name)
+	parameters := "key, value string"
+	if op == repository.OperatorHStoreGet {
+		documentation += " (Postgres only)"
+	} else {
+		documentation += ", compared as a typed JSON value"
+		parameters = "key string, value interface{}"
+	}
+
+	return domain.Method{
+		Name:          methodName,
+		Receiver:      fmt.Sprintf("%s *%s", receiverName, filterTypeName),
+		Parameters:    parameters,
+		ReturnType:    "*" + filterTypeName,
+		Body:          f.filterBody(receiverName, structName, field, op, "repository.KeyValue{Key: key, Value: value}"),
+		Documentation: documentation,
+	}
+}
+
+// createElementFilterMethod creates a method that takes one element of an array column
+func (f *MethodFactory) createElementFilterMethod(methodName, filterTypeName, receiverName, structName string, field domain.Field, op repository.Operator) domain.Method {
+	return domain.Method{
+		Name:          methodName,
+		Receiver:      fmt.Sprintf("%s *%s", receiverName, filterTypeName),
+		Parameters:    fmt.Sprintf("element %s", field.ElemTypeName),
+		ReturnType:    "*" + filterTypeName,
+		Body:          f.filterBody(receiverName, structName, field, op, "element"),
+		Documentation: fmt.Sprintf("%s filters by %s containing element", methodName, field.Name),
+	}
+}
+
+// createRegexFilterMethod creates a method that takes a regular expression
+func (f *MethodFactory) createRegexFilterMethod(methodName, filterTypeName, receiverName, structName string, field domain.Field, op repository.Operator) domain.Method {
+	return domain.Method{
+		Name:          methodName,
+		Receiver:      fmt.Sprintf("%s *%s", receiverName, filterTypeName),
+		Parameters:    "pattern string",
+		ReturnType:    "*" + filterTypeName,
+		Body:          f.filterBody(receiverName, structName, field, op, "pattern"),
+		Documentation: fmt.Sprintf("%s filters by %s matching the regular expression pattern (Postgres and MySQL)", methodName, field.Name),
+	}
+}
+
+// createFullTextFilterMethod creates a method that takes a full-text query, searched with the
+// field's text search configuration if it has one
+func (f *MethodFactory) createFullTextFilterMethod(methodName, filterTypeName, receiverName, structName string, field domain.Field, op repository.Operator) domain.Method {
+	value := "query"
+	if field.FullTextConfig != "" {
+		value = fmt.Sprintf("repository.TextSearch{Config: %q, Query: query}", field.FullTextConfig)
+	}
+
+	return domain.Method{
+		Name:          methodName,
+		Receiver:      fmt.Sprintf("%s *%s", receiverName, filterTypeName),
+		Parameters:    "query string",
+		ReturnType:    "*" + filterTypeName,
+		Body:          f.filterBody(receiverName, structName, field, op, value),
+		Documentation: fmt.Sprintf("%s filters by a full-text search of %s for the words of query (Postgres and MySQL)", methodName, field.Name),
+	}
+}
+
+// createRangeFilterMethod creates a method that takes inclusive lower and upper bounds (for BETWEEN/NOT BETWEEN)
+func (f *MethodFactory) createRangeFilterMethod(methodName, filterTypeName, receiverName, structName string, field domain.Field, op repository.Operator) domain.Method {
+	documentation := fmt.Sprintf("%s filters by %s between lower and upper (inclusive)", methodName, field.Name)
+	if op == repository.OperatorNotBetween {
+		documentation = fmt.Sprintf("%s filters by %s outside the inclusive range lower to upper", methodName, field.Name)
+	}
+
+	return domain.Method{
+		Name:          methodName,
+		Receiver:      fmt.Sprintf("%s *%s", receiverName, filterTypeName),
+		Parameters:    fmt.Sprintf("lower, upper %s", field.FilterTypeName()),
+		ReturnType:    "*" + filterTypeName,
+		Body:          f.filterBody(receiverName, structName, field, op, "repository.Range{Lower: lower, Upper: upper}"),
+		Documentation: documentation,
+	}
+}
+
+// createCustomFilterMethod creates the method of a registered operator with the parameters of its kind
+func (f *MethodFactory) createCustomFilterMethod(methodName, filterTypeName, receiverName, structName string, field domain.Field, op repository.Operator, kind OperatorKind) domain.Method {
+	paramName := f.fieldNameToParamName(field.Name)
+	parameters := fmt.Sprintf("%s %s", paramName, field.FilterTypeName())
+	value := paramName
+
+	switch kind {
+	case OperatorKindUnary:
+		parameters, value = "", "nil"
+	case OperatorKindVariadic:
+		paramName += "s"
+		parameters, value = fmt.Sprintf("%s ...%s", paramName, field.FilterTypeName()), paramName
+	case OperatorKindRange:
+		parameters = fmt.Sprintf("lower, upper %s", field.FilterTypeName())
+		value = "repository.Range{Lower: lower, Upper: upper}"
+	}
+
+	return domain.Method{
+		Name:          methodName,
+		Receiver:      fmt.Sprintf("%s *%s", receiverName, filterTypeName),
+		Parameters:    parameters,
+		ReturnType:    "*" + filterTypeName,
+		Body:          f.filterBody(receiverName, structName, field, op, value),
+		Documentation: fmt.Sprintf("%s filters by %s %s", methodName, field.Name, strings.ToLower(f.methodSuffixes[op])),
+	}
+}
+
+// CreateClearFilterMethod creates Clear<Field>, which removes the filters added for a field
+func (f *MethodFactory) CreateClearFilterMethod(structName string, field domain.Field) domain.Method {
+	methodName := "Clear" + field.Name
+	filterTypeName := structName + "Filters"
+	receiverName := strings.ToLower(string(filterTypeName[0]))
+
+	return domain.Method{
+		Name:          methodName,
+		Receiver:      fmt.Sprintf("%s *%s", receiverName, filterTypeName),
+		ReturnType:    "*" + filterTypeName,
+		Body:          fmt.Sprintf("return %s.clearField(%sDBSchema.%s)", receiverName, structName, field.Name),
+		Documentation: fmt.Sprintf("%s removes the filters on %s, keeping the others in order", methodName, field.Name),
+	}
+}
+
+// filterBody renders the statement that appends a filter for the field and returns the receiver
+func (f *MethodFactory) filterBody(receiverName, structName string, field domain.Field, op repository.Operator, value string) string {
+	return fmt.Sprintf(`return %s.addFilter(%sDBSchema.%s, &repository.Filter{
+	Field:    string(%sDBSchema.%s),
+	Operator: %s,
+	Value:    %s,
+})`,
+		receiverName, structName, field.Name,
+		structName, field.Name,
+		f.operatorExpression(op), value)
+}
+
+// operatorExpression returns the Go expression of op: its repository constant, or a string
+// literal for registered operators, which have none
+func (f *MethodFactory) operatorExpression(op repository.Operator) string {
+	if name, ok := f.operatorNames[op]; ok {
+		return "repository." + name
+	}
+	return strconv.Quote(string(op))
+}
+
+// patternMethods are the LIKE helpers generated for string fields. The input is escaped with
+// repository.EscapeLike and wrapped in the wildcards given by prefix and suffix.
+var patternMethods = []struct{ name, prefix, suffix, doc string }{
+	{"Contains", `"%" + `, ` + "%"`, "contains"},
+	{"StartsWith", "", ` + "%"`, "starts with"},
+	{"EndsWith", `"%" + `, "", "ends with"},
+}
+
+// CreatePatternFilterMethods creates the Contains, StartsWith and EndsWith methods of a string field.
+// They filter with the LIKE operator; wildcards in the input match literally.
+func (f *MethodFactory) CreatePatternFilterMethods(structName string, field domain.Field) []domain.Method {
+	if field.Type != domain.FieldTypeString {
+		return nil
+	}
+
+	filterTypeName := structName + "Filters"
+	receiverName := strings.ToLower(string(filterTypeName[0]))
+	paramName := f.fieldNameToParamName(field.Name)
+
+	// Named string types need a conversion to be escaped
+	input := paramName
+	if field.FilterTypeName() != "string" {
+		input = fmt.Sprintf("string(%s)", paramName)
+	}
+
+	methods := make([]domain.Method, 0, len(patternMethods))
+	for _, pattern := range patternMethods {
+		methodName := f.patternMethodName(field.Name, pattern.name)
+		value := fmt.Sprintf("%srepository.EscapeLike(%s)%s", pattern.prefix, input, pattern.suffix)
+
+		methods = append(methods, domain.Method{
+			Name:          methodName,
+			Receiver:      fmt.Sprintf("%s *%s", receiverName, filterTypeName),
+			Parameters:    fmt.Sprintf("%s %s", paramName, field.FilterTypeName()),
+			ReturnType:    "*" + filterTypeName,
+			Body:          f.filterBody(receiverName, structName, field, repository.OperatorLike, value),
+			Documentation: f.withFieldDoc(fmt.Sprintf("%s filters by %s %s %s; LIKE wildcards in %s match literally", methodName, field.Name, pattern.doc, paramName, paramName), field),
+		})
+	}
+
+	return methods
+}
+
+// CreateValueFilterMethod creates <Field>EqValue for a pointer field, which takes the pointed-to
+// value so that callers need no temporary variable. Returns false for other fields.
+func (f *MethodFactory) CreateValueFilterMethod(structName string, field domain.Field) (domain.Method, bool) {
+	if field.Type != domain.FieldTypePointer {
+		return domain.Method{}, false
+	}
+
+	methodName := f.FilterMethodName(field.Name, repository.OperatorEqual) + "Value"
+	filterTypeName := structName + "Filters"
+	receiverName := strings.ToLower(string(filterTypeName[0]))
+	paramName := f.fieldNameToParamName(field.Name)
+
+	return domain.Method{
+		Name:          methodName,
+		Receiver:      fmt.Sprintf("%s *%s", receiverName, filterTypeName),
+		Parameters:    fmt.Sprintf("%s %s", paramName, strings.TrimPrefix(field.TypeName, "*")),
+		ReturnType:    "*" + filterTypeName,
+		Body:          f.filterBody(receiverName, structName, field, repository.OperatorEqual, paramName),
+		Documentation: f.withFieldDoc(fmt.Sprintf("%s filters by %s equal to %s; rows where %s is NULL never match", methodName, field.Name, paramName, field.Name), field),
+	}, true
+}
+
+// stringParsers maps Go types to the expression parsing the string parameter s into value
+// and the conversion of value to the type. A parser without expression takes s as is.
+var stringParsers = map[string]struct{ parse, convert string }{
+	"string":    {"", "s"},
+	"bool":      {"strconv.ParseBool(s)", "value"},
+	"int":       {"strconv.Atoi(s)", "value"},
+	"int8":      {"strconv.ParseInt(s, 10, 8)", "int8(value)"},
+	"int16":     {"strconv.ParseInt(s, 10, 16)", "int16(value)"},
+	"int32":     {"strconv.ParseInt(s, 10, 32)", "int32(value)"},
+	"int64":     {"strconv.ParseInt(s, 10, 64)", "value"},
+	"uint":      {"strconv.ParseUint(s, 10, 0)", "uint(value)"},
+	"uint8":     {"strconv.ParseUint(s, 10, 8)", "uint8(value)"},
+	"uint16":    {"strconv.ParseUint(s, 10, 16)", "uint16(value)"},
+	"uint32":    {"strconv.ParseUint(s, 10, 32)", "uint32(value)"},
+	"uint64":    {"strconv.ParseUint(s, 10, 64)", "value"},
+	"float32":   {"strconv.ParseFloat(s, 32)", "float32(value)"},
+	"float64":   {"strconv.ParseFloat(s, 64)", "value"},
+	"time.Time": {"time.Parse(time.RFC3339, s)", "value"},
+}
+
+// CreateStringFilterMethod creates a variant of a comparison filter method that takes the value
+// as a string and parses it to the field's type. Parse failures are collected on the filter
+// and reported by its Err method. Returns false if the operator or field type isn't supported.
+func (f *MethodFactory) CreateStringFilterMethod(structName string, field domain.Field, op repository.Operator) (domain.Method, bool) {
+	if f.isUnaryOperator(op) || f.isVariadicOperator(op) || f.isKeyOperator(op) || f.isKeyValueOperator(op) ||
+		f.isRangeOperator(op) || op == repository.OperatorLike || op == repository.OperatorNotLike || op == repository.OperatorILike ||
+		op == repository.OperatorRegex || op == repository.OperatorFullText {
+		return domain.Method{}, false
+	}
+
+	paramType := field.FilterTypeName()
+	typeName := strings.TrimPrefix(paramType, "*")
+	isPointer := typeName != paramType
+	parser, ok := stringParsers[typeName]
+	if !ok {
+		return domain.Method{}, false
+	}
+
+	typedMethodName := f.FilterMethodName(field.Name, op)
+	methodName := typedMethodName + "String"
+	filterTypeName := structName + "Filters"
+	receiverName := strings.ToLower(string(filterTypeName[0]))
+
+	var body strings.Builder
+	if parser.parse != "" {
+		fmt.Fprintf(&body, `value, err := %s
+if err != nil {
+	%s.errs = append(%s.errs, fmt.Errorf("%s: parse %%q as %s: %%w", s, err))
+	return %s
+}
+`, parser.parse, receiverName, receiverName, methodName, typeName, receiverName)
+	}
+
+	argument := parser.convert
+	if isPointer {
+		// Take the address of the parsed value, converting into a variable first if needed
+		if parser.convert != "value" && parser.convert != "s" {
+			fmt.Fprintf(&body, "typed := %s\n", parser.convert)
+			argument = "typed"
+		}
+		argument = "&" + argument
+	}
+	fmt.Fprintf(&body, "return %s.%s(%s)", receiverName, typedMethodName, argument)
+
+	documentation := fmt.Sprintf("%s parses s as %s and calls %s; parse errors are reported by Err", methodName, typeName, typedMethodName)
+	if parser.parse == "" {
+		documentation = fmt.Sprintf("%s calls %s with s", methodName, typedMethodName)
+	}
+
+	return domain.Method{
+		Name:          methodName,
+		Receiver:      fmt.Sprintf("%s *%s", receiverName, filterTypeName),
+		Parameters:    "s string",
+		ReturnType:    "*" + filterTypeName,
+		Body:          body.String(),
+		Documentation: documentation,
+	}, true
+}
+
 // CreateUpdaterMethod creates an updater setter method
 func (f *MethodFactory) CreateUpdaterMethod(structName string, field domain.Field) domain.Method {
 	methodName := "Set" + field.Name
@@ -149,23 +641,176 @@ func (f *MethodFactory) CreateUpdaterMethod(structName string, field domain.Fiel
 		ReturnType: "*" + updaterTypeName,
 		Body: fmt.Sprintf(`%s.fields[string(%sDBSchema.%s)] = %s
 return %s`, receiverName, structName, field.Name, paramName, receiverName),
-		Documentation: fmt.Sprintf("%s sets the %s field for update", methodName, field.Name),
+		Documentation: f.withFieldDoc(fmt.Sprintf("%s sets the %s field for update", methodName, field.Name), field),
+	}
+}
+
+// CreateValueUpdaterMethod creates Set<Field>Value for a pointer field, which takes the pointed-to
+// value and stores its address. Returns false for other fields.
+func (f *MethodFactory) CreateValueUpdaterMethod(structName string, field domain.Field) (domain.Method, bool) {
+	if field.Type != domain.FieldTypePointer {
+		return domain.Method{}, false
+	}
+
+	methodName := "Set" + field.Name + "Value"
+	updaterTypeName := structName + "Updater"
+	receiverName := strings.ToLower(string(updaterTypeName[0]))
+	paramName := f.fieldNameToParamName(field.Name)
+
+	return domain.Method{
+		Name:       methodName,
+		Receiver:   fmt.Sprintf("%s *%s", receiverName, updaterTypeName),
+		Parameters: fmt.Sprintf("%s %s", paramName, strings.TrimPrefix(field.TypeName, "*")),
+		ReturnType: "*" + updaterTypeName,
+		Body: fmt.Sprintf(`%s.fields[string(%sDBSchema.%s)] = &%s
+return %s`, receiverName, structName, field.Name, paramName, receiverName),
+		Documentation: f.withFieldDoc(fmt.Sprintf("%s sets the %s field to %s for update", methodName, field.Name, paramName), field),
+	}, true
+}
+
+// CreateFromStructMethod creates the updater's FromStruct, which stages the values of the given
+// fields of a record through their setters, or of every updatable field but the primary key when
+// none are given. ok is false when the struct has no updatable fields.
+func (f *MethodFactory) CreateFromStructMethod(s domain.Struct) (method domain.Method, ok bool) {
+	fields := s.UpdatableFields()
+	if len(fields) == 0 {
+		return domain.Method{}, false
+	}
+
+	updaterTypeName := s.Name + "Updater"
+	receiverName := strings.ToLower(string(updaterTypeName[0]))
+	schemaFieldType := s.Name + "DBSchemaField"
+
+	isKey := make(map[string]bool)
+	for _, key := range s.PrimaryKeyFields() {
+		isKey[key.Name] = true
+	}
+	columns := make([]string, 0, len(fields))
+	var defaults []string
+	for _, field := range fields {
+		column := fmt.Sprintf("%sDBSchema.%s", s.Name, field.Name)
+		columns = append(columns, column)
+		if !isKey[field.Name] {
+			defaults = append(defaults, column)
+		}
 	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "if len(fields) == 0 {\nfields = []%s{%s}\n}\n", schemaFieldType, strings.Join(defaults, ", "))
+	body.WriteString("for _, field := range fields {\nswitch field {\n")
+	for i, field := range fields {
+		fmt.Fprintf(&body, "case %s:\n%s.Set%s(record.%s)\n", columns[i], receiverName, field.Name, field.Name)
+	}
+	fmt.Fprintf(&body, "}\n}\nreturn %s", receiverName)
+
+	return domain.Method{
+		Name:          "FromStruct",
+		Receiver:      fmt.Sprintf("%s *%s", receiverName, updaterTypeName),
+		Parameters:    fmt.Sprintf("record *%s, fields ...%s", s.EntityTypeName(), schemaFieldType),
+		ReturnType:    "*" + updaterTypeName,
+		Body:          body.String(),
+		Documentation: "FromStruct stages the given fields of record, zero values included, or every field with a setter but the primary key when none are given; other fields are ignored",
+	}, true
+}
+
+// CreateValidateMethod creates the updater's Validate, which checks staged values against the
+// constraints of the struct's updatable fields and returns every violation joined. A nil pointer
+// is not checked. ok is false when no updatable field has constraints.
+func (f *MethodFactory) CreateValidateMethod(s domain.Struct) (method domain.Method, ok bool) {
+	updaterTypeName := s.Name + "Updater"
+	receiverName := strings.ToLower(string(updaterTypeName[0]))
+
+	var body strings.Builder
+	body.WriteString("var errs []error\n")
+	for _, field := range s.UpdatableFields() {
+		if len(field.Constraints) == 0 {
+			continue
+		}
+		ok = true
+
+		key := fmt.Sprintf("%s.fields[string(%sDBSchema.%s)]", receiverName, s.Name, field.Name)
+		if pointedType, isPointer := strings.CutPrefix(field.TypeName, "*"); isPointer {
+			fmt.Fprintf(&body, "if pointer, ok := %s.(*%s); ok && pointer != nil {\nvalue := *pointer\n", key, pointedType)
+		} else {
+			fmt.Fprintf(&body, "if value, ok := %s.(%s); ok {\n", key, field.TypeName)
+		}
+		for _, constraint := range field.Constraints {
+			condition, violation := constraintCheck(constraint, field)
+			fmt.Fprintf(&body, "if %s {\nerrs = append(errs, %s)\n}\n", condition, violation)
+		}
+		body.WriteString("}\n")
+	}
+	if !ok {
+		return domain.Method{}, false
+	}
+	body.WriteString("return errors.Join(errs...)")
+
+	return domain.Method{
+		Name:          "Validate",
+		Receiver:      fmt.Sprintf("%s *%s", receiverName, updaterTypeName),
+		ReturnType:    "error",
+		Body:          body.String(),
+		Documentation: "Validate checks the staged values against the field constraints, returning every violation joined",
+	}, true
 }
 
-// CreateOrderMethod creates an ordering method
-func (f *MethodFactory) CreateOrderMethod(structName string, field domain.Field, ascending bool) domain.Method {
-	direction := "Desc"
-	directionLower := "desc"
-	if ascending {
-		direction = "Asc"
-		directionLower = "asc"
+// constraintCheck returns the condition under which a staged value violates a constraint, and
+// the expression of the error reported for it
+func constraintCheck(constraint domain.Constraint, field domain.Field) (condition, violation string) {
+	fieldName, bound := field.Name, constraint.Value
+
+	// Named string types are converted for utf8.RuneCountInString
+	text := "value"
+	if strings.TrimPrefix(field.TypeName, "*") != "string" {
+		text = "string(value)"
 	}
 
-	methodName := "OrderBy" + field.Name + direction
+	switch constraint.Name {
+	case domain.ConstraintMin:
+		return "value < " + bound, fmt.Sprintf(
+			`fmt.Errorf("%%w: %s must be at least %s, got %%v", repository.ErrConstraintViolation, value)`, fieldName, bound)
+	case domain.ConstraintMax:
+		return "value > " + bound, fmt.Sprintf(
+			`fmt.Errorf("%%w: %s must be at most %s, got %%v", repository.ErrConstraintViolation, value)`, fieldName, bound)
+	case domain.ConstraintMinLen:
+		return "n := utf8.RuneCountInString(" + text + "); n < " + bound, fmt.Sprintf(
+			`fmt.Errorf("%%w: %s must be at least %s characters long, got %%d", repository.ErrConstraintViolation, n)`, fieldName, bound)
+	case domain.ConstraintMaxLen:
+		return "n := utf8.RuneCountInString(" + text + "); n > " + bound, fmt.Sprintf(
+			`fmt.Errorf("%%w: %s must be at most %s characters long, got %%d", repository.ErrConstraintViolation, n)`, fieldName, bound)
+	default:
+		return `value == ""`, fmt.Sprintf(
+			`fmt.Errorf("%%w: %s must not be empty", repository.ErrConstraintViolation)`, fieldName)
+	}
+}
+
+// CreateOrderMethod creates an ordering method: OrderBy<Field>Asc or OrderBy<Field>Desc for a fixed
+// direction, or OrderBy<Field>(dir repository.SortDirection) when direction is empty
+func (f *MethodFactory) CreateOrderMethod(structName string, field domain.Field, direction repository.SortDirection) domain.Method {
 	optionsTypeName := structName + "Options"
 	receiverName := strings.ToLower(string(optionsTypeName[0]))
 
+	if direction == "" {
+		methodName := "OrderBy" + field.Name
+		return domain.Method{
+			Name:       methodName,
+			Receiver:   fmt.Sprintf("%s *%s", receiverName, optionsTypeName),
+			Parameters: "dir repository.SortDirection",
+			ReturnType: "*" + optionsTypeName,
+			Body: fmt.Sprintf(`%s.options = append(%s.options, func(options *repository.Options) {
+	options.SortFields = append(options.SortFields, &repository.SortField{
+		Field:     string(%sDBSchema.%s),
+		Direction: dir,
+	})
+})
+return %s`, receiverName, receiverName, structName, field.Name, receiverName),
+			Documentation: f.withFieldDoc(fmt.Sprintf("%s orders results by %s in direction dir", methodName, field.Name), field),
+		}
+	}
+
+	directionLower := string(direction)
+	methodName := "OrderBy" + field.Name + strings.ToUpper(directionLower[:1]) + directionLower[1:]
+
 	return domain.Method{
 		Name:       methodName,
 		Receiver:   fmt.Sprintf("%s *%s", receiverName, optionsTypeName),
@@ -178,12 +823,153 @@ func (f *MethodFactory) CreateOrderMethod(structName string, field domain.Field,
 	})
 })
 return %s`, receiverName, receiverName, structName, field.Name, directionLower, receiverName),
-		Documentation: fmt.Sprintf("%s orders results by %s %s", methodName, field.Name, directionLower),
+		Documentation: f.withFieldDoc(fmt.Sprintf("%s orders results by %s %s", methodName, field.Name, directionLower), field),
 	}
 }
 
+// CreateGroupByMethod creates an option method that groups results by a field
+func (f *MethodFactory) CreateGroupByMethod(structName string, field domain.Field) domain.Method {
+	methodName := "GroupBy" + field.Name
+	optionsTypeName := structName + "Options"
+	receiverName := strings.ToLower(string(optionsTypeName[0]))
+
+	return domain.Method{
+		Name:       methodName,
+		Receiver:   fmt.Sprintf("%s *%s", receiverName, optionsTypeName),
+		Parameters: "",
+		ReturnType: "*" + optionsTypeName,
+		Body: fmt.Sprintf(`%s.options = append(%s.options, func(options *repository.Options) {
+	options.GroupBy = append(options.GroupBy, string(%sDBSchema.%s))
+})
+return %s`, receiverName, receiverName, structName, field.Name, receiverName),
+		Documentation: f.withFieldDoc(fmt.Sprintf("%s groups results by %s", methodName, field.Name), field),
+	}
+}
+
+// CreatePreloadMethod creates an option method that eager-loads an association
+func (f *MethodFactory) CreatePreloadMethod(structName string, field domain.Field) domain.Method {
+	methodName := "With" + field.Name
+	optionsTypeName := structName + "Options"
+	receiverName := strings.ToLower(string(optionsTypeName[0]))
+
+	return domain.Method{
+		Name:       methodName,
+		Receiver:   fmt.Sprintf("%s *%s", receiverName, optionsTypeName),
+		Parameters: "conditions ...interface{}",
+		ReturnType: "*" + optionsTypeName,
+		Body: fmt.Sprintf(`%s.options = append(%s.options, func(options *repository.Options) {
+	options.Preloads = append(options.Preloads, &repository.Preload{
+		Association: "%s",
+		Conditions:  conditions,
+	})
+})
+return %s`, receiverName, receiverName, field.Name, receiverName),
+		Documentation: f.withFieldDoc(fmt.Sprintf("%s eager-loads the %s association, keeping the records matching the optional GORM conditions", methodName, field.Name), field),
+	}
+}
+
+// CreateFindByPrimaryKeyFunction creates <Struct>FindByPrimaryKey, a function looking a record up
+// by its primary key with one typed parameter per key column. ok is false without a filterable key.
+func (f *MethodFactory) CreateFindByPrimaryKeyFunction(s domain.Struct) (method domain.Method, ok bool) {
+	keys := s.PrimaryKeyFields()
+	if len(keys) == 0 {
+		return domain.Method{}, false
+	}
+
+	entityName := s.EntityTypeName()
+	params := []string{"ctx context.Context", fmt.Sprintf("repo repository.PrimaryKeyFinder[%s]", entityName)}
+	args := []string{"ctx"}
+	for _, key := range keys {
+		if !key.IsFilterable() {
+			return domain.Method{}, false
+		}
+		paramName := f.fieldNameToParamName(key.Name)
+		params = append(params, fmt.Sprintf("%s %s", paramName, key.FilterTypeName()))
+		args = append(args, paramName)
+	}
+
+	methodName := s.Name + "FindByPrimaryKey"
+	return domain.Method{
+		Name:          methodName,
+		Parameters:    strings.Join(params, ", "),
+		ReturnType:    fmt.Sprintf("(*%s, bool, error)", entityName),
+		Body:          fmt.Sprintf("return repo.FindByPrimaryKey(%s)", strings.Join(args, ", ")),
+		Documentation: fmt.Sprintf("%s returns the %s with the given primary key, reporting false when there is none", methodName, entityName),
+	}, true
+}
+
+// CreateFindByUniqueIndexFunction creates <Struct>FindBy<Field>[And<Field>...], a function looking
+// a record up by the columns of a unique index through FindOne. ok is false when a column cannot
+// be compared for equality or the index is the primary key, which has its own lookup.
+func (f *MethodFactory) CreateFindByUniqueIndexFunction(s domain.Struct, index domain.UniqueIndex) (method domain.Method, ok bool) {
+	if len(index.Fields) == 0 {
+		return domain.Method{}, false
+	}
+
+	keys := s.PrimaryKeyFields()
+	isPrimaryKey := len(keys) == len(index.Fields)
+	for i := 0; isPrimaryKey && i < len(keys); i++ {
+		isPrimaryKey = keys[i].Name == index.Fields[i].Name
+	}
+	if isPrimaryKey {
+		return domain.Method{}, false
+	}
+
+	entityName := s.EntityTypeName()
+	filterTypeName := s.Name + "Filters"
+	params := []string{"ctx context.Context", fmt.Sprintf("repo repository.OneFinder[%s, *%s]", entityName, filterTypeName)}
+	names := make([]string, len(index.Fields))
+	filter := "New" + filterTypeName + "()"
+	for i, field := range index.Fields {
+		if !field.IsFilterable() || !slices.Contains(field.SupportedOperators(), repository.OperatorEqual) {
+			return domain.Method{}, false
+		}
+
+		paramName := f.fieldNameToParamName(field.Name)
+		paramType := field.FilterTypeName()
+		filterMethod := f.FilterMethodName(field.Name, repository.OperatorEqual)
+		if field.Type == domain.FieldTypePointer {
+			// Looked up by value; a nil pointer would match rows where the column is NULL
+			paramType = strings.TrimPrefix(paramType, "*")
+			filterMethod += "Value"
+		}
+		params = append(params, fmt.Sprintf("%s %s", paramName, paramType))
+		filter += fmt.Sprintf(".%s(%s)", filterMethod, paramName)
+		names[i] = field.Name
+	}
+
+	methodName := s.Name + "FindBy" + strings.Join(names, "And")
+	return domain.Method{
+		Name:       methodName,
+		Parameters: strings.Join(params, ", "),
+		ReturnType: fmt.Sprintf("(*%s, bool, error)", entityName),
+		Body:       fmt.Sprintf("return repo.FindOne(ctx, %s)", filter),
+		Documentation: fmt.Sprintf("%s returns the %s with the given %s, reporting false when there is none",
+			methodName, entityName, strings.Join(names, " and ")),
+	}, true
+}
+
 // Helper methods
 
+// withFieldDoc appends the doc comment of field to the first line of doc as a sentence, so the
+// generated methods repeat what the struct field documents
+func (f *MethodFactory) withFieldDoc(doc string, field domain.Field) string {
+	text := strings.Join(strings.Fields(field.Doc), " ")
+	if text == "" {
+		return doc
+	}
+	if !strings.ContainsAny(text[len(text)-1:], ".!?") {
+		text += "."
+	}
+
+	first, rest, multiline := strings.Cut(doc, "\n")
+	doc = first + ". " + text
+	if multiline {
+		doc += "\n" + rest
+	}
+	return doc
+}
+
 func (f *MethodFactory) isUnaryOperator(op repository.Operator) bool {
 	return op == repository.OperatorIsNull || op == repository.OperatorIsNotNull
 }
@@ -192,6 +978,18 @@ func (f *MethodFactory) isVariadicOperator(op repository.Operator) bool {
 	return op == repository.OperatorIn || op == repository.OperatorNotIn
 }
 
+func (f *MethodFactory) isKeyOperator(op repository.Operator) bool {
+	return op == repository.OperatorHStoreHasKey || op == repository.OperatorJSONHasKey
+}
+
+func (f *MethodFactory) isKeyValueOperator(op repository.Operator) bool {
+	return op == repository.OperatorHStoreGet || op == repository.OperatorJSONEqual
+}
+
+func (f *MethodFactory) isRangeOperator(op repository.Operator) bool {
+	return op == repository.OperatorBetween || op == repository.OperatorNotBetween
+}
+
 func (f *MethodFactory) fieldNameToParamName(fieldName string) string {
 	if len(fieldName) == 0 {
 		return "value"
@@ -1,6 +1,7 @@
 package generation
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -79,7 +80,7 @@ func TestMethodFactory_CreateFilterMethod_Binary(t *testing.T) {
 
 	// Test method body contains expected elements
 	expectedBodyParts := []string{
-		"p.filters[ProductDBSchema.Name]",
+		"p.addFilter(ProductDBSchema.Name,",
 		"repository.OperatorEqual",
 		"name",
 		"return p",
@@ -121,6 +122,27 @@ func TestMethodFactory_CreateFilterMethod_Variadic(t *testing.T) {
 	if !strings.Contains(method.Body, "iDs") {
 		t.Errorf("Variadic method body should contain parameter name 'iDs'")
 	}
+
+	if !strings.Contains(method.Documentation, "note: empty call matches nothing") {
+		t.Errorf("IN method should document empty calls, got %q", method.Documentation)
+	}
+
+	notIn := factory.CreateFilterMethod("Product", field, repository.OperatorNotIn)
+	if !strings.Contains(notIn.Documentation, "note: empty call matches everything") {
+		t.Errorf("NOT IN method should document empty calls, got %q", notIn.Documentation)
+	}
+
+	// Unsigned values are passed on as they are, never converted to int64
+	for _, typeName := range []string{"uint", "uint8", "uint32", "uint64"} {
+		unsigned := domain.Field{Name: "Code", TypeName: typeName, Type: domain.FieldTypeNumeric}
+		method := factory.CreateFilterMethod("Product", unsigned, repository.OperatorIn)
+		if want := "codes ..." + typeName; method.Parameters != want {
+			t.Errorf("%s IN parameters = %v, want %v", typeName, method.Parameters, want)
+		}
+		if !strings.Contains(method.Body, "Value:    codes,") {
+			t.Errorf("%s IN body should pass the values unchanged:\n%s", typeName, method.Body)
+		}
+	}
 }
 
 func TestMethodFactory_CreateFilterMethod_Unary(t *testing.T) {
@@ -149,6 +171,46 @@ func TestMethodFactory_CreateFilterMethod_Unary(t *testing.T) {
 	}
 }
 
+func TestMethodFactory_FieldDoc(t *testing.T) {
+	factory := NewMethodFactory()
+
+	field := domain.Field{
+		Name:     "Name",
+		TypeName: "string",
+		Type:     domain.FieldTypeString,
+		Doc:      "Name is the display name\nshown in listings",
+	}
+
+	tests := []struct {
+		method   domain.Method
+		expected string
+	}{
+		{factory.CreateFilterMethod("User", field, repository.OperatorEqual),
+			"NameEq filters by Name eq. Name is the display name shown in listings."},
+		{factory.CreateFilterMethod("User", field, repository.OperatorIn),
+			"NameIn filters by Name in list. Name is the display name shown in listings.\n// note: empty call matches nothing"},
+		{factory.CreateUpdaterMethod("User", field),
+			"SetName sets the Name field for update. Name is the display name shown in listings."},
+		{factory.CreateOrderMethod("User", field, repository.Asc),
+			"OrderByNameAsc orders results by Name asc. Name is the display name shown in listings."},
+	}
+	for _, tt := range tests {
+		if tt.method.Documentation != tt.expected {
+			t.Errorf("%s documentation = %q, want %q", tt.method.Name, tt.method.Documentation, tt.expected)
+		}
+	}
+
+	field.Doc = "Is it unique?"
+	if doc := factory.CreateGroupByMethod("User", field).Documentation; doc != "GroupByName groups results by Name. Is it unique?" {
+		t.Errorf("Documentation = %q, want the field doc without an extra period", doc)
+	}
+
+	field.Doc = ""
+	if doc := factory.CreateUpdaterMethod("User", field).Documentation; doc != "SetName sets the Name field for update" {
+		t.Errorf("Documentation = %q, want it unchanged without a field doc", doc)
+	}
+}
+
 func TestMethodFactory_CreateUpdaterMethod(t *testing.T) {
 	factory := NewMethodFactory()
 
@@ -191,6 +253,133 @@ func TestMethodFactory_CreateUpdaterMethod(t *testing.T) {
 	}
 }
 
+func TestMethodFactory_PointerValueMethods(t *testing.T) {
+	factory := NewMethodFactory()
+	field := domain.Field{Name: "UpdatedAt", TypeName: "*time.Time", Type: domain.FieldTypePointer}
+
+	filter, ok := factory.CreateValueFilterMethod("Product", field)
+	if !ok {
+		t.Fatal("CreateValueFilterMethod() ok = false for a pointer field")
+	}
+	if filter.Name != "UpdatedAtEqValue" || filter.Parameters != "updatedAt time.Time" {
+		t.Errorf("filter = %s(%s), want UpdatedAtEqValue(updatedAt time.Time)", filter.Name, filter.Parameters)
+	}
+	if !strings.Contains(filter.Body, "repository.OperatorEqual") || !strings.Contains(filter.Body, "Value:    updatedAt,") {
+		t.Errorf("filter body doesn't compare the value:\n%s", filter.Body)
+	}
+
+	updater, ok := factory.CreateValueUpdaterMethod("Product", field)
+	if !ok {
+		t.Fatal("CreateValueUpdaterMethod() ok = false for a pointer field")
+	}
+	if updater.Name != "SetUpdatedAtValue" || updater.Parameters != "updatedAt time.Time" {
+		t.Errorf("updater = %s(%s), want SetUpdatedAtValue(updatedAt time.Time)", updater.Name, updater.Parameters)
+	}
+	if !strings.Contains(updater.Body, "p.fields[string(ProductDBSchema.UpdatedAt)] = &updatedAt") {
+		t.Errorf("updater body doesn't store the address:\n%s", updater.Body)
+	}
+
+	plain := domain.Field{Name: "Name", TypeName: "string", Type: domain.FieldTypeString}
+	if _, ok := factory.CreateValueFilterMethod("Product", plain); ok {
+		t.Error("CreateValueFilterMethod() ok = true for a non-pointer field")
+	}
+	if _, ok := factory.CreateValueUpdaterMethod("Product", plain); ok {
+		t.Error("CreateValueUpdaterMethod() ok = true for a non-pointer field")
+	}
+}
+
+func TestMethodFactory_CreateValidateMethod(t *testing.T) {
+	factory := NewMethodFactory()
+	s := domain.Struct{
+		Name: "Product",
+		Fields: []domain.Field{
+			{Name: "ID", DBName: "id", TypeName: "int64", Type: domain.FieldTypeNumeric},
+			{Name: "Code", DBName: "code", TypeName: "SKU", Type: domain.FieldTypeString,
+				Constraints: []domain.Constraint{{Name: domain.ConstraintMinLen, Value: "3"}}},
+			{Name: "Stock", DBName: "stock", TypeName: "*int", Type: domain.FieldTypePointer,
+				Constraints: []domain.Constraint{{Name: domain.ConstraintMin, Value: "0"}}},
+		},
+	}
+
+	method, ok := factory.CreateValidateMethod(s)
+	if !ok {
+		t.Fatal("CreateValidateMethod() ok = false for constrained fields")
+	}
+	if method.Name != "Validate" || method.Receiver != "p *ProductUpdater" || method.ReturnType != "error" {
+		t.Errorf("method = (%s) %s() %s, want (p *ProductUpdater) Validate() error", method.Receiver, method.Name, method.ReturnType)
+	}
+	for _, expected := range []string{
+		"if value, ok := p.fields[string(ProductDBSchema.Code)].(SKU); ok {",
+		"if n := utf8.RuneCountInString(string(value)); n < 3 {",
+		"if pointer, ok := p.fields[string(ProductDBSchema.Stock)].(*int); ok && pointer != nil {",
+		`fmt.Errorf("%w: Stock must be at least 0, got %v", repository.ErrConstraintViolation, value)`,
+		"return errors.Join(errs...)",
+	} {
+		if !strings.Contains(method.Body, expected) {
+			t.Errorf("Validate body missing %q:\n%s", expected, method.Body)
+		}
+	}
+
+	s.Fields = s.Fields[:1]
+	if _, ok := factory.CreateValidateMethod(s); ok {
+		t.Error("CreateValidateMethod() ok = true without constraints")
+	}
+}
+
+func TestMethodFactory_CreateFromStructMethod(t *testing.T) {
+	factory := NewMethodFactory()
+	s := domain.Struct{
+		Name: "Product",
+		Fields: []domain.Field{
+			{Name: "ID", DBName: "id", TypeName: "int64", Type: domain.FieldTypeNumeric, PrimaryKey: true},
+			{Name: "Name", DBName: "name", TypeName: "string", Type: domain.FieldTypeString},
+			{Name: "CreatedAt", DBName: "created_at", TypeName: "time.Time", Type: domain.FieldTypeTime, ReadOnly: true},
+		},
+	}
+
+	method, ok := factory.CreateFromStructMethod(s)
+	if !ok {
+		t.Fatal("CreateFromStructMethod() ok = false for updatable fields")
+	}
+	if method.Parameters != "record *Product, fields ...ProductDBSchemaField" {
+		t.Errorf("Parameters = %q", method.Parameters)
+	}
+	for _, expected := range []string{
+		"fields = []ProductDBSchemaField{ProductDBSchema.Name}",
+		"case ProductDBSchema.ID:\np.SetID(record.ID)",
+		"case ProductDBSchema.Name:\np.SetName(record.Name)",
+	} {
+		if !strings.Contains(method.Body, expected) {
+			t.Errorf("FromStruct body missing %q:\n%s", expected, method.Body)
+		}
+	}
+	if strings.Contains(method.Body, "CreatedAt") {
+		t.Errorf("FromStruct stages read-only field:\n%s", method.Body)
+	}
+
+	s.Fields = s.Fields[2:]
+	if _, ok := factory.CreateFromStructMethod(s); ok {
+		t.Error("CreateFromStructMethod() ok = true without updatable fields")
+	}
+}
+
+func TestMethodFactory_CreateOrderMethod_Direction(t *testing.T) {
+	factory := NewMethodFactory()
+	field := domain.Field{Name: "CreatedAt", TypeName: "time.Time", Type: domain.FieldTypeTime}
+
+	method := factory.CreateOrderMethod("Product", field, "")
+
+	if method.Name != "OrderByCreatedAt" {
+		t.Errorf("Order method name = %v, want OrderByCreatedAt", method.Name)
+	}
+	if method.Parameters != "dir repository.SortDirection" {
+		t.Errorf("Order method parameters = %v, want 'dir repository.SortDirection'", method.Parameters)
+	}
+	if !strings.Contains(method.Body, "Direction: dir,") {
+		t.Errorf("Order method body should pass dir through, got:\n%s", method.Body)
+	}
+}
+
 func TestMethodFactory_CreateOrderMethod(t *testing.T) {
 	factory := NewMethodFactory()
 
@@ -201,7 +390,7 @@ func TestMethodFactory_CreateOrderMethod(t *testing.T) {
 	}
 
 	// Test ascending order method
-	ascMethod := factory.CreateOrderMethod("Product", field, true)
+	ascMethod := factory.CreateOrderMethod("Product", field, repository.Asc)
 
 	if ascMethod.Name != "OrderByCreatedAtAsc" {
 		t.Errorf("Ascending order method name = %v, want OrderByCreatedAtAsc", ascMethod.Name)
@@ -212,7 +401,7 @@ func TestMethodFactory_CreateOrderMethod(t *testing.T) {
 	}
 
 	// Test descending order method
-	descMethod := factory.CreateOrderMethod("Product", field, false)
+	descMethod := factory.CreateOrderMethod("Product", field, repository.Desc)
 
 	if descMethod.Name != "OrderByCreatedAtDesc" {
 		t.Errorf("Descending order method name = %v, want OrderByCreatedAtDesc", descMethod.Name)
@@ -396,7 +585,7 @@ func TestMethodFactory_ComplexGenericScenarios(t *testing.T) {
 
 	// Test method contains expected structure
 	expectedParts := []string{
-		"c.filters[ContainerDBSchema.ComplexGeneric]",
+		"c.addFilter(ContainerDBSchema.ComplexGeneric,",
 		"repository.OperatorEqual",
 		"return c",
 	}
@@ -407,3 +596,553 @@ func TestMethodFactory_ComplexGenericScenarios(t *testing.T) {
 		}
 	}
 }
+
+func TestMethodFactory_CreateFilterMethod_HStore(t *testing.T) {
+	factory := NewMethodFactory()
+
+	field := domain.Field{
+		Name:     "Attributes",
+		TypeName: "map[string]string",
+		Type:     domain.FieldTypeHStore,
+	}
+
+	hasKey := factory.CreateFilterMethod("Product", field, repository.OperatorHStoreHasKey)
+	if hasKey.Name != "AttributesHStoreHasKey" {
+		t.Errorf("Method name = %v, want AttributesHStoreHasKey", hasKey.Name)
+	}
+	if hasKey.Parameters != "key string" {
+		t.Errorf("Method parameters = %v, want 'key string'", hasKey.Parameters)
+	}
+	if !strings.Contains(hasKey.Body, "repository.OperatorHStoreHasKey") || !strings.Contains(hasKey.Body, "Value:    key") {
+		t.Errorf("HStoreHasKey body should filter by key\nBody: %s", hasKey.Body)
+	}
+
+	get := factory.CreateFilterMethod("Product", field, repository.OperatorHStoreGet)
+	if get.Name != "AttributesHStoreGet" {
+		t.Errorf("Method name = %v, want AttributesHStoreGet", get.Name)
+	}
+	if get.Parameters != "key, value string" {
+		t.Errorf("Method parameters = %v, want 'key, value string'", get.Parameters)
+	}
+	if !strings.Contains(get.Body, "repository.KeyValue{Key: key, Value: value}") {
+		t.Errorf("HStoreGet body should carry a KeyValue\nBody: %s", get.Body)
+	}
+}
+
+func TestMethodFactory_CreateFilterMethod_JSONEqual(t *testing.T) {
+	factory := NewMethodFactory()
+
+	field := domain.Field{
+		Name:     "Attributes",
+		TypeName: "datatypes.JSON",
+		Type:     domain.FieldTypeJSON,
+	}
+
+	equals := factory.CreateFilterMethod("Product", field, repository.OperatorJSONEqual)
+	if equals.Name != "AttributesEquals" {
+		t.Errorf("Method name = %v, want AttributesEquals", equals.Name)
+	}
+	if equals.Parameters != "key string, value interface{}" {
+		t.Errorf("Method parameters = %v, want 'key string, value interface{}' so booleans and numbers keep their type", equals.Parameters)
+	}
+	if !strings.Contains(equals.Body, "repository.KeyValue{Key: key, Value: value}") {
+		t.Errorf("JSONEqual body should carry a KeyValue\nBody: %s", equals.Body)
+	}
+}
+
+func TestMethodFactory_CreatePreloadMethod(t *testing.T) {
+	factory := NewMethodFactory()
+
+	field := domain.Field{
+		Name:     "Category",
+		TypeName: "Category",
+		Type:     domain.FieldTypeAssociation,
+	}
+
+	method := factory.CreatePreloadMethod("Product", field)
+
+	if method.Name != "WithCategory" {
+		t.Errorf("Preload method name = %v, want WithCategory", method.Name)
+	}
+
+	if method.Receiver != "p *ProductOptions" {
+		t.Errorf("Preload method receiver = %v, want 'p *ProductOptions'", method.Receiver)
+	}
+
+	if method.Parameters != "conditions ...interface{}" {
+		t.Errorf("Preload method parameters = %v, want optional conditions", method.Parameters)
+	}
+
+	for _, part := range []string{"options.Preloads", `Association: "Category"`, "Conditions:  conditions,", "return p"} {
+		if !strings.Contains(method.Body, part) {
+			t.Errorf("Preload method body missing expected part: %s\nBody: %s", part, method.Body)
+		}
+	}
+}
+
+func TestMethodFactory_CreateGroupByMethod(t *testing.T) {
+	factory := NewMethodFactory()
+
+	field := domain.Field{Name: "CategoryID", DBName: "category_id", TypeName: "int64", Type: domain.FieldTypeNumeric}
+
+	method := factory.CreateGroupByMethod("Product", field)
+
+	if method.Name != "GroupByCategoryID" {
+		t.Errorf("Group by method name = %v, want GroupByCategoryID", method.Name)
+	}
+
+	if method.Receiver != "p *ProductOptions" {
+		t.Errorf("Group by method receiver = %v, want 'p *ProductOptions'", method.Receiver)
+	}
+
+	for _, part := range []string{"options.GroupBy = append(options.GroupBy, string(ProductDBSchema.CategoryID))", "return p"} {
+		if !strings.Contains(method.Body, part) {
+			t.Errorf("Group by method body missing expected part: %s\nBody: %s", part, method.Body)
+		}
+	}
+}
+
+func TestMethodFactory_CreateStringFilterMethod(t *testing.T) {
+	factory := NewMethodFactory()
+
+	tests := []struct {
+		name       string
+		field      domain.Field
+		op         repository.Operator
+		wantName   string
+		wantBody   []string
+		unexpected []string
+	}{
+		{
+			name:     "int64 parses with strconv",
+			field:    domain.Field{Name: "ID", TypeName: "int64", Type: domain.FieldTypeNumeric},
+			op:       repository.OperatorEqual,
+			wantName: "IDEqString",
+			wantBody: []string{
+				"value, err := strconv.ParseInt(s, 10, 64)",
+				`p.errs = append(p.errs, fmt.Errorf("IDEqString: parse %q as int64: %w", s, err))`,
+				"return p.IDEq(value)",
+			},
+		},
+		{
+			name:     "int32 converts the parsed value",
+			field:    domain.Field{Name: "Rank", TypeName: "int32", Type: domain.FieldTypeNumeric},
+			op:       repository.OperatorGreaterThan,
+			wantName: "RankGtString",
+			wantBody: []string{"strconv.ParseInt(s, 10, 32)", "return p.RankGt(int32(value))"},
+		},
+		{
+			name:     "pointer time takes the address",
+			field:    domain.Field{Name: "UpdatedAt", TypeName: "*time.Time", Type: domain.FieldTypePointer},
+			op:       repository.OperatorNotEqual,
+			wantName: "UpdatedAtNeString",
+			wantBody: []string{"time.Parse(time.RFC3339, s)", "return p.UpdatedAtNe(&value)"},
+		},
+		{
+			name:     "pointer float32 converts before taking the address",
+			field:    domain.Field{Name: "Weight", TypeName: "*float32", Type: domain.FieldTypePointer},
+			op:       repository.OperatorEqual,
+			wantName: "WeightEqString",
+			wantBody: []string{"typed := float32(value)", "return p.WeightEq(&typed)"},
+		},
+		{
+			name:       "string passes through",
+			field:      domain.Field{Name: "Name", TypeName: "string", Type: domain.FieldTypeString},
+			op:         repository.OperatorEqual,
+			wantName:   "NameEqString",
+			wantBody:   []string{"return p.NameEq(s)"},
+			unexpected: []string{"errs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method, ok := factory.CreateStringFilterMethod("Product", tt.field, tt.op)
+			if !ok {
+				t.Fatalf("CreateStringFilterMethod() should support %s %s", tt.field.TypeName, tt.op)
+			}
+			if method.Name != tt.wantName {
+				t.Errorf("Method name = %v, want %v", method.Name, tt.wantName)
+			}
+			if method.Parameters != "s string" {
+				t.Errorf("Method parameters = %v, want 's string'", method.Parameters)
+			}
+			for _, part := range tt.wantBody {
+				if !strings.Contains(method.Body, part) {
+					t.Errorf("Method body missing %q\nBody: %s", part, method.Body)
+				}
+			}
+			for _, part := range tt.unexpected {
+				if strings.Contains(method.Body, part) {
+					t.Errorf("Method body should not contain %q\nBody: %s", part, method.Body)
+				}
+			}
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		intField := domain.Field{Name: "Stock", TypeName: "int", Type: domain.FieldTypeNumeric}
+		for _, op := range []repository.Operator{repository.OperatorIn, repository.OperatorIsNull, repository.OperatorLike} {
+			if _, ok := factory.CreateStringFilterMethod("Product", intField, op); ok {
+				t.Errorf("Operator %s should not get a string variant", op)
+			}
+		}
+
+		customField := domain.Field{Name: "Status", TypeName: "Status", Type: domain.FieldTypeString}
+		if _, ok := factory.CreateStringFilterMethod("Product", customField, repository.OperatorEqual); ok {
+			t.Error("Named types should not get a string variant")
+		}
+	})
+}
+
+func TestMethodFactory_CreateFilterMethod_Range(t *testing.T) {
+	factory := NewMethodFactory()
+
+	field := domain.Field{
+		Name:     "CreatedAt",
+		TypeName: "time.Time",
+		Type:     domain.FieldTypeTime,
+	}
+
+	method := factory.CreateFilterMethod("Product", field, repository.OperatorBetween)
+	if method.Name != "CreatedAtBetween" {
+		t.Errorf("Method name = %v, want CreatedAtBetween", method.Name)
+	}
+	if method.Parameters != "lower, upper time.Time" {
+		t.Errorf("Range method parameters = %v, want 'lower, upper time.Time'", method.Parameters)
+	}
+	for _, part := range []string{
+		"Operator: repository.OperatorBetween",
+		"Value:    repository.Range{Lower: lower, Upper: upper}",
+	} {
+		if !strings.Contains(method.Body, part) {
+			t.Errorf("Method body missing %q\nBody: %s", part, method.Body)
+		}
+	}
+
+	notBetween := factory.CreateFilterMethod("Product", field, repository.OperatorNotBetween)
+	if notBetween.Name != "CreatedAtNotBetween" || !strings.Contains(notBetween.Body, "repository.OperatorNotBetween") {
+		t.Errorf("Unexpected NOT BETWEEN method %s:\n%s", notBetween.Name, notBetween.Body)
+	}
+}
+
+func TestMethodFactory_CreatePatternFilterMethods(t *testing.T) {
+	factory := NewMethodFactory()
+
+	field := domain.Field{
+		Name:     "Name",
+		TypeName: "string",
+		Type:     domain.FieldTypeString,
+	}
+
+	methods := factory.CreatePatternFilterMethods("Product", field)
+	expected := map[string]string{
+		"NameContains":   `Value:    "%" + repository.EscapeLike(name) + "%"`,
+		"NameStartsWith": `Value:    repository.EscapeLike(name) + "%"`,
+		"NameEndsWith":   `Value:    "%" + repository.EscapeLike(name),`,
+	}
+	if len(methods) != len(expected) {
+		t.Fatalf("Expected %d pattern methods, got %d", len(expected), len(methods))
+	}
+	for _, method := range methods {
+		value, ok := expected[method.Name]
+		if !ok {
+			t.Errorf("Unexpected pattern method %s", method.Name)
+			continue
+		}
+		if method.Parameters != "name string" {
+			t.Errorf("%s parameters = %v, want 'name string'", method.Name, method.Parameters)
+		}
+		for _, part := range []string{"Operator: repository.OperatorLike", value} {
+			if !strings.Contains(method.Body, part) {
+				t.Errorf("%s body missing %q\nBody: %s", method.Name, part, method.Body)
+			}
+		}
+	}
+
+	named := factory.CreatePatternFilterMethods("Product", domain.Field{Name: "Status", TypeName: "Status", Type: domain.FieldTypeString})
+	if len(named) == 0 || !strings.Contains(named[0].Body, "repository.EscapeLike(string(status))") {
+		t.Errorf("Named string types should be converted before escaping: %+v", named)
+	}
+
+	if methods := factory.CreatePatternFilterMethods("Product", domain.Field{Name: "Price", TypeName: "float64", Type: domain.FieldTypeNumeric}); methods != nil {
+		t.Errorf("Non-string fields should not get pattern methods, got %d", len(methods))
+	}
+}
+
+func TestMethodFactory_CreateFindByPrimaryKeyFunction(t *testing.T) {
+	factory := NewMethodFactory()
+
+	t.Run("string key", func(t *testing.T) {
+		s := domain.Struct{
+			Name:       "AccountV1",
+			EntityName: "Account",
+			Fields: []domain.Field{
+				{Name: "ID", DBName: "id", TypeName: "int64", Type: domain.FieldTypeNumeric},
+				{Name: "UUID", DBName: "uuid", TypeName: "string", Type: domain.FieldTypeString, PrimaryKey: true},
+			},
+		}
+
+		method, ok := factory.CreateFindByPrimaryKeyFunction(s)
+		if !ok {
+			t.Fatal("CreateFindByPrimaryKeyFunction returned ok = false")
+		}
+		if method.Name != "AccountV1FindByPrimaryKey" {
+			t.Errorf("Function name = %v, want AccountV1FindByPrimaryKey", method.Name)
+		}
+		if want := "ctx context.Context, repo repository.PrimaryKeyFinder[Account], uUID string"; method.Parameters != want {
+			t.Errorf("Function parameters = %v, want %v", method.Parameters, want)
+		}
+		if method.ReturnType != "(*Account, bool, error)" {
+			t.Errorf("Function return type = %v, want (*Account, bool, error)", method.ReturnType)
+		}
+		if method.Body != "return repo.FindByPrimaryKey(ctx, uUID)" {
+			t.Errorf("Function body = %v", method.Body)
+		}
+	})
+
+	t.Run("composite key", func(t *testing.T) {
+		s := domain.Struct{
+			Name: "Membership",
+			Fields: []domain.Field{
+				{Name: "AccountID", DBName: "account_id", TypeName: "string", Type: domain.FieldTypeString, PrimaryKey: true},
+				{Name: "GroupID", DBName: "group_id", TypeName: "int64", Type: domain.FieldTypeNumeric, PrimaryKey: true},
+			},
+		}
+
+		method, ok := factory.CreateFindByPrimaryKeyFunction(s)
+		if !ok {
+			t.Fatal("CreateFindByPrimaryKeyFunction returned ok = false")
+		}
+		if method.Body != "return repo.FindByPrimaryKey(ctx, accountID, groupID)" {
+			t.Errorf("Function body = %v", method.Body)
+		}
+	})
+
+	t.Run("no key", func(t *testing.T) {
+		s := domain.Struct{
+			Name:   "Event",
+			Fields: []domain.Field{{Name: "Name", DBName: "name", TypeName: "string", Type: domain.FieldTypeString}},
+		}
+
+		if _, ok := factory.CreateFindByPrimaryKeyFunction(s); ok {
+			t.Error("CreateFindByPrimaryKeyFunction returned ok = true for a struct without a primary key")
+		}
+	})
+}
+
+func TestMethodFactory_CreateFindByUniqueIndexFunction(t *testing.T) {
+	factory := NewMethodFactory()
+
+	id := domain.Field{Name: "ID", DBName: "id", TypeName: "int64", Type: domain.FieldTypeNumeric}
+	sku := domain.Field{Name: "SKU", DBName: "sku", TypeName: "string", Type: domain.FieldTypeString}
+	categoryID := domain.Field{Name: "CategoryID", DBName: "category_id", TypeName: "int64", Type: domain.FieldTypeNumeric}
+	code := domain.Field{Name: "Code", DBName: "code", TypeName: "*string", Type: domain.FieldTypePointer}
+	s := domain.Struct{Name: "Product", Fields: []domain.Field{id, sku, categoryID, code}}
+
+	t.Run("composite index", func(t *testing.T) {
+		method, ok := factory.CreateFindByUniqueIndexFunction(s, domain.UniqueIndex{Name: "idx", Fields: []domain.Field{categoryID, sku}})
+		if !ok {
+			t.Fatal("CreateFindByUniqueIndexFunction returned ok = false")
+		}
+		if method.Name != "ProductFindByCategoryIDAndSKU" {
+			t.Errorf("Function name = %v, want ProductFindByCategoryIDAndSKU", method.Name)
+		}
+		if want := "ctx context.Context, repo repository.OneFinder[Product, *ProductFilters], categoryID int64, sKU string"; method.Parameters != want {
+			t.Errorf("Function parameters = %v, want %v", method.Parameters, want)
+		}
+		if want := "return repo.FindOne(ctx, NewProductFilters().CategoryIDEq(categoryID).SKUEq(sKU))"; method.Body != want {
+			t.Errorf("Function body = %v, want %v", method.Body, want)
+		}
+	})
+
+	t.Run("pointer column is looked up by value", func(t *testing.T) {
+		method, ok := factory.CreateFindByUniqueIndexFunction(s, domain.UniqueIndex{Fields: []domain.Field{code}})
+		if !ok {
+			t.Fatal("CreateFindByUniqueIndexFunction returned ok = false")
+		}
+		if !strings.HasSuffix(method.Parameters, "code string") || !strings.Contains(method.Body, "CodeEqValue(code)") {
+			t.Errorf("Function = %s(%s) { %s }", method.Name, method.Parameters, method.Body)
+		}
+	})
+
+	t.Run("primary key and non-comparable columns are skipped", func(t *testing.T) {
+		if _, ok := factory.CreateFindByUniqueIndexFunction(s, domain.UniqueIndex{Fields: []domain.Field{id}}); ok {
+			t.Error("CreateFindByUniqueIndexFunction returned ok = true for the primary key")
+		}
+
+		noEq := sku
+		noEq.ExcludedOperators = []repository.Operator{repository.OperatorEqual}
+		if _, ok := factory.CreateFindByUniqueIndexFunction(s, domain.UniqueIndex{Fields: []domain.Field{noEq}}); ok {
+			t.Error("CreateFindByUniqueIndexFunction returned ok = true for a column without Eq")
+		}
+	})
+}
+
+func TestMethodFactory_CreateFilterMethod_JSONContains(t *testing.T) {
+	factory := NewMethodFactory()
+
+	field := domain.Field{Name: "Tags", DBName: "tags", TypeName: "datatypes.JSONSlice[string]", Type: domain.FieldTypeJSONArray, ElemTypeName: "string"}
+
+	method := factory.CreateFilterMethod("Product", field, repository.OperatorJSONContains)
+
+	if method.Name != "TagsContains" {
+		t.Errorf("Method name = %v, want TagsContains", method.Name)
+	}
+	if method.Parameters != "element string" {
+		t.Errorf("Method parameters = %v, want 'element string'", method.Parameters)
+	}
+	for _, part := range []string{"Operator: repository.OperatorJSONContains", "Value:    element"} {
+		if !strings.Contains(method.Body, part) {
+			t.Errorf("Method body missing expected part: %s\nBody: %s", part, method.Body)
+		}
+	}
+}
+
+func TestMethodFactory_RegisterOperator(t *testing.T) {
+	factory := NewMethodFactory()
+	factory.RegisterOperator("SIMILAR_TO", "SimilarTo", OperatorKindBinary)
+	factory.RegisterOperator("ANY_OF", "AnyOf", OperatorKindVariadic)
+	factory.RegisterOperator("EMPTY", "IsEmpty", OperatorKindUnary)
+
+	op, ok := factory.RegisteredOperator("similarto")
+	if !ok || op != "SIMILAR_TO" {
+		t.Fatalf("RegisteredOperator(similarto) = %q, %v", op, ok)
+	}
+	if _, ok := factory.RegisteredOperator("eq"); ok {
+		t.Error("built-in operators are not registered operators")
+	}
+
+	field := domain.Field{Name: "Name", TypeName: "string", Type: domain.FieldTypeString}
+	tests := []struct {
+		op         repository.Operator
+		name       string
+		parameters string
+		value      string
+	}{
+		{"SIMILAR_TO", "NameSimilarTo", "name string", "Value:    name,"},
+		{"ANY_OF", "NameAnyOf", "names ...string", "Value:    names,"},
+		{"EMPTY", "NameIsEmpty", "", "Value:    nil,"},
+	}
+	for _, tt := range tests {
+		method := factory.CreateFilterMethod("User", field, tt.op)
+		if method.Name != tt.name || method.Parameters != tt.parameters {
+			t.Errorf("CreateFilterMethod(%s) = %s(%s), want %s(%s)", tt.op, method.Name, method.Parameters, tt.name, tt.parameters)
+		}
+		if !strings.Contains(method.Body, "Operator: \""+string(tt.op)+"\",") || !strings.Contains(method.Body, tt.value) {
+			t.Errorf("CreateFilterMethod(%s) body:\n%s", tt.op, method.Body)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a built-in operator should panic")
+		}
+	}()
+	factory.RegisterOperator(repository.OperatorEqual, "Same", OperatorKindBinary)
+}
+
+func TestMethodFactory_CreateFilterMethod_Regex(t *testing.T) {
+	factory := NewMethodFactory()
+	field := domain.Field{Name: "Name", TypeName: "string", Type: domain.FieldTypeString}
+
+	method := factory.CreateFilterMethod("User", field, repository.OperatorRegex)
+	if method.Name != "NameMatches" || method.Parameters != "pattern string" {
+		t.Errorf("CreateFilterMethod = %s(%s), want NameMatches(pattern string)", method.Name, method.Parameters)
+	}
+	if !strings.Contains(method.Body, "repository.OperatorRegex") {
+		t.Errorf("body should use OperatorRegex:\n%s", method.Body)
+	}
+
+	// The pattern already is a string
+	if _, ok := factory.CreateStringFilterMethod("User", field, repository.OperatorRegex); ok {
+		t.Error("regex filters need no string variant")
+	}
+}
+
+func TestMethodFactory_CreateFilterMethod_FullText(t *testing.T) {
+	factory := NewMethodFactory()
+	field := domain.Field{Name: "Description", TypeName: "string", Type: domain.FieldTypeString, FullText: true}
+
+	method := factory.CreateFilterMethod("Product", field, repository.OperatorFullText)
+	if method.Name != "DescriptionSearch" || method.Parameters != "query string" {
+		t.Errorf("CreateFilterMethod = %s(%s), want DescriptionSearch(query string)", method.Name, method.Parameters)
+	}
+	if !strings.Contains(method.Body, "Value:    query,") {
+		t.Errorf("body should pass the query as is:\n%s", method.Body)
+	}
+
+	field.FullTextConfig = "english"
+	method = factory.CreateFilterMethod("Product", field, repository.OperatorFullText)
+	if !strings.Contains(method.Body, `repository.TextSearch{Config: "english", Query: query}`) {
+		t.Errorf("body should pass the configuration:\n%s", method.Body)
+	}
+}
+
+func TestMethodFactory_SetMethodNames(t *testing.T) {
+	field := domain.Field{Name: "Age", TypeName: "*int", Type: domain.FieldTypePointer}
+
+	factory := NewMethodFactory()
+	if err := factory.SetMethodNames("", map[repository.Operator]string{repository.OperatorEqual: "Equals"}); err != nil {
+		t.Fatalf("SetMethodNames failed: %v", err)
+	}
+	method := factory.CreateFilterMethod("User", field, repository.OperatorEqual)
+	if method.Name != "AgeEquals" || method.Documentation != "AgeEquals filters by Age equals" {
+		t.Errorf("CreateFilterMethod = %s, %q", method.Name, method.Documentation)
+	}
+	if name := factory.FilterMethodName("Age", repository.OperatorGreaterThan); name != "AgeGt" {
+		t.Errorf("FilterMethodName(gt) = %s, want the default suffix", name)
+	}
+
+	factory = NewMethodFactory()
+	factory.RegisterOperator("SIMILAR_TO", "SimilarTo", OperatorKindBinary)
+	if err := factory.SetMethodNames("Where{{.Field}}{{.OpName}}", nil); err != nil {
+		t.Fatalf("SetMethodNames failed: %v", err)
+	}
+	expected := map[string]string{
+		factory.CreateFilterMethod("User", field, repository.OperatorGreaterThanOrEqual).Name: "WhereAgeGreaterThanOrEqual",
+		factory.CreateFilterMethod("User", field, "SIMILAR_TO").Name:                          "WhereAgeSimilarTo",
+	}
+	for got, want := range expected {
+		if got != want {
+			t.Errorf("method name = %s, want %s", got, want)
+		}
+	}
+	if method, ok := factory.CreateValueFilterMethod("User", field); !ok || method.Name != "WhereAgeEqualValue" {
+		t.Errorf("CreateValueFilterMethod = %s, %v", method.Name, ok)
+	}
+	lookup, ok := factory.CreateFindByUniqueIndexFunction(domain.Struct{Name: "User", Fields: []domain.Field{field}},
+		domain.UniqueIndex{Fields: []domain.Field{field}})
+	if !ok || !strings.Contains(lookup.Body, ".WhereAgeEqualValue(age)") {
+		t.Errorf("CreateFindByUniqueIndexFunction body = %s, want it to call the renamed filter", lookup.Body)
+	}
+	intField := domain.Field{Name: "Age", TypeName: "int", Type: domain.FieldTypeNumeric}
+	if method, ok := factory.CreateStringFilterMethod("User", intField, repository.OperatorLessThan); !ok ||
+		method.Name != "WhereAgeLessThanString" || !strings.Contains(method.Body, "u.WhereAgeLessThan(") {
+		t.Errorf("CreateStringFilterMethod = %s:\n%s", method.Name, method.Body)
+	}
+
+	tests := []struct {
+		name         string
+		nameTemplate string
+		suffixes     map[repository.Operator]string
+	}{
+		{"template syntax", "{{.Field", nil},
+		{"unknown template field", "{{.Field}}{{.Operator}}", nil},
+		{"not an identifier", "{{.Field}}-{{.Suffix}}", nil},
+		{"unexported", "where{{.Field}}{{.Suffix}}", nil},
+		{"empty name", "{{.Suffix}}", map[repository.Operator]string{repository.OperatorIn: ""}},
+		{"registered operator suffix", "", map[repository.Operator]string{"SIMILAR_TO": "Like"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := factory.SetMethodNames(tt.nameTemplate, tt.suffixes)
+			if !errors.Is(err, repository.ErrInvalidMethodName) {
+				t.Errorf("SetMethodNames error = %v, want ErrInvalidMethodName", err)
+			}
+			if name := factory.FilterMethodName("Age", repository.OperatorEqual); name != "WhereAgeEqual" {
+				t.Errorf("a failed SetMethodNames changed the names: %s", name)
+			}
+		})
+	}
+}
@@ -3,29 +3,139 @@ package querybuilder
 import (
 	"context"
 	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm/schema"
 
 	"github.com/dchlong/querybuilder/builder"
 	"github.com/dchlong/querybuilder/domain"
 	"github.com/dchlong/querybuilder/field"
+	"github.com/dchlong/querybuilder/generation"
 	"github.com/dchlong/querybuilder/parser"
 	"github.com/dchlong/querybuilder/repository"
 )
 
+// Options configures optional generation output
+type Options struct {
+	// FacadeOutput is the path of a companion file that re-exports the generated types and
+	// constructors from another package. Used when the generated code lives in an internal
+	// package. Empty disables the facade.
+	FacadeOutput string
+
+	// Package is the package clause of the generated code. When it differs from the package of
+	// the input file, the code is meant for another directory: the input package's types are
+	// qualified (models.Product) and imported. Empty uses the input file's package.
+	Package string
+
+	// FacadePackage is the package clause of the facade file.
+	// Defaults to the name of the facade file's directory.
+	FacadePackage string
+
+	// FilterStorage selects how generated filter types store their conditions:
+	// "map" (default) groups them by field, "slice" keeps them in call order.
+	FilterStorage domain.FilterStorage
+
+	// StringFilters also generates <Method>String filter variants that parse their value from a
+	// string. Parse errors are collected on the filter and returned by its Err method.
+	StringFilters bool
+
+	// EmitJSONSchema also writes a JSON Schema (draft-07) describing the valid filter payloads
+	// of each struct to a .schema.json sibling of the output file.
+	EmitJSONSchema bool
+
+	// BuildTags are written as a //go:build line at the top of every generated file.
+	// Multiple tags are ANDed together.
+	BuildTags []string
+
+	// HeaderComment is written above the generated-code notice, e.g. a license banner.
+	// Lines not already starting with "//" are commented out.
+	HeaderComment string
+
+	// TimeTypes are extra type names (e.g. "mytime.Timestamp") treated like time.Time,
+	// in addition to field.DefaultTimeTypes.
+	TimeTypes []field.TimeTypePattern
+
+	// ExcludeStructs lists annotated structs that are skipped during generation.
+	ExcludeStructs []string
+
+	// Namer derives column names from field names without a gorm:"column:..." tag, e.g.
+	// field.NamingCamel.Namer(). Defaults to GORM's snake_case; use the same namer in gorm.Config.
+	Namer schema.Namer
+
+	// JSONTagColumns uses the json tag name as the column of fields without a column tag,
+	// for structs that only carry json tags
+	JSONTagColumns bool
+
+	// Acronyms are extra words such as "OAuth" kept as one word in derived column names, in
+	// addition to field.DefaultAcronyms. Configure GORM with field.NewAcronymNamer to match.
+	Acronyms []string
+
+	// ArrayTypes are extra named slice types (e.g. "pgtype.TextArray") stored as native Postgres
+	// arrays, in addition to field.DefaultArrayTypes. They get ArrayContains and ArrayOverlaps.
+	ArrayTypes []string
+
+	// NumericTypes are extra named types (e.g. "money.Amount") filtered as numbers, in addition to
+	// field.DefaultNumericTypes. They get the comparison, range and membership filters.
+	NumericTypes []string
+
+	// ScalarTypes are extra named types (e.g. "ulid.ULID") filtered as opaque values, in addition
+	// to field.DefaultScalarTypes. They get Eq, Ne, In and NotIn with the type in the signatures.
+	ScalarTypes []string
+
+	// OrderByDirection generates a single OrderBy<Field>(dir repository.SortDirection) option per
+	// field instead of the OrderBy<Field>Asc and OrderBy<Field>Desc pair.
+	OrderByDirection bool
+
+	// Operators are custom filter operators, generated for fields tagged querybuilder:"ops=<suffix>".
+	// Register their SQL with repository.RegisterOperator in the code using the filters.
+	Operators []generation.CustomOperator
+
+	// MethodSuffixes override the filter method suffixes of built-in operators, keyed by the
+	// operator's keyword, e.g. {"eq": "Equals", "gt": "GreaterThan"} for NameEquals and AgeGreaterThan.
+	MethodSuffixes map[string]string
+
+	// MethodNameTemplate is a text/template naming filter methods from .Field, .Suffix and .OpName,
+	// e.g. "Where{{.Field}}{{.OpName}}" for WhereNameEqual. Defaults to "{{.Field}}{{.Suffix}}".
+	// Names must be exported identifiers that don't collide, or generation fails.
+	MethodNameTemplate string
+}
+
 // Generator provides a clean, readable API for querybuilder generation
 type Generator struct {
 	structsParser *parser.Structs
 	converter     *parser.Converter
 	generator     *builder.Generator
+	options       Options
 }
 
 // NewQueryBuilderGenerator creates a new querybuilder generator
 func NewQueryBuilderGenerator(structsParser *parser.Structs) *Generator {
+	return NewQueryBuilderGeneratorWithOptions(structsParser, Options{})
+}
+
+// NewQueryBuilderGeneratorWithOptions creates a new querybuilder generator with optional output enabled
+func NewQueryBuilderGeneratorWithOptions(structsParser *parser.Structs, options Options) *Generator {
 	fieldInfoGen := field.NewInfoGenerator(nil) // Will be set when parsing
 
 	return &Generator{
 		structsParser: structsParser,
 		converter:     parser.NewConverter(fieldInfoGen),
-		generator:     builder.NewGenerator(),
+		generator: builder.NewGeneratorWithOptions(builder.GenerateOptions{
+			FilterStorage:    options.FilterStorage,
+			StringFilters:    options.StringFilters,
+			BuildTags:        options.BuildTags,
+			HeaderComment:    options.HeaderComment,
+			OrderByDirection: options.OrderByDirection,
+			Operators:        options.Operators,
+
+			MethodSuffixes:     options.MethodSuffixes,
+			MethodNameTemplate: options.MethodNameTemplate,
+		}),
+		options: options,
 	}
 }
 
@@ -42,41 +152,41 @@ func (g *Generator) Generate(ctx context.Context, inputFile, outputFile, suffix
 		return fmt.Errorf("%w %s: %w", repository.ErrParseFile, inputFile, err)
 	}
 
-	// Update field info generator with parsed types
-	fieldInfoGen := field.NewInfoGenerator(parsedFile.Types)
-	g.converter = parser.NewConverter(fieldInfoGen)
-
-	// Convert to domain structs
-	var domainStructs []domain.Struct
-	for _, parsedStruct := range parsedFile.Structs {
-		if !g.converter.ShouldGenerateQueryBuilder(parsedStruct.Doc) {
-			continue
-		}
-
-		// Apply suffix if provided
-		structWithSuffix := parsedStruct
-		if suffix != "" {
-			structWithSuffix.TypeName = parsedStruct.TypeName + suffix
-		}
-
-		domainStruct := g.converter.ConvertStruct(structWithSuffix)
-		domainStruct.PackageName = parsedFile.PackageName
-		domainStructs = append(domainStructs, domainStruct)
+	if g.options.FacadeOutput != "" && g.isExternalPackage(parsedFile) {
+		return repository.ErrFacadeExternalPackage
 	}
 
-	// Check if we have any structs to generate
-	if len(domainStructs) == 0 {
-		return fmt.Errorf("%w in %s", repository.ErrNoAnnotatedStructs, inputFile)
+	domainStructs, err := g.convertStructs(parsedFile, suffix)
+	if err != nil {
+		return fmt.Errorf("%w in %s", err, inputFile)
 	}
 
 	// Generate the code
-	if err := g.generator.GenerateFile(ctx, domainStructs, parsedFile.PackageName, outputFile); err != nil {
+	if err := g.generator.GenerateFile(ctx, domainStructs, g.outputPackage(parsedFile), outputFile); err != nil {
 		return fmt.Errorf("failed to generate querybuilder code: %w", err)
 	}
 
+	if g.options.FacadeOutput != "" {
+		err := g.generator.GenerateFacadeFile(ctx, domainStructs, g.facadePackageName(), parsedFile.PackagePath, g.options.FacadeOutput)
+		if err != nil {
+			return fmt.Errorf("failed to generate querybuilder facade: %w", err)
+		}
+	}
+
+	if g.options.EmitJSONSchema {
+		if err := g.generator.GenerateJSONSchemaFile(domainStructs, JSONSchemaPath(outputFile)); err != nil {
+			return fmt.Errorf("failed to generate querybuilder JSON schema: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// JSONSchemaPath returns the path of the JSON Schema written next to a generated file
+func JSONSchemaPath(outputFile string) string {
+	return strings.TrimSuffix(outputFile, ".go") + ".schema.json"
+}
+
 // GenerateInMemory generates querybuilder code and returns it as bytes
 func (g *Generator) GenerateInMemory(ctx context.Context, inputFile, suffix string) ([]byte, string, error) {
 	// Parse the input file
@@ -85,16 +195,120 @@ func (g *Generator) GenerateInMemory(ctx context.Context, inputFile, suffix stri
 		return nil, "", fmt.Errorf("%w %s: %w", repository.ErrParseFile, inputFile, err)
 	}
 
+	return g.generateInMemory(ctx, parsedFile, inputFile, suffix)
+}
+
+// GenerateSource generates querybuilder code for Go source that is not on disk, e.g. read from
+// stdin. fileName is a synthetic path that places the source in its package directory.
+func (g *Generator) GenerateSource(ctx context.Context, fileName string, src []byte, suffix string) ([]byte, string, error) {
+	if g.structsParser == nil {
+		return nil, "", repository.ErrNilParser
+	}
+
+	parsedFile, err := g.structsParser.ParseSource(ctx, fileName, src)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w %s: %w", repository.ErrParseFile, fileName, err)
+	}
+
+	return g.generateInMemory(ctx, parsedFile, fileName, suffix)
+}
+
+// AnalyzeFile parses a Go source file and returns its annotated structs as the generator sees
+// them, with field types, column names and supported operators, without rendering any code.
+func (g *Generator) AnalyzeFile(ctx context.Context, inputFile string) ([]domain.Struct, error) {
+	if g.structsParser == nil {
+		return nil, repository.ErrNilParser
+	}
+
+	parsedFile, err := g.structsParser.ParseFile(ctx, inputFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", repository.ErrParseFile, inputFile, err)
+	}
+
+	domainStructs, err := g.convertStructs(parsedFile, "")
+	if err != nil {
+		return nil, fmt.Errorf("%w in %s", err, inputFile)
+	}
+
+	return domainStructs, nil
+}
+
+// GenerateStructs generates querybuilder code for structs that were not parsed from Go source,
+// e.g. built with domain.NewStruct, with the generator's output options
+func (g *Generator) GenerateStructs(ctx context.Context, structs []domain.Struct, packageName string) ([]byte, error) {
+	code, err := g.generator.GenerateCode(ctx, structs, packageName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate querybuilder code: %w", err)
+	}
+	return code, nil
+}
+
+// generateInMemory generates the code for an already parsed input file
+func (g *Generator) generateInMemory(ctx context.Context, parsedFile *parser.Result, inputFile, suffix string) ([]byte, string, error) {
+	domainStructs, err := g.convertStructs(parsedFile, suffix)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w in %s", err, inputFile)
+	}
+
+	// Generate the code
+	packageName := g.outputPackage(parsedFile)
+	code, err := g.generator.GenerateCode(ctx, domainStructs, packageName)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate querybuilder code: %w", err)
+	}
+
+	return code, packageName, nil
+}
+
+// outputPackage returns the package clause of the generated code
+func (g *Generator) outputPackage(parsedFile *parser.Result) string {
+	if g.options.Package != "" {
+		return g.options.Package
+	}
+	return parsedFile.PackageName
+}
+
+// isExternalPackage reports whether the code is generated into another package than the input's
+func (g *Generator) isExternalPackage(parsedFile *parser.Result) bool {
+	return g.outputPackage(parsedFile) != parsedFile.PackageName
+}
+
+// convertStructs converts the annotated structs of a parsed file to domain structs
+func (g *Generator) convertStructs(parsedFile *parser.Result, suffix string) ([]domain.Struct, error) {
 	// Update field info generator with parsed types
 	fieldInfoGen := field.NewInfoGenerator(parsedFile.Types)
+	if len(g.options.TimeTypes) > 0 {
+		timeTypes := append(slices.Clone(field.DefaultTimeTypes), g.options.TimeTypes...)
+		fieldInfoGen = field.NewInfoGeneratorWithTimeTypes(parsedFile.Types, timeTypes)
+	}
+	fieldInfoGen.SetNamer(g.options.Namer)
+	fieldInfoGen.SetJSONTagColumns(g.options.JSONTagColumns)
+	fieldInfoGen.SetExternalPackage(g.isExternalPackage(parsedFile))
+	if len(g.options.Acronyms) > 0 {
+		fieldInfoGen.SetAcronyms(append(slices.Clone(field.DefaultAcronyms), g.options.Acronyms...))
+	}
+	if len(g.options.ArrayTypes) > 0 {
+		fieldInfoGen.SetArrayTypes(append(slices.Clone(field.DefaultArrayTypes), g.options.ArrayTypes...))
+	}
+	if len(g.options.NumericTypes) > 0 {
+		fieldInfoGen.SetNumericTypes(append(slices.Clone(field.DefaultNumericTypes), g.options.NumericTypes...))
+	}
+	if len(g.options.ScalarTypes) > 0 {
+		fieldInfoGen.SetScalarTypes(append(slices.Clone(field.DefaultScalarTypes), g.options.ScalarTypes...))
+	}
 	g.converter = parser.NewConverter(fieldInfoGen)
 
-	// Convert to domain structs
 	var domainStructs []domain.Struct
 	for _, parsedStruct := range parsedFile.Structs {
 		if !g.converter.ShouldGenerateQueryBuilder(parsedStruct.Doc) {
 			continue
 		}
+		if slices.Contains(g.options.ExcludeStructs, parsedStruct.TypeName) {
+			continue
+		}
+		for _, warning := range parsedStruct.Warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s: %s\n", parsedStruct.TypeName, warning)
+		}
 
 		// Apply suffix if provided
 		structWithSuffix := parsedStruct
@@ -102,23 +316,37 @@ func (g *Generator) GenerateInMemory(ctx context.Context, inputFile, suffix stri
 			structWithSuffix.TypeName = parsedStruct.TypeName + suffix
 		}
 
-		domainStruct := g.converter.ConvertStruct(structWithSuffix)
+		domainStruct, err := g.converter.ConvertAnnotatedStruct(structWithSuffix)
+		if err != nil {
+			return nil, err
+		}
+		domainStruct.EntityName = parsedStruct.TypeName
 		domainStruct.PackageName = parsedFile.PackageName
+		if g.isExternalPackage(parsedFile) {
+			domainStruct.EntityPkg = parsedFile.PackagePath
+		}
 		domainStructs = append(domainStructs, domainStruct)
 	}
 
 	// Check if we have any structs to generate
 	if len(domainStructs) == 0 {
-		return nil, "", fmt.Errorf("%w in %s", repository.ErrNoAnnotatedStructs, inputFile)
+		return nil, repository.ErrNoAnnotatedStructs
 	}
 
-	// Generate the code
-	code, err := g.generator.GenerateCode(ctx, domainStructs, parsedFile.PackageName)
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to generate querybuilder code: %w", err)
+	return domainStructs, nil
+}
+
+// facadePackageName returns the configured facade package or the name of the facade's directory
+func (g *Generator) facadePackageName() string {
+	if g.options.FacadePackage != "" {
+		return g.options.FacadePackage
 	}
 
-	return code, parsedFile.PackageName, nil
+	absPath, err := filepath.Abs(g.options.FacadeOutput)
+	if err != nil {
+		absPath = g.options.FacadeOutput
+	}
+	return filepath.Base(filepath.Dir(absPath))
 }
 
 // validateInputs validates the input parameters
@@ -132,6 +360,35 @@ func (g *Generator) validateInputs(inputFile, outputFile string) error {
 	if g.structsParser == nil {
 		return repository.ErrNilParser
 	}
+	if g.options.Package != "" && !token.IsIdentifier(g.options.Package) {
+		return fmt.Errorf("%w: %q", repository.ErrInvalidPackageName, g.options.Package)
+	}
+	if g.options.FacadeOutput != "" {
+		return g.validateFacade(outputFile)
+	}
+	return nil
+}
+
+// validateFacade checks that the facade goes to another package under a valid package name
+func (g *Generator) validateFacade(outputFile string) error {
+	facadeDir, err := filepath.Abs(filepath.Dir(g.options.FacadeOutput))
+	if err != nil {
+		return fmt.Errorf("%w for %s: %w", repository.ErrGetAbsPath, g.options.FacadeOutput, err)
+	}
+
+	outputDir, err := filepath.Abs(filepath.Dir(outputFile))
+	if err != nil {
+		return fmt.Errorf("%w for %s: %w", repository.ErrGetAbsPath, outputFile, err)
+	}
+
+	if facadeDir == outputDir {
+		return fmt.Errorf("%w: %s", repository.ErrFacadeSamePackage, g.options.FacadeOutput)
+	}
+
+	if name := g.facadePackageName(); !token.IsIdentifier(name) {
+		return fmt.Errorf("%w: %q", repository.ErrInvalidPackageName, name)
+	}
+
 	return nil
 }
 
@@ -143,6 +400,11 @@ func (g *Generator) GetSupportedFieldTypes() []string {
 		domain.FieldTypeTime.String(),
 		domain.FieldTypeBool.String(),
 		domain.FieldTypePointer.String(),
+		domain.FieldTypeHStore.String(),
+		domain.FieldTypeJSON.String(),
+		domain.FieldTypeJSONArray.String(),
+		domain.FieldTypeArray.String(),
+		domain.FieldTypeScalar.String(),
 	}
 }
 
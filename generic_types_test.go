@@ -253,13 +253,13 @@ func TestGenericTypes_OrderMethods(t *testing.T) {
 	}
 
 	// Test ascending order
-	ascMethod := factory.CreateOrderMethod("Queue", genericField, true)
+	ascMethod := factory.CreateOrderMethod("Queue", genericField, repository.Asc)
 	if ascMethod.Name != "OrderByPriorityAsc" {
 		t.Errorf("Ascending order method name = %v, want OrderByPriorityAsc", ascMethod.Name)
 	}
 
 	// Test descending order
-	descMethod := factory.CreateOrderMethod("Queue", genericField, false)
+	descMethod := factory.CreateOrderMethod("Queue", genericField, repository.Desc)
 	if descMethod.Name != "OrderByPriorityDesc" {
 		t.Errorf("Descending order method name = %v, want OrderByPriorityDesc", descMethod.Name)
 	}
@@ -2,14 +2,21 @@ package querybuilder
 
 import (
 	"context"
+	"errors"
 	"go/parser"
 	"go/token"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
+	"github.com/dchlong/querybuilder/domain"
+	"github.com/dchlong/querybuilder/field"
+	"github.com/dchlong/querybuilder/generation"
 	parserPkg "github.com/dchlong/querybuilder/parser"
+	"github.com/dchlong/querybuilder/repository"
+	"golang.org/x/tools/go/packages"
 )
 
 // Integration tests for the complete querybuilder system
@@ -586,3 +593,1135 @@ type Post struct {
 
 	t.Logf("Generated %d bytes of code for real-world scenario", len(generatedCode))
 }
+
+func TestQueryBuilderGenerator_Associations(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	_ = os.MkdirAll(tempDir, 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	inputFile := filepath.Join(tempDir, "associations.go")
+
+	testGoCode := `package associations
+
+import (
+	"database/sql/driver"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+type Category struct {
+	ID   int64
+	Name string
+}
+
+type Review struct {
+	ID        int64
+	ProductID int64
+}
+
+// Money implements sql.Scanner, so it is a column value rather than an association
+type Money struct {
+	Cents int64
+}
+
+func (m *Money) Scan(value interface{}) error { return nil }
+func (m Money) Value() (driver.Value, error) { return m.Cents, nil }
+
+type Dimensions struct {
+	Width  float64
+	Height float64
+}
+
+//gen:querybuilder
+type Product struct {
+	ID         int64
+	CategoryID int64
+	Category   Category
+	Supplier   *Category
+	Reviews    []*Review
+	Price      Money
+	Size       Dimensions                  ` + "`gorm:\"serializer:json\"`" + `
+	Attributes datatypes.JSONType[Category]
+	CreatedAt  time.Time
+}
+`
+
+	err := os.WriteFile(inputFile, []byte(testGoCode), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create associations test file: %v", err)
+	}
+
+	generator := NewQueryBuilderGenerator(&parserPkg.Structs{})
+	code, _, err := generator.GenerateInMemory(context.Background(), inputFile, "")
+	if err != nil {
+		t.Fatalf("Associations generation failed: %v", err)
+	}
+	codeStr := string(code)
+
+	for _, expected := range []string{
+		"func (p *ProductOptions) WithCategory(conditions ...interface{}) *ProductOptions",
+		"func (p *ProductOptions) WithSupplier(conditions ...interface{}) *ProductOptions",
+		"func (p *ProductOptions) WithReviews(conditions ...interface{}) *ProductOptions",
+		`Association: "Category"`,
+		"SetPrice(price Money)",
+		"SetSize(size Dimensions)",
+	} {
+		if !strings.Contains(codeStr, expected) {
+			t.Errorf("Generated code missing: %s", expected)
+		}
+	}
+
+	for _, unexpected := range []string{
+		"WithPrice",
+		"WithSize",
+		"WithAttributes",
+		"WithCreatedAt",
+		"SetCategory(",
+		"SetReviews(",
+		`ProductDBSchemaField("category")`,
+	} {
+		if strings.Contains(codeStr, unexpected) {
+			t.Errorf("Generated code should not contain: %s", unexpected)
+		}
+	}
+
+	if err := validateGeneratedGoCode(codeStr); err != nil {
+		t.Errorf("Generated associations code is not valid Go: %v", err)
+	}
+}
+
+func TestQueryBuilderGenerator_Facade(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	internalDir := filepath.Join(tempDir, "internal", "models")
+	facadeDir := filepath.Join(tempDir, "facade")
+	_ = os.MkdirAll(internalDir, 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	inputFile := filepath.Join(internalDir, "models.go")
+	outputFile := filepath.Join(internalDir, "models_querybuilder.go")
+	facadeFile := filepath.Join(facadeDir, "models_querybuilder.go")
+
+	testGoCode := `package models
+
+import "time"
+
+//gen:querybuilder
+type Product struct {
+	ID        int64
+	Name      string
+	CreatedAt time.Time
+}
+`
+
+	err := os.WriteFile(inputFile, []byte(testGoCode), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create facade test file: %v", err)
+	}
+
+	generator := NewQueryBuilderGeneratorWithOptions(&parserPkg.Structs{}, Options{FacadeOutput: facadeFile})
+	if err := generator.Generate(context.Background(), inputFile, outputFile, ""); err != nil {
+		t.Fatalf("Facade generation failed: %v", err)
+	}
+
+	facadeCode, err := os.ReadFile(facadeFile)
+	if err != nil {
+		t.Fatalf("Failed to read facade file: %v", err)
+	}
+	if !strings.HasPrefix(string(facadeCode), "// Code generated") {
+		t.Error("Facade should start with the generated code header")
+	}
+
+	// Type-check the facade package against the internal package it re-exports
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedImports | packages.NeedDeps,
+	}, "./"+filepath.ToSlash(facadeDir))
+	if err != nil {
+		t.Fatalf("Failed to load facade package: %v", err)
+	}
+	if len(pkgs) != 1 {
+		t.Fatalf("Expected 1 facade package, got %d", len(pkgs))
+	}
+	for _, pkgErr := range pkgs[0].Errors {
+		t.Errorf("Facade does not compile: %v", pkgErr)
+	}
+
+	if pkgs[0].Name != "facade" {
+		t.Errorf("Expected facade package name 'facade', got %q", pkgs[0].Name)
+	}
+
+	scope := pkgs[0].Types.Scope()
+	for _, name := range []string{
+		"NewProductFilters",
+		"NewProductUpdater",
+		"NewProductOptions",
+		"ProductFilters",
+		"ProductDBSchema",
+		"ProductUpdateWhere",
+		"ProductCountByMonth",
+		"ProductFindByPrimaryKey",
+	} {
+		if obj := scope.Lookup(name); obj == nil || !obj.Exported() {
+			t.Errorf("Facade should export %s", name)
+		}
+	}
+}
+
+func TestQueryBuilderGenerator_FacadeSamePackage(t *testing.T) {
+	generator := NewQueryBuilderGeneratorWithOptions(&parserPkg.Structs{}, Options{
+		FacadeOutput: filepath.Join("testdata", "models_facade.go"),
+	})
+
+	err := generator.Generate(context.Background(), filepath.Join("testdata", "models.go"),
+		filepath.Join("testdata", "models_querybuilder.go"), "")
+	if !errors.Is(err, repository.ErrFacadeSamePackage) {
+		t.Errorf("Expected ErrFacadeSamePackage, got %v", err)
+	}
+}
+
+func TestQueryBuilderGenerator_FilterStorage(t *testing.T) {
+	for _, storage := range []domain.FilterStorage{domain.FilterStorageMap, domain.FilterStorageSlice} {
+		t.Run(string(storage), func(t *testing.T) {
+			tempDir := filepath.Join("testdata", "tmp", string(storage))
+			_ = os.MkdirAll(tempDir, 0755)
+			defer func() {
+				_ = os.RemoveAll(filepath.Join("testdata", "tmp"))
+			}()
+			inputFile := filepath.Join(tempDir, "models.go")
+			outputFile := filepath.Join(tempDir, "models_querybuilder.go")
+
+			testGoCode := `package models
+
+import "time"
+
+//gen:querybuilder
+type Product struct {
+	ID        int64
+	Name      string
+	Price     float64
+	DeletedAt *time.Time
+}
+`
+
+			if err := os.WriteFile(inputFile, []byte(testGoCode), 0644); err != nil {
+				t.Fatalf("Failed to create filter storage test file: %v", err)
+			}
+
+			generator := NewQueryBuilderGeneratorWithOptions(&parserPkg.Structs{}, Options{FilterStorage: storage})
+			if err := generator.Generate(context.Background(), inputFile, outputFile, ""); err != nil {
+				t.Fatalf("Generation with %s storage failed: %v", storage, err)
+			}
+
+			pkgs, err := packages.Load(&packages.Config{
+				Mode: packages.NeedName | packages.NeedTypes | packages.NeedImports | packages.NeedDeps,
+			}, "./"+filepath.ToSlash(tempDir))
+			if err != nil {
+				t.Fatalf("Failed to load generated package: %v", err)
+			}
+			for _, pkgErr := range pkgs[0].Errors {
+				t.Errorf("Generated %s storage code does not compile: %v", storage, pkgErr)
+			}
+		})
+	}
+}
+
+func TestQueryBuilderGenerator_SourceReference(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	_ = os.MkdirAll(tempDir, 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	inputFile := filepath.Join(tempDir, "source.go")
+
+	testGoCode := `package models
+
+type Config struct {
+	Value string
+}
+
+// Product is declared after Config
+//
+//gen:querybuilder
+type Product struct {
+	ID   int64
+	Name string
+}
+`
+
+	if err := os.WriteFile(inputFile, []byte(testGoCode), 0644); err != nil {
+		t.Fatalf("Failed to create source reference test file: %v", err)
+	}
+
+	generator := NewQueryBuilderGenerator(&parserPkg.Structs{})
+	code, _, err := generator.GenerateInMemory(context.Background(), inputFile, "")
+	if err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	codeStr := string(code)
+
+	reference := "// generated from testdata/tmp/source.go:10"
+	for _, typeDoc := range []string{
+		"// concurrently, e.g. by ListFilters. Derive variants from a shared filter with Clone.\n" + reference,
+		"// ProductUpdater provides update capabilities for Product\n" + reference,
+		"// ProductOptions provides query options for Product\n" + reference,
+		"// ProductDBSchemaField represents database field names\n" + reference,
+	} {
+		if !strings.Contains(codeStr, typeDoc) {
+			t.Errorf("Generated code missing source reference:\n%s", typeDoc)
+		}
+	}
+
+	// The reference must point at the struct declaration
+	sourceLines := strings.Split(testGoCode, "\n")
+	if line := sourceLines[10-1]; !strings.HasPrefix(line, "type Product struct") {
+		t.Errorf("Line 10 of the source is %q, not the Product declaration", line)
+	}
+}
+
+func TestQueryBuilderGenerator_ExcludedOperators(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	_ = os.MkdirAll(tempDir, 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	inputFile := filepath.Join(tempDir, "ops.go")
+
+	testGoCode := `package models
+
+//gen:querybuilder ops=-like,-notlike
+type Product struct {
+	ID   int64
+	Name string
+	SKU  string
+}
+
+//gen:querybuilder
+type Category struct {
+	ID   int64
+	Name string
+}
+`
+
+	if err := os.WriteFile(inputFile, []byte(testGoCode), 0644); err != nil {
+		t.Fatalf("Failed to create ops test file: %v", err)
+	}
+
+	generator := NewQueryBuilderGenerator(&parserPkg.Structs{})
+	code, _, err := generator.GenerateInMemory(context.Background(), inputFile, "")
+	if err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	codeStr := string(code)
+
+	for _, unexpected := range []string{"ProductFilters) NameLike(", "ProductFilters) NameNotLike(", "ProductFilters) SKULike("} {
+		if strings.Contains(codeStr, unexpected) {
+			t.Errorf("Generated code should not contain disabled operator method: %s", unexpected)
+		}
+	}
+	for _, expected := range []string{"ProductFilters) NameEq(", "ProductFilters) SKUIn(", "CategoryFilters) NameLike("} {
+		if !strings.Contains(codeStr, expected) {
+			t.Errorf("Generated code missing: %s", expected)
+		}
+	}
+
+	invalidCode := strings.Replace(testGoCode, "ops=-like,-notlike", "ops=-likes", 1)
+	if err := os.WriteFile(inputFile, []byte(invalidCode), 0644); err != nil {
+		t.Fatalf("Failed to update ops test file: %v", err)
+	}
+
+	_, _, err = generator.GenerateInMemory(context.Background(), inputFile, "")
+	if !errors.Is(err, repository.ErrInvalidAnnotation) {
+		t.Errorf("Expected ErrInvalidAnnotation for unknown operator, got %v", err)
+	}
+}
+
+func TestQueryBuilderGenerator_FieldExcludedOperators(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	_ = os.MkdirAll(tempDir, 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	inputFile := filepath.Join(tempDir, "field_ops.go")
+
+	testGoCode := `package models
+
+import "time"
+
+//gen:querybuilder
+type Job struct {
+	ID      int64
+	Timeout time.Duration ` + "`querybuilder:\"ops=-lt|-lte|-gt|-gte|-between|-notbetween\"`" + `
+	Retries int
+}
+`
+
+	if err := os.WriteFile(inputFile, []byte(testGoCode), 0644); err != nil {
+		t.Fatalf("Failed to create field ops test file: %v", err)
+	}
+
+	generator := NewQueryBuilderGenerator(&parserPkg.Structs{})
+	code, _, err := generator.GenerateInMemory(context.Background(), inputFile, "")
+	if err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	codeStr := string(code)
+
+	for _, unexpected := range []string{"JobFilters) TimeoutLt(", "JobFilters) TimeoutGte(", "JobFilters) TimeoutBetween("} {
+		if strings.Contains(codeStr, unexpected) {
+			t.Errorf("Generated code should not contain removed operator method: %s", unexpected)
+		}
+	}
+	for _, expected := range []string{
+		"JobFilters) TimeoutEq(timeout time.Duration)",
+		"JobFilters) TimeoutIn(",
+		"JobUpdater) SetTimeout(timeout time.Duration)",
+		"JobFilters) RetriesLt(",
+	} {
+		if !strings.Contains(codeStr, expected) {
+			t.Errorf("Generated code missing: %s", expected)
+		}
+	}
+
+	invalidCode := strings.Replace(testGoCode, "ops=-lt|", "ops=-less|", 1)
+	if err := os.WriteFile(inputFile, []byte(invalidCode), 0644); err != nil {
+		t.Fatalf("Failed to update field ops test file: %v", err)
+	}
+
+	_, _, err = generator.GenerateInMemory(context.Background(), inputFile, "")
+	if !errors.Is(err, repository.ErrInvalidAnnotation) {
+		t.Errorf("Expected ErrInvalidAnnotation for unknown operator, got %v", err)
+	}
+}
+
+func TestQueryBuilderGenerator_TimeTypesAndExcludedStructs(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	_ = os.MkdirAll(tempDir, 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	inputFile := filepath.Join(tempDir, "config.go")
+
+	testGoCode := `package models
+
+import "time"
+
+type Timestamp struct {
+	time.Time
+}
+
+//gen:querybuilder
+type Event struct {
+	ID        int64
+	CreatedAt Timestamp
+}
+
+//gen:querybuilder
+type LegacyEvent struct {
+	ID int64
+}
+`
+
+	if err := os.WriteFile(inputFile, []byte(testGoCode), 0644); err != nil {
+		t.Fatalf("Failed to create config test file: %v", err)
+	}
+
+	generator := NewQueryBuilderGeneratorWithOptions(&parserPkg.Structs{}, Options{
+		TimeTypes:      []field.TimeTypePattern{{Pattern: "Timestamp", IsNumeric: true}},
+		ExcludeStructs: []string{"LegacyEvent"},
+	})
+	code, _, err := generator.GenerateInMemory(context.Background(), inputFile, "")
+	if err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	codeStr := string(code)
+
+	if !strings.Contains(codeStr, "EventFilters) CreatedAtGt(createdAt Timestamp)") {
+		t.Error("Custom time type should get comparison filters")
+	}
+	if strings.Contains(codeStr, "LegacyEventFilters") {
+		t.Error("Excluded struct should not be generated")
+	}
+
+	generator = NewQueryBuilderGeneratorWithOptions(&parserPkg.Structs{}, Options{
+		ExcludeStructs: []string{"Event", "LegacyEvent"},
+	})
+	if _, _, err := generator.GenerateInMemory(context.Background(), inputFile, ""); !errors.Is(err, repository.ErrNoAnnotatedStructs) {
+		t.Errorf("Expected ErrNoAnnotatedStructs when every struct is excluded, got %v", err)
+	}
+}
+
+func TestQueryBuilderGenerator_GenerateSource(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	_ = os.MkdirAll(tempDir, 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+
+	// The type lives on disk; the struct using it only exists in memory
+	if err := os.WriteFile(filepath.Join(tempDir, "status.go"), []byte("package models\n\ntype Status string\n"), 0644); err != nil {
+		t.Fatalf("Failed to create status file: %v", err)
+	}
+
+	src := []byte(`package models
+
+//gen:querybuilder
+type Ticket struct {
+	ID     int64
+	Status Status
+}
+`)
+
+	generator := NewQueryBuilderGenerator(&parserPkg.Structs{})
+	code, packageName, err := generator.GenerateSource(context.Background(), filepath.Join(tempDir, "stdin.go"), src, "")
+	if err != nil {
+		t.Fatalf("Generation from source failed: %v", err)
+	}
+
+	if packageName != "models" {
+		t.Errorf("Package name = %v, want models", packageName)
+	}
+	if !strings.Contains(string(code), "func (t *TicketFilters) StatusEq(status Status) *TicketFilters") {
+		t.Error("Generated code missing filter for type declared in a sibling file")
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "stdin.go")); !os.IsNotExist(err) {
+		t.Error("Source should not be written to disk")
+	}
+
+	if _, _, err := generator.GenerateSource(context.Background(), filepath.Join(tempDir, "stdin.go"), []byte("package models\n\ntype Ticket struct {"), ""); !errors.Is(err, repository.ErrParseFile) {
+		t.Errorf("Expected ErrParseFile for invalid source, got %v", err)
+	}
+}
+
+func TestQueryBuilderGenerator_NamedStringTypes(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	_ = os.MkdirAll(tempDir, 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	inputFile := filepath.Join(tempDir, "enums.go")
+
+	// schema.DataType is a string type declared in an imported package
+	testGoCode := `package models
+
+import "gorm.io/gorm/schema"
+
+type Status string
+
+//gen:querybuilder
+type Column struct {
+	ID     int64
+	Status Status
+	Type   schema.DataType
+}
+`
+
+	if err := os.WriteFile(inputFile, []byte(testGoCode), 0644); err != nil {
+		t.Fatalf("Failed to create enum test file: %v", err)
+	}
+
+	generator := NewQueryBuilderGenerator(&parserPkg.Structs{})
+	code, _, err := generator.GenerateInMemory(context.Background(), inputFile, "")
+	if err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	codeStr := string(code)
+
+	expected := []string{
+		"func (c *ColumnFilters) StatusEq(status Status) *ColumnFilters",
+		"func (c *ColumnFilters) StatusIn(statuss ...Status) *ColumnFilters",
+		"func (c *ColumnFilters) TypeEq(typeValue schema.DataType) *ColumnFilters",
+		"func (c *ColumnFilters) TypeLike(typeValue schema.DataType) *ColumnFilters",
+		"func (c *ColumnUpdater) SetType(typeValue schema.DataType) *ColumnUpdater",
+		`"gorm.io/gorm/schema"`,
+	}
+	for _, part := range expected {
+		if !strings.Contains(codeStr, part) {
+			t.Errorf("Generated code missing %q", part)
+		}
+	}
+}
+
+func TestQueryBuilderGenerator_EmbeddedStructs(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	_ = os.MkdirAll(tempDir, 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	inputFile := filepath.Join(tempDir, "embedded.go")
+
+	testGoCode := `package models
+
+import "time"
+
+type BaseModel struct {
+	ID        int64
+	CreatedAt time.Time
+}
+
+type Audit struct {
+	By string
+	ID int64
+}
+
+//gen:querybuilder
+type Order struct {
+	BaseModel
+	*Audit ` + "`gorm:\"embeddedPrefix:audit_\"`" + `
+	Total float64
+}
+`
+
+	if err := os.WriteFile(inputFile, []byte(testGoCode), 0644); err != nil {
+		t.Fatalf("Failed to create embedded test file: %v", err)
+	}
+
+	generator := NewQueryBuilderGenerator(&parserPkg.Structs{})
+	code, _, err := generator.GenerateInMemory(context.Background(), inputFile, "")
+	if err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	codeStr := string(code)
+
+	expected := []string{
+		"func (o *OrderFilters) IDEq(iD int64) *OrderFilters",
+		"func (o *OrderFilters) CreatedAtGte(createdAt time.Time) *OrderFilters",
+		"func (o *OrderFilters) ByEq(by string) *OrderFilters",
+		`By:        OrderDBSchemaField("audit_by"),`,
+		`ID:        OrderDBSchemaField("id"),`,
+	}
+	for _, part := range expected {
+		if !strings.Contains(codeStr, part) {
+			t.Errorf("Generated code missing %q", part)
+		}
+	}
+	if strings.Count(codeStr, "func (o *OrderFilters) IDEq(") != 1 {
+		t.Error("Shadowed embedded ID should not generate a second IDEq")
+	}
+}
+
+func TestQueryBuilderGenerator_EmbeddedTag(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	_ = os.MkdirAll(tempDir, 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	inputFile := filepath.Join(tempDir, "address.go")
+
+	testGoCode := `package models
+
+type Address struct {
+	Street string
+	City   string
+}
+
+//gen:querybuilder
+type Customer struct {
+	ID      int64
+	Address Address ` + "`gorm:\"embedded;embeddedPrefix:addr_\"`" + `
+	Home    *Address ` + "`gorm:\"embeddedPrefix:home_\"`" + `
+}
+`
+
+	if err := os.WriteFile(inputFile, []byte(testGoCode), 0644); err != nil {
+		t.Fatalf("Failed to create address test file: %v", err)
+	}
+
+	generator := NewQueryBuilderGenerator(&parserPkg.Structs{})
+	code, _, err := generator.GenerateInMemory(context.Background(), inputFile, "")
+	if err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	codeStr := string(code)
+
+	expected := []string{
+		"func (c *CustomerFilters) StreetEq(street string) *CustomerFilters",
+		"func (c *CustomerFilters) CityIn(citys ...string) *CustomerFilters",
+		"func (c *CustomerUpdater) SetStreet(street string) *CustomerUpdater",
+		`Street: CustomerDBSchemaField("addr_street"),`,
+		`City:   CustomerDBSchemaField("addr_city"),`,
+	}
+	for _, part := range expected {
+		if !strings.Contains(codeStr, part) {
+			t.Errorf("Generated code missing %q", part)
+		}
+	}
+	// Without the embedded tag a struct field is not flattened
+	if strings.Contains(codeStr, "home_") {
+		t.Error("Home is not tagged embedded and should not be flattened")
+	}
+}
+
+func TestQueryBuilderGenerator_QueryBuilderTag(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	_ = os.MkdirAll(tempDir, 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	inputFile := filepath.Join(tempDir, "audit.go")
+
+	testGoCode := `package models
+
+import "time"
+
+//gen:querybuilder readonly=ID
+type Account struct {
+	ID         int64
+	Balance    float64
+	RowVersion int64     ` + "`qb:\"-\"`" + `
+	CreatedAt  time.Time ` + "`querybuilder:\"readonly\"`" + `
+}
+`
+
+	if err := os.WriteFile(inputFile, []byte(testGoCode), 0644); err != nil {
+		t.Fatalf("Failed to create audit test file: %v", err)
+	}
+
+	generator := NewQueryBuilderGenerator(&parserPkg.Structs{})
+	code, _, err := generator.GenerateInMemory(context.Background(), inputFile, "")
+	if err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	codeStr := string(code)
+
+	expected := []string{
+		"func (a *AccountFilters) CreatedAtGte(createdAt time.Time) *AccountFilters",
+		"func (a *AccountOptions) OrderByCreatedAtDesc() *AccountOptions",
+		"func (a *AccountUpdater) SetBalance(balance float64) *AccountUpdater",
+		"func (a *AccountFilters) IDEq(iD int64) *AccountFilters",
+	}
+	for _, part := range expected {
+		if !strings.Contains(codeStr, part) {
+			t.Errorf("Generated code missing %q", part)
+		}
+	}
+	for _, part := range []string{"RowVersion", "row_version", "SetCreatedAt", "SetID"} {
+		if strings.Contains(codeStr, part) {
+			t.Errorf("Generated code should not contain %q", part)
+		}
+	}
+}
+
+func TestQueryBuilderGenerator_NamedBoolTypes(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	_ = os.MkdirAll(tempDir, 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	inputFile := filepath.Join(tempDir, "flags.go")
+	outputFile := filepath.Join(tempDir, "flags_querybuilder.go")
+
+	testGoCode := `package models
+
+type Flag bool
+
+//gen:querybuilder
+type Feature struct {
+	ID      int64
+	Visible Flag
+}
+`
+
+	if err := os.WriteFile(inputFile, []byte(testGoCode), 0644); err != nil {
+		t.Fatalf("Failed to create named bool test file: %v", err)
+	}
+
+	generator := NewQueryBuilderGeneratorWithOptions(&parserPkg.Structs{}, Options{EmitJSONSchema: true})
+	if err := generator.Generate(context.Background(), inputFile, outputFile, ""); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	code, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read generated code: %v", err)
+	}
+	for _, part := range []string{
+		"func (f *FeatureFilters) VisibleEq(visible Flag) *FeatureFilters",
+		"func (f *FeatureUpdater) SetVisible(visible Flag) *FeatureUpdater",
+	} {
+		if !strings.Contains(string(code), part) {
+			t.Errorf("Generated code missing %q", part)
+		}
+	}
+
+	// The schema only types the value when the field is recognized as a bool
+	schema, err := os.ReadFile(JSONSchemaPath(outputFile))
+	if err != nil {
+		t.Fatalf("Failed to read JSON schema: %v", err)
+	}
+	if !strings.Contains(string(schema), `"type": "boolean"`) {
+		t.Errorf("JSON schema should describe Visible as a boolean:\n%s", schema)
+	}
+}
+
+func TestQueryBuilderGenerator_ArrayTypes(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	_ = os.MkdirAll(tempDir, 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	inputFile := filepath.Join(tempDir, "arrays.go")
+	outputFile := filepath.Join(tempDir, "arrays_querybuilder.go")
+
+	testGoCode := `package models
+
+type Tags []string
+
+//gen:querybuilder
+type Post struct {
+	ID     int64
+	Tags   Tags
+	Scores []int64
+}
+`
+
+	if err := os.WriteFile(inputFile, []byte(testGoCode), 0644); err != nil {
+		t.Fatalf("Failed to create array test file: %v", err)
+	}
+
+	generator := NewQueryBuilderGeneratorWithOptions(&parserPkg.Structs{}, Options{ArrayTypes: []string{"Tags"}, EmitJSONSchema: true})
+	if err := generator.Generate(context.Background(), inputFile, outputFile, ""); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	code, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read generated code: %v", err)
+	}
+	for _, part := range []string{
+		"func (p *PostFilters) TagsArrayContains(tags Tags) *PostFilters",
+		"func (p *PostFilters) TagsArrayOverlaps(tags Tags) *PostFilters",
+		"repository.OperatorArrayContains",
+	} {
+		if !strings.Contains(string(code), part) {
+			t.Errorf("Generated code missing %q", part)
+		}
+	}
+	// Plain slices stay update-only
+	if strings.Contains(string(code), "ScoresArrayContains") {
+		t.Error("[]int64 is not a configured array type and should not be filterable")
+	}
+
+	schema, err := os.ReadFile(JSONSchemaPath(outputFile))
+	if err != nil {
+		t.Fatalf("Failed to read JSON schema: %v", err)
+	}
+	if !strings.Contains(string(schema), `"ARRAY_CONTAINS"`) {
+		t.Errorf("JSON schema should list the array operators:\n%s", schema)
+	}
+}
+
+func TestQueryBuilderGenerator_AnalyzeFile(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	_ = os.MkdirAll(tempDir, 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	inputFile := filepath.Join(tempDir, "analyze.go")
+	outputFile := filepath.Join(tempDir, "analyze_querybuilder.go")
+
+	testGoCode := `package models
+
+//gen:querybuilder
+type Account struct {
+	ID    int64
+	Email string ` + "`gorm:\"column:email_address\"`" + `
+	Admin bool
+}
+
+type Plain struct {
+	ID int64
+}
+`
+
+	if err := os.WriteFile(inputFile, []byte(testGoCode), 0644); err != nil {
+		t.Fatalf("Failed to create analyze test file: %v", err)
+	}
+
+	generator := NewQueryBuilderGenerator(&parserPkg.Structs{})
+	structs, err := generator.AnalyzeFile(context.Background(), inputFile)
+	if err != nil {
+		t.Fatalf("AnalyzeFile failed: %v", err)
+	}
+	if len(structs) != 1 || structs[0].Name != "Account" || structs[0].PackageName != "models" {
+		t.Fatalf("Expected the annotated Account struct, got %+v", structs)
+	}
+
+	fields := make(map[string]domain.Field)
+	for _, f := range structs[0].Fields {
+		fields[f.Name] = f
+	}
+	if email := fields["Email"]; email.DBName != "email_address" || email.Type != domain.FieldTypeString {
+		t.Errorf("Email = %+v, want column email_address of type string", email)
+	}
+	ops := fields["Admin"].SupportedOperators()
+	if !slices.Contains(ops, repository.OperatorEqual) || slices.Contains(ops, repository.OperatorLessThan) {
+		t.Errorf("Admin operators = %v, want equality only", ops)
+	}
+
+	if _, err := os.Stat(outputFile); !os.IsNotExist(err) {
+		t.Errorf("AnalyzeFile should not write files, stat error: %v", err)
+	}
+
+	if _, err := NewQueryBuilderGenerator(nil).AnalyzeFile(context.Background(), inputFile); !errors.Is(err, repository.ErrNilParser) {
+		t.Errorf("Expected ErrNilParser, got %v", err)
+	}
+}
+
+func TestQueryBuilderGenerator_ScalarTypes(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	_ = os.MkdirAll(tempDir, 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	inputFile := filepath.Join(tempDir, "scalars.go")
+
+	testGoCode := `package models
+
+import "github.com/google/uuid"
+
+type Token [16]byte
+
+//gen:querybuilder
+type Session struct {
+	ID      uuid.UUID
+	OwnerID *uuid.UUID
+	Token   Token
+}
+`
+
+	if err := os.WriteFile(inputFile, []byte(testGoCode), 0644); err != nil {
+		t.Fatalf("Failed to create scalar test file: %v", err)
+	}
+
+	generator := NewQueryBuilderGeneratorWithOptions(&parserPkg.Structs{}, Options{ScalarTypes: []string{"Token"}})
+	code, _, err := generator.GenerateInMemory(context.Background(), inputFile, "")
+	if err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	codeStr := string(code)
+
+	for _, expected := range []string{
+		"SessionFilters) IDEq(iD uuid.UUID)",
+		"SessionFilters) IDIn(iDs ...uuid.UUID)",
+		"SessionFilters) IDNotIn(",
+		"SessionFilters) OwnerIDIsNull()",
+		"SessionFilters) TokenEq(token Token)",
+		"SessionFilters) TokenIn(",
+		"SessionUpdater) SetID(iD uuid.UUID)",
+		`"github.com/google/uuid"`,
+	} {
+		if !strings.Contains(codeStr, expected) {
+			t.Errorf("Generated code missing: %s", expected)
+		}
+	}
+	for _, unexpected := range []string{"IDLt(", "IDLike(", "IDBetween(", "TokenGt("} {
+		if strings.Contains(codeStr, unexpected) {
+			t.Errorf("Scalar types should only get equality filters, found %s", unexpected)
+		}
+	}
+}
+
+func TestQueryBuilderGenerator_CustomOperators(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	_ = os.MkdirAll(tempDir, 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	inputFile := filepath.Join(tempDir, "operators.go")
+	outputFile := filepath.Join(tempDir, "operators_querybuilder.go")
+
+	testGoCode := `package models
+
+//gen:querybuilder
+type Article struct {
+	ID    int64
+	Title string ` + "`querybuilder:\"ops=similarto|soundslike\"`" + `
+	Body  string
+}
+`
+
+	if err := os.WriteFile(inputFile, []byte(testGoCode), 0644); err != nil {
+		t.Fatalf("Failed to create operator test file: %v", err)
+	}
+
+	options := Options{Operators: []generation.CustomOperator{
+		{Operator: "SIMILAR_TO", Suffix: "SimilarTo", Kind: generation.OperatorKindBinary},
+		{Operator: "SOUNDS_LIKE", Suffix: "SoundsLike", Kind: generation.OperatorKindBinary},
+	}}
+	generator := NewQueryBuilderGeneratorWithOptions(&parserPkg.Structs{}, options)
+	if err := generator.Generate(context.Background(), inputFile, outputFile, ""); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	code, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read generated code: %v", err)
+	}
+	for _, part := range []string{
+		"func (a *ArticleFilters) TitleSimilarTo(title string) *ArticleFilters",
+		"func (a *ArticleFilters) TitleSoundsLike(title string) *ArticleFilters",
+		`Operator: "SIMILAR_TO",`,
+	} {
+		if !strings.Contains(string(code), part) {
+			t.Errorf("Generated code missing %q", part)
+		}
+	}
+	if strings.Contains(string(code), "BodySimilarTo") {
+		t.Error("only tagged fields should get registered operators")
+	}
+
+	// Operators fields opt into must be registered
+	generator = NewQueryBuilderGeneratorWithOptions(&parserPkg.Structs{}, Options{})
+	err = generator.Generate(context.Background(), inputFile, outputFile, "")
+	if !errors.Is(err, repository.ErrUnknownOperator) {
+		t.Errorf("Generate() error = %v, want ErrUnknownOperator", err)
+	}
+}
+
+func TestQueryBuilderGenerator_FullText(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	_ = os.MkdirAll(tempDir, 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	inputFile := filepath.Join(tempDir, "fulltext.go")
+	outputFile := filepath.Join(tempDir, "fulltext_querybuilder.go")
+
+	testGoCode := `package models
+
+//gen:querybuilder
+type Document struct {
+	ID          int64
+	Title       string
+	Description string ` + "`qb:\"fulltext\"`" + `
+	Body        string ` + "`qb:\"fulltext=english\"`" + `
+}
+`
+
+	if err := os.WriteFile(inputFile, []byte(testGoCode), 0644); err != nil {
+		t.Fatalf("Failed to create full-text test file: %v", err)
+	}
+
+	generator := NewQueryBuilderGenerator(&parserPkg.Structs{})
+	if err := generator.Generate(context.Background(), inputFile, outputFile, ""); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	code, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read generated code: %v", err)
+	}
+	for _, part := range []string{
+		"func (d *DocumentFilters) DescriptionSearch(query string) *DocumentFilters",
+		"func (d *DocumentFilters) BodySearch(query string) *DocumentFilters",
+		`repository.TextSearch{Config: "english", Query: query}`,
+	} {
+		if !strings.Contains(string(code), part) {
+			t.Errorf("Generated code missing %q", part)
+		}
+	}
+	if strings.Contains(string(code), "TitleSearch") {
+		t.Error("full-text search should only be generated for tagged fields")
+	}
+}
+
+func TestQueryBuilderGenerator_FieldTypeImports(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	_ = os.MkdirAll(filepath.Join(tempDir, "enums"), 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+
+	// goimports skips testdata, so the import must come from the field types
+	if err := os.WriteFile(filepath.Join(tempDir, "enums", "enums.go"), []byte("package enums\n\ntype Status string\n"), 0644); err != nil {
+		t.Fatalf("Failed to create enums file: %v", err)
+	}
+	inputFile := filepath.Join(tempDir, "ticket.go")
+
+	testGoCode := `package models
+
+import (
+	"time"
+
+	"github.com/dchlong/querybuilder/testdata/tmp/enums"
+)
+
+//gen:querybuilder
+type Ticket struct {
+	ID       int64
+	Status   enums.Status
+	Previous *enums.Status
+	OpenedAt time.Time
+}
+`
+
+	if err := os.WriteFile(inputFile, []byte(testGoCode), 0644); err != nil {
+		t.Fatalf("Failed to create import test file: %v", err)
+	}
+
+	generator := NewQueryBuilderGenerator(&parserPkg.Structs{})
+	code, _, err := generator.GenerateInMemory(context.Background(), inputFile, "")
+	if err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	codeStr := string(code)
+
+	for _, part := range []string{
+		`"github.com/dchlong/querybuilder/testdata/tmp/enums"`,
+		`"time"`,
+		"func (t *TicketFilters) StatusEq(status enums.Status) *TicketFilters",
+		"func (t *TicketUpdater) SetPrevious(previous *enums.Status) *TicketUpdater",
+	} {
+		if !strings.Contains(codeStr, part) {
+			t.Errorf("Generated code missing %q", part)
+		}
+	}
+}
+
+func TestQueryBuilderGenerator_ExternalPackage(t *testing.T) {
+	ctx := context.Background()
+	inputFile := filepath.Join("examples", "product.go")
+
+	generator := NewQueryBuilderGeneratorWithOptions(&parserPkg.Structs{}, Options{Package: "queries"})
+	code, packageName, err := generator.GenerateInMemory(ctx, inputFile, "")
+	if err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	if packageName != "queries" {
+		t.Errorf("Package name = %v, want queries", packageName)
+	}
+
+	codeStr := string(code)
+	for _, part := range []string{
+		"package queries",
+		`"github.com/dchlong/querybuilder/examples"`,
+		"func ProductFindByPrimaryKey(ctx context.Context, repo repository.PrimaryKeyFinder[examples.Product], iD int64) (*examples.Product, bool, error)",
+		"func (p *ProductUpdater) SetAttributes(attributes datatypes.JSONType[*examples.Attributes]) *ProductUpdater",
+	} {
+		if !strings.Contains(codeStr, part) {
+			t.Errorf("Generated code missing %q", part)
+		}
+	}
+
+	fset := token.NewFileSet()
+	if _, err := parser.ParseFile(fset, "queries.go", code, 0); err != nil {
+		t.Errorf("Generated code doesn't parse: %v", err)
+	}
+
+	outputFile := filepath.Join(t.TempDir(), "queries", "product_querybuilder.go")
+	generator = NewQueryBuilderGeneratorWithOptions(&parserPkg.Structs{}, Options{
+		Package:       "queries",
+		FacadeOutput:  filepath.Join(t.TempDir(), "facade.go"),
+		FacadePackage: "api",
+	})
+	if err := generator.Generate(ctx, inputFile, outputFile, ""); !errors.Is(err, repository.ErrFacadeExternalPackage) {
+		t.Errorf("Expected ErrFacadeExternalPackage, got %v", err)
+	}
+
+	generator = NewQueryBuilderGeneratorWithOptions(&parserPkg.Structs{}, Options{Package: "not-a-package"})
+	if err := generator.Generate(ctx, inputFile, outputFile, ""); !errors.Is(err, repository.ErrInvalidPackageName) {
+		t.Errorf("Expected ErrInvalidPackageName, got %v", err)
+	}
+}
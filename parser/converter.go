@@ -1,11 +1,17 @@
 package parser
 
 import (
+	"errors"
+	"fmt"
 	"go/ast"
+	"go/types"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/dchlong/querybuilder/domain"
 	"github.com/dchlong/querybuilder/field"
+	"github.com/dchlong/querybuilder/repository"
 )
 
 // Converter converts from existing parser types to clean domain types
@@ -21,37 +27,156 @@ func NewConverter(fieldInfoGenerator *field.InfoGenerator) *Converter {
 }
 
 // ConvertStruct converts a ParsedStruct to domain.Struct.
-// Only includes fields that can be processed by the field info generator.
+// Only includes fields that can be processed by the field info generator. Unknown operators
+// removed by a querybuilder:"ops=-..." tag are ignored; ConvertAnnotatedStruct reports them.
 func (c *Converter) ConvertStruct(s ParsedStruct) domain.Struct {
+	domainStruct, _ := c.convertStruct(s)
+	return domainStruct
+}
+
+// convertStruct converts a ParsedStruct, returning the struct even when a field tag names an
+// unknown operator
+func (c *Converter) convertStruct(s ParsedStruct) (domain.Struct, error) {
 	domainStruct := domain.Struct{
 		Name:        s.TypeName,
 		PackageName: "", // Will be set by caller
 		Fields:      make([]domain.Field, 0, len(s.Fields)),
+		Source:      s.Source,
 	}
 
+	var errs []error
 	for _, f := range s.Fields {
 		fieldInfo := c.fieldInfoGenerator.GenFieldInfo(f)
 		if fieldInfo != nil {
 			domainField := c.convertField(*fieldInfo)
+			excluded, err := c.parseFieldExclusions(fieldInfo.ExcludedOperators)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", fieldInfo.Name, err))
+			}
+			domainField.ExcludedOperators = excluded
+			constraints, err := c.parseConstraints(*fieldInfo)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", fieldInfo.Name, err))
+			}
+			domainField.Constraints = constraints
+			if fieldInfo.IsVersion && fieldInfo.BasicInfo&types.IsInteger == 0 {
+				errs = append(errs, fmt.Errorf("%s: %w: version field must be an integer", fieldInfo.Name, repository.ErrInvalidAnnotation))
+			}
 			domainStruct.Fields = append(domainStruct.Fields, domainField)
 		}
 	}
 
-	return domainStruct
+	return domainStruct, errors.Join(errs...)
+}
+
+// parseFieldExclusions returns the operators of the method suffixes a field tag removes,
+// e.g. "lt" and "gt" of querybuilder:"ops=-lt|-gt"
+func (c *Converter) parseFieldExclusions(keywords []string) ([]repository.Operator, error) {
+	var (
+		excluded []repository.Operator
+		errs     []error
+	)
+	for _, keyword := range keywords {
+		op, ok := domain.ParseOperatorKeyword(keyword)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: unknown operator %q", repository.ErrInvalidAnnotation, keyword))
+			continue
+		}
+		excluded = append(excluded, op)
+	}
+	return excluded, errors.Join(errs...)
+}
+
+// parseConstraints checks the validation constraints of a field tag: min and max bounds must be
+// constants of the field's number type, minlen and maxlen non-negative integers on a string field
+func (c *Converter) parseConstraints(fi field.Info) ([]domain.Constraint, error) {
+	var (
+		constraints []domain.Constraint
+		errs        []error
+	)
+	for _, tag := range fi.Constraints {
+		var err error
+		switch tag.Name {
+		case domain.ConstraintMin, domain.ConstraintMax:
+			err = checkNumberBound(fi.BasicInfo, tag.Value)
+		case domain.ConstraintMinLen, domain.ConstraintMaxLen:
+			if fi.BasicInfo&types.IsString == 0 {
+				err = errors.New("needs a string field")
+			} else if n, parseErr := strconv.Atoi(tag.Value); parseErr != nil || n < 0 {
+				err = fmt.Errorf("length %q is not a non-negative integer", tag.Value)
+			}
+		case domain.ConstraintNotEmpty:
+			if fi.BasicInfo&types.IsString == 0 {
+				err = errors.New("needs a string field")
+			} else if tag.Value != "" {
+				err = errors.New("takes no value")
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: constraint %s: %w", repository.ErrInvalidAnnotation, tag.Name, err))
+			continue
+		}
+		constraints = append(constraints, domain.Constraint{Name: tag.Name, Value: tag.Value})
+	}
+	return constraints, errors.Join(errs...)
+}
+
+// checkNumberBound checks that a min or max bound is a constant of a field's basic number type
+func checkNumberBound(info types.BasicInfo, value string) error {
+	var err error
+	switch {
+	case info&types.IsUnsigned != 0:
+		_, err = strconv.ParseUint(value, 10, 64)
+	case info&types.IsInteger != 0:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case info&types.IsFloat != 0:
+		_, err = strconv.ParseFloat(value, 64)
+	default:
+		return errors.New("needs a number field")
+	}
+	if err != nil {
+		return fmt.Errorf("bound %q is not a valid number for the field", value)
+	}
+	return nil
 }
 
 // convertField converts field.Info to domain.Field.
 // Maps all relevant field metadata from the parsed field info.
 func (c *Converter) convertField(fi field.Info) domain.Field {
 	return domain.Field{
-		Name:     fi.Name,
-		DBName:   fi.DBName,
-		Type:     c.convertFieldType(fi),
-		TypeName: fi.TypeName,
-		GoType:   fi.GetTypeName(), // Use full type name including generics
+		Name:            fi.Name,
+		DBName:          fi.DBName,
+		Type:            c.convertFieldType(fi),
+		TypeName:        fi.TypeName,
+		GoType:          fi.GetTypeName(), // Use full type name including generics
+		Doc:             fi.Doc,
+		Nullable:        fi.IsNullable,
+		ValueTypeName:   fi.ValueTypeName,
+		PrimaryKey:      fi.IsPrimaryKey,
+		ReadOnly:        fi.IsReadOnly || fi.IsVersion, // The repository increments the version
+		Version:         fi.IsVersion,
+		ElemTypeName:    fi.ElemTypeName,
+		CustomOperators: fi.Operators,
+		FullText:        fi.IsFullText,
+		FullTextConfig:  fi.FullTextConfig,
+		Imports:         fi.Imports,
+
+		UniqueIndexes: c.convertIndexes(fi.UniqueIndexes),
 	}
 }
 
+// convertIndexes converts the unique index tags of a field
+func (c *Converter) convertIndexes(tags []field.IndexTag) []domain.IndexColumn {
+	if len(tags) == 0 {
+		return nil
+	}
+	indexes := make([]domain.IndexColumn, len(tags))
+	for i, tag := range tags {
+		indexes[i] = domain.IndexColumn{Name: tag.Name, Priority: tag.Priority}
+	}
+	return indexes
+}
+
 // convertFieldType converts field.Info to domain.FieldType.
 // Uses a priority-based approach where more specific types take precedence.
 func (c *Converter) convertFieldType(fi field.Info) domain.FieldType {
@@ -59,11 +184,33 @@ func (c *Converter) convertFieldType(fi field.Info) domain.FieldType {
 	if fi.IsTime {
 		return domain.FieldTypeTime
 	}
+	if fi.IsScalar {
+		return domain.FieldTypeScalar
+	}
+
+	// Handle related models before the generic container and pointer checks
+	if fi.IsAssociation || (fi.IsPointer && fi.GetPointed().IsAssociation) {
+		return domain.FieldTypeAssociation
+	}
+
+	// JSON object columns are queried by key, whatever their Go container type
+	if fi.IsJSON {
+		return domain.FieldTypeJSON
+	}
+	if fi.IsJSONArray {
+		return domain.FieldTypeJSONArray
+	}
+	if fi.IsArray {
+		return domain.FieldTypeArray
+	}
 
 	// Handle container types
 	if fi.IsSlice {
 		return domain.FieldTypeSlice
 	}
+	if fi.IsHStore {
+		return domain.FieldTypeHStore
+	}
 	if fi.IsMap {
 		return domain.FieldTypeMap
 	}
@@ -83,19 +230,110 @@ func (c *Converter) convertFieldType(fi field.Info) domain.FieldType {
 	if fi.IsNumeric {
 		return domain.FieldTypeNumeric
 	}
-
-	// Check for boolean type (fallback to string matching)
-	if c.isBooleanType(fi.TypeName) {
+	if fi.IsBool {
 		return domain.FieldTypeBool
 	}
 
 	return domain.FieldTypeUnknown
 }
 
-// isBooleanType checks if a type name represents a boolean type.
-func (c *Converter) isBooleanType(typeName string) bool {
-	lowerTypeName := strings.ToLower(typeName)
-	return strings.Contains(lowerTypeName, "bool")
+// ConvertAnnotatedStruct converts a ParsedStruct to domain.Struct and applies the arguments
+// of its querybuilder annotation, e.g. "//gen:querybuilder ops=-like,-notlike".
+func (c *Converter) ConvertAnnotatedStruct(s ParsedStruct) (domain.Struct, error) {
+	domainStruct, err := c.convertStruct(s)
+	if err != nil {
+		return domain.Struct{}, fmt.Errorf("%s: %w", s.TypeName, err)
+	}
+
+	for key, value := range c.AnnotationArgs(s.Doc) {
+		switch key {
+		case "ops":
+			excluded, err := c.parseExcludedOperators(value)
+			if err != nil {
+				return domain.Struct{}, fmt.Errorf("%s: %w", s.TypeName, err)
+			}
+			for i := range domainStruct.Fields {
+				domainStruct.Fields[i].ExcludedOperators = append(domainStruct.Fields[i].ExcludedOperators, excluded...)
+			}
+		case "readonly":
+			if err := c.markReadOnly(&domainStruct, value); err != nil {
+				return domain.Struct{}, fmt.Errorf("%s: %w", s.TypeName, err)
+			}
+		default:
+			return domain.Struct{}, fmt.Errorf("%s: %w: unknown argument %q", s.TypeName, repository.ErrInvalidAnnotation, key)
+		}
+	}
+
+	return domainStruct, nil
+}
+
+// AnnotationArgs returns the key=value arguments that follow the querybuilder annotation.
+// Keys are lowercased; tokens without "=" are ignored.
+func (c *Converter) AnnotationArgs(doc *ast.CommentGroup) map[string]string {
+	args := map[string]string{}
+	if doc == nil {
+		return args
+	}
+
+	for _, comment := range doc.List {
+		text := c.cleanCommentText(comment.Text)
+		annotationEnd := c.annotationEnd(text)
+		if annotationEnd < 0 {
+			continue
+		}
+
+		for _, token := range strings.Fields(text[annotationEnd:]) {
+			key, value, ok := strings.Cut(token, "=")
+			if !ok {
+				continue
+			}
+			args[strings.ToLower(key)] = value
+		}
+	}
+
+	return args
+}
+
+// parseExcludedOperators parses an ops argument such as "-like,-notlike"
+func (c *Converter) parseExcludedOperators(value string) ([]repository.Operator, error) {
+	var excluded []repository.Operator
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		keyword, ok := strings.CutPrefix(item, "-")
+		if !ok {
+			return nil, fmt.Errorf("%w: ops entry %q must start with '-'", repository.ErrInvalidAnnotation, item)
+		}
+
+		op, ok := domain.ParseOperatorKeyword(keyword)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown operator %q", repository.ErrInvalidAnnotation, keyword)
+		}
+		excluded = append(excluded, op)
+	}
+
+	return excluded, nil
+}
+
+// markReadOnly marks the fields of a readonly argument such as "ID,CreatedAt" as readonly
+func (c *Converter) markReadOnly(s *domain.Struct, value string) error {
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		i := slices.IndexFunc(s.Fields, func(f domain.Field) bool { return f.Name == name })
+		if i < 0 {
+			return fmt.Errorf("%w: unknown readonly field %q", repository.ErrInvalidAnnotation, name)
+		}
+		s.Fields[i].ReadOnly = true
+	}
+
+	return nil
 }
 
 // ShouldGenerateQueryBuilder checks if struct should have querybuilder generated
@@ -125,22 +363,28 @@ func (c *Converter) hasQueryBuilderAnnotation(comment string) bool {
 		return false
 	}
 
-	// Check against supported annotation formats
-	annotations := []string{
-		"gen:querybuilder",
-		"@querybuilder",
-		"+querybuilder",
-		"//go:generate querybuilder",
-	}
+	return c.annotationEnd(text) >= 0
+}
 
+// annotationFormats lists the supported annotation formats
+var annotationFormats = []string{
+	"gen:querybuilder",
+	"@querybuilder",
+	"+querybuilder",
+	"//go:generate querybuilder",
+}
+
+// annotationEnd returns the index just past the annotation in the cleaned comment text,
+// or -1 if the text has no annotation
+func (c *Converter) annotationEnd(text string) int {
 	lowerText := strings.ToLower(text)
-	for _, annotation := range annotations {
-		if strings.Contains(lowerText, strings.ToLower(annotation)) {
-			return true
+	for _, annotation := range annotationFormats {
+		if idx := strings.Index(lowerText, annotation); idx >= 0 {
+			return idx + len(annotation)
 		}
 	}
 
-	return false
+	return -1
 }
 
 // cleanCommentText removes comment prefixes/suffixes and normalizes whitespace.
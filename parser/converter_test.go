@@ -0,0 +1,221 @@
+package parser
+
+import (
+	"errors"
+	"go/ast"
+	"go/types"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/dchlong/querybuilder/domain"
+	"github.com/dchlong/querybuilder/field"
+	"github.com/dchlong/querybuilder/repository"
+)
+
+func commentGroup(lines ...string) *ast.CommentGroup {
+	group := &ast.CommentGroup{}
+	for _, line := range lines {
+		group.List = append(group.List, &ast.Comment{Text: line})
+	}
+	return group
+}
+
+func TestConverter_AnnotationArgs(t *testing.T) {
+	converter := NewConverter(field.NewInfoGenerator(nil))
+
+	tests := []struct {
+		name     string
+		doc      *ast.CommentGroup
+		expected map[string]string
+	}{
+		{name: "nil doc", doc: nil, expected: map[string]string{}},
+		{name: "no args", doc: commentGroup("//gen:querybuilder"), expected: map[string]string{}},
+		{
+			name:     "ops argument",
+			doc:      commentGroup("// Product is sold", "//gen:querybuilder ops=-like,-notlike"),
+			expected: map[string]string{"ops": "-like,-notlike"},
+		},
+		{
+			name:     "alternative format and uppercase key",
+			doc:      commentGroup("//@querybuilder OPS=-In flag"),
+			expected: map[string]string{"ops": "-In"},
+		},
+		{
+			name:     "args on other comment lines are ignored",
+			doc:      commentGroup("// ops=-eq", "//gen:querybuilder"),
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if args := converter.AnnotationArgs(tt.doc); !reflect.DeepEqual(args, tt.expected) {
+				t.Errorf("AnnotationArgs() = %v, want %v", args, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConverter_ConvertStruct_BoolTypes(t *testing.T) {
+	pkg := types.NewPackage("models", "models")
+	converter := NewConverter(field.NewInfoGenerator(pkg))
+	flag := types.NewNamed(types.NewTypeName(0, pkg, "Flag", nil), types.Typ[types.Bool], nil)
+	boolText := types.NewNamed(types.NewTypeName(0, pkg, "BoolText", nil), types.Typ[types.String], nil)
+
+	domainStruct := converter.ConvertStruct(ParsedStruct{
+		TypeName: "Setting",
+		Fields: []StructField{
+			{name: "Enabled", typ: types.Typ[types.Bool]},
+			{name: "Visible", typ: flag},
+			{name: "Label", typ: boolText},
+		},
+	})
+
+	want := map[string]domain.FieldType{
+		"Enabled": domain.FieldTypeBool,
+		"Visible": domain.FieldTypeBool,
+		"Label":   domain.FieldTypeString,
+	}
+	for _, f := range domainStruct.Fields {
+		if f.Type != want[f.Name] {
+			t.Errorf("Field %s (%s) type = %s, want %s", f.Name, f.TypeName, f.Type, want[f.Name])
+		}
+	}
+}
+
+func TestConverter_ConvertAnnotatedStruct_ReadOnly(t *testing.T) {
+	converter := NewConverter(field.NewInfoGenerator(nil))
+	parsed := ParsedStruct{
+		TypeName: "Product",
+		Fields: []StructField{
+			{name: "ID", typ: types.Typ[types.Int64]},
+			{name: "Name", typ: types.Typ[types.String]},
+			{name: "Stock", typ: types.Typ[types.Int], tag: `qb:"readonly"`},
+		},
+		Doc: commentGroup("//gen:querybuilder readonly=ID"),
+	}
+
+	domainStruct, err := converter.ConvertAnnotatedStruct(parsed)
+	if err != nil {
+		t.Fatalf("ConvertAnnotatedStruct failed: %v", err)
+	}
+
+	want := map[string]bool{"ID": true, "Name": false, "Stock": true}
+	for _, f := range domainStruct.Fields {
+		if f.ReadOnly != want[f.Name] {
+			t.Errorf("Field %s ReadOnly = %v, want %v", f.Name, f.ReadOnly, want[f.Name])
+		}
+	}
+
+	parsed.Doc = commentGroup("//gen:querybuilder readonly=ID,Price")
+	if _, err := converter.ConvertAnnotatedStruct(parsed); !errors.Is(err, repository.ErrInvalidAnnotation) {
+		t.Errorf("unknown readonly field error = %v, want ErrInvalidAnnotation", err)
+	}
+}
+
+func TestConverter_ConvertAnnotatedStruct_Version(t *testing.T) {
+	converter := NewConverter(field.NewInfoGenerator(nil))
+	parsed := ParsedStruct{
+		TypeName: "Product",
+		Fields: []StructField{
+			{name: "Name", typ: types.Typ[types.String]},
+			{name: "Version", typ: types.Typ[types.Int64], tag: `gorm:"version"`},
+			{name: "Revision", typ: types.NewPointer(types.Typ[types.Uint]), tag: `qb:"version"`},
+		},
+		Doc: commentGroup("//gen:querybuilder"),
+	}
+
+	domainStruct, err := converter.ConvertAnnotatedStruct(parsed)
+	if err != nil {
+		t.Fatalf("ConvertAnnotatedStruct failed: %v", err)
+	}
+
+	want := map[string]bool{"Name": false, "Version": true, "Revision": true}
+	for _, f := range domainStruct.Fields {
+		if f.Version != want[f.Name] || f.ReadOnly != want[f.Name] {
+			t.Errorf("Field %s Version = %v, ReadOnly = %v, want both %v", f.Name, f.Version, f.ReadOnly, want[f.Name])
+		}
+	}
+
+	parsed.Fields[1].typ = types.Typ[types.String]
+	if _, err := converter.ConvertAnnotatedStruct(parsed); !errors.Is(err, repository.ErrInvalidAnnotation) {
+		t.Errorf("string version error = %v, want ErrInvalidAnnotation", err)
+	}
+}
+
+func TestConverter_ConvertAnnotatedStruct_ExcludedOperators(t *testing.T) {
+	converter := NewConverter(field.NewInfoGenerator(nil))
+	fields := []StructField{
+		{name: "Name", typ: types.Typ[types.String]},
+		{name: "Stock", typ: types.Typ[types.Int]},
+	}
+
+	parsed := ParsedStruct{
+		TypeName: "Product",
+		Fields:   fields,
+		Doc:      commentGroup("//gen:querybuilder ops=-like,-NotLike,-in"),
+	}
+
+	domainStruct, err := converter.ConvertAnnotatedStruct(parsed)
+	if err != nil {
+		t.Fatalf("ConvertAnnotatedStruct failed: %v", err)
+	}
+
+	for _, f := range domainStruct.Fields {
+		operators := f.SupportedOperators()
+		for _, excluded := range []repository.Operator{repository.OperatorLike, repository.OperatorNotLike, repository.OperatorIn} {
+			if slices.Contains(operators, excluded) {
+				t.Errorf("Field %s should not support excluded operator %s", f.Name, excluded)
+			}
+		}
+		if !slices.Contains(operators, repository.OperatorNotIn) {
+			t.Errorf("Field %s should keep operator NOT_IN", f.Name)
+		}
+	}
+
+	for _, doc := range []string{
+		"//gen:querybuilder ops=-regex",
+		"//gen:querybuilder ops=like",
+		"//gen:querybuilder unknown=1",
+	} {
+		parsed.Doc = commentGroup(doc)
+		if _, err := converter.ConvertAnnotatedStruct(parsed); !errors.Is(err, repository.ErrInvalidAnnotation) {
+			t.Errorf("ConvertAnnotatedStruct(%q) error = %v, want ErrInvalidAnnotation", doc, err)
+		}
+	}
+}
+
+func TestConverter_ConvertAnnotatedStruct_Constraints(t *testing.T) {
+	converter := NewConverter(field.NewInfoGenerator(nil))
+	parsed := ParsedStruct{
+		TypeName: "Product",
+		Fields: []StructField{
+			{name: "Name", typ: types.Typ[types.String], tag: `qb:"maxlen=255,notempty"`},
+			{name: "Stock", typ: types.NewPointer(types.Typ[types.Uint]), tag: `qb:"min=1,max=100"`},
+		},
+		Doc: commentGroup("//gen:querybuilder"),
+	}
+
+	domainStruct, err := converter.ConvertAnnotatedStruct(parsed)
+	if err != nil {
+		t.Fatalf("ConvertAnnotatedStruct failed: %v", err)
+	}
+
+	want := map[string][]domain.Constraint{
+		"Name":  {{Name: domain.ConstraintNotEmpty}, {Name: domain.ConstraintMaxLen, Value: "255"}},
+		"Stock": {{Name: domain.ConstraintMin, Value: "1"}, {Name: domain.ConstraintMax, Value: "100"}},
+	}
+	for _, f := range domainStruct.Fields {
+		if !slices.Equal(f.Constraints, want[f.Name]) {
+			t.Errorf("Field %s Constraints = %v, want %v", f.Name, f.Constraints, want[f.Name])
+		}
+	}
+
+	for _, tag := range []string{`qb:"min=-1"`, `qb:"max=1.5"`, `qb:"maxlen=10"`, `qb:"notempty"`} {
+		parsed.Fields = []StructField{{name: "Stock", typ: types.Typ[types.Uint], tag: reflect.StructTag(tag)}}
+		if _, err := converter.ConvertAnnotatedStruct(parsed); !errors.Is(err, repository.ErrInvalidAnnotation) {
+			t.Errorf("%s on a uint field error = %v, want ErrInvalidAnnotation", tag, err)
+		}
+	}
+}
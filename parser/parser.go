@@ -11,15 +11,18 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/dchlong/querybuilder/field"
 	"github.com/dchlong/querybuilder/repository"
 	"golang.org/x/tools/go/packages"
 )
 
 // StructField represents one field in struct
 type StructField struct {
-	name string            //
-	typ  types.Type        // field/method/parameter type
-	tag  reflect.StructTag // field tag; or nil
+	name         string            //
+	typ          types.Type        // field/method/parameter type
+	tag          reflect.StructTag // field tag; or nil
+	columnPrefix string            // embeddedPrefix of the embedded structs the field is promoted from
+	doc          string            // doc comment of a field declared in the struct itself; or empty
 }
 
 func (sf StructField) Name() string {
@@ -34,28 +37,54 @@ func (sf StructField) Tag() reflect.StructTag {
 	return sf.tag
 }
 
+func (sf StructField) ColumnPrefix() string {
+	return sf.columnPrefix
+}
+
+func (sf StructField) Doc() string {
+	return sf.doc
+}
+
 // ParsedStruct represents struct info
 type ParsedStruct struct {
 	TypeName string
 	Fields   []StructField
 	Doc      *ast.CommentGroup // line comments; or nil
+	Source   string            // "file.go:line" of the declaration, relative to the module root
+	Warnings []string          // e.g. embedded fields shadowed by outer ones
 }
 
 type Result struct {
 	Structs     map[string]ParsedStruct
 	PackageName string
+	PackagePath string // Import path of the parsed package
 	Types       *types.Package
 }
 
 type Structs struct{}
 
 func (p Structs) ParseFile(ctx context.Context, filePath string) (*Result, error) {
+	return p.parse(ctx, filePath, nil)
+}
+
+// ParseSource parses Go source that is not on disk, e.g. read from stdin. filePath is a
+// synthetic name placing the source in a package directory, so that the rest of the package
+// and its imports still resolve; the source replaces the file if it exists.
+func (p Structs) ParseSource(ctx context.Context, filePath string, src []byte) (*Result, error) {
+	if src == nil {
+		src = []byte{}
+	}
+	return p.parse(ctx, filePath, src)
+}
+
+// parse parses filePath, or src in place of its contents when src is not nil
+func (p Structs) parse(ctx context.Context, filePath string, src []byte) (*Result, error) {
 	absFilePath, err := filepath.Abs(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("%w for %s: %w", repository.ErrGetAbsPath, filePath, err)
 	}
 
-	neededStructs, err := p.getStructNamesInFile(absFilePath)
+	neededStructs, err := p.getStructNamesInFile(absFilePath, src)
 	if err != nil {
 		return nil, fmt.Errorf("can't get struct names: %w", err)
 	}
@@ -69,11 +98,16 @@ func (p Structs) ParseFile(ctx context.Context, filePath string) (*Result, error
 		inPkgName = fmt.Sprintf(".%c%s", filepath.Separator, inPkgName)
 	}
 
-	pkgs, err := packages.Load(&packages.Config{
-		Mode:    packages.LoadAllSyntax,
+	loadConfig := &packages.Config{
+		Mode:    packages.LoadAllSyntax | packages.NeedModule,
 		Context: ctx,
 		Tests:   false,
-	}, inPkgName)
+	}
+	if src != nil {
+		loadConfig.Overlay = map[string][]byte{absFilePath: src}
+	}
+
+	pkgs, err := packages.Load(loadConfig, inPkgName)
 	if err != nil {
 		return nil, fmt.Errorf("%w for file %s: %w", repository.ErrLoadPackage, filePath, err)
 	}
@@ -82,10 +116,12 @@ func (p Structs) ParseFile(ctx context.Context, filePath string) (*Result, error
 		return nil, fmt.Errorf("%w: got %d packages: %#v", repository.ErrTooManyPackages, len(pkgs), pkgs)
 	}
 
+	p.resolveSources(pkgs[0], absFilePath, neededStructs)
 	structs := p.buildParsedStructs(pkgs[0], neededStructs)
 	return &Result{
 		Structs:     structs,
 		PackageName: pkgs[0].Name,
+		PackagePath: pkgs[0].PkgPath,
 		Types:       pkgs[0].Types,
 	}, nil
 }
@@ -97,16 +133,21 @@ func (p Structs) buildParsedStructs(pkg *packages.Package, neededStructs structN
 	for _, name := range scope.Names() {
 		obj := scope.Lookup(name)
 
-		if neededStructs[name] == nil {
+		decl, ok := neededStructs[name]
+		if !ok {
 			continue
 		}
 
 		t := obj.Type().(*types.Named)
 		s := t.Underlying().(*types.Struct)
 
-		parsedStruct := parseStruct(s, neededStructs[name])
+		parsedStruct := parseStruct(s, decl.doc)
 		if parsedStruct != nil {
+			for i := range parsedStruct.Fields {
+				parsedStruct.Fields[i].doc = decl.fieldDocs[parsedStruct.Fields[i].name]
+			}
 			parsedStruct.TypeName = name
+			parsedStruct.Source = decl.source
 			ret[name] = *parsedStruct
 		}
 	}
@@ -114,9 +155,19 @@ func (p Structs) buildParsedStructs(pkg *packages.Package, neededStructs structN
 	return ret
 }
 
-type structNamesInfo map[string]*ast.GenDecl
+// structDecl holds what is only available from the AST of a struct declaration
+type structDecl struct {
+	doc       *ast.CommentGroup
+	fieldDocs map[string]string // doc comments of the fields declared in the struct, by name
+	line      int
+	source    string // set once the module root is known
+}
+
+// structNamesInfo maps struct names to their declarations
+type structNamesInfo map[string]*structDecl
 
 type structNamesVisitor struct {
+	fset       *token.FileSet
 	names      structNamesInfo
 	curGenDecl *ast.GenDecl
 }
@@ -126,28 +177,88 @@ func (v *structNamesVisitor) Visit(n ast.Node) (w ast.Visitor) {
 	case *ast.GenDecl:
 		v.curGenDecl = n
 	case *ast.TypeSpec:
-		if _, ok := n.Type.(*ast.StructType); ok {
-			v.names[n.Name.Name] = v.curGenDecl
+		if st, ok := n.Type.(*ast.StructType); ok {
+			v.names[n.Name.Name] = &structDecl{
+				doc:       v.typeSpecDoc(n),
+				fieldDocs: fieldDocs(st),
+				line:      v.fset.Position(n.Pos()).Line,
+			}
 		}
 	}
 
 	return v
 }
 
-func (p Structs) getStructNamesInFile(fname string) (structNamesInfo, error) {
+// typeSpecDoc returns the doc comment of a type spec. Inside a grouped "type ( ... )"
+// block the comment above a spec belongs to that spec, while the comment above the
+// block applies to every spec that has no comment of its own.
+func (v *structNamesVisitor) typeSpecDoc(spec *ast.TypeSpec) *ast.CommentGroup {
+	if spec.Doc != nil {
+		return spec.Doc
+	}
+	if v.curGenDecl == nil {
+		return nil
+	}
+	return v.curGenDecl.Doc
+}
+
+// fieldDocs returns the doc comments of the named fields of st, falling back to the comment
+// at the end of the field's line. Fields promoted from embedded structs are not included:
+// a field declared in st shadows them anyway.
+func fieldDocs(st *ast.StructType) map[string]string {
+	docs := map[string]string{}
+	for _, f := range st.Fields.List {
+		doc := f.Doc
+		if doc == nil {
+			doc = f.Comment
+		}
+		text := strings.TrimSpace(doc.Text())
+		if text == "" {
+			continue
+		}
+		for _, name := range f.Names {
+			docs[name.Name] = text
+		}
+	}
+	return docs
+}
+
+func (p Structs) getStructNamesInFile(fname string, src []byte) (structNamesInfo, error) {
+	// A nil []byte would still be read as empty source, so only pass src when it is set
+	var source any
+	if src != nil {
+		source = src
+	}
+
 	fset := token.NewFileSet()
-	f, err := parser.ParseFile(fset, fname, nil, parser.ParseComments)
+	f, err := parser.ParseFile(fset, fname, source, parser.ParseComments)
 	if err != nil {
 		return nil, fmt.Errorf("%w %q: %w", repository.ErrParseFile, fname, err)
 	}
 
 	v := structNamesVisitor{
+		fset:  fset,
 		names: structNamesInfo{},
 	}
 	ast.Walk(&v, f)
 	return v.names, nil
 }
 
+// resolveSources sets the "file.go:line" reference of each declaration, with the file path
+// relative to the module root, or just the file name outside of a module
+func (p Structs) resolveSources(pkg *packages.Package, absFilePath string, decls structNamesInfo) {
+	path := filepath.Base(absFilePath)
+	if pkg.Module != nil && pkg.Module.Dir != "" {
+		if rel, err := filepath.Rel(pkg.Module.Dir, absFilePath); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+	}
+
+	for _, decl := range decls {
+		decl.source = fmt.Sprintf("%s:%d", filepath.ToSlash(path), decl.line)
+	}
+}
+
 func newStructField(f *types.Var, tag string) *StructField {
 	return &StructField{
 		name: f.Name(),
@@ -156,8 +267,59 @@ func newStructField(f *types.Var, tag string) *StructField {
 	}
 }
 
-func parseStructFields(s *types.Struct) []StructField {
-	var fields []StructField
+// promotedField is a field collected from a struct or one of its embedded structs
+type promotedField struct {
+	StructField
+	path  string // e.g. "BaseModel.ID" for a field of the embedded BaseModel
+	depth int    // number of embedded structs the field is promoted through
+}
+
+// parseStructFields returns the exported fields of s, promoting the fields of embedded structs
+// like GORM does: a field shadows the fields of the same name embedded deeper, the first
+// one wins at the same depth. Shadowed fields are reported in the returned warnings.
+func parseStructFields(s *types.Struct) ([]StructField, []string) {
+	c := fieldCollector{visiting: make(map[*types.Struct]bool)}
+	c.collect(s, "", "", 0)
+
+	winners := make(map[string]promotedField)
+	for _, f := range c.fields {
+		if w, ok := winners[f.name]; !ok || f.depth < w.depth {
+			winners[f.name] = f
+		}
+	}
+
+	var (
+		fields   []promotedField
+		warnings []string
+	)
+	for _, f := range c.fields {
+		w := winners[f.name]
+		if f.path != w.path {
+			warnings = append(warnings, fmt.Sprintf("field %s is shadowed by %s", f.path, w.path))
+			continue
+		}
+		fields = append(fields, f)
+	}
+
+	ret := make([]StructField, len(fields))
+	for i, f := range fields {
+		ret[i] = f.StructField
+	}
+	return ret, warnings
+}
+
+// fieldCollector collects the fields of a struct and of its embedded structs
+type fieldCollector struct {
+	fields   []promotedField
+	visiting map[*types.Struct]bool // structs being collected, against self-embedding through pointers
+}
+
+// collect appends the exported fields of s, recursing into anonymous embedded structs, fields
+// tagged embedded and pointers to such structs
+func (c *fieldCollector) collect(s *types.Struct, columnPrefix, path string, depth int) {
+	c.visiting[s] = true
+	defer delete(c.visiting, s)
+
 	for i := 0; i < s.NumFields(); i++ {
 		f := s.Field(i)
 		if _, ok := f.Type().Underlying().(*types.Interface); ok {
@@ -165,46 +327,44 @@ func parseStructFields(s *types.Struct) []StructField {
 			continue
 		}
 
-		if f.Anonymous() {
-			e, ok := f.Type().Underlying().(*types.Struct)
-			if !ok {
-				continue
+		tag := reflect.StructTag(s.Tag(i))
+		embedded := f.Anonymous() || (f.Exported() && field.IsEmbedded(tag))
+		if e := embeddedStruct(f.Type()); embedded && e != nil {
+			if !c.visiting[e] {
+				c.collect(e, columnPrefix+field.EmbeddedPrefix(tag), path+f.Name()+".", depth+1)
 			}
-
-			pf := parseStructFields(e)
-			if len(pf) == 0 {
-				continue
-			}
-
-			fields = append(fields, pf...)
 			continue
 		}
 
-		if !f.Exported() {
+		if f.Anonymous() || !f.Exported() {
 			continue
 		}
 
 		sf := newStructField(f, s.Tag(i))
-		fields = append(fields, *sf)
+		sf.columnPrefix = columnPrefix
+		c.fields = append(c.fields, promotedField{StructField: *sf, path: path + f.Name(), depth: depth})
 	}
+}
 
-	return fields
+// embeddedStruct returns the struct t or *t refers to, nil for other types
+func embeddedStruct(t types.Type) *types.Struct {
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	s, _ := t.Underlying().(*types.Struct)
+	return s
 }
 
-func parseStruct(s *types.Struct, decl *ast.GenDecl) *ParsedStruct {
-	fields := parseStructFields(s)
+func parseStruct(s *types.Struct, doc *ast.CommentGroup) *ParsedStruct {
+	fields, warnings := parseStructFields(s)
 	if len(fields) == 0 {
 		// e.g. no exported fields in struct
 		return nil
 	}
 
-	var doc *ast.CommentGroup
-	if decl != nil { // decl can be nil for embedded structs
-		doc = decl.Doc // can obtain doc only from AST
-	}
-
 	return &ParsedStruct{
-		Fields: fields,
-		Doc:    doc,
+		Fields:   fields,
+		Doc:      doc,
+		Warnings: warnings,
 	}
 }
@@ -0,0 +1,246 @@
+package parser
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dchlong/querybuilder/field"
+)
+
+func TestStructs_ParseFile_GroupedTypeBlock(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	_ = os.MkdirAll(tempDir, 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	inputFile := filepath.Join(tempDir, "grouped.go")
+
+	testGoCode := `package grouped
+
+// Models of the catalog
+type (
+	// Category groups products
+	Category struct {
+		ID   int64
+		Name string
+	}
+
+	// Product is sold in the shop
+	//
+	//gen:querybuilder
+	Product struct {
+		ID    int64
+		Price float64
+	}
+
+	Tag struct {
+		Label string
+	}
+)
+
+//gen:querybuilder
+type (
+	Order struct {
+		ID int64
+	}
+
+	// Invoice has its own doc without the annotation
+	Invoice struct {
+		Number string
+	}
+)
+
+//gen:querybuilder
+type Customer struct {
+	Email string
+}
+`
+
+	if err := os.WriteFile(inputFile, []byte(testGoCode), 0644); err != nil {
+		t.Fatalf("Failed to create grouped test file: %v", err)
+	}
+
+	result, err := Structs{}.ParseFile(context.Background(), inputFile)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	converter := NewConverter(field.NewInfoGenerator(result.Types))
+	expected := map[string]bool{
+		"Category": false,
+		"Product":  true,
+		"Tag":      false,
+		"Order":    true,
+		"Invoice":  false,
+		"Customer": true,
+	}
+
+	for name, annotated := range expected {
+		parsed, ok := result.Structs[name]
+		if !ok {
+			t.Errorf("Struct %s not parsed", name)
+			continue
+		}
+		if got := converter.ShouldGenerateQueryBuilder(parsed.Doc); got != annotated {
+			t.Errorf("ShouldGenerateQueryBuilder(%s) = %v, want %v", name, got, annotated)
+		}
+	}
+
+	if doc := result.Structs["Product"].Doc.Text(); !strings.Contains(doc, "Product is sold in the shop") {
+		t.Errorf("Product should keep its own doc comment, got %q", doc)
+	}
+
+	sources := map[string]string{
+		"Product":  "parser/testdata/tmp/grouped.go:14",
+		"Customer": "parser/testdata/tmp/grouped.go:37",
+	}
+	for name, expected := range sources {
+		if source := result.Structs[name].Source; source != expected {
+			t.Errorf("%s source = %q, want %q", name, source, expected)
+		}
+	}
+}
+
+func TestStructs_ParseFile_EmbeddedStructs(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	_ = os.MkdirAll(tempDir, 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	inputFile := filepath.Join(tempDir, "embedded.go")
+
+	testGoCode := `package embedded
+
+import "time"
+
+type BaseModel struct {
+	ID        int64
+	CreatedAt time.Time
+	Name      string
+}
+
+type Audit struct {
+	CreatedBy string
+	Name      string
+}
+
+type Node struct {
+	*Node
+	Value int
+}
+
+//gen:querybuilder
+type Product struct {
+	BaseModel
+	*Audit ` + "`gorm:\"embeddedPrefix:audit_\"`" + `
+	Name   string
+}
+`
+
+	if err := os.WriteFile(inputFile, []byte(testGoCode), 0644); err != nil {
+		t.Fatalf("Failed to create embedded test file: %v", err)
+	}
+
+	result, err := Structs{}.ParseFile(context.Background(), inputFile)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	product := result.Structs["Product"]
+	converted := NewConverter(field.NewInfoGenerator(result.Types)).ConvertStruct(product)
+
+	columns := make(map[string]string)
+	var names []string
+	for _, f := range converted.Fields {
+		names = append(names, f.Name)
+		columns[f.Name] = f.DBName
+	}
+	if got, want := strings.Join(names, ","), "ID,CreatedAt,CreatedBy,Name"; got != want {
+		t.Errorf("fields = %s, want %s", got, want)
+	}
+	expectedColumns := map[string]string{
+		"ID":        "id",
+		"CreatedAt": "created_at",
+		"Name":      "name",
+		"CreatedBy": "audit_created_by",
+	}
+	for name, expected := range expectedColumns {
+		if columns[name] != expected {
+			t.Errorf("column of %s = %q, want %q", name, columns[name], expected)
+		}
+	}
+
+	expectedWarnings := []string{
+		"field BaseModel.Name is shadowed by Name",
+		"field Audit.Name is shadowed by Name",
+	}
+	if got := strings.Join(product.Warnings, "\n"); got != strings.Join(expectedWarnings, "\n") {
+		t.Errorf("warnings = %q, want %q", product.Warnings, expectedWarnings)
+	}
+
+	if node := result.Structs["Node"]; len(node.Fields) != 1 || node.Fields[0].Name() != "Value" {
+		t.Errorf("self-embedding Node should only have Value, got %v", node.Fields)
+	}
+}
+
+func TestStructs_ParseFile_FieldDocs(t *testing.T) {
+	tempDir := filepath.Join("testdata", "tmp")
+	_ = os.MkdirAll(tempDir, 0755)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	inputFile := filepath.Join(tempDir, "docs.go")
+
+	testGoCode := `package docs
+
+type Base struct {
+	// ID is the surrogate key
+	ID int64
+}
+
+type Product struct {
+	Base
+
+	// Name is the display name.
+	// It is shown in listings.
+	Name string
+
+	Price float64 // Price in cents
+
+	//gen:ignored directive
+	Stock, Reserved int
+
+	Note string
+}
+`
+
+	if err := os.WriteFile(inputFile, []byte(testGoCode), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	result, err := Structs{}.ParseFile(context.Background(), inputFile)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	converted := NewConverter(field.NewInfoGenerator(result.Types)).ConvertStruct(result.Structs["Product"])
+	expected := map[string]string{
+		"ID":       "", // promoted fields only keep docs of the struct being parsed
+		"Name":     "Name is the display name.\nIt is shown in listings.",
+		"Price":    "Price in cents",
+		"Stock":    "",
+		"Reserved": "",
+		"Note":     "",
+	}
+	if len(converted.Fields) != len(expected) {
+		t.Fatalf("Expected %d fields, got %d", len(expected), len(converted.Fields))
+	}
+	for _, f := range converted.Fields {
+		if f.Doc != expected[f.Name] {
+			t.Errorf("%s doc = %q, want %q", f.Name, f.Doc, expected[f.Name])
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// BulkCreateResult reports which records BulkCreate inserted and which it skipped
+type BulkCreateResult struct {
+	Created []int         // Indexes of the inserted records, whose primary keys are now set
+	Failed  []RecordError // Records rejected by a constraint, in input order
+}
+
+// RecordError is the error that kept the record at Index from being inserted
+type RecordError struct {
+	Index int
+	Err   error
+}
+
+// constraintErrorMessages are fragments of driver messages reporting an integrity constraint violation
+var constraintErrorMessages = []string{
+	"constraint failed",            // SQLite
+	"violates",                     // Postgres: violates unique/foreign key/not-null/check constraint
+	"duplicate key",                // Postgres, SQL Server
+	"duplicate entry",              // MySQL
+	"cannot be null",               // MySQL
+	"foreign key constraint fails", // MySQL
+	"sqlstate 23",                  // Postgres integrity constraint violation class
+}
+
+// IsConstraintViolation reports whether err rejects the written data itself, such as a duplicate
+// key or a missing foreign key, rather than the statement or the connection
+func IsConstraintViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, gorm.ErrDuplicatedKey) ||
+		errors.Is(err, gorm.ErrForeignKeyViolated) ||
+		errors.Is(err, gorm.ErrCheckConstraintViolated) {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, fragment := range constraintErrorMessages {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
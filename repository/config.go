@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// RepoConfig holds optional GormRepository behaviour.
+// The zero value matches NewGormRepository.
+type RepoConfig struct {
+	// ReadRetry retries FindOne, FindAll and Count after connection-level errors.
+	// Nil disables retries.
+	ReadRetry *RetryPolicy
+
+	// QueryTimeout bounds each repository call, retries included, unless WithTimeout overrides it.
+	// Zero leaves the caller's context as is.
+	QueryTimeout time.Duration
+
+	// HealthTimeout bounds the ping of Health; zero means 5 seconds
+	HealthTimeout time.Duration
+
+	// Observer is notified once after every repository call that runs a query, including within
+	// transactions. Shorthands such as FindOneByID or Iterate report the call they delegate to.
+	// Nil disables it.
+	Observer QueryObserver
+
+	// Tracer starts a span around the calls the Observer sees. Nil disables tracing.
+	Tracer Tracer
+
+	// DefaultScope is ANDed into every query that reads, updates or deletes existing records,
+	// e.g. NewProductFilters().TenantIDEq(tenant) for row-level isolation. Create is not
+	// checked against it. Nil disables it.
+	DefaultScope EntityFilter
+}
+
+// defaultHealthTimeout bounds Health when RepoConfig.HealthTimeout is zero
+const defaultHealthTimeout = 5 * time.Second
+
+// timeoutContext bounds ctx by timeout; a timeout of zero or less leaves it unbounded
+func timeoutContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// recordDeadlines records the remaining time of each query's context, or zero without a deadline
+func recordDeadlines(t *testing.T, db *gorm.DB) *[]time.Duration {
+	var remaining []time.Duration
+	require.NoError(t, db.Callback().Query().Before("gorm:query").Register("test:deadline", func(tx *gorm.DB) {
+		var left time.Duration
+		if deadline, ok := tx.Statement.Context.Deadline(); ok {
+			left = time.Until(deadline)
+		}
+		remaining = append(remaining, left)
+	}))
+	return &remaining
+}
+
+func TestGormRepository_QueryTimeout(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	remaining := recordDeadlines(t, db)
+
+	t.Run("no timeout", func(t *testing.T) {
+		*remaining = nil
+		repo := NewGormRepository[TestEntity, *TestFilter, *TestUpdater](db)
+		_, err := repo.FindAll(ctx, NewTestFilter())
+		require.NoError(t, err)
+		assert.Equal(t, []time.Duration{0}, *remaining)
+	})
+
+	t.Run("repository default", func(t *testing.T) {
+		*remaining = nil
+		repo := NewGormRepositoryWithConfig[TestEntity, *TestFilter, *TestUpdater](db, RepoConfig{QueryTimeout: time.Minute})
+		_, err := repo.Count(ctx, NewTestFilter())
+		require.NoError(t, err)
+		require.Len(t, *remaining, 1)
+		assert.InDelta(t, time.Minute, (*remaining)[0], float64(time.Second))
+	})
+
+	t.Run("option overrides the default", func(t *testing.T) {
+		*remaining = nil
+		repo := NewGormRepositoryWithConfig[TestEntity, *TestFilter, *TestUpdater](db, RepoConfig{QueryTimeout: time.Minute})
+		_, _, err := repo.FindOne(ctx, NewTestFilter(), WithTimeout(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, *remaining, 1)
+		assert.InDelta(t, time.Hour, (*remaining)[0], float64(time.Second))
+	})
+
+	t.Run("expired timeout", func(t *testing.T) {
+		repo := NewGormRepository[TestEntity, *TestFilter, *TestUpdater](db)
+		_, err := repo.FindAll(ctx, NewTestFilter(), WithTimeout(time.Nanosecond))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestGormRepository_HealthTimeout(t *testing.T) {
+	db := setupTestDB(t)
+
+	repo := NewGormRepositoryWithConfig[TestEntity, *TestFilter, *TestUpdater](db, RepoConfig{HealthTimeout: time.Nanosecond})
+	assert.ErrorIs(t, repo.Health(context.Background()), context.DeadlineExceeded)
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Cursor positions a keyset-paginated query after the last row of the previous page
+type Cursor struct {
+	// Field is the column the pages are ordered by. When it is not unique, the primary key
+	// orders rows with equal values.
+	Field string
+	// Value is the column value of the last row already seen; nil starts at the first page
+	Value interface{}
+	// Key is the primary key of the last row already seen. It breaks ties when Field is not
+	// unique; without it rows equal to Value are skipped.
+	Key interface{}
+	// Direction is "asc" (the default when empty) or "desc"
+	Direction string
+
+	// keyField is the primary key column breaking ties, set when Field is not unique
+	keyField string
+}
+
+// WithCursor orders the query by field and only returns rows after lastValue in that direction.
+// A nil lastValue starts at the first page.
+func WithCursor(field string, lastValue interface{}, direction string) OptionFunc {
+	return &functionOption{
+		f: func(o *Options) {
+			o.Cursor = &Cursor{Field: field, Value: lastValue, Direction: direction}
+		},
+	}
+}
+
+// withCursor sets the whole cursor, including its tie-breaking key
+func withCursor(cursor Cursor) OptionFunc {
+	return &functionOption{
+		f: func(o *Options) {
+			o.Cursor = &cursor
+		},
+	}
+}
+
+// WithCursorToken continues from a next-cursor token returned by FindPage.
+// An empty token starts at the first page; a malformed one makes FindPage fail with ErrInvalidCursor.
+func WithCursorToken(token string) OptionFunc {
+	return &functionOption{
+		f: func(o *Options) {
+			o.CursorToken = token
+		},
+	}
+}
+
+// descending reports whether the cursor walks the column from high to low values
+func (c *Cursor) descending() bool {
+	return strings.EqualFold(c.Direction, "desc")
+}
+
+// validate checks the cursor has a field and a known direction
+func (c *Cursor) validate() error {
+	if c.Field == "" {
+		return fmt.Errorf("%w: %w", ErrInvalidCursor, ErrEmptyFieldName)
+	}
+	if c.Direction != "" && !strings.EqualFold(c.Direction, "asc") && !c.descending() {
+		return fmt.Errorf("%w: direction %q, expected asc or desc", ErrInvalidCursor, c.Direction)
+	}
+	return nil
+}
+
+// cursorToken is the JSON payload of an encoded cursor. Type records the kind of Value so
+// that it decodes back to a value the database compares the same way as the column.
+type cursorToken struct {
+	Field     string          `json:"field"`
+	Direction string          `json:"direction"`
+	Type      string          `json:"type"`
+	Value     json.RawMessage `json:"value"`
+	KeyType   string          `json:"key_type,omitempty"`
+	Key       json.RawMessage `json:"key,omitempty"`
+}
+
+// Cursor value types stored in tokens
+const (
+	cursorTypeString = "string"
+	cursorTypeInt    = "int"
+	cursorTypeUint   = "uint"
+	cursorTypeFloat  = "float"
+	cursorTypeBool   = "bool"
+	cursorTypeTime   = "time"
+)
+
+// EncodeCursor encodes a cursor as an opaque token: URL-safe base64 (without padding) of the
+// JSON object {"field", "direction", "type", "value"}, plus {"key_type", "key"} when the cursor
+// has a Key. Times are stored as RFC 3339 strings.
+func EncodeCursor(cursor Cursor) (string, error) {
+	if err := cursor.validate(); err != nil {
+		return "", err
+	}
+
+	token := cursorToken{Field: cursor.Field, Direction: strings.ToLower(cursor.Direction)}
+	var err error
+	if token.Type, token.Value, err = encodeCursorValue(cursor.Value); err != nil {
+		return "", err
+	}
+	if cursor.Key != nil {
+		if token.KeyType, token.Key, err = encodeCursorValue(cursor.Key); err != nil {
+			return "", err
+		}
+	}
+
+	payload, err := json.Marshal(token)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload), nil
+}
+
+// DecodeCursor decodes a token produced by EncodeCursor
+func DecodeCursor(token string) (Cursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	var decoded cursorToken
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		return Cursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	value, err := decodeCursorValue(decoded.Type, decoded.Value)
+	if err != nil {
+		return Cursor{}, err
+	}
+
+	cursor := Cursor{Field: decoded.Field, Value: value, Direction: decoded.Direction}
+	if decoded.KeyType != "" {
+		if cursor.Key, err = decodeCursorValue(decoded.KeyType, decoded.Key); err != nil {
+			return Cursor{}, err
+		}
+	}
+	if err := cursor.validate(); err != nil {
+		return Cursor{}, err
+	}
+
+	return cursor, nil
+}
+
+// encodeCursorValue returns the token type and JSON form of a cursor value
+func encodeCursorValue(value interface{}) (string, json.RawMessage, error) {
+	valueType, encodable, err := cursorValue(value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	raw, err := json.Marshal(encodable)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	return valueType, raw, nil
+}
+
+// cursorValue returns the token type and JSON-encodable form of a cursor value
+func cursorValue(value interface{}) (string, interface{}, error) {
+	if t, ok := value.(time.Time); ok {
+		return cursorTypeTime, t.Format(time.RFC3339Nano), nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		return cursorTypeString, v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cursorTypeInt, v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cursorTypeUint, v.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return cursorTypeFloat, v.Float(), nil
+	case reflect.Bool:
+		return cursorTypeBool, v.Bool(), nil
+	default:
+		return "", nil, fmt.Errorf("%w: unsupported value type %T", ErrInvalidCursor, value)
+	}
+}
+
+// decodeCursorValue converts a token value back to its Go type
+func decodeCursorValue(valueType string, raw json.RawMessage) (interface{}, error) {
+	var (
+		value interface{}
+		err   error
+	)
+	switch valueType {
+	case cursorTypeString:
+		var s string
+		err = json.Unmarshal(raw, &s)
+		value = s
+	case cursorTypeInt:
+		var i int64
+		err = json.Unmarshal(raw, &i)
+		value = i
+	case cursorTypeUint:
+		var u uint64
+		err = json.Unmarshal(raw, &u)
+		value = u
+	case cursorTypeFloat:
+		var f float64
+		err = json.Unmarshal(raw, &f)
+		value = f
+	case cursorTypeBool:
+		var b bool
+		err = json.Unmarshal(raw, &b)
+		value = b
+	case cursorTypeTime:
+		var s string
+		if err = json.Unmarshal(raw, &s); err == nil {
+			value, err = time.Parse(time.RFC3339Nano, s)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown value type %q", ErrInvalidCursor, valueType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorToken_RoundTrip(t *testing.T) {
+	type status string
+
+	placedAt := time.Date(2025, time.March, 1, 12, 30, 0, 123, time.UTC)
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected interface{}
+	}{
+		{"int", 42, int64(42)},
+		{"large int64", int64(1<<62 + 1), int64(1<<62 + 1)},
+		{"uint", uint32(7), uint64(7)},
+		{"float", 9.75, 9.75},
+		{"string", "A-1", "A-1"},
+		{"named string", status("paid"), "paid"},
+		{"bool", true, true},
+		{"time", placedAt, placedAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := EncodeCursor(Cursor{Field: "value", Value: tt.value, Direction: "DESC"})
+			require.NoError(t, err)
+
+			cursor, err := DecodeCursor(token)
+			require.NoError(t, err)
+			assert.Equal(t, Cursor{Field: "value", Value: tt.expected, Direction: "desc"}, cursor)
+		})
+	}
+}
+
+func TestCursorToken_Format(t *testing.T) {
+	token, err := EncodeCursor(Cursor{Field: "id", Value: 10, Direction: "asc"})
+	require.NoError(t, err)
+
+	payload, err := base64.RawURLEncoding.DecodeString(token)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"field":"id","direction":"asc","type":"int","value":10}`, string(payload))
+
+	token, err = EncodeCursor(Cursor{Field: "age", Value: 30, Key: int64(7), Direction: "asc"})
+	require.NoError(t, err)
+
+	payload, err = base64.RawURLEncoding.DecodeString(token)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"field":"age","direction":"asc","type":"int","value":30,"key_type":"int","key":7}`, string(payload))
+
+	cursor, err := DecodeCursor(token)
+	require.NoError(t, err)
+	assert.Equal(t, Cursor{Field: "age", Value: int64(30), Key: int64(7), Direction: "asc"}, cursor)
+}
+
+func TestCursorToken_Invalid(t *testing.T) {
+	_, err := EncodeCursor(Cursor{Field: "id", Value: []int{1}})
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = EncodeCursor(Cursor{Value: 1})
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	for _, token := range []string{
+		"%%%",
+		base64.RawURLEncoding.EncodeToString([]byte(`not json`)),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"field":"id","type":"complex","value":1}`)),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"field":"id","type":"int","value":"1"}`)),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"field":"id","direction":"up","type":"int","value":1}`)),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"field":"id","type":"int","value":1,"key_type":"map","key":{}}`)),
+	} {
+		_, err := DecodeCursor(token)
+		assert.ErrorIs(t, err, ErrInvalidCursor, "token %q", token)
+	}
+}
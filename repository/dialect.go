@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Dialect names as reported by gorm.Dialector.Name()
+const (
+	DialectPostgres = "postgres"
+	DialectMySQL    = "mysql"
+	DialectSQLite   = "sqlite"
+)
+
+// dialectName returns the name of the dialect the query will run against
+func dialectName(db *gorm.DB) string {
+	if db == nil || db.Dialector == nil {
+		return ""
+	}
+	return db.Dialector.Name()
+}
+
+// requireDialect returns ErrUnsupportedDialect unless name is one of the given dialects
+func requireDialect(name string, op Operator, dialects ...string) error {
+	if slices.Contains(dialects, name) {
+		return nil
+	}
+	return fmt.Errorf("%s on %q: %w", op, name, ErrUnsupportedDialect)
+}
+
+// regexCondition returns a regular expression match for the dialect. SQLite only understands
+// REGEXP once a regexp() function is registered with its driver, so it is rejected like other
+// databases without one.
+func regexCondition(dialect, quotedField string, op Operator, pattern interface{}) (string, []interface{}, error) {
+	switch dialect {
+	case DialectPostgres:
+		return quotedField + " ~ ?", []interface{}{pattern}, nil
+	case DialectMySQL:
+		return quotedField + " REGEXP ?", []interface{}{pattern}, nil
+	default:
+		return "", nil, requireDialect(dialect, op, DialectPostgres, DialectMySQL)
+	}
+}
+
+// textSearchConfigPattern matches the Postgres text search configurations that can be inlined,
+// e.g. english or public.my_config
+var textSearchConfigPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// fullTextCondition returns a full-text match of the query in value, a string or TextSearch.
+// The Postgres configuration is inlined rather than bound so that the condition repeats the
+// expression of a to_tsvector('english', column) index.
+func fullTextCondition(dialect, quotedField string, op Operator, value interface{}) (string, []interface{}, error) {
+	search, ok := value.(TextSearch)
+	if !ok {
+		query, isString := value.(string)
+		if !isString {
+			return "", nil, fmt.Errorf("%s expects a string or repository.TextSearch, got %T: %w", op, value, ErrInvalidFilterValue)
+		}
+		search = TextSearch{Query: query}
+	}
+
+	switch dialect {
+	case DialectPostgres:
+		if search.Config == "" {
+			return "to_tsvector(" + quotedField + ") @@ plainto_tsquery(?)", []interface{}{search.Query}, nil
+		}
+		if !textSearchConfigPattern.MatchString(search.Config) {
+			return "", nil, fmt.Errorf("%s text search configuration %q: %w", op, search.Config, ErrInvalidFilterValue)
+		}
+		config := "'" + search.Config + "'"
+		return "to_tsvector(" + config + ", " + quotedField + ") @@ plainto_tsquery(" + config + ", ?)", []interface{}{search.Query}, nil
+	case DialectMySQL:
+		return "MATCH(" + quotedField + ") AGAINST (? IN NATURAL LANGUAGE MODE)", []interface{}{search.Query}, nil
+	default:
+		return "", nil, requireDialect(dialect, op, DialectPostgres, DialectMySQL)
+	}
+}
+
+// iLikeCondition returns a case-insensitive LIKE condition for the dialect.
+// Postgres has ILIKE; elsewhere both sides are lowercased, which works whatever the column collation.
+func iLikeCondition(dialect, quotedField string) string {
+	if dialect == DialectPostgres {
+		return quotedField + " ILIKE ?"
+	}
+	return "LOWER(" + quotedField + ") LIKE LOWER(?)" + likeEscapeClause(dialect)
+}
+
+// likeEscapeClause makes the backslash the LIKE escape character on SQLite, which has none by
+// default. Postgres and MySQL already escape with a backslash, so EscapeLike works everywhere.
+func likeEscapeClause(dialect string) string {
+	if dialect == DialectSQLite {
+		return ` ESCAPE '\'`
+	}
+	return ""
+}
+
+// jsonPath returns the JSON path of a top-level object key, quoted so that any key is addressable
+func jsonPath(key string) string {
+	return "$." + strconv.Quote(key)
+}
+
+// jsonHasKeyCondition returns a condition matching rows whose JSON object column has key,
+// including keys holding a JSON null
+func jsonHasKeyCondition(dialect, quotedField, key string) (string, []interface{}, error) {
+	switch dialect {
+	case DialectPostgres:
+		// -> returns a JSON null for null values and SQL NULL only for missing keys; the ? operator
+		// would clash with bind placeholders
+		return "(" + quotedField + " -> ?) IS NOT NULL", []interface{}{key}, nil
+	case DialectMySQL:
+		return "JSON_CONTAINS_PATH(" + quotedField + ", 'one', ?)", []interface{}{jsonPath(key)}, nil
+	case DialectSQLite:
+		return "json_type(" + quotedField + ", ?) IS NOT NULL", []interface{}{jsonPath(key)}, nil
+	default:
+		return "", nil, fmt.Errorf("%s on %q: %w", OperatorJSONHasKey, dialect, ErrUnsupportedDialect)
+	}
+}
+
+// jsonEqualCondition returns a condition comparing the value under a key of a JSON object column
+// with kv.Value encoded as JSON, so that strings, numbers and booleans only match their own type.
+// SQLite extracts booleans as 1 and 0, so there true also equals the number 1.
+func jsonEqualCondition(dialect, quotedField string, kv KeyValue) (string, []interface{}, error) {
+	encoded, err := json.Marshal(kv.Value)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w: %w", OperatorJSONEqual, ErrInvalidFilterValue, err)
+	}
+	value := string(encoded)
+
+	switch dialect {
+	case DialectPostgres:
+		return "CAST(" + quotedField + " -> ? AS jsonb) = CAST(? AS jsonb)", []interface{}{kv.Key, value}, nil
+	case DialectMySQL:
+		return "JSON_EXTRACT(" + quotedField + ", ?) = CAST(? AS JSON)", []interface{}{jsonPath(kv.Key), value}, nil
+	case DialectSQLite:
+		return "json_extract(" + quotedField + ", ?) = json_extract(?, '$')", []interface{}{jsonPath(kv.Key), value}, nil
+	default:
+		return "", nil, fmt.Errorf("%s on %q: %w", OperatorJSONEqual, dialect, ErrUnsupportedDialect)
+	}
+}
+
+// jsonContainsCondition returns a condition matching rows whose JSON array column has element
+func jsonContainsCondition(dialect, quotedField string, element interface{}) (string, []interface{}, error) {
+	switch dialect {
+	case DialectPostgres:
+		document, err := json.Marshal([]interface{}{element})
+		if err != nil {
+			return "", nil, fmt.Errorf("%s: %w: %w", OperatorJSONContains, ErrInvalidFilterValue, err)
+		}
+		// @> instead of the ? operator, which would clash with bind placeholders
+		return "CAST(" + quotedField + " AS jsonb) @> CAST(? AS jsonb)", []interface{}{string(document)}, nil
+	case DialectMySQL:
+		candidate, err := json.Marshal(element)
+		if err != nil {
+			return "", nil, fmt.Errorf("%s: %w: %w", OperatorJSONContains, ErrInvalidFilterValue, err)
+		}
+		return "JSON_CONTAINS(" + quotedField + ", ?)", []interface{}{string(candidate)}, nil
+	case DialectSQLite:
+		return "EXISTS (SELECT 1 FROM json_each(" + quotedField + ") WHERE json_each.value = ?)", []interface{}{element}, nil
+	default:
+		return "", nil, fmt.Errorf("%s on %q: %w", OperatorJSONContains, dialect, ErrUnsupportedDialect)
+	}
+}
+
+// lockingClause returns the FOR ... clause of lock for the query's dialect. ok is false on SQLite,
+// which locks the whole database for writes instead of rows.
+func lockingClause(db *gorm.DB, lock *Lock) (locking clause.Locking, ok bool, err error) {
+	strength := strings.ToUpper(strings.TrimSpace(lock.Strength))
+	if !slices.Contains([]string{LockUpdate, LockShare, LockNoKeyUpdate, LockKeyShare}, strength) {
+		return clause.Locking{}, false, fmt.Errorf("%w: %q", ErrInvalidLock, lock.Strength)
+	}
+
+	if dialectName(db) == DialectSQLite {
+		return clause.Locking{}, false, nil
+	}
+
+	locking = clause.Locking{Strength: strength}
+	if lock.SkipLocked {
+		locking.Options = clause.LockingOptionsSkipLocked
+	}
+	return locking, true, nil
+}
+
+// requireReturning returns ErrUnsupportedDialect unless the query's database supports RETURNING,
+// which MySQL lacks
+func requireReturning(db *gorm.DB) error {
+	switch name := dialectName(db); name {
+	case DialectPostgres, DialectSQLite:
+		return nil
+	default:
+		return fmt.Errorf("RETURNING on %q: %w", name, ErrUnsupportedDialect)
+	}
+}
+
+// monthExpression returns an expression formatting a time column as YYYY-MM for the query's dialect
+func monthExpression(db *gorm.DB, quotedField string) (string, error) {
+	switch name := dialectName(db); name {
+	case DialectPostgres:
+		return "to_char(date_trunc('month', " + quotedField + "), 'YYYY-MM')", nil
+	case DialectMySQL:
+		return "DATE_FORMAT(" + quotedField + ", '%Y-%m')", nil
+	case DialectSQLite:
+		return "strftime('%Y-%m', " + quotedField + ")", nil
+	default:
+		return "", fmt.Errorf("month bucketing on %q: %w", name, ErrUnsupportedDialect)
+	}
+}
@@ -0,0 +1,769 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+// namedDialector reuses the SQLite driver while reporting another dialect name,
+// so dialect-specific SQL can be asserted in dry-run mode without a live server
+type namedDialector struct {
+	gorm.Dialector
+	name string
+}
+
+func (d namedDialector) Name() string {
+	return d.name
+}
+
+func (d namedDialector) QuoteTo(writer clause.Writer, str string) {
+	quote := byte('"')
+	if d.name == DialectMySQL {
+		quote = '`'
+	}
+	_ = writer.WriteByte(quote)
+	_, _ = writer.WriteString(str)
+	_ = writer.WriteByte(quote)
+}
+
+func (d namedDialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, v interface{}) {
+	if d.name != DialectPostgres {
+		d.Dialector.BindVarTo(writer, stmt, v)
+		return
+	}
+	_ = writer.WriteByte('$')
+	_, _ = writer.WriteString(strconv.Itoa(len(stmt.Vars)))
+}
+
+func setupDialectDB(t *testing.T, name string) *gorm.DB {
+	var dialector gorm.Dialector = sqlite.Open(":memory:")
+	if name != DialectSQLite {
+		dialector = namedDialector{Dialector: dialector, name: name}
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+		DryRun: true,
+	})
+	require.NoError(t, err)
+	if name != DialectSQLite {
+		// The SQLite driver drops FOR clauses; the dialects it stands in for render them
+		delete(db.ClauseBuilders, "FOR")
+	}
+	return db
+}
+
+// buildDryRunSQL renders the SELECT statement produced for the filters without executing it
+func buildDryRunSQL(t *testing.T, db *gorm.DB, filters ...*Filter) (string, []interface{}, error) {
+	repo := NewGormRepository[TestEntity, *TestFilter, *TestUpdater](db)
+	query, err := repo.buildQuery(db, &TestFilter{filters: filters})
+	if err != nil {
+		return "", nil, err
+	}
+
+	stmt := query.Find(&[]*TestEntity{}).Statement
+	return stmt.SQL.String(), stmt.Vars, nil
+}
+
+func TestBuildQuery_FilterOrder(t *testing.T) {
+	db := setupDialectDB(t, DialectSQLite)
+	filters := []*Filter{
+		{Field: "name", Operator: OperatorLike, Value: "%a%"},
+		{Field: "age", Operator: OperatorGreaterThan, Value: 18},
+		{Field: "age", Operator: OperatorLessThan, Value: 65},
+		{Field: "is_active", Operator: OperatorEqual, Value: true},
+	}
+
+	var first string
+	for i := 0; i < 20; i++ {
+		sql, vars, err := buildDryRunSQL(t, db, filters...)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{"%a%", 18, 65, true}, vars)
+
+		if i == 0 {
+			first = sql
+			continue
+		}
+		require.Equal(t, first, sql)
+	}
+
+	nameAt := strings.Index(first, "`name`")
+	ageAt := strings.Index(first, "`age`")
+	activeAt := strings.Index(first, "`is_active`")
+	assert.True(t, nameAt < ageAt && ageAt < activeAt, "WHERE clause should follow filter order: %s", first)
+}
+
+func TestBuildQuery_EmptyIn(t *testing.T) {
+	db := setupDialectDB(t, DialectSQLite)
+
+	t.Run("empty IN matches nothing", func(t *testing.T) {
+		for _, value := range []interface{}{[]int64{}, []string(nil), nil} {
+			sql, vars, err := buildDryRunSQL(t, db, &Filter{Field: "id", Operator: OperatorIn, Value: value})
+			require.NoError(t, err)
+			assert.Contains(t, sql, "1 = 0")
+			assert.NotContains(t, sql, "IN")
+			assert.Empty(t, vars)
+		}
+	})
+
+	t.Run("empty NOT IN is skipped", func(t *testing.T) {
+		sql, vars, err := buildDryRunSQL(t, db,
+			&Filter{Field: "id", Operator: OperatorNotIn, Value: []int64{}},
+			&Filter{Field: "age", Operator: OperatorGreaterThan, Value: 18},
+		)
+		require.NoError(t, err)
+		assert.NotContains(t, sql, "NOT IN")
+		assert.Contains(t, sql, "`age` > ?")
+		assert.Equal(t, []interface{}{18}, vars)
+	})
+
+	t.Run("non-empty IN is unchanged", func(t *testing.T) {
+		sql, vars, err := buildDryRunSQL(t, db, &Filter{Field: "id", Operator: OperatorIn, Value: []int64{1, 2}})
+		require.NoError(t, err)
+		assert.Contains(t, sql, "`id` IN (?,?)")
+		assert.Equal(t, []interface{}{int64(1), int64(2)}, vars)
+	})
+}
+
+func TestBuildQuery_NilEquality(t *testing.T) {
+	db := setupDialectDB(t, DialectSQLite)
+	var nilTime *time.Time
+
+	sql, vars, err := buildDryRunSQL(t, db,
+		&Filter{Field: "updated_at", Operator: OperatorEqual, Value: nilTime},
+		&Filter{Field: "deleted_at", Operator: OperatorNotEqual, Value: nil},
+	)
+	require.NoError(t, err)
+	assert.Contains(t, sql, "`updated_at` IS NULL")
+	assert.Contains(t, sql, "`deleted_at` IS NOT NULL")
+	assert.Empty(t, vars)
+
+	now := time.Now()
+	sql, vars, err = buildDryRunSQL(t, db, &Filter{Field: "updated_at", Operator: OperatorEqual, Value: &now})
+	require.NoError(t, err)
+	assert.Contains(t, sql, "`updated_at` = ?")
+	assert.Equal(t, []interface{}{&now}, vars)
+}
+
+func TestBuildQuery_Raw(t *testing.T) {
+	db := setupDialectDB(t, DialectSQLite)
+
+	sql, vars, err := buildDryRunSQL(t, db,
+		&Filter{Field: "age", Operator: OperatorGreaterThan, Value: 18},
+		&Filter{Operator: OperatorRaw, Value: RawSQL{SQL: "id IN (SELECT entity_id FROM tags WHERE name = ?) OR age = ?", Args: []interface{}{"vip", 99}}},
+	)
+	require.NoError(t, err)
+	assert.Contains(t, sql, "`age` > ? AND (id IN (SELECT entity_id FROM tags WHERE name = ?) OR age = ?)")
+	assert.Equal(t, []interface{}{18, "vip", 99}, vars)
+
+	for _, value := range []interface{}{"id = 1", RawSQL{SQL: "  "}} {
+		_, _, err = buildDryRunSQL(t, db, &Filter{Operator: OperatorRaw, Value: value})
+		assert.ErrorIs(t, err, ErrInvalidFilterValue)
+	}
+}
+
+func TestBuildQuery_ILike(t *testing.T) {
+	filter := &Filter{Field: "name", Operator: OperatorILike, Value: "%widget%"}
+
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DialectPostgres, `"name" ILIKE $1`},
+		{DialectMySQL, "LOWER(`name`) LIKE LOWER(?)"},
+		{DialectSQLite, "LOWER(`name`) LIKE LOWER(?)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			sql, vars, err := buildDryRunSQL(t, setupDialectDB(t, tt.dialect), filter)
+
+			require.NoError(t, err)
+			assert.Contains(t, sql, tt.expected)
+			assert.Equal(t, []interface{}{"%widget%"}, vars)
+		})
+	}
+}
+
+func TestBuildQuery_LikeEscape(t *testing.T) {
+	filter := &Filter{Field: "name", Operator: OperatorLike, Value: `100\%%`}
+
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DialectPostgres, `"name" LIKE $1`},
+		{DialectMySQL, "`name` LIKE ?"},
+		{DialectSQLite, "`name` LIKE ? ESCAPE '\\'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			sql, _, err := buildDryRunSQL(t, setupDialectDB(t, tt.dialect), filter)
+
+			require.NoError(t, err)
+			assert.True(t, strings.HasSuffix(sql, tt.expected), "%s does not end with %s", sql, tt.expected)
+		})
+	}
+}
+
+func TestBuildQuery_Between(t *testing.T) {
+	db := setupDialectDB(t, DialectPostgres)
+
+	t.Run("between", func(t *testing.T) {
+		sql, vars, err := buildDryRunSQL(t, db, &Filter{
+			Field:    "age",
+			Operator: OperatorBetween,
+			Value:    Range{Lower: 18, Upper: 65},
+		})
+
+		require.NoError(t, err)
+		assert.Contains(t, sql, `"age" BETWEEN $1 AND $2`)
+		assert.Equal(t, []interface{}{18, 65}, vars)
+	})
+
+	t.Run("not between", func(t *testing.T) {
+		sql, vars, err := buildDryRunSQL(t, db, &Filter{
+			Field:    "age",
+			Operator: OperatorNotBetween,
+			Value:    Range{Lower: 18, Upper: 65},
+		})
+
+		require.NoError(t, err)
+		assert.Contains(t, sql, `"age" NOT BETWEEN $1 AND $2`)
+		assert.Equal(t, []interface{}{18, 65}, vars)
+	})
+
+	t.Run("requires range", func(t *testing.T) {
+		_, _, err := buildDryRunSQL(t, db, &Filter{
+			Field:    "age",
+			Operator: OperatorBetween,
+			Value:    []int{18, 65},
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidFilterValue)
+	})
+}
+
+func TestBuildQuery_HStore(t *testing.T) {
+	t.Run("has key on postgres", func(t *testing.T) {
+		db := setupDialectDB(t, DialectPostgres)
+		sql, vars, err := buildDryRunSQL(t, db, &Filter{
+			Field:    "attributes",
+			Operator: OperatorHStoreHasKey,
+			Value:    "color",
+		})
+
+		require.NoError(t, err)
+		assert.Contains(t, sql, `exist("attributes", $1)`)
+		assert.Equal(t, []interface{}{"color"}, vars)
+	})
+
+	t.Run("get on postgres", func(t *testing.T) {
+		db := setupDialectDB(t, DialectPostgres)
+		sql, vars, err := buildDryRunSQL(t, db, &Filter{
+			Field:    "attributes",
+			Operator: OperatorHStoreGet,
+			Value:    KeyValue{Key: "color", Value: "red"},
+		})
+
+		require.NoError(t, err)
+		assert.Contains(t, sql, `"attributes" -> $1 = $2`)
+		assert.Equal(t, []interface{}{"color", "red"}, vars)
+	})
+
+	t.Run("get requires key value", func(t *testing.T) {
+		db := setupDialectDB(t, DialectPostgres)
+		_, _, err := buildDryRunSQL(t, db, &Filter{
+			Field:    "attributes",
+			Operator: OperatorHStoreGet,
+			Value:    "color",
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidFilterValue)
+	})
+
+	t.Run("rejected on sqlite", func(t *testing.T) {
+		db := setupDialectDB(t, DialectSQLite)
+		_, _, err := buildDryRunSQL(t, db, &Filter{
+			Field:    "attributes",
+			Operator: OperatorHStoreHasKey,
+			Value:    "color",
+		})
+
+		assert.ErrorIs(t, err, ErrUnsupportedDialect)
+	})
+}
+
+func TestBuildQuery_Array(t *testing.T) {
+	t.Run("contains on postgres", func(t *testing.T) {
+		db := setupDialectDB(t, DialectPostgres)
+		sql, vars, err := buildDryRunSQL(t, db, &Filter{
+			Field:    "tags",
+			Operator: OperatorArrayContains,
+			Value:    []string{"sale"},
+		})
+
+		require.NoError(t, err)
+		assert.Contains(t, sql, `"tags" @> ARRAY[$1]`)
+		assert.Equal(t, []interface{}{"sale"}, vars)
+	})
+
+	t.Run("overlaps on postgres", func(t *testing.T) {
+		db := setupDialectDB(t, DialectPostgres)
+		sql, _, err := buildDryRunSQL(t, db, &Filter{
+			Field:    "tags",
+			Operator: OperatorArrayOverlaps,
+			Value:    []string{"sale", "new"},
+		})
+
+		require.NoError(t, err)
+		assert.Contains(t, sql, `"tags" && ARRAY[$1,$2]`)
+	})
+
+	t.Run("rejected on mysql", func(t *testing.T) {
+		db := setupDialectDB(t, DialectMySQL)
+		_, _, err := buildDryRunSQL(t, db, &Filter{
+			Field:    "tags",
+			Operator: OperatorArrayContains,
+			Value:    []string{"sale"},
+		})
+
+		assert.ErrorIs(t, err, ErrUnsupportedDialect)
+	})
+}
+
+func TestBuildQuery_Regex(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DialectPostgres, `"name" ~ $1`},
+		{DialectMySQL, "`name` REGEXP ?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			db := setupDialectDB(t, tt.dialect)
+			sql, vars, err := buildDryRunSQL(t, db, &Filter{Field: "name", Operator: OperatorRegex, Value: "^Al"})
+
+			require.NoError(t, err)
+			assert.Contains(t, sql, tt.expected)
+			assert.Equal(t, []interface{}{"^Al"}, vars)
+		})
+	}
+
+	t.Run("rejected on sqlite", func(t *testing.T) {
+		db := setupDialectDB(t, DialectSQLite)
+		_, _, err := buildDryRunSQL(t, db, &Filter{Field: "name", Operator: OperatorRegex, Value: "^Al"})
+		assert.ErrorIs(t, err, ErrUnsupportedDialect)
+	})
+
+	t.Run("needs a string pattern", func(t *testing.T) {
+		db := setupDialectDB(t, DialectPostgres)
+		_, _, err := buildDryRunSQL(t, db, &Filter{Field: "name", Operator: OperatorRegex, Value: 1})
+		assert.ErrorIs(t, err, ErrFilterValueNotString)
+	})
+}
+
+func TestBuildQuery_FullText(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  string
+		value    interface{}
+		expected string
+	}{
+		{"postgres", DialectPostgres, "red shoes", `to_tsvector("description") @@ plainto_tsquery($1)`},
+		{"postgres with configuration", DialectPostgres, TextSearch{Config: "english", Query: "red shoes"},
+			`to_tsvector('english', "description") @@ plainto_tsquery('english', $1)`},
+		{"mysql", DialectMySQL, TextSearch{Config: "english", Query: "red shoes"},
+			"MATCH(`description`) AGAINST (? IN NATURAL LANGUAGE MODE)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupDialectDB(t, tt.dialect)
+			sql, vars, err := buildDryRunSQL(t, db, &Filter{Field: "description", Operator: OperatorFullText, Value: tt.value})
+
+			require.NoError(t, err)
+			assert.Contains(t, sql, tt.expected)
+			assert.Equal(t, []interface{}{"red shoes"}, vars)
+		})
+	}
+
+	t.Run("rejected on sqlite", func(t *testing.T) {
+		db := setupDialectDB(t, DialectSQLite)
+		_, _, err := buildDryRunSQL(t, db, &Filter{Field: "description", Operator: OperatorFullText, Value: "red"})
+		assert.ErrorIs(t, err, ErrUnsupportedDialect)
+	})
+
+	t.Run("rejects configurations that cannot be inlined", func(t *testing.T) {
+		db := setupDialectDB(t, DialectPostgres)
+		_, _, err := buildDryRunSQL(t, db, &Filter{
+			Field:    "description",
+			Operator: OperatorFullText,
+			Value:    TextSearch{Config: "english'); DROP TABLE x; --", Query: "red"},
+		})
+		assert.ErrorIs(t, err, ErrInvalidFilterValue)
+	})
+}
+
+func TestBuildQuery_JSON(t *testing.T) {
+	tests := []struct {
+		dialect   string
+		hasKey    string
+		equal     string
+		keyVars   []interface{}
+		equalVars []interface{}
+	}{
+		{DialectPostgres, `("attributes" -> $1) IS NOT NULL`, `CAST("attributes" -> $1 AS jsonb) = CAST($2 AS jsonb)`,
+			[]interface{}{"color"}, []interface{}{"color", `"red"`}},
+		{DialectMySQL, "JSON_CONTAINS_PATH(`attributes`, 'one', ?)", "JSON_EXTRACT(`attributes`, ?) = CAST(? AS JSON)",
+			[]interface{}{`$."color"`}, []interface{}{`$."color"`, `"red"`}},
+		{DialectSQLite, "json_type(`attributes`, ?) IS NOT NULL", "json_extract(`attributes`, ?) = json_extract(?, '$')",
+			[]interface{}{`$."color"`}, []interface{}{`$."color"`, `"red"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			db := setupDialectDB(t, tt.dialect)
+
+			sql, vars, err := buildDryRunSQL(t, db, &Filter{Field: "attributes", Operator: OperatorJSONHasKey, Value: "color"})
+			require.NoError(t, err)
+			assert.Contains(t, sql, tt.hasKey)
+			assert.Equal(t, tt.keyVars, vars)
+
+			sql, vars, err = buildDryRunSQL(t, db, &Filter{
+				Field:    "attributes",
+				Operator: OperatorJSONEqual,
+				Value:    KeyValue{Key: "color", Value: "red"},
+			})
+			require.NoError(t, err)
+			assert.Contains(t, sql, tt.equal)
+			assert.Equal(t, tt.equalVars, vars)
+		})
+	}
+
+	t.Run("equal requires key value", func(t *testing.T) {
+		_, _, err := buildDryRunSQL(t, setupDialectDB(t, DialectSQLite), &Filter{
+			Field:    "attributes",
+			Operator: OperatorJSONEqual,
+			Value:    "color",
+		})
+		assert.ErrorIs(t, err, ErrInvalidFilterValue)
+	})
+}
+
+// JSONEntity keeps a JSON object in a text column, as SQLite stores JSON
+type JSONEntity struct {
+	ID         int64 `gorm:"primaryKey"`
+	Name       string
+	Attributes string
+}
+
+func TestGormRepository_JSONEqual(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&JSONEntity{}))
+	repo := NewGormRepository[JSONEntity, *TestFilter, *TestUpdater](db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx,
+		&JSONEntity{Name: "active", Attributes: `{"active": true, "size": 42, "ratio": 1.5, "color": "red"}`},
+		&JSONEntity{Name: "inactive", Attributes: `{"active": false, "size": 7, "ratio": 0.5, "color": "blue"}`},
+		&JSONEntity{Name: "text", Attributes: `{"active": "true", "size": "42", "color": "42"}`},
+	))
+
+	names := func(key string, value interface{}) []string {
+		filter := NewTestFilter()
+		filter.filters = append(filter.filters, &Filter{Field: "attributes", Operator: OperatorJSONEqual, Value: KeyValue{Key: key, Value: value}})
+		found, err := repo.FindAll(ctx, filter, WithSort("id", Asc))
+		require.NoError(t, err)
+		result := make([]string, 0, len(found))
+		for _, entity := range found {
+			result = append(result, entity.Name)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"active"}, names("active", true))
+	assert.Equal(t, []string{"inactive"}, names("active", false))
+	assert.Equal(t, []string{"active"}, names("size", 42))
+	assert.Equal(t, []string{"active"}, names("ratio", 1.5))
+	assert.Equal(t, []string{"active"}, names("color", "red"))
+	assert.Equal(t, []string{"text"}, names("size", "42"), "a JSON string does not equal a number")
+	assert.Equal(t, []string{"text"}, names("active", "true"))
+	assert.Empty(t, names("missing", "red"))
+}
+
+func TestBuildQuery_JSONContains(t *testing.T) {
+	tests := []struct {
+		dialect string
+		sql     string
+		vars    []interface{}
+	}{
+		{DialectPostgres, `CAST("tags" AS jsonb) @> CAST($1 AS jsonb)`, []interface{}{`["widget"]`}},
+		{DialectMySQL, "JSON_CONTAINS(`tags`, ?)", []interface{}{`"widget"`}},
+		{DialectSQLite, "EXISTS (SELECT 1 FROM json_each(`tags`) WHERE json_each.value = ?)", []interface{}{"widget"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			sql, vars, err := buildDryRunSQL(t, setupDialectDB(t, tt.dialect),
+				&Filter{Field: "tags", Operator: OperatorJSONContains, Value: "widget"})
+			require.NoError(t, err)
+			assert.Contains(t, sql, tt.sql)
+			assert.Equal(t, tt.vars, vars)
+		})
+	}
+}
+
+func TestBuildQuery_QualifiedColumns(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		where    string
+		joined   string
+		orderBy  string
+		groupBy  string
+		selected string
+	}{
+		{
+			dialect:  DialectSQLite,
+			where:    "WHERE `categories`.`name` = ? AND `name` = ?",
+			joined:   "WHERE `categories`.`name` = ? AND `test_entities`.`name` = ?",
+			orderBy:  "ORDER BY `categories`.`name` asc,`test_entities`.`age` desc",
+			groupBy:  "GROUP BY `categories`.`name`",
+			selected: "SELECT `test_entities`.`name`,`test_entities`.`id` FROM",
+		},
+		{
+			dialect:  DialectPostgres,
+			where:    `WHERE "categories"."name" = $1 AND "name" = $2`,
+			joined:   `WHERE "categories"."name" = $1 AND "test_entities"."name" = $2`,
+			orderBy:  `ORDER BY "categories"."name" asc,"test_entities"."age" desc`,
+			groupBy:  `GROUP BY "categories"."name"`,
+			selected: `SELECT "test_entities"."name","test_entities"."id" FROM`,
+		},
+		{
+			dialect:  DialectMySQL,
+			where:    "WHERE `categories`.`name` = ? AND `name` = ?",
+			joined:   "WHERE `categories`.`name` = ? AND `test_entities`.`name` = ?",
+			orderBy:  "ORDER BY `categories`.`name` asc,`test_entities`.`age` desc",
+			groupBy:  "GROUP BY `categories`.`name`",
+			selected: "SELECT `test_entities`.`name`,`test_entities`.`id` FROM",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			db := setupDialectDB(t, tt.dialect)
+			repo := NewGormRepository[TestEntity, *TestFilter, *TestUpdater](db)
+			filter := &TestFilter{filters: []*Filter{
+				{Field: "categories.name", Operator: OperatorEqual, Value: "Tools"},
+				{Field: "name", Operator: OperatorEqual, Value: "Widget"},
+			}}
+
+			sql, _, err := buildDryRunSQL(t, db, filter.filters...)
+			require.NoError(t, err)
+			assert.Contains(t, sql, tt.where)
+
+			join := WithJoin("JOIN categories ON categories.id = test_entities.age")
+			sql, _, err = repo.ExplainFilter(filter, join,
+				WithSort("categories.name", Asc), WithSort("age", Desc), WithSelect("name"))
+			require.NoError(t, err)
+			assert.Contains(t, sql, "JOIN categories ON categories.id = test_entities.age "+tt.joined)
+			assert.Contains(t, sql, tt.orderBy)
+			assert.Contains(t, sql, tt.selected)
+
+			query, err := repo.applyOptions(db, WithGroupBy("categories.name"))
+			require.NoError(t, err)
+			assert.Contains(t, query.Find(&[]*TestEntity{}).Statement.SQL.String(), tt.groupBy)
+		})
+	}
+}
+
+func TestMonthExpression(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DialectPostgres, `to_char(date_trunc('month', "created_at"), 'YYYY-MM')`},
+		{DialectMySQL, "DATE_FORMAT(`created_at`, '%Y-%m')"},
+		{DialectSQLite, "strftime('%Y-%m', `created_at`)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			db := setupDialectDB(t, tt.dialect)
+			expr, err := monthExpression(db, db.Statement.Quote("created_at"))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, expr)
+		})
+	}
+
+	t.Run("unknown dialect", func(t *testing.T) {
+		db := setupDialectDB(t, "sqlserver")
+		_, err := monthExpression(db, "created_at")
+		assert.ErrorIs(t, err, ErrUnsupportedDialect)
+	})
+}
+
+func TestApplyOptions_SortFields(t *testing.T) {
+	db := setupDialectDB(t, DialectSQLite)
+	repo := NewGormRepository[TestEntity, *TestFilter, *TestUpdater](db)
+
+	orderBy := func(options ...OptionFunc) string {
+		t.Helper()
+		query, err := repo.applyOptions(db, options...)
+		require.NoError(t, err)
+		sql := query.Find(&[]*TestEntity{}).Statement.SQL.String()
+		_, order, _ := strings.Cut(sql, "ORDER BY ")
+		return order
+	}
+
+	t.Run("duplicates keep the first occurrence", func(t *testing.T) {
+		order := orderBy(WithSort("age", Desc), WithSort("name", Asc), WithSort("age", Asc))
+		assert.Equal(t, "`age` desc,`name` asc", order)
+	})
+
+	t.Run("stable appends the primary key", func(t *testing.T) {
+		assert.Equal(t, "`age` desc,`id` asc", orderBy(WithSort("age", Desc), WithSortStable()))
+	})
+
+	t.Run("stable keeps an explicit primary key order", func(t *testing.T) {
+		assert.Equal(t, "`id` desc,`age` asc", orderBy(WithSort("id", Desc), WithSort("age", Asc), WithSortStable()))
+	})
+
+	t.Run("cursor field is not sorted twice", func(t *testing.T) {
+		order := orderBy(WithCursor("age", 30, "desc"), WithSort("age", Asc), WithSortStable())
+		assert.Equal(t, "`age` desc,`id` desc", order, "the primary key breaks cursor ties in the cursor direction")
+	})
+}
+
+func TestApplyOptions_GroupByHaving(t *testing.T) {
+	db := setupDialectDB(t, DialectPostgres)
+	repo := NewGormRepository[TestEntity, *TestFilter, *TestUpdater](db)
+
+	query, err := repo.applyOptions(db,
+		WithGroupBy("is_active", "age"),
+		WithHaving(
+			&Filter{Field: "sum( age )", Operator: OperatorGreaterThanOrEqual, Value: 50},
+			&Filter{Field: "is_active", Operator: OperatorEqual, Value: true},
+		),
+	)
+	require.NoError(t, err)
+
+	stmt := query.Find(&[]*TestEntity{}).Statement
+	assert.Contains(t, stmt.SQL.String(), `GROUP BY "is_active","age" HAVING SUM("age") >= $1 AND "is_active" = $2`)
+	assert.Equal(t, []interface{}{50, true}, stmt.Vars)
+
+	_, err = repo.applyOptions(db, WithHaving(&Filter{Field: "COUNT(*)", Operator: "~"}))
+	assert.ErrorIs(t, err, ErrUnknownOperator)
+}
+
+func TestApplyOptions_Lock(t *testing.T) {
+	lockSQL := func(t *testing.T, dialect string, options ...OptionFunc) (string, error) {
+		t.Helper()
+		db := setupDialectDB(t, dialect)
+		repo := NewGormRepository[TestEntity, *TestFilter, *TestUpdater](db)
+		query, err := repo.applyOptions(db, options...)
+		if err != nil {
+			return "", err
+		}
+		return query.Find(&[]*TestEntity{}).Statement.SQL.String(), nil
+	}
+
+	t.Run("postgres for update skip locked", func(t *testing.T) {
+		sql, err := lockSQL(t, DialectPostgres, WithLock(LockUpdate, true))
+		require.NoError(t, err)
+		assert.True(t, strings.HasSuffix(sql, "FOR UPDATE SKIP LOCKED"), sql)
+	})
+
+	t.Run("strength is case-insensitive", func(t *testing.T) {
+		sql, err := lockSQL(t, DialectMySQL, WithLock("share", false))
+		require.NoError(t, err)
+		assert.True(t, strings.HasSuffix(sql, "FOR SHARE"), sql)
+	})
+
+	t.Run("sqlite ignores the lock", func(t *testing.T) {
+		sql, err := lockSQL(t, DialectSQLite, WithLock(LockUpdate, true))
+		require.NoError(t, err)
+		assert.NotContains(t, sql, "FOR ")
+	})
+
+	t.Run("invalid strength", func(t *testing.T) {
+		_, err := lockSQL(t, DialectSQLite, WithLock("UPDATE; DROP TABLE users", false))
+		assert.ErrorIs(t, err, ErrInvalidLock)
+	})
+}
+
+func TestApplyOptions_Distinct(t *testing.T) {
+	distinctSQL := func(t *testing.T, dialect string, options ...OptionFunc) (string, error) {
+		t.Helper()
+		db := setupDialectDB(t, dialect)
+		repo := NewGormRepository[TestEntity, *TestFilter, *TestUpdater](db)
+		query, err := repo.applyOptions(db, options...)
+		if err != nil {
+			return "", err
+		}
+		return query.Find(&[]*TestEntity{}).Statement.SQL.String(), nil
+	}
+
+	tests := []struct {
+		name     string
+		dialect  string
+		options  []OptionFunc
+		expected string
+	}{
+		{"distinct rows", DialectMySQL, []OptionFunc{WithDistinct()}, "SELECT DISTINCT `test_entities`.* FROM `test_entities`"},
+		{"distinct selected rows", DialectMySQL, []OptionFunc{WithDistinct(), WithSelect("name")}, "SELECT DISTINCT `name`,`id` FROM `test_entities`"},
+		{"distinct fields", DialectMySQL, []OptionFunc{WithDistinct("age", "is_active")},
+			"SELECT DISTINCT `age`,`is_active` FROM `test_entities`"},
+		{"distinct on", DialectPostgres, []OptionFunc{WithDistinctOn("age"), WithSort("age", Asc), WithSort("name", Desc)},
+			`SELECT DISTINCT ON ("age") "test_entities".* FROM "test_entities" ORDER BY "age" asc,"name" desc`},
+		{"distinct on unsorted", DialectPostgres, []OptionFunc{WithDistinctOn("age", "is_active")},
+			`SELECT DISTINCT ON ("age", "is_active") "test_entities".* FROM "test_entities"`},
+		{"distinct on sorted in other order", DialectPostgres,
+			[]OptionFunc{WithDistinctOn("age", "is_active"), WithSort("is_active", Desc), WithSort("test_entities.age", Asc)},
+			`SELECT DISTINCT ON ("age", "is_active") "test_entities".* FROM "test_entities" ORDER BY "is_active" desc,"test_entities"."age" asc`},
+		{"distinct on selected", DialectPostgres, []OptionFunc{WithDistinctOn("age"), WithSelect("name")},
+			`SELECT DISTINCT ON ("age") "name", "id" FROM "test_entities"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, err := distinctSQL(t, tt.dialect, tt.options...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, sql)
+		})
+	}
+
+	errorTests := []struct {
+		name     string
+		dialect  string
+		options  []OptionFunc
+		expected error
+	}{
+		{"distinct on outside postgres", DialectMySQL, []OptionFunc{WithDistinctOn("age")}, ErrUnsupportedDialect},
+		{"sorted by another field first", DialectPostgres, []OptionFunc{WithDistinctOn("age"), WithSort("name", Asc)}, ErrInvalidDistinct},
+		{"cursor on another field", DialectPostgres, []OptionFunc{WithDistinctOn("age"), WithCursor("id", 10, "asc")}, ErrInvalidDistinct},
+		{"distinct and distinct on", DialectPostgres, []OptionFunc{WithDistinct(), WithDistinctOn("age")}, ErrInvalidDistinct},
+		{"distinct fields and select", DialectSQLite, []OptionFunc{WithDistinct("age"), WithSelect("name")}, ErrInvalidDistinct},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := distinctSQL(t, tt.dialect, tt.options...)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
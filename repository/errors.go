@@ -23,6 +23,18 @@ var (
 	// ErrNoGoFiles indicates that no Go files were found in the specified directory
 	ErrNoGoFiles = errors.New("no Go files found in directory")
 
+	// ErrInvalidConfig indicates a malformed CLI config file
+	ErrInvalidConfig = errors.New("invalid config file")
+
+	// ErrTableNotFound indicates that a table to generate from does not exist in the database
+	ErrTableNotFound = errors.New("table does not exist")
+
+	// ErrUnsupportedDatabaseURL indicates a -from-db URL whose database is not supported
+	ErrUnsupportedDatabaseURL = errors.New("unsupported database URL, expected postgres://, mysql:// or sqlite://")
+
+	// ErrOutputDiffers indicates that the generated code differs from the existing output file
+	ErrOutputDiffers = errors.New("generated code differs from the output file")
+
 	// ErrUnknownOperator indicates that an unknown operator was used in a filter
 	ErrUnknownOperator = errors.New("unknown operator in filter")
 )
@@ -34,6 +46,33 @@ var (
 
 	// ErrNoAnnotatedStructs indicates that no structs with querybuilder annotations were found
 	ErrNoAnnotatedStructs = errors.New("no structs with querybuilder annotations found")
+
+	// ErrFacadeSamePackage indicates that the facade file would be written into the generated package itself
+	ErrFacadeSamePackage = errors.New("facade must be generated into a different directory than the querybuilder code")
+
+	// ErrFacadeExternalPackage indicates a facade requested for code generated into another package
+	ErrFacadeExternalPackage = errors.New("facade cannot be combined with generating into another package")
+
+	// ErrInvalidPackageName indicates that a package name is not a valid Go identifier
+	ErrInvalidPackageName = errors.New("invalid package name")
+
+	// ErrInvalidFilterStorage indicates an unknown filter storage mode
+	ErrInvalidFilterStorage = errors.New("invalid filter storage, expected map or slice")
+
+	// ErrInvalidNaming indicates an unknown column naming, expected snake, camel or none
+	ErrInvalidNaming = errors.New("invalid naming, expected snake, camel or none")
+
+	// ErrInvalidBuildTag indicates a build tag that is not a valid build constraint expression
+	ErrInvalidBuildTag = errors.New("invalid build tag")
+
+	// ErrInvalidMethodName indicates a method name template or suffix giving invalid or colliding method names
+	ErrInvalidMethodName = errors.New("invalid method name")
+
+	// ErrInvalidAnnotation indicates a malformed querybuilder annotation argument
+	ErrInvalidAnnotation = errors.New("invalid querybuilder annotation")
+
+	// ErrInvalidStruct indicates a struct definition assembled outside the parser that cannot be generated
+	ErrInvalidStruct = errors.New("invalid struct definition")
 )
 
 // Repository operation errors
@@ -43,6 +82,63 @@ var (
 
 	// ErrEmptyFieldName indicates that a filter has an empty field name
 	ErrEmptyFieldName = errors.New("empty field name in filter")
+
+	// ErrUnknownField indicates a field name that is not a column of the entity
+	ErrUnknownField = errors.New("unknown field")
+
+	// ErrNotSoftDeletable indicates an entity without a gorm.DeletedAt field
+	ErrNotSoftDeletable = errors.New("entity has no gorm.DeletedAt field")
+
+	// ErrUnsupportedDialect indicates that an operator is not available for the database dialect in use
+	ErrUnsupportedDialect = errors.New("operator not supported by database dialect")
+
+	// ErrInvalidFilterValue indicates that a filter value has the wrong shape for its operator
+	ErrInvalidFilterValue = errors.New("invalid filter value for operator")
+
+	// ErrFilterValueNotList indicates an IN or NOT IN filter whose value is not a slice or array
+	ErrFilterValueNotList = errors.New("IN and NOT IN filters need a slice or array value")
+
+	// ErrFilterValueNotNil indicates an IS NULL or IS NOT NULL filter carrying a value
+	ErrFilterValueNotNil = errors.New("IS NULL and IS NOT NULL filters take no value")
+
+	// ErrFilterValueNotString indicates a LIKE, NOT LIKE, ILIKE or REGEX filter whose value is not a string
+	ErrFilterValueNotString = errors.New("LIKE and REGEX filters need a string value")
+
+	// ErrInvalidSortDirection indicates a sort direction other than asc or desc
+	ErrInvalidSortDirection = errors.New("invalid sort direction")
+
+	// ErrInvalidAggregate indicates an aggregate function or expression that is not supported
+	ErrInvalidAggregate = errors.New("invalid aggregate")
+
+	// ErrInvalidLock indicates a lock strength other than the Lock* constants
+	ErrInvalidLock = errors.New("invalid lock strength")
+
+	// ErrInvalidDistinct indicates DISTINCT options that conflict with each other or with the sort order
+	ErrInvalidDistinct = errors.New("invalid DISTINCT option")
+
+	// ErrInvalidPrimaryKey indicates a primary key lookup whose values don't match the entity's key columns
+	ErrInvalidPrimaryKey = errors.New("invalid primary key")
+
+	// ErrInvalidCursor indicates a pagination cursor or cursor token that cannot be used
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+	// ErrUnsupportedOption indicates a query option the repository implementation cannot apply
+	ErrUnsupportedOption = errors.New("option not supported by this repository")
+
+	// ErrNestedTransactionOptions indicates transaction options passed for a transaction nested in another
+	ErrNestedTransactionOptions = errors.New("transaction options cannot be changed inside a transaction")
+
+	// ErrInvalidPageSize indicates a page size that is not positive
+	ErrInvalidPageSize = errors.New("page size must be positive")
+
+	// ErrInvalidPage indicates a page number below 1, or an offset that doesn't start a page
+	ErrInvalidPage = errors.New("invalid page")
+
+	// ErrConstraintViolation indicates an updater value that violates a querybuilder:"min=0" style field constraint
+	ErrConstraintViolation = errors.New("value violates field constraint")
+
+	// ErrOptimisticLock indicates an Update of a record whose version another writer changed, or that was deleted
+	ErrOptimisticLock = errors.New("record was modified or deleted concurrently")
 )
 
 // Template and formatting errors
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"reflect"
+	"strings"
+)
+
+// likeEscaper escapes the LIKE wildcards and the escape character itself
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// EscapeLike escapes the LIKE wildcards % and _ (and the backslash escape character) in s,
+// so that s matches literally when embedded in a LIKE pattern.
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// FiltersEqual reports whether a and b hold the same filters regardless of their order.
+// Filters are compared by field, operator and deep-equal value, and duplicates must
+// appear the same number of times in both slices.
+func FiltersEqual(a, b []*Filter) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	matched := make([]bool, len(b))
+	for _, filter := range a {
+		found := false
+		for i, candidate := range b {
+			if matched[i] || !filterEqual(filter, candidate) {
+				continue
+			}
+			matched[i] = true
+			found = true
+			break
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// filterEqual compares two filters by field, operator and value
+func filterEqual(a, b *Filter) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Field == b.Field && a.Operator == b.Operator && reflect.DeepEqual(a.Value, b.Value)
+}
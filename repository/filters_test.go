@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFiltersEqual(t *testing.T) {
+	name := &Filter{Field: "name", Operator: OperatorEqual, Value: "Alice"}
+	age := &Filter{Field: "age", Operator: OperatorGreaterThan, Value: 18}
+	ids := &Filter{Field: "id", Operator: OperatorIn, Value: []int64{1, 2, 3}}
+
+	tests := []struct {
+		name     string
+		a        []*Filter
+		b        []*Filter
+		expected bool
+	}{
+		{name: "both empty", a: nil, b: []*Filter{}, expected: true},
+		{name: "same order", a: []*Filter{name, age, ids}, b: []*Filter{name, age, ids}, expected: true},
+		{name: "different order", a: []*Filter{name, age, ids}, b: []*Filter{ids, name, age}, expected: true},
+		{
+			name:     "equal copies",
+			a:        []*Filter{ids},
+			b:        []*Filter{{Field: "id", Operator: OperatorIn, Value: []int64{1, 2, 3}}},
+			expected: true,
+		},
+		{name: "different length", a: []*Filter{name, age}, b: []*Filter{name}, expected: false},
+		{
+			name:     "different value",
+			a:        []*Filter{age},
+			b:        []*Filter{{Field: "age", Operator: OperatorGreaterThan, Value: 21}},
+			expected: false,
+		},
+		{
+			name:     "different operator",
+			a:        []*Filter{age},
+			b:        []*Filter{{Field: "age", Operator: OperatorGreaterThanOrEqual, Value: 18}},
+			expected: false,
+		},
+		{name: "duplicates counted", a: []*Filter{name, name, age}, b: []*Filter{name, age, age}, expected: false},
+		{name: "nil entries", a: []*Filter{nil, name}, b: []*Filter{name, nil}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FiltersEqual(tt.a, tt.b))
+			assert.Equal(t, tt.expected, FiltersEqual(tt.b, tt.a))
+		})
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	tests := map[string]string{
+		"widget":    "widget",
+		"100%":      `100\%`,
+		"snake_key": `snake\_key`,
+		`C:\temp`:   `C:\\temp`,
+		`50%_\`:     `50\%\_\\`,
+	}
+
+	for input, expected := range tests {
+		assert.Equal(t, expected, EscapeLike(input), "input %q", input)
+	}
+}
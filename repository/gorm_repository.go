@@ -2,17 +2,25 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
-	"time"
+	"iter"
+	"reflect"
+	"slices"
+	"strings"
+	"sync/atomic"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 )
 
 // GormRepository provides a complete GORM-based repository implementation
 // that integrates seamlessly with the existing filter and updater system
 type GormRepository[Entity any, Filter EntityFilter, Updater EntityUpdater] struct {
-	db *gorm.DB
+	db     *gorm.DB
+	config RepoConfig
 }
 
 // NewGormRepository creates a new GORM-based repository
@@ -24,13 +32,65 @@ func NewGormRepository[Entity any, Filter EntityFilter, Updater EntityUpdater](
 	}
 }
 
+// NewGormRepositoryWithConfig creates a new GORM-based repository with optional behaviour enabled
+func NewGormRepositoryWithConfig[Entity any, Filter EntityFilter, Updater EntityUpdater](
+	db *gorm.DB,
+	config RepoConfig,
+) *GormRepository[Entity, Filter, Updater] {
+	return &GormRepository[Entity, Filter, Updater]{
+		db:     db,
+		config: config,
+	}
+}
+
 // Create implements efficient record creation
-func (r *GormRepository[Entity, Filter, Updater]) Create(ctx context.Context, records ...*Entity) error {
+func (r *GormRepository[Entity, Filter, Updater]) Create(ctx context.Context, records ...*Entity) (err error) {
+	var noFilter Filter
+	ctx, done := r.instrument(ctx, "Create", noFilter, nil)
+	defer func() { done(err, int64(len(records))) }()
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
+	if len(records) == 0 {
+		return ErrNoRecordsProvided
+	}
+
+	err = r.db.WithContext(ctx).Create(records).Error
+	if err != nil {
+		return fmt.Errorf("create records: %w", err)
+	}
+
+	return nil
+}
+
+// CreateReturning creates records like Create, then fills in every column the database set,
+// such as defaults and generated columns, through RETURNING. Only Postgres and SQLite support
+// it; other databases return ErrUnsupportedDialect.
+func (r *GormRepository[Entity, Filter, Updater]) CreateReturning(ctx context.Context, records ...*Entity) (err error) {
+	var noFilter Filter
+	ctx, done := r.instrument(ctx, "CreateReturning", noFilter, nil)
+	defer func() { done(err, int64(len(records))) }()
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
 	if len(records) == 0 {
 		return ErrNoRecordsProvided
 	}
+	if err := requireReturning(r.db); err != nil {
+		return err
+	}
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return err
+	}
 
-	err := r.db.WithContext(ctx).Create(records).Error
+	// Naming the columns makes GORM scan into the given records rather than replace them
+	returning := clause.Returning{}
+	for _, column := range entitySchema.DBNames {
+		returning.Columns = append(returning.Columns, clause.Column{Name: column})
+	}
+
+	err = r.db.WithContext(ctx).Clauses(returning).Create(records).Error
 	if err != nil {
 		return fmt.Errorf("create records: %w", err)
 	}
@@ -38,22 +98,151 @@ func (r *GormRepository[Entity, Filter, Updater]) Create(ctx context.Context, re
 	return nil
 }
 
-// FindOneByID implements single record lookup by ID
+// MustCreate creates records and panics if that fails. It exists to keep test fixtures short;
+// production code should call Create and handle the error.
+func (r *GormRepository[Entity, Filter, Updater]) MustCreate(ctx context.Context, records ...*Entity) {
+	if err := r.Create(ctx, records...); err != nil {
+		panic(fmt.Errorf("MustCreate: %w", err))
+	}
+}
+
+// FindOneByID implements single record lookup by an integer primary key
 func (r *GormRepository[Entity, Filter, Updater]) FindOneByID(
 	ctx context.Context,
 	id int64,
 ) (*Entity, bool, error) {
-	var result Entity
-	err := r.db.WithContext(ctx).Where("id = ?", id).Take(&result).Error
+	return r.FindByPrimaryKey(ctx, id)
+}
+
+// FindOneByStringID implements single record lookup by a string primary key, such as a UUID
+func (r *GormRepository[Entity, Filter, Updater]) FindOneByStringID(
+	ctx context.Context,
+	id string,
+) (*Entity, bool, error) {
+	return r.FindByPrimaryKey(ctx, id)
+}
+
+// FindByPrimaryKey looks a record up by the columns tagged gorm:"primaryKey", or by ID when none
+// are tagged. Composite keys take one value per key column, in field declaration order.
+func (r *GormRepository[Entity, Filter, Updater]) FindByPrimaryKey(
+	ctx context.Context,
+	keys ...interface{},
+) (result *Entity, found bool, err error) {
+	var noFilter Filter
+	ctx, done := r.instrument(ctx, "FindByPrimaryKey", noFilter, nil)
+	defer func() { done(err, boolRows(found)) }()
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(entitySchema.PrimaryFields) == 0 {
+		return nil, false, fmt.Errorf("%w: %s has no primary key", ErrInvalidPrimaryKey, entitySchema.Name)
+	}
+	if len(keys) != len(entitySchema.PrimaryFields) {
+		return nil, false, fmt.Errorf("%w: %s has %d primary key columns, got %d values",
+			ErrInvalidPrimaryKey, entitySchema.Name, len(entitySchema.PrimaryFields), len(keys))
+	}
 
+	query, err := r.applyScope(r.db.WithContext(ctx))
 	if err != nil {
+		return nil, false, err
+	}
+	for i, field := range entitySchema.PrimaryFields {
+		query = query.Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: keys[i]})
+	}
+
+	var record Entity
+	if err := query.Take(&record).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, false, nil
 		}
-		return nil, false, fmt.Errorf("find record by ID %d: %w", id, err)
+		return nil, false, fmt.Errorf("find record by primary key %v: %w", keys, err)
 	}
 
-	return &result, true, nil
+	return &record, true, nil
+}
+
+// FindByIDs returns the records whose integer primary key is one of ids, in a single IN query.
+// Missing IDs are skipped, and the records come back in no particular order.
+func (r *GormRepository[Entity, Filter, Updater]) FindByIDs(
+	ctx context.Context,
+	ids ...int64,
+) (result []*Entity, err error) {
+	var noFilter Filter
+	ctx, done := r.instrument(ctx, "FindByIDs", noFilter, nil)
+	defer func() { done(err, int64(len(result))) }()
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
+	keyField, err := r.singlePrimaryField()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	values := make([]interface{}, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+
+	query, err := r.applyScope(r.db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	err = query.
+		Where(clause.IN{Column: clause.Column{Table: clause.CurrentTable, Name: keyField.DBName}, Values: values}).
+		Find(&result).Error
+	if err != nil {
+		return nil, fmt.Errorf("find records by IDs: %w", err)
+	}
+	return result, nil
+}
+
+// FindMapByIDs is FindByIDs keyed by primary key, e.g. to resolve the references of a list of
+// records without a query per record
+func (r *GormRepository[Entity, Filter, Updater]) FindMapByIDs(
+	ctx context.Context,
+	ids ...int64,
+) (map[int64]*Entity, error) {
+	records, err := r.FindByIDs(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	keyField, err := r.singlePrimaryField()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int64]*Entity, len(records))
+	for _, record := range records {
+		key := reflect.Indirect(keyField.ReflectValueOf(ctx, reflect.ValueOf(record).Elem()))
+		if !key.CanInt() {
+			return nil, fmt.Errorf("%w: %s is not an integer", ErrInvalidPrimaryKey, keyField.Name)
+		}
+		result[key.Int()] = record
+	}
+	return result, nil
+}
+
+// singlePrimaryField returns the primary key field of an entity keyed by one column
+func (r *GormRepository[Entity, Filter, Updater]) singlePrimaryField() (*schema.Field, error) {
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return nil, err
+	}
+	if len(entitySchema.PrimaryFields) != 1 {
+		return nil, fmt.Errorf("%w: %s has %d primary key columns, expected 1",
+			ErrInvalidPrimaryKey, entitySchema.Name, len(entitySchema.PrimaryFields))
+	}
+	return entitySchema.PrimaryFields[0], nil
 }
 
 // FindOne implements single record lookup with filters
@@ -61,16 +250,34 @@ func (r *GormRepository[Entity, Filter, Updater]) FindOne(
 	ctx context.Context,
 	filter Filter,
 	options ...OptionFunc,
-) (*Entity, bool, error) {
+) (entity *Entity, found bool, err error) {
+	ctx, done := r.instrument(ctx, "FindOne", filter, options)
+	defer func() {
+		var rows int64
+		if found {
+			rows = 1
+		}
+		done(err, rows)
+	}()
+	ctx, cancel := r.queryContext(ctx, options...)
+	defer cancel()
+
 	var result Entity
-	query, err := r.buildQuery(r.db.WithContext(ctx), filter)
+	query, err := r.buildQuery(r.db.WithContext(ctx), filter, options...)
 	if err != nil {
 		return nil, false, fmt.Errorf("FindOne build query: %w", err)
 	}
 
-	query = r.applyOptions(query, options...)
+	query, err = r.applyOptions(query, options...)
+	if err != nil {
+		return nil, false, fmt.Errorf("FindOne: %w", err)
+	}
+	// A session lets the query be executed again on retry without sharing statement state
+	query = query.Session(&gorm.Session{})
 
-	err = query.Take(&result).Error
+	err = r.config.ReadRetry.do(ctx, func() error {
+		return query.Take(&result).Error
+	})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, false, nil
@@ -86,16 +293,26 @@ func (r *GormRepository[Entity, Filter, Updater]) FindAll(
 	ctx context.Context,
 	filter Filter,
 	options ...OptionFunc,
-) ([]*Entity, error) {
-	var result []*Entity
-	query, err := r.buildQuery(r.db.WithContext(ctx), filter)
+) (result []*Entity, err error) {
+	ctx, done := r.instrument(ctx, "FindAll", filter, options)
+	defer func() { done(err, int64(len(result))) }()
+	ctx, cancel := r.queryContext(ctx, options...)
+	defer cancel()
+
+	query, err := r.buildQuery(r.db.WithContext(ctx), filter, options...)
 	if err != nil {
 		return nil, fmt.Errorf("FindAll build query: %w", err)
 	}
 
-	query = r.applyOptions(query, options...)
+	query, err = r.applyOptions(query, options...)
+	if err != nil {
+		return nil, fmt.Errorf("FindAll: %w", err)
+	}
+	query = query.Session(&gorm.Session{})
 
-	err = query.Find(&result).Error
+	err = r.config.ReadRetry.do(ctx, func() error {
+		return query.Find(&result).Error
+	})
 	if err != nil {
 		return nil, fmt.Errorf("find all records: %w", err)
 	}
@@ -103,206 +320,1617 @@ func (r *GormRepository[Entity, Filter, Updater]) FindAll(
 	return result, nil
 }
 
-// Update implements record updates using updaters
-func (r *GormRepository[Entity, Filter, Updater]) Update(
+// FindAllAndCount returns the records FindAll returns for filter and options, together with the
+// total number of records matching the filter, as Count returns it: limits, offsets and sorting
+// don't change the total. With distinct, grouping or join options the total counts the rows the
+// page query selects, over a subquery. Both queries share the filter conditions; outside a
+// transaction concurrent writes may still land between them. The page query is skipped when
+// nothing matches.
+func (r *GormRepository[Entity, Filter, Updater]) FindAllAndCount(
 	ctx context.Context,
-	record *Entity,
-	updater Updater,
-) error {
-	changeSet := updater.GetChangeSet()
-	if len(changeSet) == 0 {
-		return nil // No changes to apply
-	}
+	filter Filter,
+	options ...OptionFunc,
+) (result []*Entity, total int64, err error) {
+	ctx, done := r.instrument(ctx, "FindAllAndCount", filter, options)
+	defer func() { done(err, int64(len(result))) }()
+	ctx, cancel := r.queryContext(ctx, options...)
+	defer cancel()
 
-	result := r.db.WithContext(ctx).Model(record).Updates(changeSet)
-	if result.Error != nil {
-		return fmt.Errorf("update record: %w", result.Error)
+	db := r.db.WithContext(ctx)
+	if newOptions(options...).Unscoped {
+		db = db.Unscoped()
 	}
 
-	return nil
-}
+	filtered, err := r.buildQuery(db, filter, options...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("FindAllAndCount build query: %w", err)
+	}
+	// A new session lets the count and the page query start from the same conditions
+	filtered = filtered.Session(&gorm.Session{})
 
-// WithTransaction executes a function within a database transaction
-func (r *GormRepository[Entity, Filter, Updater]) WithTransaction(
-	ctx context.Context,
-	fn func(*GormRepository[Entity, Filter, Updater]) error,
-) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
-		txRepo := &GormRepository[Entity, Filter, Updater]{
-			db: tx,
+	count := filtered.Model(new(Entity))
+	if opts := newOptions(options...); opts.Distinct || len(opts.DistinctOn) > 0 || len(opts.GroupBy) > 0 || len(opts.Joins) > 0 {
+		// Rows may be merged or repeated, so count the rows the page query selects
+		counted, err := r.applyOptions(filtered, append(slices.Clip(options), withoutPaging())...)
+		if err != nil {
+			return nil, 0, fmt.Errorf("FindAllAndCount: %w", err)
 		}
-		return fn(txRepo)
+		count = r.db.WithContext(ctx).Table("(?) AS counted", counted.Model(new(Entity)))
+	}
+	err = r.config.ReadRetry.do(ctx, func() error {
+		return count.Count(&total).Error
 	})
-}
-
-// CreateInBatches implements batch creation
-func (r *GormRepository[Entity, Filter, Updater]) CreateInBatches(
-	ctx context.Context,
-	batchSize int,
-	records ...*Entity,
-) error {
-	if len(records) == 0 {
-		return ErrNoRecordsProvided
+	if err != nil {
+		return nil, 0, fmt.Errorf("count records: %w", err)
+	}
+	if total == 0 {
+		return []*Entity{}, 0, nil
 	}
 
-	if batchSize <= 0 {
-		batchSize = 100 // Default batch size
+	page, err := r.applyOptions(filtered, options...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("FindAllAndCount: %w", err)
 	}
+	page = page.Session(&gorm.Session{})
 
-	result := r.db.WithContext(ctx).CreateInBatches(records, batchSize)
-	if result.Error != nil {
-		return fmt.Errorf("create records in batches: %w", result.Error)
+	err = r.config.ReadRetry.do(ctx, func() error {
+		return page.Find(&result).Error
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("find all records: %w", err)
 	}
 
-	return nil
+	return result, total, nil
 }
 
-// UpdateWithFilter implements batch updates using filters
-func (r *GormRepository[Entity, Filter, Updater]) UpdateWithFilter(
-	ctx context.Context,
-	filter Filter,
-	updater Updater,
-) (int64, error) {
-	changeSet := updater.GetChangeSet()
-	if len(changeSet) == 0 {
-		return 0, nil
+// withoutPaging drops the options that only shape the page, not which rows match
+func withoutPaging() OptionFunc {
+	return &functionOption{
+		f: func(o *Options) {
+			o.Limit, o.Offset, o.Lock, o.Preloads = nil, nil, nil, nil
+		},
 	}
+}
 
-	query, err := r.buildQuery(r.db.WithContext(ctx), filter)
+// ExplainFilter returns the SELECT FindAll would run for filter and options, with its bind
+// variables, without executing it. Placeholders are those of the database, e.g. $1 on Postgres.
+func (r *GormRepository[Entity, Filter, Updater]) ExplainFilter(
+	filter Filter,
+	options ...OptionFunc,
+) (string, []interface{}, error) {
+	query, err := r.buildQuery(r.db.Session(&gorm.Session{DryRun: true}), filter, options...)
 	if err != nil {
-		return 0, fmt.Errorf("UpdateWithFilter build query: %w", err)
+		return "", nil, fmt.Errorf("ExplainFilter build query: %w", err)
 	}
 
-	result := query.Model(new(Entity)).Updates(changeSet)
-	if result.Error != nil {
-		return 0, fmt.Errorf("update records with filter: %w", result.Error)
+	query, err = r.applyOptions(query, options...)
+	if err != nil {
+		return "", nil, fmt.Errorf("ExplainFilter: %w", err)
 	}
 
-	return result.RowsAffected, nil
+	var result []*Entity
+	query = query.Find(&result)
+	if query.Error != nil {
+		return "", nil, fmt.Errorf("explain query: %w", query.Error)
+	}
+	return query.Statement.SQL.String(), query.Statement.Vars, nil
 }
 
-// DeleteWithFilter implements batch deletion using filters
-func (r *GormRepository[Entity, Filter, Updater]) DeleteWithFilter(
+// FindEach streams the records matching filter to fn one row at a time, without loading the whole
+// result into memory. Iteration stops at the first error returned by fn, which FindEach returns.
+// Streamed queries are not retried, and preloads are ignored since rows are scanned one by one.
+func (r *GormRepository[Entity, Filter, Updater]) FindEach(
 	ctx context.Context,
 	filter Filter,
-) (int64, error) {
-	query, err := r.buildQuery(r.db.WithContext(ctx), filter)
+	fn func(*Entity) error,
+	options ...OptionFunc,
+) (err error) {
+	var streamed int64
+	ctx, done := r.instrument(ctx, "FindEach", filter, options)
+	defer func() { done(err, streamed) }()
+	ctx, cancel := r.queryContext(ctx, options...)
+	defer cancel()
+
+	query, err := r.buildQuery(r.db.WithContext(ctx), filter, options...)
 	if err != nil {
-		return 0, fmt.Errorf("DeleteWithFilter build query: %w", err)
+		return fmt.Errorf("FindEach build query: %w", err)
 	}
 
-	result := query.Delete(new(Entity))
-	if result.Error != nil {
-		return 0, fmt.Errorf("delete records with filter: %w", result.Error)
+	query, err = r.applyOptions(query, options...)
+	if err != nil {
+		return fmt.Errorf("FindEach: %w", err)
 	}
 
-	return result.RowsAffected, nil
+	rows, err := query.Model(new(Entity)).Rows()
+	if err != nil {
+		return fmt.Errorf("find each record: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record Entity
+		if err := query.ScanRows(rows, &record); err != nil {
+			return fmt.Errorf("find each record: scan row: %w", err)
+		}
+		streamed++
+		if err := fn(&record); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("find each record: %w", err)
+	}
+	return nil
 }
 
-// Count implements record counting
-func (r *GormRepository[Entity, Filter, Updater]) Count(
+// errStopIteration ends FindEach when the consumer of Iterate breaks out of its loop
+var errStopIteration = errors.New("stop iteration")
+
+// Iterate is FindEach for range loops. A query error is yielded once with a nil record and ends
+// the iteration; breaking out of the loop closes the underlying rows.
+//
+//	for record, err := range repo.Iterate(ctx, filter) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (r *GormRepository[Entity, Filter, Updater]) Iterate(
 	ctx context.Context,
 	filter Filter,
-) (int64, error) {
-	query, err := r.buildQuery(r.db.WithContext(ctx), filter)
+	options ...OptionFunc,
+) iter.Seq2[*Entity, error] {
+	return func(yield func(*Entity, error) bool) {
+		err := r.FindEach(ctx, filter, func(record *Entity) error {
+			if !yield(record, nil) {
+				return errStopIteration
+			}
+			return nil
+		}, options...)
+		if err != nil && !errors.Is(err, errStopIteration) {
+			yield(nil, err)
+		}
+	}
+}
+
+// FindPage returns one page of records using keyset (cursor) pagination. Pages are ordered by
+// the WithCursor or WithCursorToken field, or by the primary key ascending when neither is
+// given; a field that is not unique is followed by the primary key, so that rows with equal
+// values are neither skipped nor repeated. nextCursor is an opaque token to pass to
+// WithCursorToken for the following page; it is empty on the last page.
+func (r *GormRepository[Entity, Filter, Updater]) FindPage(
+	ctx context.Context,
+	filter Filter,
+	pageSize int,
+	options ...OptionFunc,
+) (result []*Entity, nextCursor string, err error) {
+	ctx, done := r.instrument(ctx, "FindPage", filter, options)
+	defer func() { done(err, int64(len(result))) }()
+	ctx, cancel := r.queryContext(ctx, options...)
+	defer cancel()
+
+	if pageSize <= 0 {
+		return nil, "", fmt.Errorf("%w: %d", ErrInvalidPageSize, pageSize)
+	}
+
+	entitySchema, err := r.entitySchema()
 	if err != nil {
-		return 0, fmt.Errorf("count build query: %w", err)
+		return nil, "", fmt.Errorf("FindPage: %w", err)
 	}
 
-	var count int64
-	err = query.Model(new(Entity)).Count(&count).Error
+	cursor, err := pageCursor(entitySchema, options)
 	if err != nil {
-		return 0, fmt.Errorf("count records: %w", err)
+		return nil, "", fmt.Errorf("FindPage: %w", err)
+	}
+	resolved, err := r.cursorColumn(&cursor)
+	if err != nil {
+		return nil, "", fmt.Errorf("FindPage: %w", err)
 	}
+	cursor = *resolved
 
-	return count, nil
-}
+	query, err := r.buildQuery(r.db.WithContext(ctx), filter, options...)
+	if err != nil {
+		return nil, "", fmt.Errorf("FindPage build query: %w", err)
+	}
 
-// Exists checks if any records match the filter efficiently
-func (r *GormRepository[Entity, Filter, Updater]) Exists(
-	ctx context.Context,
-	filter Filter,
-) (bool, error) {
-	count, err := r.Count(ctx, filter)
+	// Fetch one extra row to learn whether another page follows
+	pageOptions := append(slices.Clip(options), withCursor(cursor), WithLimit(pageSize+1))
+	query, err = r.applyOptions(query, pageOptions...)
 	if err != nil {
-		return false, fmt.Errorf("exists check: %w", err)
+		return nil, "", fmt.Errorf("FindPage: %w", err)
 	}
-	return count > 0, nil
-}
+	query = query.Session(&gorm.Session{})
 
-// applyOptions applies query options
-func (r *GormRepository[Entity, Filter, Updater]) applyOptions(query *gorm.DB, options ...OptionFunc) *gorm.DB {
-	opts := &Options{}
-	for _, opt := range options {
-		opt.Apply(opts)
+	err = r.config.ReadRetry.do(ctx, func() error {
+		return query.Find(&result).Error
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("find page: %w", err)
 	}
 
-	if opts.Limit != nil {
-		query = query.Limit(*opts.Limit)
+	if len(result) <= pageSize {
+		return result, "", nil
 	}
+	result = result[:pageSize]
 
-	if opts.Offset != nil {
-		query = query.Offset(*opts.Offset)
+	last := reflect.ValueOf(result[pageSize-1]).Elem()
+	next := Cursor{Field: cursor.Field, Direction: cursor.Direction}
+	if next.Value, err = cursorFieldValue(ctx, entitySchema, cursor.Field, last); err != nil {
+		return nil, "", fmt.Errorf("FindPage: %w", err)
+	}
+	if cursor.keyField != "" {
+		if next.Key, err = cursorFieldValue(ctx, entitySchema, cursor.keyField, last); err != nil {
+			return nil, "", fmt.Errorf("FindPage: %w", err)
+		}
 	}
 
-	for _, field := range opts.SortFields {
-		quotedField := query.Statement.Quote(field.Field)
-		query = query.Order(fmt.Sprintf("%s %s", quotedField, field.Direction))
-	}
-
-	return query
-}
-
-// buildQuery builds a GORM query from filters
-func (r *GormRepository[Entity, Filter, Updater]) buildQuery(db *gorm.DB, filter Filter) (*gorm.DB, error) {
-	for _, repositoryFilter := range filter.ListFilters() {
-		if repositoryFilter.Field == "" {
-			return nil, ErrEmptyFieldName
-		}
-
-		quotedField := db.Statement.Quote(repositoryFilter.Field)
-
-		switch repositoryFilter.Operator {
-		case OperatorEqual:
-			db = db.Where(quotedField+" = ?", repositoryFilter.Value)
-		case OperatorNotEqual:
-			db = db.Where(quotedField+" != ?", repositoryFilter.Value)
-		case OperatorLessThan:
-			db = db.Where(quotedField+" < ?", repositoryFilter.Value)
-		case OperatorLessThanOrEqual:
-			db = db.Where(quotedField+" <= ?", repositoryFilter.Value)
-		case OperatorGreaterThan:
-			db = db.Where(quotedField+" > ?", repositoryFilter.Value)
-		case OperatorGreaterThanOrEqual:
-			db = db.Where(quotedField+" >= ?", repositoryFilter.Value)
-		case OperatorLike:
-			db = db.Where(quotedField+" LIKE ?", repositoryFilter.Value)
-		case OperatorNotLike:
-			db = db.Where(quotedField+" NOT LIKE ?", repositoryFilter.Value)
-		case OperatorIsNull:
-			db = db.Where(quotedField + " IS NULL")
-		case OperatorIsNotNull:
-			db = db.Where(quotedField + " IS NOT NULL")
-		case OperatorIn:
-			db = db.Where(quotedField+" IN (?)", repositoryFilter.Value)
-		case OperatorNotIn:
-			db = db.Where(quotedField+" NOT IN (?)", repositoryFilter.Value)
-		default:
-			return nil, fmt.Errorf("unknown operator %s: %w", repositoryFilter.Operator, ErrUnknownOperator)
+	nextCursor, err = EncodeCursor(next)
+	if err != nil {
+		return nil, "", fmt.Errorf("FindPage: %w", err)
+	}
+
+	return result, nextCursor, nil
+}
+
+// cursorFieldValue reads the column of row for a next-page cursor
+func cursorFieldValue(ctx context.Context, entitySchema *schema.Schema, column string, row reflect.Value) (interface{}, error) {
+	value, _ := entitySchema.LookUpField(column).ValueOf(ctx, row)
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, fmt.Errorf("%w: NULL value in cursor field %q", ErrInvalidCursor, column)
 		}
+		value = v.Elem().Interface()
 	}
+	return value, nil
+}
 
-	return db, nil
+// pageCursor returns the cursor selected by options, defaulting to the primary key ascending
+func pageCursor(entitySchema *schema.Schema, options []OptionFunc) (Cursor, error) {
+	opts := newOptions(options...)
+
+	switch {
+	case opts.CursorToken != "":
+		return DecodeCursor(opts.CursorToken)
+	case opts.Cursor != nil:
+		return *opts.Cursor, opts.Cursor.validate()
+	case entitySchema.PrioritizedPrimaryField != nil:
+		return Cursor{Field: entitySchema.PrioritizedPrimaryField.DBName, Direction: "asc"}, nil
+	default:
+		return Cursor{}, fmt.Errorf("%w: no cursor given and %s has no primary key", ErrInvalidCursor, entitySchema.Name)
+	}
 }
 
-// GetDB returns the underlying GORM database instance for advanced operations
-func (r *GormRepository[Entity, Filter, Updater]) GetDB() *gorm.DB {
-	return r.db
+// entitySchema parses the GORM schema of the entity
+func (r *GormRepository[Entity, Filter, Updater]) entitySchema() (*schema.Schema, error) {
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(new(Entity)); err != nil {
+		return nil, fmt.Errorf("parse entity schema: %w", err)
+	}
+	return stmt.Schema, nil
 }
 
-// Health performs a health check on the database connection
-func (r *GormRepository[Entity, Filter, Updater]) Health(ctx context.Context) error {
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+// Update implements record updates using updaters.
+// Entities with a gorm:"version" or querybuilder:"version" integer field are locked optimistically:
+// the update only matches the row while it still has the record's version, increments it, and
+// returns ErrOptimisticLock when another writer got there first. UpdateWithFilter checks no versions.
+func (r *GormRepository[Entity, Filter, Updater]) Update(
+	ctx context.Context,
+	record *Entity,
+	updater Updater,
+) (err error) {
+	var noFilter Filter
+	var rows int64
+	ctx, done := r.instrument(ctx, "Update", noFilter, nil)
+	defer func() { done(err, rows) }()
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
+	changeSet := updater.GetChangeSet()
+	if len(changeSet) == 0 {
+		return nil // No changes to apply
+	}
+
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return err
+	}
+	if version := versionField(entitySchema); version != nil {
+		rows, err = r.updateVersioned(ctx, record, changeSet, version)
+		return err
+	}
+
+	query, err := r.applyScope(r.db.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	result := query.Model(record).Updates(changeSet)
+	if result.Error != nil {
+		return fmt.Errorf("update record: %w", result.Error)
+	}
+	rows = result.RowsAffected
+
+	return nil
+}
+
+// UpdateNonZero updates the record's row with the record's non-zero fields, through GORM's
+// struct-based Updates: fields set to zero values such as "", 0 or false are left unchanged, so
+// use Update with an updater, or an updater's FromStruct, to clear them. Versions are not checked.
+func (r *GormRepository[Entity, Filter, Updater]) UpdateNonZero(ctx context.Context, record *Entity) (err error) {
+	var noFilter Filter
+	var rows int64
+	ctx, done := r.instrument(ctx, "UpdateNonZero", noFilter, nil)
+	defer func() { done(err, rows) }()
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
+	query, err := r.applyScope(r.db.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	result := query.Model(record).Updates(record)
+	if result.Error != nil {
+		return fmt.Errorf("update record: %w", result.Error)
+	}
+	rows = result.RowsAffected
+
+	return nil
+}
+
+// updateVersioned applies changeSet only if the row still has the record's version, incrementing it
+func (r *GormRepository[Entity, Filter, Updater]) updateVersioned(
+	ctx context.Context,
+	record *Entity,
+	changeSet map[string]interface{},
+	version *schema.Field,
+) (int64, error) {
+	recordValue := reflect.ValueOf(record).Elem()
+	current := version.ReflectValueOf(ctx, recordValue)
+	var next int64
+	switch {
+	case current.CanInt():
+		next = current.Int() + 1
+	case current.CanUint():
+		next = int64(current.Uint()) + 1
+	default:
+		return 0, fmt.Errorf("%w: version field %s must be an integer", ErrInvalidAnnotation, version.Name)
+	}
+	currentVersion := current.Interface()
+
+	changes := make(map[string]interface{}, len(changeSet)+1)
+	for column, value := range changeSet {
+		changes[column] = value
+	}
+	changes[version.DBName] = next
+
+	query, err := r.applyScope(r.db.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	result := query.Model(record).
+		Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: version.DBName}, Value: currentVersion}).
+		Updates(changes)
+	if result.Error != nil {
+		return 0, fmt.Errorf("update record: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		// GORM assigns the changes to the record before running the update; keep the stale version
+		if err := version.Set(ctx, recordValue, currentVersion); err != nil {
+			return 0, fmt.Errorf("restore version: %w", err)
+		}
+		return 0, ErrOptimisticLock
+	}
+	if err := version.Set(ctx, recordValue, next); err != nil {
+		return result.RowsAffected, fmt.Errorf("set version: %w", err)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// versionField returns the optimistic locking version field of the schema, or nil
+func versionField(entitySchema *schema.Schema) *schema.Field {
+	for _, field := range entitySchema.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		if _, ok := field.TagSettings["VERSION"]; ok || hasQueryBuilderOption(field.Tag, "version") {
+			return field
+		}
+	}
+	return nil
+}
+
+// hasQueryBuilderOption reports whether a querybuilder or qb struct tag lists option
+func hasQueryBuilderOption(tag reflect.StructTag, option string) bool {
+	for _, key := range []string{"querybuilder", "qb"} {
+		for _, value := range strings.Split(tag.Get(key), ",") {
+			name, _, _ := strings.Cut(value, "=")
+			if strings.EqualFold(strings.TrimSpace(name), option) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WithTransaction executes a function within a database transaction. Called on a repository
+// handed out by another WithTransaction, it runs fn within a savepoint of that transaction
+// instead, so that an error from fn only rolls back what fn did.
+func (r *GormRepository[Entity, Filter, Updater]) WithTransaction(
+	ctx context.Context,
+	fn func(*GormRepository[Entity, Filter, Updater]) error,
+) error {
+	return r.WithTransactionOpts(ctx, nil, fn)
+}
+
+// WithTransactionOpts is WithTransaction with the isolation level and read-only flag of opts,
+// e.g. &sql.TxOptions{Isolation: sql.LevelSerializable} for transfers, or {ReadOnly: true} for
+// reports. Nil opts use the database defaults. Drivers reject levels their database lacks.
+// Inside a transaction, fn runs within a savepoint and opts must be nil.
+func (r *GormRepository[Entity, Filter, Updater]) WithTransactionOpts(
+	ctx context.Context,
+	opts *sql.TxOptions,
+	fn func(*GormRepository[Entity, Filter, Updater]) error,
+) error {
+	if r.inTransaction() {
+		if opts != nil {
+			return ErrNestedTransactionOptions
+		}
+		return r.withSavepoint(ctx, fn)
+	}
+
+	var txOptions []*sql.TxOptions
+	if opts != nil {
+		txOptions = append(txOptions, opts)
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Reads are not retried inside a transaction: a dropped connection aborts it
+		txConfig := r.config
+		txConfig.ReadRetry = nil
+
+		txRepo := &GormRepository[Entity, Filter, Updater]{
+			db:     tx,
+			config: txConfig,
+		}
+		return fn(txRepo)
+	}, txOptions...)
+}
+
+// savepointCounter numbers savepoints so that nested ones get distinct names
+var savepointCounter atomic.Uint64
+
+// inTransaction reports whether the repository runs on a transaction
+func (r *GormRepository[Entity, Filter, Updater]) inTransaction() bool {
+	committer, ok := r.db.Statement.ConnPool.(gorm.TxCommitter)
+	return ok && committer != nil
+}
+
+// withSavepoint runs fn within a savepoint of the current transaction, rolling back to it when
+// fn returns an error or panics. The transaction itself is left to its owner.
+func (r *GormRepository[Entity, Filter, Updater]) withSavepoint(
+	ctx context.Context,
+	fn func(*GormRepository[Entity, Filter, Updater]) error,
+) (err error) {
+	tx := r.db.WithContext(ctx)
+	name := fmt.Sprintf("qb_savepoint_%d", savepointCounter.Add(1))
+	if err := tx.SavePoint(name).Error; err != nil {
+		return fmt.Errorf("create savepoint: %w", err)
+	}
+
+	completed := false
+	defer func() {
+		if completed && err == nil {
+			return
+		}
+		if rollbackErr := tx.RollbackTo(name).Error; rollbackErr != nil && completed {
+			err = errors.Join(err, fmt.Errorf("roll back to savepoint: %w", rollbackErr))
+		}
+	}()
+
+	err = fn(r)
+	completed = true
+	return err
+}
+
+// CreateInBatches implements batch creation
+func (r *GormRepository[Entity, Filter, Updater]) CreateInBatches(
+	ctx context.Context,
+	batchSize int,
+	records ...*Entity,
+) (err error) {
+	var noFilter Filter
+	ctx, done := r.instrument(ctx, "CreateInBatches", noFilter, nil)
+	defer func() { done(err, int64(len(records))) }()
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
+	if len(records) == 0 {
+		return ErrNoRecordsProvided
+	}
+
+	if batchSize <= 0 {
+		batchSize = 100 // Default batch size
+	}
+
+	result := r.db.WithContext(ctx).CreateInBatches(records, batchSize)
+	if result.Error != nil {
+		return fmt.Errorf("create records in batches: %w", result.Error)
+	}
+
+	return nil
+}
+
+// BulkCreate inserts records in batches like CreateInBatches, but a batch rejected by a constraint
+// violation is retried one record at a time, so that the rest of it is still inserted. The result
+// lists the inserted and the rejected records. Any other error stops the insert and is returned
+// with the result so far.
+func (r *GormRepository[Entity, Filter, Updater]) BulkCreate(
+	ctx context.Context,
+	batchSize int,
+	records ...*Entity,
+) (result *BulkCreateResult, err error) {
+	var noFilter Filter
+	ctx, done := r.instrument(ctx, "BulkCreate", noFilter, nil)
+	defer func() {
+		var created int64
+		if result != nil {
+			created = int64(len(result.Created))
+		}
+		done(err, created)
+	}()
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
+	if len(records) == 0 {
+		return nil, ErrNoRecordsProvided
+	}
+
+	if batchSize <= 0 {
+		batchSize = 100 // Default batch size
+	}
+
+	result = &BulkCreateResult{}
+	for start := 0; start < len(records); start += batchSize {
+		end := min(start+batchSize, len(records))
+		err := r.insert(ctx, records[start:end])
+		if err == nil {
+			for i := start; i < end; i++ {
+				result.Created = append(result.Created, i)
+			}
+			continue
+		}
+		if !IsConstraintViolation(err) {
+			return result, fmt.Errorf("bulk create records %d to %d: %w", start, end-1, err)
+		}
+
+		for i := start; i < end; i++ {
+			err := r.insert(ctx, records[i:i+1])
+			switch {
+			case err == nil:
+				result.Created = append(result.Created, i)
+			case IsConstraintViolation(err):
+				result.Failed = append(result.Failed, RecordError{Index: i, Err: err})
+			default:
+				return result, fmt.Errorf("bulk create record %d: %w", i, err)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// insert inserts records in one statement. Inside a transaction the statement runs within a
+// savepoint, as a failed statement aborts the whole transaction on some databases.
+func (r *GormRepository[Entity, Filter, Updater]) insert(ctx context.Context, records []*Entity) error {
+	if !r.inTransaction() {
+		return r.db.WithContext(ctx).Create(records).Error
+	}
+	return r.withSavepoint(ctx, func(txRepo *GormRepository[Entity, Filter, Updater]) error {
+		return txRepo.db.WithContext(ctx).Create(records).Error
+	})
+}
+
+// UpdateWithFilter implements batch updates using filters
+func (r *GormRepository[Entity, Filter, Updater]) UpdateWithFilter(
+	ctx context.Context,
+	filter Filter,
+	updater Updater,
+) (rows int64, err error) {
+	ctx, done := r.instrument(ctx, "UpdateWithFilter", filter, nil)
+	defer func() { done(err, rows) }()
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
+	changeSet := updater.GetChangeSet()
+	if len(changeSet) == 0 {
+		return 0, nil
+	}
+
+	query, err := r.buildQuery(r.db.WithContext(ctx), filter)
+	if err != nil {
+		return 0, fmt.Errorf("UpdateWithFilter build query: %w", err)
+	}
+
+	result := query.Model(new(Entity)).Updates(changeSet)
+	if result.Error != nil {
+		return 0, fmt.Errorf("update records with filter: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// UpdateWithFilterReturning updates the records matching the filter like UpdateWithFilter and
+// returns them as updated, through RETURNING. Only Postgres and SQLite support it; other
+// databases return ErrUnsupportedDialect.
+func (r *GormRepository[Entity, Filter, Updater]) UpdateWithFilterReturning(
+	ctx context.Context,
+	filter Filter,
+	updater Updater,
+) (records []*Entity, err error) {
+	var rows int64
+	ctx, done := r.instrument(ctx, "UpdateWithFilterReturning", filter, nil)
+	defer func() { done(err, rows) }()
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
+	changeSet := updater.GetChangeSet()
+	if len(changeSet) == 0 {
+		return nil, nil
+	}
+	if err := requireReturning(r.db); err != nil {
+		return nil, err
+	}
+
+	query, err := r.buildQuery(r.db.WithContext(ctx), filter)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateWithFilterReturning build query: %w", err)
+	}
+
+	result := query.Model(&records).Clauses(clause.Returning{}).Updates(changeSet)
+	if result.Error != nil {
+		return nil, fmt.Errorf("update records with filter: %w", result.Error)
+	}
+	rows = result.RowsAffected
+
+	return records, nil
+}
+
+// DeleteWithFilter implements batch deletion using filters.
+// Entities with a gorm.DeletedAt field are soft-deleted; use HardDelete to remove them.
+func (r *GormRepository[Entity, Filter, Updater]) DeleteWithFilter(
+	ctx context.Context,
+	filter Filter,
+) (rows int64, err error) {
+	ctx, done := r.instrument(ctx, "DeleteWithFilter", filter, nil)
+	defer func() { done(err, rows) }()
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
+	query, err := r.buildQuery(r.db.WithContext(ctx), filter)
+	if err != nil {
+		return 0, fmt.Errorf("DeleteWithFilter build query: %w", err)
+	}
+
+	result := query.Delete(new(Entity))
+	if result.Error != nil {
+		return 0, fmt.Errorf("delete records with filter: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// HardDelete permanently deletes the records matching the filter, including soft-deleted ones
+func (r *GormRepository[Entity, Filter, Updater]) HardDelete(
+	ctx context.Context,
+	filter Filter,
+) (rows int64, err error) {
+	ctx, done := r.instrument(ctx, "HardDelete", filter, nil)
+	defer func() { done(err, rows) }()
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
+	query, err := r.buildQuery(r.db.WithContext(ctx).Unscoped(), filter)
+	if err != nil {
+		return 0, fmt.Errorf("HardDelete build query: %w", err)
+	}
+
+	result := query.Delete(new(Entity))
+	if result.Error != nil {
+		return 0, fmt.Errorf("hard delete records with filter: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// DeleteByID deletes the record with the integer primary key id and reports whether there was one.
+// Like DeleteWithFilter, entities with a gorm.DeletedAt field are soft-deleted.
+func (r *GormRepository[Entity, Filter, Updater]) DeleteByID(
+	ctx context.Context,
+	id int64,
+) (deleted bool, err error) {
+	var noFilter Filter
+	var rows int64
+	ctx, done := r.instrument(ctx, "DeleteByID", noFilter, nil)
+	defer func() { done(err, rows) }()
+
+	rows, err = r.deleteByIDs(ctx, false, []int64{id})
+	return rows > 0, err
+}
+
+// DeleteByIDs deletes the records whose integer primary key is one of ids in a single statement,
+// soft-deleting entities with a gorm.DeletedAt field, and returns how many there were
+func (r *GormRepository[Entity, Filter, Updater]) DeleteByIDs(
+	ctx context.Context,
+	ids ...int64,
+) (deleted int64, err error) {
+	var noFilter Filter
+	ctx, done := r.instrument(ctx, "DeleteByIDs", noFilter, nil)
+	defer func() { done(err, deleted) }()
+
+	return r.deleteByIDs(ctx, false, ids)
+}
+
+// HardDeleteByID permanently deletes the record with the integer primary key id, even when it is
+// soft-deleted, and reports whether there was one
+func (r *GormRepository[Entity, Filter, Updater]) HardDeleteByID(
+	ctx context.Context,
+	id int64,
+) (deleted bool, err error) {
+	var noFilter Filter
+	var rows int64
+	ctx, done := r.instrument(ctx, "HardDeleteByID", noFilter, nil)
+	defer func() { done(err, rows) }()
+
+	rows, err = r.deleteByIDs(ctx, true, []int64{id})
+	return rows > 0, err
+}
+
+// deleteByIDs deletes the records with the given integer primary keys, permanently when unscoped
+func (r *GormRepository[Entity, Filter, Updater]) deleteByIDs(
+	ctx context.Context,
+	unscoped bool,
+	ids []int64,
+) (int64, error) {
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
+	keyField, err := r.singlePrimaryField()
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	values := make([]interface{}, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+
+	query, err := r.applyScope(r.db.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	if unscoped {
+		query = query.Unscoped()
+	}
+
+	result := query.
+		Where(clause.IN{Column: clause.Column{Table: clause.CurrentTable, Name: keyField.DBName}, Values: values}).
+		Delete(new(Entity))
+	if result.Error != nil {
+		return 0, fmt.Errorf("delete records by IDs: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// Restore undeletes the soft-deleted records matching the filter by setting their
+// gorm.DeletedAt field back to NULL. It fails with ErrNotSoftDeletable for entities without one.
+func (r *GormRepository[Entity, Filter, Updater]) Restore(
+	ctx context.Context,
+	filter Filter,
+) (restored int64, err error) {
+	ctx, done := r.instrument(ctx, "Restore", filter, nil)
+	defer func() { done(err, restored) }()
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return 0, fmt.Errorf("Restore: %w", err)
+	}
+
+	deletedAt := softDeleteField(entitySchema)
+	if deletedAt == nil {
+		return 0, fmt.Errorf("Restore: %w: %s", ErrNotSoftDeletable, entitySchema.Name)
+	}
+
+	query, err := r.buildQuery(r.db.WithContext(ctx).Unscoped(), filter)
+	if err != nil {
+		return 0, fmt.Errorf("Restore build query: %w", err)
+	}
+
+	quotedField := query.Statement.Quote(deletedAt.DBName)
+	result := query.Model(new(Entity)).
+		Where(quotedField+" IS NOT NULL").
+		Update(deletedAt.DBName, nil)
+	if result.Error != nil {
+		return 0, fmt.Errorf("restore records with filter: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// softDeleteField returns the gorm.DeletedAt field of the schema, or nil if it has none
+func softDeleteField(entitySchema *schema.Schema) *schema.Field {
+	deletedAtType := reflect.TypeOf(gorm.DeletedAt{})
+	for _, field := range entitySchema.Fields {
+		if field.FieldType == deletedAtType && field.DBName != "" {
+			return field
+		}
+	}
+	return nil
+}
+
+// Count implements record counting.
+// Of the options only WithUnscoped and WithJoin apply; limits and sorting do not change a count.
+func (r *GormRepository[Entity, Filter, Updater]) Count(
+	ctx context.Context,
+	filter Filter,
+	options ...OptionFunc,
+) (count int64, err error) {
+	ctx, done := r.instrument(ctx, "Count", filter, options)
+	defer func() { done(err, -1) }()
+	ctx, cancel := r.queryContext(ctx, options...)
+	defer cancel()
+
+	db := r.db.WithContext(ctx)
+	if newOptions(options...).Unscoped {
+		db = db.Unscoped()
+	}
+
+	query, err := r.buildQuery(db, filter, options...)
+	if err != nil {
+		return 0, fmt.Errorf("count build query: %w", err)
+	}
+
+	query = query.Model(new(Entity)).Session(&gorm.Session{})
+
+	err = r.config.ReadRetry.do(ctx, func() error {
+		return query.Count(&count).Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("count records: %w", err)
+	}
+
+	return count, nil
+}
+
+// Exists checks if any records match the filter, selecting at most one row
+func (r *GormRepository[Entity, Filter, Updater]) Exists(
+	ctx context.Context,
+	filter Filter,
+) (exists bool, err error) {
+	ctx, done := r.instrument(ctx, "Exists", filter, nil)
+	defer func() { done(err, boolRows(exists)) }()
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
+	query, err := r.buildQuery(r.db.WithContext(ctx), filter)
+	if err != nil {
+		return false, fmt.Errorf("exists build query: %w", err)
+	}
+
+	exists, err = r.exists(ctx, query)
+	if err != nil {
+		return false, fmt.Errorf("exists check: %w", err)
+	}
+	return exists, nil
+}
+
+// CountDistinct counts the distinct non-NULL values of field among the records matching the filter
+func (r *GormRepository[Entity, Filter, Updater]) CountDistinct(
+	ctx context.Context,
+	filter Filter,
+	field string,
+	options ...OptionFunc,
+) (count int64, err error) {
+	ctx, done := r.instrument(ctx, "CountDistinct", filter, options)
+	defer func() { done(err, -1) }()
+	ctx, cancel := r.queryContext(ctx, options...)
+	defer cancel()
+
+	if field == "" {
+		return 0, ErrEmptyFieldName
+	}
+
+	db := r.db.WithContext(ctx)
+	if newOptions(options...).Unscoped {
+		db = db.Unscoped()
+	}
+
+	query, err := r.buildQuery(db, filter, options...)
+	if err != nil {
+		return 0, fmt.Errorf("CountDistinct build query: %w", err)
+	}
+
+	query = query.Model(new(Entity)).Distinct(field).Session(&gorm.Session{})
+
+	err = r.config.ReadRetry.do(ctx, func() error {
+		return query.Count(&count).Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("count distinct %s: %w", field, err)
+	}
+
+	return count, nil
+}
+
+// ExistsByID reports whether a record has the integer primary key id, selecting at most one row
+func (r *GormRepository[Entity, Filter, Updater]) ExistsByID(
+	ctx context.Context,
+	id int64,
+) (exists bool, err error) {
+	var noFilter Filter
+	ctx, done := r.instrument(ctx, "ExistsByID", noFilter, nil)
+	defer func() { done(err, boolRows(exists)) }()
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
+	keyField, err := r.singlePrimaryField()
+	if err != nil {
+		return false, err
+	}
+
+	query, err := r.applyScope(r.db.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+	query = query.Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: keyField.DBName}, Value: id})
+
+	exists, err = r.exists(ctx, query)
+	if err != nil {
+		return false, fmt.Errorf("exists by ID %d: %w", id, err)
+	}
+	return exists, nil
+}
+
+// exists runs query as SELECT 1 ... LIMIT 1 and reports whether it returned a row
+func (r *GormRepository[Entity, Filter, Updater]) exists(ctx context.Context, query *gorm.DB) (bool, error) {
+	query = query.Model(new(Entity)).Select("1").Limit(1).Session(&gorm.Session{})
+
+	var rows []int
+	err := r.config.ReadRetry.do(ctx, func() error {
+		rows = rows[:0]
+		return query.Scan(&rows).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(rows) > 0, nil
+}
+
+// CountByMonth counts records matching the filter grouped by the month of a time column.
+// The result is keyed by "YYYY-MM"; rows where the column is NULL are not counted.
+func (r *GormRepository[Entity, Filter, Updater]) CountByMonth(
+	ctx context.Context,
+	filter Filter,
+	field string,
+) (counts map[string]int64, err error) {
+	ctx, done := r.instrument(ctx, "CountByMonth", filter, nil)
+	defer func() { done(err, int64(len(counts))) }()
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
+	if field == "" {
+		return nil, ErrEmptyFieldName
+	}
+
+	query, err := r.buildQuery(r.db.WithContext(ctx), filter)
+	if err != nil {
+		return nil, fmt.Errorf("CountByMonth build query: %w", err)
+	}
+
+	quotedField := quoteColumn(query, field)
+	month, err := monthExpression(query, quotedField)
+	if err != nil {
+		return nil, fmt.Errorf("CountByMonth: %w", err)
+	}
+
+	var rows []struct {
+		Month string
+		Total int64
+	}
+	err = query.Model(new(Entity)).
+		Select(month + " AS month, COUNT(*) AS total").
+		Where(quotedField + " IS NOT NULL").
+		Group("month").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("count records by month: %w", err)
+	}
+
+	counts = make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Month] = row.Total
+	}
+
+	return counts, nil
+}
+
+// Sum returns the sum of field over the records matching the filter.
+// hasRows is false, with a zero sum, when no non-NULL values match.
+func (r *GormRepository[Entity, Filter, Updater]) Sum(
+	ctx context.Context,
+	filter Filter,
+	field string,
+) (value float64, hasRows bool, err error) {
+	ctx, done := r.instrument(ctx, "Sum", filter, nil)
+	defer func() { done(err, -1) }()
+
+	return r.aggregate(ctx, filter, "SUM", field)
+}
+
+// Avg returns the average of field over the records matching the filter.
+// hasRows is false, with a zero average, when no non-NULL values match.
+func (r *GormRepository[Entity, Filter, Updater]) Avg(
+	ctx context.Context,
+	filter Filter,
+	field string,
+) (value float64, hasRows bool, err error) {
+	ctx, done := r.instrument(ctx, "Avg", filter, nil)
+	defer func() { done(err, -1) }()
+
+	return r.aggregate(ctx, filter, "AVG", field)
+}
+
+// Min returns the smallest value of field among the records matching the filter.
+// hasRows is false, with a zero minimum, when no non-NULL values match.
+func (r *GormRepository[Entity, Filter, Updater]) Min(
+	ctx context.Context,
+	filter Filter,
+	field string,
+) (value float64, hasRows bool, err error) {
+	ctx, done := r.instrument(ctx, "Min", filter, nil)
+	defer func() { done(err, -1) }()
+
+	return r.aggregate(ctx, filter, "MIN", field)
+}
+
+// Max returns the largest value of field among the records matching the filter.
+// hasRows is false, with a zero maximum, when no non-NULL values match.
+func (r *GormRepository[Entity, Filter, Updater]) Max(
+	ctx context.Context,
+	filter Filter,
+	field string,
+) (value float64, hasRows bool, err error) {
+	ctx, done := r.instrument(ctx, "Max", filter, nil)
+	defer func() { done(err, -1) }()
+
+	return r.aggregate(ctx, filter, "MAX", field)
+}
+
+// FindGrouped runs a grouped query and scans one row per group into dest, a pointer to a slice
+// of projection structs. It selects the WithGroupBy columns followed by the WithAggregate
+// columns, so the struct fields should match those column names and aliases; WithSelect is ignored.
+//
+//	var rows []struct {
+//		CategoryID int64
+//		Count      int64
+//	}
+//	err := repo.FindGrouped(ctx, filter, &rows,
+//		repository.WithGroupBy("category_id"),
+//		repository.WithAggregate("COUNT", "*", "count"),
+//		repository.WithHaving(&repository.Filter{Field: "COUNT(*)", Operator: repository.OperatorGreaterThan, Value: 5}),
+//	)
+func (r *GormRepository[Entity, Filter, Updater]) FindGrouped(
+	ctx context.Context,
+	filter Filter,
+	dest interface{},
+	options ...OptionFunc,
+) (err error) {
+	ctx, done := r.instrument(ctx, "FindGrouped", filter, options)
+	defer func() { done(err, -1) }()
+	ctx, cancel := r.queryContext(ctx, options...)
+	defer cancel()
+
+	query, err := r.buildQuery(r.db.WithContext(ctx), filter, options...)
+	if err != nil {
+		return fmt.Errorf("FindGrouped build query: %w", err)
+	}
+
+	query, err = r.applyOptions(query, options...)
+	if err != nil {
+		return fmt.Errorf("FindGrouped apply options: %w", err)
+	}
+
+	opts := newOptions(options...)
+	columns := make([]string, 0, len(opts.GroupBy)+len(opts.Aggregates))
+	for _, field := range opts.GroupBy {
+		columns = append(columns, quoteColumn(query, field))
+	}
+	for _, aggregate := range opts.Aggregates {
+		expression, err := aggregateExpression(query, aggregate.Function, aggregate.Field)
+		if err != nil {
+			return err
+		}
+		if aggregate.Alias == "" {
+			return fmt.Errorf("%w: %s needs an alias", ErrInvalidAggregate, expression)
+		}
+		columns = append(columns, expression+" AS "+query.Statement.Quote(aggregate.Alias))
+	}
+	if len(columns) == 0 {
+		return fmt.Errorf("%w: FindGrouped needs group by columns or aggregates", ErrInvalidAggregate)
+	}
+
+	query = query.Model(new(Entity)).Select(columns).Session(&gorm.Session{})
+
+	err = r.config.ReadRetry.do(ctx, func() error {
+		return query.Scan(dest).Error
+	})
+	if err != nil {
+		return fmt.Errorf("find grouped records: %w", err)
+	}
+
+	return nil
+}
+
+// aggregate runs an SQL aggregate function over field; the aggregate is NULL when nothing matches
+func (r *GormRepository[Entity, Filter, Updater]) aggregate(
+	ctx context.Context,
+	filter Filter,
+	function string,
+	field string,
+) (float64, bool, error) {
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
+	if field == "" {
+		return 0, false, ErrEmptyFieldName
+	}
+
+	query, err := r.buildQuery(r.db.WithContext(ctx), filter)
+	if err != nil {
+		return 0, false, fmt.Errorf("%s build query: %w", function, err)
+	}
+
+	query = query.Model(new(Entity)).
+		Select(fmt.Sprintf("%s(%s)", function, quoteColumn(query, field))).
+		Session(&gorm.Session{})
+
+	var result sql.NullFloat64
+	err = r.config.ReadRetry.do(ctx, func() error {
+		return query.Row().Scan(&result)
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("aggregate %s(%s): %w", function, field, err)
+	}
+
+	return result.Float64, result.Valid, nil
+}
+
+// applyOptions applies query options
+func (r *GormRepository[Entity, Filter, Updater]) applyOptions(query *gorm.DB, options ...OptionFunc) (*gorm.DB, error) {
+	opts := newOptions(options...)
+
+	if opts.Cursor != nil && opts.Cursor.Field != "" {
+		cursor, err := r.cursorColumn(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cursor = cursor
+	}
+
+	if opts.Unscoped {
+		query = query.Unscoped()
+	}
+
+	quote, err := r.columnQuoter(query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(opts.DistinctOn) > 0:
+		query, err = r.distinctOn(query, opts, quote)
+		if err != nil {
+			return nil, err
+		}
+	case len(opts.DistinctFields) > 0:
+		if len(opts.SelectFields) > 0 {
+			return nil, fmt.Errorf("%w: WithDistinct fields are the selected columns, so WithSelect cannot be added", ErrInvalidDistinct)
+		}
+		columns := make([]string, len(opts.DistinctFields))
+		for i, field := range opts.DistinctFields {
+			columns[i] = quote(field)
+		}
+		query = query.Distinct(columns)
+	case len(opts.SelectFields) > 0:
+		columns, err := r.selectColumns(opts)
+		if err != nil {
+			return nil, err
+		}
+		if len(opts.Joins) > 0 {
+			// Qualified with the entity's table, the columns are no longer looked up by GORM
+			for i, column := range columns {
+				columns[i] = quote(column)
+			}
+		}
+		query = query.Select(columns)
+	}
+	if opts.Distinct && len(opts.DistinctFields) == 0 {
+		if len(opts.SelectFields) > 0 {
+			query = query.Distinct()
+		} else {
+			// GORM only writes DISTINCT before explicitly selected columns
+			entitySchema, err := r.entitySchema()
+			if err != nil {
+				return nil, err
+			}
+			query = query.Distinct(query.Statement.Quote(entitySchema.Table) + ".*")
+		}
+	}
+
+	query, err = applyGrouping(query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Lock != nil {
+		locking, ok, err := lockingClause(query, opts.Lock)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			query = query.Clauses(locking)
+		}
+	}
+
+	if opts.Limit != nil {
+		query = query.Limit(*opts.Limit)
+	}
+
+	if opts.Offset != nil {
+		query = query.Offset(*opts.Offset)
+	}
+
+	// The cursor column orders the page first so that the keyset condition stays consistent.
+	// A non-unique column is followed by the primary key, which then continues within ties.
+	if cursor := opts.Cursor; cursor != nil && cursor.Field != "" {
+		quotedField := quote(cursor.Field)
+		direction, comparison := "asc", ">"
+		if cursor.descending() {
+			direction, comparison = "desc", "<"
+		}
+		switch {
+		case cursor.Value != nil && cursor.keyField != "" && cursor.Key != nil:
+			quotedKey := quote(cursor.keyField)
+			query = query.Where(fmt.Sprintf("(%s %s ? OR (%s = ? AND %s %s ?))", quotedField, comparison, quotedField, quotedKey, comparison),
+				cursor.Value, cursor.Value, cursor.Key)
+		case cursor.Value != nil:
+			query = query.Where(fmt.Sprintf("%s %s ?", quotedField, comparison), cursor.Value)
+		}
+		query = query.Order(fmt.Sprintf("%s %s", quotedField, direction))
+		if cursor.keyField != "" {
+			query = query.Order(fmt.Sprintf("%s %s", quote(cursor.keyField), direction))
+		}
+	}
+
+	sortFields, err := r.sortFields(opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range sortFields {
+		// The direction is written into the SQL, so only asc and desc get through
+		direction, err := ParseSortDirection(string(field.Direction))
+		if err != nil {
+			return nil, err
+		}
+		quotedField := quote(field.Field)
+		query = query.Order(fmt.Sprintf("%s %s", quotedField, direction))
+	}
+
+	for _, preload := range opts.Preloads {
+		query = query.Preload(preload.Association, preload.Conditions...)
+	}
+
+	return query, nil
+}
+
+// distinctOn selects DISTINCT ON the opts.DistinctOn columns, which the ORDER BY has to start with
+func (r *GormRepository[Entity, Filter, Updater]) distinctOn(query *gorm.DB, opts *Options, quote func(string) string) (*gorm.DB, error) {
+	if name := dialectName(query); name != DialectPostgres {
+		return nil, fmt.Errorf("DISTINCT ON on %q: %w", name, ErrUnsupportedDialect)
+	}
+	if opts.Distinct {
+		return nil, fmt.Errorf("%w: WithDistinct and WithDistinctOn cannot be combined", ErrInvalidDistinct)
+	}
+
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return nil, err
+	}
+	sortFields, err := r.sortFields(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Postgres requires the leading ORDER BY expressions to be DISTINCT ON ones, in any order
+	distinct := make([]string, len(opts.DistinctOn))
+	for i, field := range opts.DistinctOn {
+		distinct[i] = qualifyColumn(entitySchema.Table, field)
+	}
+	var ordered []string
+	if opts.Cursor != nil && opts.Cursor.Field != "" {
+		ordered = append(ordered, opts.Cursor.Field)
+		if opts.Cursor.keyField != "" {
+			ordered = append(ordered, opts.Cursor.keyField)
+		}
+	}
+	for _, field := range sortFields {
+		ordered = append(ordered, field.Field)
+	}
+	for _, field := range ordered[:min(len(ordered), len(distinct))] {
+		if !slices.Contains(distinct, qualifyColumn(entitySchema.Table, field)) {
+			return nil, fmt.Errorf("%w: the sort order must start with the DISTINCT ON fields %s, not %s",
+				ErrInvalidDistinct, strings.Join(opts.DistinctOn, ", "), field)
+		}
+	}
+
+	on := make([]string, len(opts.DistinctOn))
+	for i, field := range opts.DistinctOn {
+		on[i] = quote(field)
+	}
+	selected := []string{query.Statement.Quote(entitySchema.Table) + ".*"}
+	if len(opts.SelectFields) > 0 {
+		if selected, err = r.selectColumns(opts); err != nil {
+			return nil, err
+		}
+		for i, column := range selected {
+			selected[i] = quote(column)
+		}
+	}
+
+	return query.Select(fmt.Sprintf("DISTINCT ON (%s) %s", strings.Join(on, ", "), strings.Join(selected, ", "))), nil
+}
+
+// cursorColumn returns a copy of cursor whose field, possibly a Go field name, is resolved to its
+// column. A column that is not unique gets the primary key as its tie-breaker.
+func (r *GormRepository[Entity, Filter, Updater]) cursorColumn(cursor *Cursor) (*Cursor, error) {
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return nil, err
+	}
+	field := entitySchema.LookUpField(cursor.Field)
+	if field == nil || field.DBName == "" {
+		return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidCursor, cursor.Field)
+	}
+
+	resolved := *cursor
+	resolved.Field = field.DBName
+	resolved.keyField = ""
+	if !uniqueColumn(entitySchema, field) {
+		if entitySchema.PrioritizedPrimaryField == nil {
+			return nil, fmt.Errorf("%w: %q is not unique and %s has no primary key to break ties",
+				ErrInvalidCursor, cursor.Field, entitySchema.Name)
+		}
+		resolved.keyField = entitySchema.PrioritizedPrimaryField.DBName
+	}
+	return &resolved, nil
+}
+
+// uniqueColumn reports whether field alone identifies a row of entitySchema
+func uniqueColumn(entitySchema *schema.Schema, field *schema.Field) bool {
+	if field.Unique || (field.PrimaryKey && len(entitySchema.PrimaryFields) == 1) {
+		return true
+	}
+	for _, index := range entitySchema.ParseIndexes() {
+		if index.Class == "UNIQUE" && len(index.Fields) == 1 && index.Fields[0].Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// sortFields returns the sort fields to apply after the cursor column, keeping the first
+// occurrence of each field. With SortStable the primary key is appended as the final
+// tie-breaker so that rows with equal sort values always come back in the same order.
+func (r *GormRepository[Entity, Filter, Updater]) sortFields(opts *Options) ([]*SortField, error) {
+	var seen []string
+	if opts.Cursor != nil && opts.Cursor.Field != "" {
+		seen = append(seen, opts.Cursor.Field)
+		if opts.Cursor.keyField != "" {
+			seen = append(seen, opts.Cursor.keyField)
+		}
+	}
+
+	sortFields := make([]*SortField, 0, len(opts.SortFields)+1)
+	for _, field := range opts.SortFields {
+		if !slices.Contains(seen, field.Field) {
+			seen = append(seen, field.Field)
+			sortFields = append(sortFields, field)
+		}
+	}
+
+	if !opts.SortStable {
+		return sortFields, nil
+	}
+
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range entitySchema.PrimaryFields {
+		if !slices.Contains(seen, field.DBName) {
+			seen = append(seen, field.DBName)
+			sortFields = append(sortFields, &SortField{Field: field.DBName, Direction: Asc})
+		}
+	}
+	return sortFields, nil
+}
+
+// selectColumns returns the columns to select for opts.SelectFields. The primary key and the
+// cursor field are added when missing, so that records can be identified and paged.
+func (r *GormRepository[Entity, Filter, Updater]) selectColumns(opts *Options) ([]string, error) {
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]string, 0, len(opts.SelectFields)+len(entitySchema.PrimaryFields)+1)
+	addColumn := func(name string) error {
+		field := entitySchema.LookUpField(name)
+		if field == nil || field.DBName == "" {
+			return fmt.Errorf("%w: %q is not a column of %s", ErrUnknownField, name, entitySchema.Name)
+		}
+		if !slices.Contains(columns, field.DBName) {
+			columns = append(columns, field.DBName)
+		}
+		return nil
+	}
+
+	for _, name := range opts.SelectFields {
+		if err := addColumn(name); err != nil {
+			return nil, err
+		}
+	}
+	for _, field := range entitySchema.PrimaryFields {
+		if err := addColumn(field.DBName); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Cursor != nil && opts.Cursor.Field != "" {
+		if err := addColumn(opts.Cursor.Field); err != nil {
+			return nil, err
+		}
+	}
+
+	return columns, nil
+}
+
+// queryContext bounds ctx by the WithTimeout option, or else by RepoConfig.QueryTimeout
+func (r *GormRepository[Entity, Filter, Updater]) queryContext(ctx context.Context, options ...OptionFunc) (context.Context, context.CancelFunc) {
+	timeout := r.config.QueryTimeout
+	if opts := newOptions(options...); opts.Timeout > 0 {
+		timeout = opts.Timeout
+	}
+	return timeoutContext(ctx, timeout)
+}
+
+// buildQuery builds a GORM query from filters and the joins among options
+func (r *GormRepository[Entity, Filter, Updater]) buildQuery(db *gorm.DB, filter Filter, options ...OptionFunc) (*gorm.DB, error) {
+	if errorer, ok := any(filter).(FilterErrorer); ok {
+		if err := errorer.Err(); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFilterValue, err)
+		}
+	}
+	scope, err := scopeFilters(r.config.DefaultScope)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := newOptions(options...)
+	for _, join := range opts.Joins {
+		db = db.Joins(join.SQL, join.Args...)
+	}
+
+	quote, err := r.columnQuoter(db, opts)
+	if err != nil {
+		return nil, err
+	}
+	filters := append(slices.Clone(scope), filter.ListFilters()...)
+	expressions, err := CompileFilters(dialectName(db), filters, quote)
+	if err != nil {
+		return nil, err
+	}
+	for _, expression := range expressions {
+		db = db.Where(expression.SQL, expression.Vars...)
+	}
+
+	return db, nil
+}
+
+// scopeFilters returns the conditions of RepoConfig.DefaultScope
+func scopeFilters(scope EntityFilter) ([]*Filter, error) {
+	if scope == nil {
+		return nil, nil
+	}
+	if errorer, ok := scope.(FilterErrorer); ok {
+		if err := errorer.Err(); err != nil {
+			return nil, fmt.Errorf("default scope: %w: %w", ErrInvalidFilterValue, err)
+		}
+	}
+	return scope.ListFilters(), nil
+}
+
+// applyScope ANDs RepoConfig.DefaultScope into a query that doesn't go through buildQuery,
+// such as a lookup or update by primary key
+func (r *GormRepository[Entity, Filter, Updater]) applyScope(db *gorm.DB) (*gorm.DB, error) {
+	scope, err := scopeFilters(r.config.DefaultScope)
+	if err != nil || len(scope) == 0 {
+		return db, err
+	}
+
+	expressions, err := CompileFilters(dialectName(db), scope, func(field string) string { return quoteColumn(db, field) })
+	if err != nil {
+		return nil, fmt.Errorf("default scope: %w", err)
+	}
+	for _, expression := range expressions {
+		db = db.Where(expression.SQL, expression.Vars...)
+	}
+	return db, nil
+}
+
+// columnQuoter returns the function quoting filter and sort fields. With joins, an unqualified
+// name is qualified with the entity's table, since the joined tables may have a column of the
+// same name.
+func (r *GormRepository[Entity, Filter, Updater]) columnQuoter(db *gorm.DB, opts *Options) (func(string) string, error) {
+	if len(opts.Joins) == 0 {
+		return func(field string) string { return quoteColumn(db, field) }, nil
+	}
+
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return nil, err
+	}
+	return func(field string) string {
+		return quoteColumn(db, qualifyColumn(entitySchema.Table, field))
+	}, nil
+}
+
+// quoteColumn quotes a column name for the dialect of db. A qualified name such as
+// "categories.name" is quoted part by part, as "categories"."name", rather than left to the
+// driver, which may quote it as a single identifier.
+func quoteColumn(db *gorm.DB, name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = db.Statement.Quote(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// qualifyColumn prefixes field with table unless it is qualified already
+func qualifyColumn(table, field string) string {
+	if strings.Contains(field, ".") {
+		return field
+	}
+	return table + "." + field
+}
+
+// GetDB returns the underlying GORM database instance for advanced operations
+func (r *GormRepository[Entity, Filter, Updater]) GetDB() *gorm.DB {
+	return r.db
+}
+
+// Health performs a health check on the database connection
+func (r *GormRepository[Entity, Filter, Updater]) Health(ctx context.Context) error {
+	timeout := r.config.HealthTimeout
+	if timeout <= 0 {
+		timeout = defaultHealthTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	sqlDB, err := r.db.DB()
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"testing"
@@ -25,15 +26,27 @@ type TestEntity struct {
 	UpdatedAt time.Time `db:"updated_at"`
 }
 
-// TestFilter implements EntityFilter for testing
+// SoftDeleteEntity is a test entity with a gorm.DeletedAt field
+type SoftDeleteEntity struct {
+	ID        int64 `gorm:"primaryKey"`
+	Name      string
+	DeletedAt gorm.DeletedAt
+}
+
+// TestFilter implements EntityFilter and FilterErrorer for testing
 type TestFilter struct {
 	filters []*Filter
+	err     error
 }
 
 func (f *TestFilter) ListFilters() []*Filter {
 	return f.filters
 }
 
+func (f *TestFilter) Err() error {
+	return f.err
+}
+
 func (f *TestFilter) NameEq(name string) *TestFilter {
 	f.filters = append(f.filters, &Filter{
 		Field:    "name",
@@ -52,6 +65,15 @@ func (f *TestFilter) EmailLike(pattern string) *TestFilter {
 	return f
 }
 
+func (f *TestFilter) NameILike(pattern string) *TestFilter {
+	f.filters = append(f.filters, &Filter{
+		Field:    "name",
+		Operator: OperatorILike,
+		Value:    pattern,
+	})
+	return f
+}
+
 func (f *TestFilter) AgeGte(age int) *TestFilter {
 	f.filters = append(f.filters, &Filter{
 		Field:    "age",
@@ -198,6 +220,42 @@ func TestGormRepository_Create(t *testing.T) {
 	})
 }
 
+func TestGormRepository_MustCreate(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+	ctx := context.Background()
+
+	t.Run("creates records", func(t *testing.T) {
+		entity := &TestEntity{Name: "Fixture", Email: "fixture@example.com", Age: 30}
+
+		assert.NotPanics(t, func() {
+			repo.MustCreate(ctx, entity)
+		})
+		assert.NotZero(t, entity.ID)
+	})
+
+	t.Run("panics on constraint violation", func(t *testing.T) {
+		existing := &TestEntity{Name: "Original", Email: "original@example.com"}
+		repo.MustCreate(ctx, existing)
+
+		duplicate := &TestEntity{ID: existing.ID, Name: "Duplicate", Email: "duplicate@example.com"}
+		defer func() {
+			recovered := recover()
+			require.NotNil(t, recovered, "MustCreate should panic on duplicate primary key")
+
+			err, ok := recovered.(error)
+			require.True(t, ok, "panic value should be an error")
+			assert.Contains(t, err.Error(), "UNIQUE constraint failed")
+		}()
+		repo.MustCreate(ctx, duplicate)
+	})
+
+	t.Run("panics without records", func(t *testing.T) {
+		assert.PanicsWithError(t, "MustCreate: "+ErrNoRecordsProvided.Error(), func() {
+			repo.MustCreate(ctx)
+		})
+	})
+}
+
 func TestGormRepository_FindOneByID(t *testing.T) {
 	repo, _ := setupTestRepository(t)
 	ctx := context.Background()
@@ -228,6 +286,142 @@ func TestGormRepository_FindOneByID(t *testing.T) {
 	})
 }
 
+// TestAccount has a string primary key; TestMembership has a composite one
+type TestAccount struct {
+	UUID string `gorm:"primaryKey"`
+	Name string
+}
+
+type TestMembership struct {
+	AccountUUID string `gorm:"primaryKey"`
+	GroupID     int64  `gorm:"primaryKey"`
+	Role        string
+}
+
+func TestGormRepository_FindByPrimaryKey(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&TestAccount{}, &TestMembership{}))
+	ctx := context.Background()
+
+	accounts := NewGormRepository[TestAccount, *TestFilter, *TestUpdater](db)
+	accounts.MustCreate(ctx, &TestAccount{UUID: "2f1c6a3e", Name: "Alice"})
+
+	t.Run("string key", func(t *testing.T) {
+		found, exists, err := accounts.FindOneByStringID(ctx, "2f1c6a3e")
+		require.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, "Alice", found.Name)
+
+		_, exists, err = accounts.FindOneByStringID(ctx, "missing")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("composite key", func(t *testing.T) {
+		memberships := NewGormRepository[TestMembership, *TestFilter, *TestUpdater](db)
+		memberships.MustCreate(ctx,
+			&TestMembership{AccountUUID: "2f1c6a3e", GroupID: 1, Role: "owner"},
+			&TestMembership{AccountUUID: "2f1c6a3e", GroupID: 2, Role: "member"},
+		)
+
+		found, exists, err := memberships.FindByPrimaryKey(ctx, "2f1c6a3e", int64(2))
+		require.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, "member", found.Role)
+
+		_, _, err = memberships.FindByPrimaryKey(ctx, "2f1c6a3e")
+		assert.ErrorIs(t, err, ErrInvalidPrimaryKey)
+	})
+}
+
+func TestGormRepository_FindByIDs(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+	ctx := context.Background()
+
+	entities := createTestEntities()
+	repo.MustCreate(ctx, entities...)
+
+	t.Run("slice", func(t *testing.T) {
+		found, err := repo.FindByIDs(ctx, entities[0].ID, entities[2].ID, 99999)
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.ElementsMatch(t, []string{entities[0].Name, entities[2].Name}, []string{found[0].Name, found[1].Name})
+	})
+
+	t.Run("map", func(t *testing.T) {
+		found, err := repo.FindMapByIDs(ctx, entities[0].ID, entities[1].ID, 99999)
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.Equal(t, entities[1].Name, found[entities[1].ID].Name)
+		assert.NotContains(t, found, int64(99999))
+	})
+
+	t.Run("no IDs", func(t *testing.T) {
+		found, err := repo.FindByIDs(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("composite key", func(t *testing.T) {
+		db := setupTestDB(t)
+		require.NoError(t, db.AutoMigrate(&TestMembership{}))
+		memberships := NewGormRepository[TestMembership, *TestFilter, *TestUpdater](db)
+
+		_, err := memberships.FindByIDs(ctx, 1)
+		assert.ErrorIs(t, err, ErrInvalidPrimaryKey)
+	})
+}
+
+func TestGormRepository_FindEach(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+	ctx := context.Background()
+
+	repo.MustCreate(ctx, createTestEntities()...)
+
+	t.Run("streams matching rows in order", func(t *testing.T) {
+		var names []string
+		err := repo.FindEach(ctx, NewTestFilter().IsActiveEq(true), func(entity *TestEntity) error {
+			names = append(names, entity.Name)
+			return nil
+		}, WithSort("age", Desc))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"David", "Bob", "Alice"}, names)
+	})
+
+	t.Run("callback error stops iteration", func(t *testing.T) {
+		errStop := errors.New("stop")
+		calls := 0
+		err := repo.FindEach(ctx, NewTestFilter(), func(*TestEntity) error {
+			calls++
+			return errStop
+		})
+		assert.ErrorIs(t, err, errStop)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("iterate with break", func(t *testing.T) {
+		var names []string
+		for entity, err := range repo.Iterate(ctx, NewTestFilter(), WithSort("name", Asc)) {
+			require.NoError(t, err)
+			names = append(names, entity.Name)
+			if len(names) == 2 {
+				break
+			}
+		}
+		assert.Equal(t, []string{"Alice", "Bob"}, names)
+	})
+
+	t.Run("iterate yields query errors", func(t *testing.T) {
+		var errs []error
+		for entity, err := range repo.Iterate(ctx, NewTestFilter(), WithSort("age", "sideways")) {
+			assert.Nil(t, entity)
+			errs = append(errs, err)
+		}
+		require.Len(t, errs, 1)
+		assert.ErrorIs(t, errs[0], ErrInvalidSortDirection)
+	})
+}
+
 func TestGormRepository_FindOne(t *testing.T) {
 	repo, _ := setupTestRepository(t)
 	ctx := context.Background()
@@ -299,6 +493,13 @@ func TestGormRepository_FindAll(t *testing.T) {
 		}
 	})
 
+	t.Run("find with case-insensitive pattern", func(t *testing.T) {
+		found, err := repo.FindAll(ctx, NewTestFilter().NameILike("%LI%"))
+
+		assert.NoError(t, err)
+		assert.Len(t, found, 2) // Alice, Charlie
+	})
+
 	t.Run("find with limit", func(t *testing.T) {
 		filter := NewTestFilter().IsActiveEq(true)
 		found, err := repo.FindAll(ctx, filter, WithLimit(2))
@@ -306,6 +507,222 @@ func TestGormRepository_FindAll(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Len(t, found, 2)
 	})
+
+	t.Run("find sorted by direction", func(t *testing.T) {
+		dir, err := ParseSortDirection("DESC")
+		require.NoError(t, err)
+
+		found, err := repo.FindAll(ctx, NewTestFilter(), WithSort("age", dir))
+
+		require.NoError(t, err)
+		require.Len(t, found, 4)
+		assert.Equal(t, "David", found[0].Name)
+		assert.Equal(t, "Charlie", found[3].Name)
+	})
+
+	t.Run("find with invalid sort direction", func(t *testing.T) {
+		_, err := repo.FindAll(ctx, NewTestFilter(), WithSort("age", "desc; DROP TABLE test_entities"))
+
+		assert.ErrorIs(t, err, ErrInvalidSortDirection)
+	})
+}
+
+func TestParseSortDirection(t *testing.T) {
+	for input, want := range map[string]SortDirection{"": Asc, "asc": Asc, "ASC": Asc, " desc ": Desc, "Desc": Desc} {
+		dir, err := ParseSortDirection(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, want, dir, input)
+	}
+
+	_, err := ParseSortDirection("up")
+	assert.ErrorIs(t, err, ErrInvalidSortDirection)
+}
+
+func TestGormRepository_FindPage(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx, createTestEntities()...))
+
+	names := func(entities []*TestEntity) []string {
+		result := make([]string, 0, len(entities))
+		for _, entity := range entities {
+			result = append(result, entity.Name)
+		}
+		return result
+	}
+
+	t.Run("walks the primary key by default", func(t *testing.T) {
+		page, next, err := repo.FindPage(ctx, NewTestFilter(), 3)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Alice", "Bob", "Charlie"}, names(page))
+		require.NotEmpty(t, next)
+
+		page, next, err = repo.FindPage(ctx, NewTestFilter(), 3, WithCursorToken(next))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"David"}, names(page))
+		assert.Empty(t, next, "last page has no next cursor")
+	})
+
+	t.Run("descending cursor with filter", func(t *testing.T) {
+		filter := NewTestFilter().IsActiveEq(true)
+		page, next, err := repo.FindPage(ctx, filter, 2, WithCursor("age", nil, "desc"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"David", "Bob"}, names(page))
+
+		cursor, err := DecodeCursor(next)
+		require.NoError(t, err)
+		assert.Equal(t, Cursor{Field: "age", Value: int64(30), Key: int64(2), Direction: "desc"}, cursor)
+
+		page, next, err = repo.FindPage(ctx, filter, 2, WithCursorToken(next))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Alice"}, names(page))
+		assert.Empty(t, next)
+	})
+
+	t.Run("exact page size has no next cursor", func(t *testing.T) {
+		page, next, err := repo.FindPage(ctx, NewTestFilter(), 4)
+		require.NoError(t, err)
+		assert.Len(t, page, 4)
+		assert.Empty(t, next)
+	})
+
+	t.Run("accepts Go field names", func(t *testing.T) {
+		page, next, err := repo.FindPage(ctx, NewTestFilter(), 3, WithCursor("CreatedAt", nil, "asc"))
+		require.NoError(t, err)
+		assert.Len(t, page, 3)
+
+		cursor, err := DecodeCursor(next)
+		require.NoError(t, err)
+		assert.Equal(t, "created_at", cursor.Field, "the token stores the column")
+
+		found, err := repo.FindAll(ctx, NewTestFilter(), WithCursor("CreatedAt", time.Time{}, "asc"))
+		require.NoError(t, err)
+		assert.Len(t, found, 4)
+	})
+
+	t.Run("primary key breaks ties across pages", func(t *testing.T) {
+		repo, _ := setupTestRepository(t)
+		require.NoError(t, repo.Create(ctx,
+			&TestEntity{Name: "Ann", Age: 25},
+			&TestEntity{Name: "Ben", Age: 30},
+			&TestEntity{Name: "Cal", Age: 25},
+			&TestEntity{Name: "Dee", Age: 25},
+			&TestEntity{Name: "Eve", Age: 20},
+		))
+
+		for _, tt := range []struct {
+			direction string
+			expected  []string
+		}{
+			{"asc", []string{"Eve", "Ann", "Cal", "Dee", "Ben"}},
+			{"desc", []string{"Ben", "Dee", "Cal", "Ann", "Eve"}},
+		} {
+			var walked []string
+			options := []OptionFunc{WithCursor("age", nil, tt.direction)}
+			for {
+				page, next, err := repo.FindPage(ctx, NewTestFilter(), 2, options...)
+				require.NoError(t, err)
+				walked = append(walked, names(page)...)
+				if next == "" {
+					break
+				}
+				options = []OptionFunc{WithCursorToken(next)}
+			}
+			assert.Equal(t, tt.expected, walked, tt.direction)
+		}
+	})
+
+	t.Run("cursor applies to FindAll", func(t *testing.T) {
+		found, err := repo.FindAll(ctx, NewTestFilter(), WithCursor("age", 25, "asc"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Bob", "David"}, names(found))
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		_, _, err := repo.FindPage(ctx, NewTestFilter(), 0)
+		assert.ErrorIs(t, err, ErrInvalidPageSize)
+
+		_, _, err = repo.FindPage(ctx, NewTestFilter(), 2, WithCursorToken("not a token"))
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+
+		_, _, err = repo.FindPage(ctx, NewTestFilter(), 2, WithCursor("missing", nil, "asc"))
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+
+		_, _, err = repo.FindPage(ctx, NewTestFilter(), 2, WithCursor("age", nil, "sideways"))
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
+
+func TestGormRepository_WithSelect(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx, createTestEntities()...))
+
+	t.Run("only loads selected columns and the primary key", func(t *testing.T) {
+		found, err := repo.FindAll(ctx, NewTestFilter().NameEq("Bob"), WithSelect("name", "age"))
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+
+		assert.NotZero(t, found[0].ID)
+		assert.Equal(t, "Bob", found[0].Name)
+		assert.Equal(t, 30, found[0].Age)
+		assert.Empty(t, found[0].Email)
+		assert.False(t, found[0].IsActive)
+	})
+
+	t.Run("accepts Go field names", func(t *testing.T) {
+		found, ok, err := repo.FindOne(ctx, NewTestFilter().NameEq("Alice"), WithSelect("Email"))
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "alice@example.com", found.Email)
+		assert.Empty(t, found.Name)
+	})
+
+	t.Run("keeps the cursor field for FindPage", func(t *testing.T) {
+		page, next, err := repo.FindPage(ctx, NewTestFilter(), 2, WithSelect("name"), WithCursor("age", nil, "asc"))
+		require.NoError(t, err)
+		assert.Equal(t, []int{20, 25}, []int{page[0].Age, page[1].Age})
+
+		cursor, err := DecodeCursor(next)
+		require.NoError(t, err)
+		assert.Equal(t, int64(25), cursor.Value)
+	})
+
+	t.Run("unknown column", func(t *testing.T) {
+		_, err := repo.FindAll(ctx, NewTestFilter(), WithSelect("name", "nickname"))
+		assert.ErrorIs(t, err, ErrUnknownField)
+		assert.ErrorContains(t, err, `"nickname"`)
+
+		_, _, err = repo.FindOne(ctx, NewTestFilter(), WithSelect("nickname"))
+		assert.ErrorIs(t, err, ErrUnknownField)
+	})
+}
+
+func TestGormRepository_FilterErrors(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+	ctx := context.Background()
+	repo.MustCreate(ctx, createTestEntities()...)
+
+	parseErr := errors.New("parse \"abc\" as int")
+	filter := &TestFilter{err: parseErr}
+	filter.AgeGte(18)
+
+	_, err := repo.FindAll(ctx, filter)
+	assert.ErrorIs(t, err, ErrInvalidFilterValue)
+	assert.ErrorIs(t, err, parseErr)
+
+	_, err = repo.Count(ctx, filter)
+	assert.ErrorIs(t, err, ErrInvalidFilterValue)
+
+	affected, err := repo.UpdateWithFilter(ctx, filter, &TestUpdater{fields: map[string]interface{}{"age": 1}})
+	assert.ErrorIs(t, err, ErrInvalidFilterValue)
+	assert.Zero(t, affected)
+
+	_, err = repo.FindAll(ctx, &TestFilter{filters: []*Filter{{Field: "age", Operator: OperatorIn, Value: 5}}})
+	assert.ErrorIs(t, err, ErrFilterValueNotList)
+	assert.ErrorContains(t, err, `IN filter on "age" got int`)
 }
 
 func TestGormRepository_Update(t *testing.T) {
@@ -358,101 +775,751 @@ func TestGormRepository_Update(t *testing.T) {
 	})
 }
 
-func TestGormRepository_Count(t *testing.T) {
+func TestGormRepository_UpdateNonZero(t *testing.T) {
 	repo, _ := setupTestRepository(t)
 	ctx := context.Background()
 
-	// Create test data
-	entities := createTestEntities()
-	err := repo.Create(ctx, entities...)
+	entity := &TestEntity{Name: "Alice", Email: "alice@example.com", Age: 30, IsActive: true}
+	require.NoError(t, repo.Create(ctx, entity))
+
+	require.NoError(t, repo.UpdateNonZero(ctx, &TestEntity{ID: entity.ID, Name: "Alicia", IsActive: false}))
+
+	stored, _, err := repo.FindOneByID(ctx, entity.ID)
 	require.NoError(t, err)
+	assert.Equal(t, "Alicia", stored.Name)
+	assert.Equal(t, "alice@example.com", stored.Email, "empty fields are skipped")
+	assert.True(t, stored.IsActive, "false is a zero value and skipped")
 
-	t.Run("count all records", func(t *testing.T) {
-		filter := NewTestFilter()
-		count, err := repo.Count(ctx, filter)
+	assert.Error(t, repo.UpdateNonZero(ctx, &TestEntity{Name: "no key"}), "a record without primary key matches no row")
+}
 
-		assert.NoError(t, err)
-		assert.Equal(t, int64(4), count)
+func TestGormRepository_DefaultScope(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	entities := createTestEntities()
+	require.NoError(t, NewGormRepository[TestEntity, *TestFilter, *TestUpdater](db).Create(ctx, entities...))
+	alice, charlie := entities[0], entities[2]
+
+	// Charlie is inactive, so outside the scope of every call
+	repo := NewGormRepositoryWithConfig[TestEntity, *TestFilter, *TestUpdater](db, RepoConfig{
+		DefaultScope: NewTestFilter().IsActiveEq(true),
 	})
 
-	t.Run("count with filter", func(t *testing.T) {
-		filter := NewTestFilter().IsActiveEq(true)
-		count, err := repo.Count(ctx, filter)
+	count, err := repo.Count(ctx, NewTestFilter())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
 
-		assert.NoError(t, err)
-		assert.Equal(t, int64(3), count)
-	})
-}
+	found, err := repo.FindAll(ctx, NewTestFilter().AgeGte(30))
+	require.NoError(t, err)
+	assert.Len(t, found, 2, "the scope is ANDed with the filter")
 
-func TestGormRepository_Exists(t *testing.T) {
-	repo, _ := setupTestRepository(t)
-	ctx := context.Background()
+	_, exists, err := repo.FindOne(ctx, NewTestFilter().NameEq("Charlie"))
+	require.NoError(t, err)
+	assert.False(t, exists)
 
-	// Create test data
-	entities := createTestEntities()
-	err := repo.Create(ctx, entities...)
+	_, exists, err = repo.FindOneByID(ctx, charlie.ID)
 	require.NoError(t, err)
+	assert.False(t, exists)
+	exists, err = repo.ExistsByID(ctx, charlie.ID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	byIDs, err := repo.FindByIDs(ctx, alice.ID, charlie.ID)
+	require.NoError(t, err)
+	assert.Len(t, byIDs, 1)
 
-	t.Run("exists with matching filter", func(t *testing.T) {
-		filter := NewTestFilter().NameEq("Alice")
-		exists, err := repo.Exists(ctx, filter)
+	require.NoError(t, repo.Update(ctx, charlie, NewTestUpdater().SetName("Chuck")))
+	updated, err := repo.UpdateWithFilter(ctx, NewTestFilter(), NewTestUpdater().SetAge(50))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), updated)
 
-		assert.NoError(t, err)
-		assert.True(t, exists)
-	})
+	deleted, err := repo.DeleteByIDs(ctx, charlie.ID)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+	deleted, err = repo.DeleteWithFilter(ctx, NewTestFilter().AgeGte(50))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
 
-	t.Run("exists with non-matching filter", func(t *testing.T) {
-		filter := NewTestFilter().NameEq("NonExistent")
-		exists, err := repo.Exists(ctx, filter)
+	var stored TestEntity
+	require.NoError(t, db.First(&stored, charlie.ID).Error)
+	assert.Equal(t, "Charlie", stored.Name)
+	assert.Equal(t, 20, stored.Age)
 
-		assert.NoError(t, err)
-		assert.False(t, exists)
+	invalid := NewGormRepositoryWithConfig[TestEntity, *TestFilter, *TestUpdater](db, RepoConfig{
+		DefaultScope: &TestFilter{err: assert.AnError},
 	})
+	_, err = invalid.Count(ctx, NewTestFilter())
+	assert.ErrorIs(t, err, ErrInvalidFilterValue)
+	_, _, err = invalid.FindOneByID(ctx, charlie.ID)
+	assert.ErrorIs(t, err, ErrInvalidFilterValue)
 }
 
-func TestGormRepository_UpdateWithFilter(t *testing.T) {
+func TestGormRepository_FindAllDistinct(t *testing.T) {
 	repo, _ := setupTestRepository(t)
 	ctx := context.Background()
+	require.NoError(t, repo.Create(ctx, createTestEntities()...))
 
-	// Create test data
-	entities := createTestEntities()
-	err := repo.Create(ctx, entities...)
+	statuses, err := repo.FindAll(ctx, NewTestFilter(), WithDistinct("is_active"), WithSort("is_active", Desc))
 	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.True(t, statuses[0].IsActive)
+	assert.Zero(t, statuses[0].ID, "only the distinct columns are selected")
 
-	t.Run("update multiple records with filter", func(t *testing.T) {
-		filter := NewTestFilter().IsActiveEq(true)
-		updater := NewTestUpdater().SetEmail("updated@example.com")
-
-		rowsAffected, err := repo.UpdateWithFilter(ctx, filter, updater)
-
-		assert.NoError(t, err)
-		assert.Equal(t, int64(3), rowsAffected)
-
-		// Verify updates
-		activeProducts, err := repo.FindAll(ctx, NewTestFilter().IsActiveEq(true))
-		assert.NoError(t, err)
+	rows, err := repo.FindAll(ctx, NewTestFilter(), WithDistinct())
+	require.NoError(t, err)
+	assert.Len(t, rows, 4)
 
-		for _, user := range activeProducts {
-			assert.Equal(t, "updated@example.com", user.Email)
-		}
-	})
+	_, err = NewMemoryRepository[TestEntity, *TestFilter, *TestUpdater]().FindAll(ctx, NewTestFilter(), WithDistinct())
+	assert.ErrorIs(t, err, ErrUnsupportedOption)
 }
 
-func TestGormRepository_DeleteWithFilter(t *testing.T) {
+func TestGormRepository_FindAllAndCount(t *testing.T) {
 	repo, _ := setupTestRepository(t)
 	ctx := context.Background()
+	require.NoError(t, repo.Create(ctx, createTestEntities()...))
 
-	// Create test data
-	entities := createTestEntities()
-	err := repo.Create(ctx, entities...)
+	page, total, err := repo.FindAllAndCount(ctx, NewTestFilter().IsActiveEq(true),
+		WithSort("age", Asc), WithLimit(2), WithOffset(1))
 	require.NoError(t, err)
+	assert.Equal(t, int64(3), total, "the total ignores limit and offset")
+	require.Len(t, page, 2)
+	assert.Equal(t, "Bob", page[0].Name)
+	assert.Equal(t, "David", page[1].Name)
 
-	t.Run("delete records with filter", func(t *testing.T) {
-		filter := NewTestFilter().IsActiveEq(false)
-		rowsAffected, err := repo.DeleteWithFilter(ctx, filter)
+	page, total, err = repo.FindAllAndCount(ctx, NewTestFilter().NameEq("nobody"), WithLimit(2))
+	require.NoError(t, err)
+	assert.Zero(t, total)
+	assert.NotNil(t, page)
+	assert.Empty(t, page)
 
-		assert.NoError(t, err)
-		assert.Equal(t, int64(1), rowsAffected) // Only Charlie is inactive
+	page, total, err = repo.FindAllAndCount(ctx, NewTestFilter(), WithDistinct("is_active"), WithLimit(1))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total, "the total counts distinct rows")
+	assert.Len(t, page, 1)
+
+	page, total, err = repo.FindAllAndCount(ctx, NewTestFilter(), WithGroupBy("is_active"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total, "the total counts groups")
+	assert.Len(t, page, 2)
+
+	_, _, err = repo.FindAllAndCount(ctx, NewTestFilter(), WithSort("age", "sideways"))
+	assert.ErrorIs(t, err, ErrInvalidSortDirection)
+	_, _, err = repo.FindAllAndCount(ctx, &TestFilter{err: assert.AnError})
+	assert.ErrorIs(t, err, ErrInvalidFilterValue)
+}
+
+// ReturningEntity has a column the database generates, which GORM doesn't know to read back
+type ReturningEntity struct {
+	ID   int64 `gorm:"primaryKey"`
+	Name string
+	Code string `gorm:"->"`
+}
+
+func TestGormRepository_Returning(t *testing.T) {
+	repo, db := setupTestRepository(t)
+	ctx := context.Background()
+	require.NoError(t, repo.Create(ctx, createTestEntities()...))
+
+	updated, err := repo.UpdateWithFilterReturning(ctx, NewTestFilter().AgeGte(30), NewTestUpdater().SetIsActive(false))
+	require.NoError(t, err)
+	require.Len(t, updated, 2)
+	for _, entity := range updated {
+		assert.NotZero(t, entity.ID)
+		assert.NotEmpty(t, entity.Name)
+		assert.False(t, entity.IsActive)
+	}
+
+	none, err := repo.UpdateWithFilterReturning(ctx, NewTestFilter().NameEq("nobody"), NewTestUpdater().SetAge(1))
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	require.NoError(t, db.Exec("CREATE TABLE returning_entities (id integer PRIMARY KEY AUTOINCREMENT, name text, "+
+		"code text GENERATED ALWAYS AS (upper(name)) VIRTUAL)").Error)
+	generated := NewGormRepository[ReturningEntity, *TestFilter, *TestUpdater](db)
+
+	plain := &ReturningEntity{Name: "plain"}
+	require.NoError(t, generated.Create(ctx, plain))
+	assert.Empty(t, plain.Code)
+
+	returned, second := &ReturningEntity{Name: "returned"}, &ReturningEntity{Name: "second"}
+	require.NoError(t, generated.CreateReturning(ctx, returned, second))
+	assert.NotZero(t, returned.ID)
+	assert.Equal(t, "RETURNED", returned.Code)
+	assert.Equal(t, "SECOND", second.Code)
+
+	mysql := NewGormRepository[TestEntity, *TestFilter, *TestUpdater](setupDialectDB(t, DialectMySQL))
+	assert.ErrorIs(t, mysql.CreateReturning(ctx, &TestEntity{Name: "x"}), ErrUnsupportedDialect)
+	_, err = mysql.UpdateWithFilterReturning(ctx, NewTestFilter(), NewTestUpdater().SetAge(1))
+	assert.ErrorIs(t, err, ErrUnsupportedDialect)
+}
+
+// VersionedEntity is locked optimistically through its Version column
+type VersionedEntity struct {
+	ID      int64 `gorm:"primaryKey"`
+	Name    string
+	Version int `gorm:"version;not null;default:1"`
+}
+
+// TaggedVersionEntity marks its version column with the querybuilder tag instead
+type TaggedVersionEntity struct {
+	ID       int64 `gorm:"primaryKey"`
+	Name     string
+	Revision uint `qb:"version"`
+}
+
+func TestGormRepository_UpdateOptimisticLock(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&VersionedEntity{}, &TaggedVersionEntity{}))
+	repo := NewGormRepository[VersionedEntity, *TestFilter, *TestUpdater](db)
+	ctx := context.Background()
+
+	entity := &VersionedEntity{Name: "original", Version: 1}
+	require.NoError(t, repo.Create(ctx, entity))
+
+	stale := *entity
+	require.NoError(t, repo.Update(ctx, entity, NewTestUpdater().SetName("first")))
+	assert.Equal(t, 2, entity.Version)
+
+	err := repo.Update(ctx, &stale, NewTestUpdater().SetName("second"))
+	assert.ErrorIs(t, err, ErrOptimisticLock)
+	assert.Equal(t, 1, stale.Version, "a failed update keeps the stale version")
+
+	stored, _, err := repo.FindOneByID(ctx, entity.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "first", stored.Name)
+	assert.Equal(t, 2, stored.Version)
+
+	deleted := &VersionedEntity{ID: 99, Version: 1}
+	assert.ErrorIs(t, repo.Update(ctx, deleted, NewTestUpdater().SetName("gone")), ErrOptimisticLock)
+
+	// UpdateWithFilter neither checks nor increments versions
+	_, err = repo.UpdateWithFilter(ctx, NewTestFilter().NameEq("first"), NewTestUpdater().SetName("bulk"))
+	require.NoError(t, err)
+	stored, _, err = repo.FindOneByID(ctx, entity.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.Version)
+
+	tagged := NewGormRepository[TaggedVersionEntity, *TestFilter, *TestUpdater](db)
+	revision := &TaggedVersionEntity{Name: "draft"}
+	require.NoError(t, tagged.Create(ctx, revision))
+	require.NoError(t, tagged.Update(ctx, revision, NewTestUpdater().SetName("published")))
+	assert.Equal(t, uint(1), revision.Revision)
+	assert.ErrorIs(t, tagged.Update(ctx, &TaggedVersionEntity{ID: revision.ID}, NewTestUpdater().SetName("x")), ErrOptimisticLock)
+}
+
+func TestGormRepository_Count(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+	ctx := context.Background()
+
+	// Create test data
+	entities := createTestEntities()
+	err := repo.Create(ctx, entities...)
+	require.NoError(t, err)
+
+	t.Run("count all records", func(t *testing.T) {
+		filter := NewTestFilter()
+		count, err := repo.Count(ctx, filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), count)
+	})
+
+	t.Run("count with filter", func(t *testing.T) {
+		filter := NewTestFilter().IsActiveEq(true)
+		count, err := repo.Count(ctx, filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+}
+
+func TestGormRepository_Exists(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+	ctx := context.Background()
+
+	// Create test data
+	entities := createTestEntities()
+	err := repo.Create(ctx, entities...)
+	require.NoError(t, err)
+
+	t.Run("exists with matching filter", func(t *testing.T) {
+		filter := NewTestFilter().NameEq("Alice")
+		exists, err := repo.Exists(ctx, filter)
+
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("exists with non-matching filter", func(t *testing.T) {
+		filter := NewTestFilter().NameEq("NonExistent")
+		exists, err := repo.Exists(ctx, filter)
+
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("filter errors are returned", func(t *testing.T) {
+		filter := NewTestFilter()
+		filter.filters = append(filter.filters, &Filter{Operator: OperatorEqual, Value: 1})
+		_, err := repo.Exists(ctx, filter)
+		assert.ErrorIs(t, err, ErrEmptyFieldName)
+	})
+}
+
+func TestGormRepository_CountDistinct(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+	ctx := context.Background()
+
+	entities := createTestEntities()
+	entities[0].Age = 30
+	require.NoError(t, repo.Create(ctx, entities...))
+
+	count, err := repo.CountDistinct(ctx, NewTestFilter(), "age")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	count, err = repo.CountDistinct(ctx, NewTestFilter().IsActiveEq(true), "age")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	_, err = repo.CountDistinct(ctx, NewTestFilter(), "")
+	assert.ErrorIs(t, err, ErrEmptyFieldName)
+}
+
+func TestGormRepository_ExplainFilter(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+	ctx := context.Background()
+	require.NoError(t, repo.Create(ctx, createTestEntities()...))
+
+	sql, vars, err := repo.ExplainFilter(NewTestFilter().NameEq("Alice").AgeGte(18), WithSort("age", Desc), WithLimit(10))
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM `test_entities` WHERE `name` = ? AND `age` >= ? ORDER BY `age` desc LIMIT 10", sql)
+	assert.Equal(t, []interface{}{"Alice", 18}, vars)
+
+	// Nothing was run against the database
+	count, err := repo.Count(ctx, NewTestFilter())
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+
+	invalid := NewTestFilter()
+	invalid.err = errors.New("bad value")
+	_, _, err = repo.ExplainFilter(invalid)
+	assert.ErrorIs(t, err, ErrInvalidFilterValue)
+}
+
+func TestGormRepository_ExistsByID(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&SoftDeleteEntity{}))
+	repo := NewGormRepository[SoftDeleteEntity, *TestFilter, *TestUpdater](db)
+	ctx := context.Background()
+
+	kept := &SoftDeleteEntity{Name: "kept"}
+	removed := &SoftDeleteEntity{Name: "removed"}
+	repo.MustCreate(ctx, kept, removed)
+	_, err := repo.DeleteWithFilter(ctx, NewTestFilter().NameEq("removed"))
+	require.NoError(t, err)
+
+	var statements []string
+	require.NoError(t, db.Callback().Row().After("gorm:row").Register("test:record_sql", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	}))
+
+	exists, err := repo.ExistsByID(ctx, kept.ID)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	require.Len(t, statements, 1)
+	assert.Contains(t, statements[0], "SELECT 1 FROM")
+	assert.Contains(t, statements[0], "LIMIT 1")
+
+	exists, err = repo.ExistsByID(ctx, removed.ID)
+	require.NoError(t, err)
+	assert.False(t, exists, "soft-deleted records don't exist")
+
+	exists, err = repo.ExistsByID(ctx, removed.ID+100)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestGormRepository_UnsignedIn(t *testing.T) {
+	type UnsignedEntity struct {
+		ID    uint64 `gorm:"primaryKey;autoIncrement:false"`
+		Level uint8
+	}
+
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&UnsignedEntity{}))
+	repo := NewGormRepository[UnsignedEntity, *TestFilter, *TestUpdater](db)
+	ctx := context.Background()
+
+	// Beyond float64 precision; SQLite stores signed 64-bit integers, so this is near its maximum
+	large := uint64(1<<62 + 1)
+	repo.MustCreate(ctx, &UnsignedEntity{ID: large, Level: 200}, &UnsignedEntity{ID: large - 1, Level: 7})
+
+	filter := NewTestFilter()
+	filter.filters = append(filter.filters, &Filter{Field: "id", Operator: OperatorIn, Value: []uint64{large}})
+	found, err := repo.FindAll(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, large, found[0].ID)
+
+	filter = NewTestFilter()
+	filter.filters = append(filter.filters, &Filter{Field: "level", Operator: OperatorIn, Value: []uint8{7, 255}})
+	found, err = repo.FindAll(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, large-1, found[0].ID)
+}
+
+func TestGormRepository_CountByMonth(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+	ctx := context.Background()
+
+	entities := createTestEntities()
+	entities[0].CreatedAt = time.Date(2024, time.January, 5, 10, 0, 0, 0, time.UTC)
+	entities[1].CreatedAt = time.Date(2024, time.January, 28, 23, 30, 0, 0, time.UTC)
+	entities[2].CreatedAt = time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+	entities[3].CreatedAt = time.Date(2024, time.April, 15, 12, 0, 0, 0, time.UTC)
+	err := repo.Create(ctx, entities...)
+	require.NoError(t, err)
+
+	t.Run("counts per month", func(t *testing.T) {
+		counts, err := repo.CountByMonth(ctx, NewTestFilter(), "created_at")
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int64{
+			"2024-01": 2,
+			"2024-02": 1,
+			"2024-04": 1,
+		}, counts)
+	})
+
+	t.Run("counts respect filter", func(t *testing.T) {
+		counts, err := repo.CountByMonth(ctx, NewTestFilter().IsActiveEq(true), "created_at")
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int64{
+			"2024-01": 2,
+			"2024-04": 1,
+		}, counts)
+	})
+
+	t.Run("empty field name", func(t *testing.T) {
+		_, err := repo.CountByMonth(ctx, NewTestFilter(), "")
+		assert.ErrorIs(t, err, ErrEmptyFieldName)
+	})
+}
+
+// TestAuthor and TestBook form a has-many association for preload tests
+type TestAuthor struct {
+	ID    int64 `gorm:"primaryKey"`
+	Name  string
+	Books []*TestBook `gorm:"foreignKey:AuthorID"`
+}
+
+type TestBook struct {
+	ID       int64 `gorm:"primaryKey"`
+	AuthorID int64
+	Title    string
+}
+
+func TestGormRepository_Aggregates(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx, createTestEntities()...))
+
+	tests := []struct {
+		name      string
+		aggregate func(context.Context, *TestFilter, string) (float64, bool, error)
+		all       float64
+		active    float64
+	}{
+		{"sum", repo.Sum, 110, 90},
+		{"avg", repo.Avg, 27.5, 30},
+		{"min", repo.Min, 20, 25},
+		{"max", repo.Max, 35, 35},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, hasRows, err := tt.aggregate(ctx, NewTestFilter(), "age")
+			require.NoError(t, err)
+			assert.True(t, hasRows)
+			assert.InDelta(t, tt.all, result, 1e-9)
+
+			result, hasRows, err = tt.aggregate(ctx, NewTestFilter().IsActiveEq(true), "age")
+			require.NoError(t, err)
+			assert.True(t, hasRows)
+			assert.InDelta(t, tt.active, result, 1e-9)
+
+			// An empty set aggregates to NULL
+			result, hasRows, err = tt.aggregate(ctx, NewTestFilter().NameEq("nobody"), "age")
+			require.NoError(t, err)
+			assert.False(t, hasRows)
+			assert.Zero(t, result)
+
+			_, _, err = tt.aggregate(ctx, NewTestFilter(), "")
+			assert.ErrorIs(t, err, ErrEmptyFieldName)
+		})
+	}
+}
+
+func TestGormRepository_FindGrouped(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx, createTestEntities()...))
+
+	type activeGroup struct {
+		IsActive bool
+		Count    int64
+		MaxAge   int
+	}
+
+	t.Run("group with aggregates", func(t *testing.T) {
+		var groups []activeGroup
+		err := repo.FindGrouped(ctx, NewTestFilter(), &groups,
+			WithGroupBy("is_active"),
+			WithAggregate("COUNT", "*", "count"),
+			WithAggregate("max", "age", "max_age"),
+			WithSort("is_active", Asc),
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, []activeGroup{
+			{IsActive: false, Count: 1, MaxAge: 20},
+			{IsActive: true, Count: 3, MaxAge: 35},
+		}, groups)
+	})
+
+	t.Run("having on an aggregate expression", func(t *testing.T) {
+		var groups []activeGroup
+		err := repo.FindGrouped(ctx, NewTestFilter().AgeGte(20), &groups,
+			WithGroupBy("is_active"),
+			WithAggregate("COUNT", "*", "count"),
+			WithHaving(&Filter{Field: "COUNT(*)", Operator: OperatorGreaterThan, Value: 1}),
+		)
+
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.True(t, groups[0].IsActive)
+		assert.Equal(t, int64(3), groups[0].Count)
+	})
+
+	t.Run("invalid aggregates", func(t *testing.T) {
+		var groups []activeGroup
+		for _, option := range []OptionFunc{
+			WithAggregate("MEDIAN", "age", "median"),
+			WithAggregate("SUM", "*", "total"),
+			WithAggregate("COUNT", "*", ""),
+		} {
+			err := repo.FindGrouped(ctx, NewTestFilter(), &groups, WithGroupBy("is_active"), option)
+			assert.ErrorIs(t, err, ErrInvalidAggregate)
+		}
+
+		assert.ErrorIs(t, repo.FindGrouped(ctx, NewTestFilter(), &groups), ErrInvalidAggregate)
+	})
+}
+
+func TestGormRepository_Preload(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&TestAuthor{}, &TestBook{}))
+	repo := NewGormRepository[TestAuthor, *TestFilter, *TestUpdater](db)
+	ctx := context.Background()
+
+	authors := []*TestAuthor{
+		{Name: "Alice", Books: []*TestBook{{Title: "First"}, {Title: "Second"}}},
+		{Name: "Bob", Books: []*TestBook{{Title: "Third"}}},
+	}
+	require.NoError(t, repo.Create(ctx, authors...))
+
+	t.Run("association is loaded when requested", func(t *testing.T) {
+		found, err := repo.FindAll(ctx, NewTestFilter().NameEq("Alice"), WithPreload("Books"))
+
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Len(t, found[0].Books, 2)
+	})
+
+	t.Run("conditions filter the loaded records", func(t *testing.T) {
+		found, err := repo.FindAll(ctx, NewTestFilter().NameEq("Alice"), WithPreload("Books", "title = ?", "Second"))
+
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		require.Len(t, found[0].Books, 1)
+		assert.Equal(t, "Second", found[0].Books[0].Title)
+	})
+
+	t.Run("limits apply to the queried records only", func(t *testing.T) {
+		found, err := repo.FindAll(ctx, NewTestFilter(), WithPreload("Books"), WithSort("name", Asc), WithLimit(1), WithOffset(1))
+
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, "Bob", found[0].Name)
+		assert.Len(t, found[0].Books, 1)
+
+		found, err = repo.FindAll(ctx, NewTestFilter(), WithPreload("Books"), WithSort("name", Asc), WithLimit(1))
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Len(t, found[0].Books, 2, "the limit must not cut the preloaded books")
+	})
+
+	t.Run("association is not loaded by default", func(t *testing.T) {
+		found, err := repo.FindAll(ctx, NewTestFilter().NameEq("Alice"))
+
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Empty(t, found[0].Books)
+	})
+}
+
+func TestGormRepository_Join(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&TestAuthor{}, &TestBook{}))
+	repo := NewGormRepository[TestAuthor, *TestFilter, *TestUpdater](db)
+	ctx := context.Background()
+
+	authors := []*TestAuthor{
+		{Name: "Alice", Books: []*TestBook{{Title: "First"}, {Title: "Second"}}},
+		{Name: "Bob", Books: []*TestBook{{Title: "Third"}}},
+	}
+	require.NoError(t, repo.Create(ctx, authors...))
+
+	joinBooks := WithJoin("JOIN test_books ON test_books.author_id = test_authors.id")
+	titleEq := func(title string) *TestFilter {
+		filter := NewTestFilter()
+		filter.filters = append(filter.filters, &Filter{Field: "test_books.title", Operator: OperatorEqual, Value: title})
+		return filter
+	}
+
+	t.Run("filters target the joined table by qualified name", func(t *testing.T) {
+		found, err := repo.FindAll(ctx, titleEq("Third"), joinBooks)
+
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, "Bob", found[0].Name)
+	})
+
+	t.Run("unqualified fields refer to the entity table", func(t *testing.T) {
+		filter := titleEq("Second")
+		filter.filters = append(filter.filters, &Filter{Field: "id", Operator: OperatorEqual, Value: authors[0].ID})
+
+		found, err := repo.FindAll(ctx, filter, joinBooks, WithSort("id", Desc), WithSelect("name"))
+
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, "Alice", found[0].Name)
+		assert.Equal(t, authors[0].ID, found[0].ID)
+	})
+
+	t.Run("a to-many join repeats records", func(t *testing.T) {
+		count, err := repo.Count(ctx, NewTestFilter().NameEq("Alice"), joinBooks)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("FindAllAndCount counts the rows it selects", func(t *testing.T) {
+		found, total, err := repo.FindAllAndCount(ctx, NewTestFilter(), joinBooks, WithDistinct(), WithLimit(1))
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total, "distinct authors, not books")
+		assert.Len(t, found, 1)
+
+		found, total, err = repo.FindAllAndCount(ctx, NewTestFilter(), joinBooks)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total, "one row per book without distinct")
+		assert.Len(t, found, 3)
+	})
+
+	t.Run("join arguments and quoting", func(t *testing.T) {
+		query, _, err := repo.ExplainFilter(NewTestFilter().NameEq("Alice"),
+			WithJoin("JOIN test_books ON test_books.author_id = test_authors.id AND test_books.title <> ?", "Draft"))
+
+		require.NoError(t, err)
+		assert.Contains(t, query, "JOIN test_books ON test_books.author_id = test_authors.id AND test_books.title <> ?")
+		assert.Contains(t, query, "`test_authors`.`name` = ?")
+	})
+}
+
+func TestGormRepository_RelatedCount(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&TestAuthor{}, &TestBook{}))
+	repo := NewGormRepository[TestAuthor, *TestFilter, *TestUpdater](db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx,
+		&TestAuthor{Name: "Alice", Books: []*TestBook{{Title: "First"}, {Title: "Second"}}},
+		&TestAuthor{Name: "Bob", Books: []*TestBook{{Title: "Third"}}},
+		&TestAuthor{Name: "Carol"},
+	))
+
+	booksCount := func(op Operator, count int64) *TestFilter {
+		filter := NewTestFilter()
+		filter.filters = append(filter.filters, &Filter{
+			Field:    "test_authors.id",
+			Operator: OperatorRelatedCount,
+			Value:    RelatedCount{Table: "test_books", ForeignKey: "author_id", Operator: op, Count: count},
+		})
+		return filter
+	}
+
+	found, err := repo.FindAll(ctx, booksCount(OperatorGreaterThanOrEqual, 2))
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "Alice", found[0].Name)
+
+	count, err := repo.Count(ctx, booksCount(OperatorEqual, 0))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	found, err = repo.FindAll(ctx, booksCount(OperatorLessThan, 2).NameEq("Bob"))
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "Bob", found[0].Name)
+}
+
+func TestGormRepository_UpdateWithFilter(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+	ctx := context.Background()
+
+	// Create test data
+	entities := createTestEntities()
+	err := repo.Create(ctx, entities...)
+	require.NoError(t, err)
+
+	t.Run("update multiple records with filter", func(t *testing.T) {
+		filter := NewTestFilter().IsActiveEq(true)
+		updater := NewTestUpdater().SetEmail("updated@example.com")
+
+		rowsAffected, err := repo.UpdateWithFilter(ctx, filter, updater)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), rowsAffected)
+
+		// Verify updates
+		activeProducts, err := repo.FindAll(ctx, NewTestFilter().IsActiveEq(true))
+		assert.NoError(t, err)
+
+		for _, user := range activeProducts {
+			assert.Equal(t, "updated@example.com", user.Email)
+		}
+	})
+}
+
+func TestGormRepository_DeleteWithFilter(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+	ctx := context.Background()
+
+	// Create test data
+	entities := createTestEntities()
+	err := repo.Create(ctx, entities...)
+	require.NoError(t, err)
+
+	t.Run("delete records with filter", func(t *testing.T) {
+		filter := NewTestFilter().IsActiveEq(false)
+		rowsAffected, err := repo.DeleteWithFilter(ctx, filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), rowsAffected) // Only Charlie is inactive
 
 		// Verify deletion
 		totalCount, err := repo.Count(ctx, NewTestFilter())
@@ -461,6 +1528,137 @@ func TestGormRepository_DeleteWithFilter(t *testing.T) {
 	})
 }
 
+func TestGormRepository_SoftDelete(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&SoftDeleteEntity{}))
+	repo := NewGormRepository[SoftDeleteEntity, *TestFilter, *TestUpdater](db)
+	ctx := context.Background()
+
+	repo.MustCreate(ctx, &SoftDeleteEntity{Name: "kept"}, &SoftDeleteEntity{Name: "removed"}, &SoftDeleteEntity{Name: "purged"})
+
+	deleted, err := repo.DeleteWithFilter(ctx, NewTestFilter().NameEq("removed"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	t.Run("soft-deleted records are hidden by default", func(t *testing.T) {
+		found, err := repo.FindAll(ctx, NewTestFilter())
+		require.NoError(t, err)
+		assert.Len(t, found, 2)
+
+		count, err := repo.Count(ctx, NewTestFilter())
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("WithUnscoped includes soft-deleted records", func(t *testing.T) {
+		found, err := repo.FindAll(ctx, NewTestFilter(), WithUnscoped())
+		require.NoError(t, err)
+		assert.Len(t, found, 3)
+
+		count, err := repo.Count(ctx, NewTestFilter().NameEq("removed"), WithUnscoped())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Restore brings soft-deleted records back", func(t *testing.T) {
+		restored, err := repo.Restore(ctx, NewTestFilter())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), restored, "only soft-deleted records are restored")
+
+		found, ok, err := repo.FindOne(ctx, NewTestFilter().NameEq("removed"))
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.False(t, found.DeletedAt.Valid)
+	})
+
+	t.Run("HardDelete removes records permanently", func(t *testing.T) {
+		_, err := repo.DeleteWithFilter(ctx, NewTestFilter().NameEq("purged"))
+		require.NoError(t, err)
+
+		purged, err := repo.HardDelete(ctx, NewTestFilter().NameEq("purged"))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), purged, "soft-deleted records can be hard-deleted")
+
+		count, err := repo.Count(ctx, NewTestFilter(), WithUnscoped())
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		restored, err := repo.Restore(ctx, NewTestFilter().NameEq("purged"))
+		require.NoError(t, err)
+		assert.Zero(t, restored)
+	})
+
+	t.Run("Restore requires a DeletedAt field", func(t *testing.T) {
+		plainRepo, _ := setupTestRepository(t)
+		_, err := plainRepo.Restore(ctx, NewTestFilter())
+		assert.ErrorIs(t, err, ErrNotSoftDeletable)
+	})
+}
+
+func TestGormRepository_DeleteByID(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&SoftDeleteEntity{}))
+	repo := NewGormRepository[SoftDeleteEntity, *TestFilter, *TestUpdater](db)
+	ctx := context.Background()
+
+	first := &SoftDeleteEntity{Name: "first"}
+	second := &SoftDeleteEntity{Name: "second"}
+	third := &SoftDeleteEntity{Name: "third"}
+	repo.MustCreate(ctx, first, second, third)
+
+	t.Run("DeleteByID soft-deletes", func(t *testing.T) {
+		deleted, err := repo.DeleteByID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.True(t, deleted)
+
+		deleted, err = repo.DeleteByID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.False(t, deleted, "already soft-deleted")
+
+		count, err := repo.Count(ctx, NewTestFilter().NameEq("first"), WithUnscoped())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("DeleteByIDs skips missing IDs", func(t *testing.T) {
+		deleted, err := repo.DeleteByIDs(ctx, second.ID, third.ID, third.ID+100)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+
+		deleted, err = repo.DeleteByIDs(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+	})
+
+	t.Run("HardDeleteByID removes soft-deleted records", func(t *testing.T) {
+		deleted, err := repo.HardDeleteByID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.True(t, deleted)
+
+		count, err := repo.Count(ctx, NewTestFilter(), WithUnscoped())
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		deleted, err = repo.HardDeleteByID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.False(t, deleted)
+	})
+
+	t.Run("entities without DeletedAt are removed", func(t *testing.T) {
+		plainRepo, _ := setupTestRepository(t)
+		entities := createTestEntities()
+		require.NoError(t, plainRepo.Create(ctx, entities...))
+
+		deleted, err := plainRepo.DeleteByID(ctx, entities[0].ID)
+		require.NoError(t, err)
+		assert.True(t, deleted)
+
+		count, err := plainRepo.Count(ctx, NewTestFilter(), WithUnscoped())
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+}
+
 func TestGormRepository_CreateInBatches(t *testing.T) {
 	repo, _ := setupTestRepository(t)
 	ctx := context.Background()
@@ -486,6 +1684,83 @@ func TestGormRepository_CreateInBatches(t *testing.T) {
 	})
 }
 
+func TestGormRepository_BulkCreate(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+	ctx := context.Background()
+
+	existing := &TestEntity{ID: 3, Name: "Existing", Email: "existing@example.com"}
+	require.NoError(t, repo.Create(ctx, existing))
+
+	newEntities := func() []*TestEntity {
+		entities := make([]*TestEntity, 5)
+		for i := range entities {
+			entities[i] = &TestEntity{Name: fmt.Sprintf("Import %d", i), Email: fmt.Sprintf("import%d@example.com", i)}
+		}
+		return entities
+	}
+
+	t.Run("fast path inserts every batch", func(t *testing.T) {
+		entities := newEntities()
+		result, err := repo.BulkCreate(ctx, 2, entities...)
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, result.Created)
+		assert.Empty(t, result.Failed)
+		for _, entity := range entities {
+			assert.NotZero(t, entity.ID)
+		}
+	})
+
+	t.Run("conflicting records are reported", func(t *testing.T) {
+		entities := newEntities()
+		entities[1].ID = existing.ID
+		entities[4].ID = existing.ID
+
+		result, err := repo.BulkCreate(ctx, 2, entities...)
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 2, 3}, result.Created)
+		require.Len(t, result.Failed, 2)
+		assert.Equal(t, 1, result.Failed[0].Index)
+		assert.Equal(t, 4, result.Failed[1].Index)
+		assert.True(t, IsConstraintViolation(result.Failed[0].Err))
+
+		count, err := repo.Count(ctx, NewTestFilter())
+		require.NoError(t, err)
+		assert.Equal(t, int64(9), count)
+	})
+
+	t.Run("inside a transaction", func(t *testing.T) {
+		err := repo.WithTransaction(ctx, func(txRepo *GormRepository[TestEntity, *TestFilter, *TestUpdater]) error {
+			entities := newEntities()
+			entities[0].ID = existing.ID
+
+			result, err := txRepo.BulkCreate(ctx, 10, entities...)
+			require.NoError(t, err)
+			assert.Equal(t, []int{1, 2, 3, 4}, result.Created)
+			require.Len(t, result.Failed, 1)
+			return nil
+		})
+		require.NoError(t, err)
+
+		count, err := repo.Count(ctx, NewTestFilter())
+		require.NoError(t, err)
+		assert.Equal(t, int64(13), count)
+	})
+
+	t.Run("no records", func(t *testing.T) {
+		_, err := repo.BulkCreate(ctx, 10)
+		assert.ErrorIs(t, err, ErrNoRecordsProvided)
+	})
+}
+
+func TestIsConstraintViolation(t *testing.T) {
+	assert.True(t, IsConstraintViolation(errors.New("UNIQUE constraint failed: test_entities.id")))
+	assert.True(t, IsConstraintViolation(errors.New(`ERROR: duplicate key value violates unique constraint "users_pkey" (SQLSTATE 23505)`)))
+	assert.True(t, IsConstraintViolation(errors.New("Error 1062 (23000): Duplicate entry '1' for key 'PRIMARY'")))
+	assert.True(t, IsConstraintViolation(fmt.Errorf("create: %w", gorm.ErrDuplicatedKey)))
+	assert.False(t, IsConstraintViolation(errors.New("no such table: test_entities")))
+	assert.False(t, IsConstraintViolation(nil))
+}
+
 func TestGormRepository_WithTransaction(t *testing.T) {
 	repo, _ := setupTestRepository(t)
 	ctx := context.Background()
@@ -529,6 +1804,126 @@ func TestGormRepository_WithTransaction(t *testing.T) {
 	})
 }
 
+func TestGormRepository_WithTransactionOpts(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+	ctx := context.Background()
+
+	err := repo.WithTransactionOpts(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable}, func(txRepo *GormRepository[TestEntity, *TestFilter, *TestUpdater]) error {
+		return txRepo.Create(ctx, &TestEntity{Name: "Serial", Email: "serial@example.com"})
+	})
+	require.NoError(t, err)
+
+	count, err := repo.Count(ctx, NewTestFilter().NameEq("Serial"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// The SQLite driver ignores transaction options, so they are checked where GORM begins the transaction
+	db := setupTestDB(t)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	recorder := &txOptionsRecorder{DB: sqlDB}
+	db.ConnPool = recorder
+	db.Statement.ConnPool = recorder
+	repo = NewGormRepository[TestEntity, *TestFilter, *TestUpdater](db)
+
+	readOnly := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	err = repo.WithTransactionOpts(ctx, readOnly, func(txRepo *GormRepository[TestEntity, *TestFilter, *TestUpdater]) error {
+		_, err := txRepo.Count(ctx, NewTestFilter())
+		return err
+	})
+	require.NoError(t, err)
+
+	err = repo.WithTransaction(ctx, func(*GormRepository[TestEntity, *TestFilter, *TestUpdater]) error { return nil })
+	require.NoError(t, err)
+	assert.Equal(t, []*sql.TxOptions{readOnly, nil}, recorder.options)
+}
+
+func TestGormRepository_NestedTransaction(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+	ctx := context.Background()
+	type repoType = GormRepository[TestEntity, *TestFilter, *TestUpdater]
+
+	innerErr := errors.New("inner failure")
+	err := repo.WithTransaction(ctx, func(txRepo *repoType) error {
+		if err := txRepo.Create(ctx, &TestEntity{Name: "Outer", Email: "outer@example.com"}); err != nil {
+			return err
+		}
+
+		err := txRepo.WithTransaction(ctx, func(innerRepo *repoType) error {
+			if err := innerRepo.Create(ctx, &TestEntity{Name: "RolledBack", Email: "rolledback@example.com"}); err != nil {
+				return err
+			}
+			return innerErr
+		})
+		require.ErrorIs(t, err, innerErr)
+
+		err = txRepo.WithTransaction(ctx, func(innerRepo *repoType) error {
+			return innerRepo.Create(ctx, &TestEntity{Name: "Kept", Email: "kept@example.com"})
+		})
+		require.NoError(t, err)
+
+		// Options cannot change once the transaction runs
+		err = txRepo.WithTransactionOpts(ctx, &sql.TxOptions{ReadOnly: true}, func(*repoType) error { return nil })
+		require.ErrorIs(t, err, ErrNestedTransactionOptions)
+		return nil
+	})
+	require.NoError(t, err)
+
+	all, err := repo.FindAll(ctx, NewTestFilter(), WithSort("name", Asc))
+	require.NoError(t, err)
+	names := make([]string, len(all))
+	for i, entity := range all {
+		names[i] = entity.Name
+	}
+	assert.Equal(t, []string{"Kept", "Outer"}, names)
+
+	t.Run("inner panic rolls back the savepoint", func(t *testing.T) {
+		err := repo.WithTransaction(ctx, func(txRepo *repoType) error {
+			assert.Panics(t, func() {
+				_ = txRepo.WithTransaction(ctx, func(innerRepo *repoType) error {
+					if err := innerRepo.Create(ctx, &TestEntity{Name: "Panicked", Email: "panicked@example.com"}); err != nil {
+						return err
+					}
+					panic("inner panic")
+				})
+			})
+			return nil
+		})
+		require.NoError(t, err)
+
+		count, err := repo.Count(ctx, NewTestFilter().NameEq("Panicked"))
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("outer rollback discards released savepoints", func(t *testing.T) {
+		outerErr := errors.New("outer failure")
+		err := repo.WithTransaction(ctx, func(txRepo *repoType) error {
+			err := txRepo.WithTransaction(ctx, func(innerRepo *repoType) error {
+				return innerRepo.Create(ctx, &TestEntity{Name: "Discarded", Email: "discarded@example.com"})
+			})
+			require.NoError(t, err)
+			return outerErr
+		})
+		require.ErrorIs(t, err, outerErr)
+
+		count, err := repo.Count(ctx, NewTestFilter().NameEq("Discarded"))
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
+
+// txOptionsRecorder records the options transactions are begun with
+type txOptionsRecorder struct {
+	*sql.DB
+	options []*sql.TxOptions
+}
+
+func (r *txOptionsRecorder) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	r.options = append(r.options, opts)
+	return r.DB.BeginTx(ctx, opts)
+}
+
 func TestGormRepository_Health(t *testing.T) {
 	repo, db := setupTestRepository(t)
 	ctx := context.Background()
@@ -582,3 +1977,35 @@ func BenchmarkGormRepository_FindAll(b *testing.B) {
 		_, _ = repo.FindAll(ctx, filter)
 	}
 }
+
+// BenchmarkGormRepository_Exists compares Exists with the COUNT query it used to run
+func BenchmarkGormRepository_Exists(b *testing.B) {
+	repo, _ := setupTestRepository(&testing.T{})
+	ctx := context.Background()
+
+	entities := make([]*TestEntity, 10000)
+	for i := range entities {
+		entities[i] = &TestEntity{
+			Name:     fmt.Sprintf("Product %d", i),
+			Email:    fmt.Sprintf("product%d@example.com", i),
+			Age:      20 + (i % 50),
+			IsActive: true,
+		}
+	}
+	_ = repo.CreateInBatches(ctx, 500, entities...)
+
+	filter := NewTestFilter().IsActiveEq(true)
+
+	b.Run("count", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			count, _ := repo.Count(ctx, filter)
+			_ = count > 0
+		}
+	})
+
+	b.Run("limit 1", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = repo.Exists(ctx, filter)
+		}
+	})
+}
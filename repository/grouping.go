@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Aggregate is an aggregate column of a grouped query, selected as Function(Field) AS Alias
+type Aggregate struct {
+	Function string // COUNT, SUM, AVG, MIN or MAX
+	Field    string // Column to aggregate, or "*" for COUNT(*)
+	Alias    string // Result column, matched to a field of the projection struct
+}
+
+// aggregateFunctions are the SQL functions allowed in aggregates and HAVING expressions
+var aggregateFunctions = []string{"COUNT", "SUM", "AVG", "MIN", "MAX"}
+
+// aggregatePattern matches an aggregate expression such as COUNT(*) or sum(price)
+var aggregatePattern = regexp.MustCompile(`^\s*([A-Za-z]+)\(\s*(\*|[A-Za-z_][A-Za-z0-9_.]*)\s*\)\s*$`)
+
+// WithGroupBy groups the results by the given columns
+func WithGroupBy(fields ...string) OptionFunc {
+	return &functionOption{
+		f: func(o *Options) {
+			o.GroupBy = append(o.GroupBy, fields...)
+		},
+	}
+}
+
+// WithHaving filters the groups of a grouped query. A filter's Field is a column, a selected
+// aggregate alias (not portable to Postgres), or an aggregate expression such as "COUNT(*)" or
+// "SUM(price)".
+func WithHaving(filters ...*Filter) OptionFunc {
+	return &functionOption{
+		f: func(o *Options) {
+			o.Having = append(o.Having, filters...)
+		},
+	}
+}
+
+// WithAggregate adds function(field) AS alias to the columns selected by FindGrouped,
+// e.g. WithAggregate("COUNT", "*", "count")
+func WithAggregate(function, field, alias string) OptionFunc {
+	return &functionOption{
+		f: func(o *Options) {
+			o.Aggregates = append(o.Aggregates, &Aggregate{Function: function, Field: field, Alias: alias})
+		},
+	}
+}
+
+// aggregateExpression returns the quoted SQL of function(field)
+func aggregateExpression(db *gorm.DB, function, field string) (string, error) {
+	function = strings.ToUpper(strings.TrimSpace(function))
+	if !slices.Contains(aggregateFunctions, function) {
+		return "", fmt.Errorf("%w: %q, expected one of %s", ErrInvalidAggregate, function, strings.Join(aggregateFunctions, ", "))
+	}
+
+	field = strings.TrimSpace(field)
+	switch {
+	case field == "*" && function == "COUNT":
+		return "COUNT(*)", nil
+	case field == "" || field == "*":
+		return "", fmt.Errorf("%w: %s needs a column", ErrInvalidAggregate, function)
+	}
+	return fmt.Sprintf("%s(%s)", function, quoteColumn(db, field)), nil
+}
+
+// havingField returns the quoted SQL a HAVING filter applies to: an aggregate expression when
+// the field is written as one, otherwise the quoted column or alias
+func havingField(db *gorm.DB, field string) (string, error) {
+	if match := aggregatePattern.FindStringSubmatch(field); match != nil && slices.Contains(aggregateFunctions, strings.ToUpper(match[1])) {
+		return aggregateExpression(db, match[1], match[2])
+	}
+	return quoteColumn(db, field), nil
+}
+
+// applyGrouping adds the GROUP BY columns and HAVING conditions of opts to query
+func applyGrouping(query *gorm.DB, opts *Options) (*gorm.DB, error) {
+	for _, field := range opts.GroupBy {
+		if field == "" {
+			return nil, ErrEmptyFieldName
+		}
+		// The column is quoted here; Group would take names with spaces as raw SQL
+		query = query.Clauses(clause.GroupBy{Columns: []clause.Column{{Name: quoteColumn(query, field), Raw: true}}})
+	}
+
+	for _, having := range opts.Having {
+		if having.Field == "" {
+			return nil, ErrEmptyFieldName
+		}
+
+		field, err := havingField(query, having.Field)
+		if err != nil {
+			return nil, err
+		}
+		condition, args, err := filterCondition(dialectName(query), field, having)
+		if err != nil {
+			return nil, err
+		}
+		if condition != "" {
+			query = query.Having(condition, args...)
+		}
+	}
+
+	return query, nil
+}
@@ -0,0 +1,772 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// memoryDialect names MemoryRepository in ErrUnsupportedDialect errors
+const memoryDialect = "memory"
+
+// MemoryRepository implements the filter and updater based operations on a slice, as a fast fake
+// for unit tests. Filters are evaluated in Go on the columns GORM would map the entity to, with the
+// same semantics as the SQL repositories: comparisons with NULL never match, Eq nil is IS NULL and an
+// empty IN list matches nothing. Operators that need a database, such as full text search, JSON
+// operators and raw SQL, return ErrUnsupportedDialect.
+//
+// Records are copied in and out, so callers cannot change stored records by accident.
+type MemoryRepository[Entity any, Filter EntityFilter, Updater EntityUpdater] struct {
+	mu      sync.RWMutex
+	records []*Entity
+	nextID  int64
+
+	schemaOnce sync.Once
+	schema     *schema.Schema
+	schemaErr  error
+}
+
+// NewMemoryRepository creates an empty in-memory repository
+func NewMemoryRepository[Entity any, Filter EntityFilter, Updater EntityUpdater]() *MemoryRepository[Entity, Filter, Updater] {
+	return &MemoryRepository[Entity, Filter, Updater]{}
+}
+
+// Create stores copies of the records. Zero auto-increment primary keys are assigned the next ID
+// and set on the records, zero CreatedAt and UpdatedAt fields are set to the current time, and a
+// primary key that is already stored returns gorm.ErrDuplicatedKey without storing any record.
+func (r *MemoryRepository[Entity, Filter, Updater]) Create(ctx context.Context, records ...*Entity) error {
+	if len(records) == 0 {
+		return ErrNoRecordsProvided
+	}
+
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Keys and timestamps are set on copies, so that a failed call leaves records untouched
+	now := time.Now()
+	nextID := r.nextID
+	stored := make([]*Entity, 0, len(records))
+	for _, record := range records {
+		copied := *record
+		value := reflect.ValueOf(&copied).Elem()
+		for _, field := range entitySchema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			fieldValue, isZero := field.ValueOf(ctx, value)
+			switch {
+			case field.PrimaryKey && field.AutoIncrement && isZero:
+				nextID++
+				if err := field.Set(ctx, value, nextID); err != nil {
+					return fmt.Errorf("create records: set %s: %w", field.Name, err)
+				}
+			case field.PrimaryKey && field.AutoIncrement:
+				// Explicit keys move the sequence past them, as on SQLite and MySQL
+				if id := reflect.ValueOf(fieldValue); id.CanInt() && id.Int() > nextID {
+					nextID = id.Int()
+				}
+			case isZero && (field.AutoCreateTime > 0 || field.AutoUpdateTime > 0):
+				if err := field.Set(ctx, value, now); err != nil {
+					return fmt.Errorf("create records: set %s: %w", field.Name, err)
+				}
+			}
+		}
+
+		for _, other := range slices.Concat(r.records, stored) {
+			if samePrimaryKey(ctx, entitySchema, reflect.ValueOf(other).Elem(), value) {
+				return fmt.Errorf("create records: %w", gorm.ErrDuplicatedKey)
+			}
+		}
+		stored = append(stored, &copied)
+	}
+
+	for i, record := range records {
+		*record = *stored[i]
+	}
+	r.records = append(r.records, stored...)
+	r.nextID = nextID
+	return nil
+}
+
+// MustCreate creates records and panics if that fails, to keep test fixtures short
+func (r *MemoryRepository[Entity, Filter, Updater]) MustCreate(ctx context.Context, records ...*Entity) {
+	if err := r.Create(ctx, records...); err != nil {
+		panic(fmt.Errorf("MustCreate: %w", err))
+	}
+}
+
+// FindOne implements single record lookup with filters
+func (r *MemoryRepository[Entity, Filter, Updater]) FindOne(
+	ctx context.Context,
+	filter Filter,
+	options ...OptionFunc,
+) (*Entity, bool, error) {
+	records, err := r.FindAll(ctx, filter, append(slices.Clip(options), WithLimit(1))...)
+	if err != nil {
+		return nil, false, fmt.Errorf("FindOne: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, false, nil
+	}
+	return records[0], true, nil
+}
+
+// FindAll implements multiple record lookup with filters. Of the options, limits, offsets, sorting,
+// column selection and WithUnscoped are supported; the others return ErrUnsupportedOption.
+// NULLs sort first, as on SQLite and MySQL.
+func (r *MemoryRepository[Entity, Filter, Updater]) FindAll(
+	ctx context.Context,
+	filter Filter,
+	options ...OptionFunc,
+) ([]*Entity, error) {
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := newOptions(options...)
+	if err := checkSQLOptions(opts); err != nil {
+		return nil, fmt.Errorf("FindAll: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched, err := r.match(ctx, entitySchema, filter, opts.Unscoped)
+	if err != nil {
+		return nil, fmt.Errorf("FindAll: %w", err)
+	}
+
+	if err := r.sort(ctx, entitySchema, matched, opts.SortFields); err != nil {
+		return nil, fmt.Errorf("FindAll: %w", err)
+	}
+
+	if opts.Offset != nil {
+		matched = matched[min(max(*opts.Offset, 0), len(matched)):]
+	}
+	if opts.Limit != nil && *opts.Limit >= 0 {
+		matched = matched[:min(*opts.Limit, len(matched))]
+	}
+
+	selected, err := r.selectFields(entitySchema, opts)
+	if err != nil {
+		return nil, fmt.Errorf("FindAll: %w", err)
+	}
+
+	result := make([]*Entity, len(matched))
+	for i, record := range matched {
+		copied := new(Entity)
+		if selected == nil {
+			*copied = *record
+		} else {
+			source, target := reflect.ValueOf(record).Elem(), reflect.ValueOf(copied).Elem()
+			for _, field := range selected {
+				field.ReflectValueOf(ctx, target).Set(field.ReflectValueOf(ctx, source))
+			}
+		}
+		result[i] = copied
+	}
+	return result, nil
+}
+
+// Count implements record counting. Of the options only WithUnscoped applies.
+func (r *MemoryRepository[Entity, Filter, Updater]) Count(
+	ctx context.Context,
+	filter Filter,
+	options ...OptionFunc,
+) (int64, error) {
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched, err := r.match(ctx, entitySchema, filter, newOptions(options...).Unscoped)
+	if err != nil {
+		return 0, fmt.Errorf("count records: %w", err)
+	}
+	return int64(len(matched)), nil
+}
+
+// Exists checks if any records match the filter
+func (r *MemoryRepository[Entity, Filter, Updater]) Exists(
+	ctx context.Context,
+	filter Filter,
+) (bool, error) {
+	count, err := r.Count(ctx, filter)
+	if err != nil {
+		return false, fmt.Errorf("exists check: %w", err)
+	}
+	return count > 0, nil
+}
+
+// UpdateWithFilter sets the change set on every matching record. Like GORM, an UpdatedAt field
+// missing from the change set is set to the current time.
+func (r *MemoryRepository[Entity, Filter, Updater]) UpdateWithFilter(
+	ctx context.Context,
+	filter Filter,
+	updater Updater,
+) (int64, error) {
+	changeSet := updater.GetChangeSet()
+	if len(changeSet) == 0 {
+		return 0, nil
+	}
+
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return 0, err
+	}
+
+	values := make(map[*schema.Field]interface{}, len(changeSet)+1)
+	for column, value := range changeSet {
+		field, err := lookUpColumn(entitySchema, column)
+		if err != nil {
+			return 0, fmt.Errorf("update records with filter: %w", err)
+		}
+		values[field] = value
+	}
+	now := time.Now()
+	for _, field := range entitySchema.Fields {
+		if _, ok := changeSet[field.DBName]; !ok && field.DBName != "" && field.AutoUpdateTime > 0 {
+			values[field] = now
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	matched, err := r.match(ctx, entitySchema, filter, false)
+	if err != nil {
+		return 0, fmt.Errorf("UpdateWithFilter: %w", err)
+	}
+
+	// Updated on copies first, so a value that cannot be set leaves every record unchanged
+	updated := make([]*Entity, len(matched))
+	for i, record := range matched {
+		copied := *record
+		value := reflect.ValueOf(&copied).Elem()
+		for field, fieldValue := range values {
+			if err := field.Set(ctx, value, fieldValue); err != nil {
+				return 0, fmt.Errorf("update records with filter: set %s: %w", field.DBName, err)
+			}
+		}
+		updated[i] = &copied
+	}
+	for i, record := range matched {
+		*record = *updated[i]
+	}
+	return int64(len(matched)), nil
+}
+
+// DeleteWithFilter implements batch deletion using filters.
+// Entities with a gorm.DeletedAt field are soft-deleted, as GormRepository does.
+func (r *MemoryRepository[Entity, Filter, Updater]) DeleteWithFilter(
+	ctx context.Context,
+	filter Filter,
+) (int64, error) {
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	matched, err := r.match(ctx, entitySchema, filter, false)
+	if err != nil {
+		return 0, fmt.Errorf("DeleteWithFilter: %w", err)
+	}
+
+	if field := softDeleteField(entitySchema); field != nil {
+		now := time.Now()
+		for _, record := range matched {
+			if err := field.Set(ctx, reflect.ValueOf(record).Elem(), now); err != nil {
+				return 0, fmt.Errorf("delete records with filter: %w", err)
+			}
+		}
+		return int64(len(matched)), nil
+	}
+
+	r.records = slices.DeleteFunc(r.records, func(record *Entity) bool {
+		return slices.Contains(matched, record)
+	})
+	return int64(len(matched)), nil
+}
+
+// match returns the stored records matching the filter, excluding soft-deleted ones unless unscoped
+func (r *MemoryRepository[Entity, Filter, Updater]) match(
+	ctx context.Context,
+	entitySchema *schema.Schema,
+	filter Filter,
+	unscoped bool,
+) ([]*Entity, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if errorer, ok := any(filter).(FilterErrorer); ok {
+		if err := errorer.Err(); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFilterValue, err)
+		}
+	}
+
+	conditions, err := memoryConditions(entitySchema, filter.ListFilters())
+	if err != nil {
+		return nil, err
+	}
+	if field := softDeleteField(entitySchema); field != nil && !unscoped {
+		conditions = append(conditions, memoryCondition{field: field, match: func(value interface{}) (bool, error) {
+			return memoryValue(value) == nil, nil
+		}})
+	}
+
+	var matched []*Entity
+	for _, record := range r.records {
+		value := reflect.ValueOf(record).Elem()
+		matches := true
+		for _, condition := range conditions {
+			ok, err := condition.matches(ctx, value)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			matched = append(matched, record)
+		}
+	}
+	return matched, nil
+}
+
+// sort orders the records by the sort fields
+func (r *MemoryRepository[Entity, Filter, Updater]) sort(
+	ctx context.Context,
+	entitySchema *schema.Schema,
+	records []*Entity,
+	sortFields []*SortField,
+) error {
+	type sortColumn struct {
+		field      *schema.Field
+		descending bool
+	}
+	columns := make([]sortColumn, len(sortFields))
+	for i, sortField := range sortFields {
+		direction, err := ParseSortDirection(string(sortField.Direction))
+		if err != nil {
+			return err
+		}
+		if sortField.Field == "" {
+			return ErrEmptyFieldName
+		}
+		field, err := lookUpColumn(entitySchema, sortField.Field)
+		if err != nil {
+			return err
+		}
+		columns[i] = sortColumn{field: field, descending: direction == Desc}
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+
+	var sortErr error
+	slices.SortStableFunc(records, func(a, b *Entity) int {
+		for _, column := range columns {
+			x := column.field.ReflectValueOf(ctx, reflect.ValueOf(a).Elem()).Interface()
+			y := column.field.ReflectValueOf(ctx, reflect.ValueOf(b).Elem()).Interface()
+
+			c, err := compareNullable(memoryValue(x), memoryValue(y))
+			if err != nil && sortErr == nil {
+				sortErr = err
+			}
+			if column.descending {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return sortErr
+}
+
+// selectFields returns the fields of opts.SelectFields plus the primary key, or nil to copy every field
+func (r *MemoryRepository[Entity, Filter, Updater]) selectFields(entitySchema *schema.Schema, opts *Options) ([]*schema.Field, error) {
+	if len(opts.SelectFields) == 0 {
+		return nil, nil
+	}
+
+	var fields []*schema.Field
+	for _, name := range append(slices.Clone(opts.SelectFields), entitySchema.PrimaryFieldDBNames...) {
+		field, err := lookUpColumn(entitySchema, name)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
+// entitySchema parses the entity's columns once
+func (r *MemoryRepository[Entity, Filter, Updater]) entitySchema() (*schema.Schema, error) {
+	r.schemaOnce.Do(func() {
+		r.schema, r.schemaErr = schema.Parse(new(Entity), &sync.Map{}, schema.NamingStrategy{})
+		if r.schemaErr != nil {
+			r.schemaErr = fmt.Errorf("parse entity schema: %w", r.schemaErr)
+		}
+	})
+	return r.schema, r.schemaErr
+}
+
+// samePrimaryKey reports whether two records of an entity with a primary key have equal keys
+func samePrimaryKey(ctx context.Context, entitySchema *schema.Schema, a, b reflect.Value) bool {
+	if len(entitySchema.PrimaryFields) == 0 {
+		return false
+	}
+	for _, field := range entitySchema.PrimaryFields {
+		x := field.ReflectValueOf(ctx, a).Interface()
+		y := field.ReflectValueOf(ctx, b).Interface()
+		if equal, err := equalValues(memoryValue(x), memoryValue(y)); err != nil || !equal {
+			return false
+		}
+	}
+	return true
+}
+
+// lookUpColumn returns the column field of name, a column or field name optionally qualified with
+// the entity's table
+func lookUpColumn(entitySchema *schema.Schema, name string) (*schema.Field, error) {
+	if table, column, ok := strings.Cut(name, "."); ok && table == entitySchema.Table {
+		name = column
+	}
+	field := entitySchema.LookUpField(name)
+	if field == nil || field.DBName == "" {
+		return nil, fmt.Errorf("%w: %q is not a column of %s", ErrUnknownField, name, entitySchema.Name)
+	}
+	return field, nil
+}
+
+// memoryCondition is a filter compiled to a test of one column's value
+type memoryCondition struct {
+	field *schema.Field
+	match func(value interface{}) (bool, error)
+}
+
+// matches tests the condition's column of record, read as the Go value rather than through a serializer
+func (c memoryCondition) matches(ctx context.Context, record reflect.Value) (bool, error) {
+	return c.match(c.field.ReflectValueOf(ctx, record).Interface())
+}
+
+// memoryConditions compiles filters, so that invalid filters fail even when nothing is stored
+func memoryConditions(entitySchema *schema.Schema, filters []*Filter) ([]memoryCondition, error) {
+	conditions := make([]memoryCondition, 0, len(filters))
+	for _, filter := range filters {
+		if filter.Field == "" && filter.Operator != OperatorRaw {
+			return nil, ErrEmptyFieldName
+		}
+
+		operator, match, err := ResolveFilter(filter)
+		if err != nil {
+			return nil, err
+		}
+		if match == MatchAll {
+			continue
+		}
+
+		test, err := memoryTest(operator, filter.Value)
+		if err != nil {
+			return nil, err
+		}
+		if match == MatchNone {
+			test = func(interface{}) (bool, error) { return false, nil }
+		}
+
+		field, err := lookUpColumn(entitySchema, filter.Field)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, memoryCondition{field: field, match: test})
+	}
+	return conditions, nil
+}
+
+// memoryTest returns the test of a column value for an operator. Like SQL, every comparison with
+// a NULL column is false, including Ne and NotIn.
+func memoryTest(operator Operator, value interface{}) (func(interface{}) (bool, error), error) {
+	compareWith := func(matches func(int) bool) func(interface{}) (bool, error) {
+		want := memoryValue(value)
+		return func(column interface{}) (bool, error) {
+			got := memoryValue(column)
+			if got == nil || want == nil {
+				return false, nil
+			}
+			c, err := compareValues(got, want)
+			return err == nil && matches(c), err
+		}
+	}
+
+	switch operator {
+	case OperatorEqual, OperatorNotEqual:
+		want := memoryValue(value)
+		return func(column interface{}) (bool, error) {
+			got := memoryValue(column)
+			if got == nil {
+				return false, nil
+			}
+			equal, err := equalValues(got, want)
+			return err == nil && equal == (operator == OperatorEqual), err
+		}, nil
+	case OperatorLessThan:
+		return compareWith(func(c int) bool { return c < 0 }), nil
+	case OperatorLessThanOrEqual:
+		return compareWith(func(c int) bool { return c <= 0 }), nil
+	case OperatorGreaterThan:
+		return compareWith(func(c int) bool { return c > 0 }), nil
+	case OperatorGreaterThanOrEqual:
+		return compareWith(func(c int) bool { return c >= 0 }), nil
+	case OperatorLike, OperatorNotLike, OperatorILike, OperatorRegex:
+		pattern := reflect.ValueOf(value).String()
+		switch operator {
+		case OperatorILike:
+			pattern = "(?is)" + LikeRegex(pattern)
+		case OperatorLike, OperatorNotLike:
+			pattern = "(?s)" + LikeRegex(pattern)
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s pattern %q: %w: %w", operator, pattern, err, ErrInvalidFilterValue)
+		}
+		return func(column interface{}) (bool, error) {
+			got := memoryValue(column)
+			if got == nil {
+				return false, nil
+			}
+			s := reflect.ValueOf(got)
+			if s.Kind() != reflect.String {
+				return false, fmt.Errorf("%s on a %T column: %w", operator, column, ErrInvalidFilterValue)
+			}
+			return re.MatchString(s.String()) != (operator == OperatorNotLike), nil
+		}, nil
+	case OperatorIsNull, OperatorIsNotNull:
+		return func(column interface{}) (bool, error) {
+			return (memoryValue(column) == nil) == (operator == OperatorIsNull), nil
+		}, nil
+	case OperatorIn, OperatorNotIn:
+		list := reflect.ValueOf(value)
+		return func(column interface{}) (bool, error) {
+			got := memoryValue(column)
+			if got == nil {
+				return false, nil
+			}
+			found, err := listContains(list, got)
+			return err == nil && found == (operator == OperatorIn), err
+		}, nil
+	case OperatorArrayContains, OperatorArrayOverlaps:
+		list := reflect.ValueOf(value)
+		return func(column interface{}) (bool, error) {
+			array := reflect.Indirect(reflect.ValueOf(column))
+			if !array.IsValid() || !isList(array) {
+				return false, fmt.Errorf("%s on a %T column: %w", operator, column, ErrInvalidFilterValue)
+			}
+			for i := 0; i < list.Len(); i++ {
+				found, err := listContains(array, memoryValue(list.Index(i).Interface()))
+				if err != nil {
+					return false, err
+				}
+				if found && operator == OperatorArrayOverlaps {
+					return true, nil
+				}
+				if !found && operator == OperatorArrayContains {
+					return false, nil
+				}
+			}
+			return operator == OperatorArrayContains, nil
+		}, nil
+	case OperatorBetween, OperatorNotBetween:
+		bounds, ok := value.(Range)
+		if !ok {
+			return nil, fmt.Errorf("%s expects repository.Range, got %T: %w", operator, value, ErrInvalidFilterValue)
+		}
+		lower, upper := memoryValue(bounds.Lower), memoryValue(bounds.Upper)
+		return func(column interface{}) (bool, error) {
+			got := memoryValue(column)
+			if got == nil || lower == nil || upper == nil {
+				return false, nil
+			}
+			low, err := compareValues(got, lower)
+			if err != nil {
+				return false, err
+			}
+			high, err := compareValues(got, upper)
+			if err != nil {
+				return false, err
+			}
+			return (low >= 0 && high <= 0) == (operator == OperatorBetween), nil
+		}, nil
+	default:
+		return nil, UnsupportedOperator(memoryDialect, operator)
+	}
+}
+
+// listContains reports whether a list holds a value equal to value
+func listContains(list reflect.Value, value interface{}) (bool, error) {
+	for i := 0; i < list.Len(); i++ {
+		element := memoryValue(list.Index(i).Interface())
+		if element == nil {
+			continue
+		}
+		equal, err := equalValues(element, value)
+		if err != nil {
+			return false, err
+		}
+		if equal {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// memoryValue unwraps a column or filter value to what it is compared as: nil for NULL, the value
+// of a pointer, and the driver value of a driver.Valuer such as gorm.DeletedAt or uuid.UUID. Types
+// with their own Compare or Cmp method, such as time.Time and decimal.Decimal, are kept.
+func memoryValue(value interface{}) interface{} {
+	for {
+		if isNilValue(value) {
+			return nil
+		}
+		v := reflect.ValueOf(value)
+		if _, ok := compareMethod(v); ok {
+			return value
+		}
+		if valuer, ok := value.(driver.Valuer); ok {
+			driverValue, err := valuer.Value()
+			if err != nil {
+				return value
+			}
+			value = driverValue
+			continue
+		}
+		if v.Kind() == reflect.Pointer {
+			value = v.Elem().Interface()
+			continue
+		}
+		return value
+	}
+}
+
+// compareMethod returns a Compare(T) int or Cmp(T) int method of v
+func compareMethod(v reflect.Value) (reflect.Value, bool) {
+	for _, name := range []string{"Compare", "Cmp"} {
+		method := v.MethodByName(name)
+		if !method.IsValid() {
+			continue
+		}
+		t := method.Type()
+		if t.NumIn() == 1 && t.In(0) == v.Type() && t.NumOut() == 1 && t.Out(0).Kind() == reflect.Int {
+			return method, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// compareNullable compares two unwrapped values, ordering NULL first
+func compareNullable(a, b interface{}) (int, error) {
+	switch {
+	case a == nil && b == nil:
+		return 0, nil
+	case a == nil:
+		return -1, nil
+	case b == nil:
+		return 1, nil
+	}
+	return compareValues(a, b)
+}
+
+// compareValues orders two non-NULL unwrapped values: numbers of any type numerically, strings,
+// byte slices, booleans, and values of a type with a Compare or Cmp method by that method
+func compareValues(a, b interface{}) (int, error) {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if av.Type() == bv.Type() {
+		if method, ok := compareMethod(av); ok {
+			return int(method.Call([]reflect.Value{bv})[0].Int()), nil
+		}
+	}
+	if x, ok := ratValue(av); ok {
+		if y, ok := ratValue(bv); ok {
+			return x.Cmp(y), nil
+		}
+	}
+
+	switch {
+	case av.Kind() == reflect.String && bv.Kind() == reflect.String:
+		return strings.Compare(av.String(), bv.String()), nil
+	case av.Kind() == reflect.Bool && bv.Kind() == reflect.Bool:
+		x, y := av.Bool(), bv.Bool()
+		switch {
+		case x == y:
+			return 0, nil
+		case y:
+			return -1, nil
+		default:
+			return 1, nil
+		}
+	}
+	if x, ok := a.([]byte); ok {
+		if y, ok := b.([]byte); ok {
+			return bytes.Compare(x, y), nil
+		}
+	}
+	return 0, fmt.Errorf("cannot compare %T with %T: %w", a, b, ErrInvalidFilterValue)
+}
+
+// equalValues reports whether two unwrapped values are equal, falling back to deep equality for
+// values of the same type that have no order
+func equalValues(a, b interface{}) (bool, error) {
+	if a == nil || b == nil {
+		return a == nil && b == nil, nil
+	}
+	c, err := compareValues(a, b)
+	if err == nil {
+		return c == 0, nil
+	}
+	if reflect.TypeOf(a) == reflect.TypeOf(b) {
+		return reflect.DeepEqual(a, b), nil
+	}
+	return false, err
+}
+
+// ratValue returns the exact value of an integer or finite float
+func ratValue(v reflect.Value) (*big.Rat, bool) {
+	switch {
+	case v.CanInt():
+		return new(big.Rat).SetInt64(v.Int()), true
+	case v.CanUint():
+		return new(big.Rat).SetUint64(v.Uint()), true
+	case v.CanFloat():
+		r := new(big.Rat).SetFloat64(v.Float())
+		return r, r != nil
+	}
+	return nil, false
+}
@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestMemoryRepository_CreateAndFind(t *testing.T) {
+	repo := NewMemoryRepository[TestEntity, *TestFilter, *TestUpdater]()
+	ctx := context.Background()
+
+	entities := createTestEntities()
+	require.NoError(t, repo.Create(ctx, entities...))
+	for i, entity := range entities {
+		assert.Equal(t, int64(i+1), entity.ID)
+		assert.False(t, entity.CreatedAt.IsZero())
+	}
+
+	active, err := repo.FindAll(ctx, NewTestFilter().IsActiveEq(true).AgeGte(30), WithSort("age", Desc))
+	require.NoError(t, err)
+	require.Len(t, active, 2)
+	assert.Equal(t, "David", active[0].Name)
+	assert.Equal(t, "Bob", active[1].Name)
+
+	page, err := repo.FindAll(ctx, NewTestFilter(), WithSort("name", Asc), WithLimit(2), WithOffset(1))
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, "Bob", page[0].Name)
+
+	one, found, err := repo.FindOne(ctx, NewTestFilter().NameILike("CHAR%"), WithSelect("name"))
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, entities[2].ID, one.ID)
+	assert.Empty(t, one.Email)
+
+	one.Name = "changed"
+	stored, _, err := repo.FindOne(ctx, NewTestFilter().NameEq("Charlie"))
+	require.NoError(t, err)
+	assert.Equal(t, "Charlie", stored.Name, "returned records are copies")
+
+	options := make([]OptionFunc, 1, 2)
+	options[0] = WithSort("age", Desc)
+	_, _, err = repo.FindOne(ctx, NewTestFilter(), options...)
+	require.NoError(t, err)
+	assert.Nil(t, options[:2][1], "FindOne leaves the caller's options untouched")
+
+	count, err := repo.Count(ctx, NewTestFilter().EmailLike("%@example.com"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+
+	err = repo.Create(ctx, &TestEntity{ID: entities[0].ID, Name: "Duplicate"})
+	assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+	assert.Panics(t, func() { repo.MustCreate(ctx, &TestEntity{ID: entities[0].ID}) })
+
+	fresh, duplicate := &TestEntity{Name: "Eve"}, &TestEntity{ID: entities[1].ID}
+	err = repo.Create(ctx, fresh, duplicate)
+	assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+	assert.Equal(t, &TestEntity{Name: "Eve"}, fresh, "a failed Create assigns no ID or timestamps")
+	assert.Equal(t, &TestEntity{ID: entities[1].ID}, duplicate)
+
+	require.NoError(t, repo.Create(ctx, fresh))
+	assert.Equal(t, int64(len(entities)+1), fresh.ID, "a failed Create does not use up IDs")
+	_, err = repo.DeleteWithFilter(ctx, NewTestFilter().NameEq("Eve"))
+	require.NoError(t, err)
+
+	_, err = repo.FindAll(ctx, NewTestFilter(), WithGroupBy("age"))
+	assert.ErrorIs(t, err, ErrUnsupportedOption)
+
+	_, err = repo.FindAll(ctx, &TestFilter{err: assert.AnError})
+	assert.ErrorIs(t, err, ErrInvalidFilterValue)
+}
+
+func TestMemoryRepository_UpdateAndDelete(t *testing.T) {
+	repo := NewMemoryRepository[TestEntity, *TestFilter, *TestUpdater]()
+	ctx := context.Background()
+	require.NoError(t, repo.Create(ctx, createTestEntities()...))
+
+	updated, err := repo.UpdateWithFilter(ctx, NewTestFilter().AgeGte(30), NewTestUpdater().SetIsActive(false).SetAge(40))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated)
+
+	inactive, err := repo.Count(ctx, NewTestFilter().IsActiveEq(false))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), inactive)
+
+	deleted, err := repo.DeleteWithFilter(ctx, NewTestFilter().IsActiveEq(false))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+
+	exists, err := repo.Exists(ctx, NewTestFilter().NameEq("Alice"))
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	remaining, err := repo.Count(ctx, NewTestFilter())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), remaining)
+
+	_, err = repo.UpdateWithFilter(ctx, NewTestFilter(), &TestUpdater{fields: map[string]interface{}{"nickname": "x"}})
+	assert.ErrorIs(t, err, ErrUnknownField)
+}
+
+func TestMemoryRepository_SoftDelete(t *testing.T) {
+	repo := NewMemoryRepository[SoftDeleteEntity, *TestFilter, *TestUpdater]()
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx, &SoftDeleteEntity{Name: "kept"}, &SoftDeleteEntity{Name: "trashed"}))
+
+	deleted, err := repo.DeleteWithFilter(ctx, NewTestFilter().NameEq("trashed"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	live, err := repo.FindAll(ctx, NewTestFilter())
+	require.NoError(t, err)
+	require.Len(t, live, 1)
+	assert.Equal(t, "kept", live[0].Name)
+
+	all, err := repo.FindAll(ctx, NewTestFilter(), WithUnscoped(), WithSort("name", Desc))
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.True(t, all[0].DeletedAt.Valid)
+}
+
+// MemoryItem has nullable, decimal and time columns
+type MemoryItem struct {
+	ID        int64 `gorm:"primaryKey"`
+	Code      string
+	Stock     *int
+	Price     decimal.Decimal
+	Tags      []string `gorm:"serializer:json"`
+	ExpiresAt *time.Time
+}
+
+func TestMemoryRepository_Operators(t *testing.T) {
+	repo := NewMemoryRepository[MemoryItem, *TestFilter, *TestUpdater]()
+	ctx := context.Background()
+
+	stock := func(n int) *int { return &n }
+	expiry := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.Create(ctx,
+		&MemoryItem{Code: "A-1", Stock: stock(5), Price: decimal.RequireFromString("9.50"), Tags: []string{"red", "sale"}, ExpiresAt: &expiry},
+		&MemoryItem{Code: "A-2", Stock: stock(0), Price: decimal.RequireFromString("10.00"), Tags: []string{"blue"}},
+		&MemoryItem{Code: "B_1", Price: decimal.RequireFromString("100")},
+	))
+
+	tests := []struct {
+		name     string
+		filter   *Filter
+		expected []string
+	}{
+		{"eq", &Filter{Field: "stock", Operator: OperatorEqual, Value: 5}, []string{"A-1"}},
+		{"eq nil", &Filter{Field: "stock", Operator: OperatorEqual, Value: nil}, []string{"B_1"}},
+		{"ne skips NULL", &Filter{Field: "stock", Operator: OperatorNotEqual, Value: 5}, []string{"A-2"}},
+		{"gt across integer types", &Filter{Field: "stock", Operator: OperatorGreaterThan, Value: int8(0)}, []string{"A-1"}},
+		{"decimal", &Filter{Field: "price", Operator: OperatorGreaterThanOrEqual, Value: decimal.RequireFromString("10")}, []string{"A-2", "B_1"}},
+		{"time", &Filter{Field: "expires_at", Operator: OperatorLessThan, Value: expiry.Add(time.Hour)}, []string{"A-1"}},
+		{"in", &Filter{Field: "code", Operator: OperatorIn, Value: []string{"A-2", "B_1"}}, []string{"A-2", "B_1"}},
+		{"empty in", &Filter{Field: "code", Operator: OperatorIn, Value: []string{}}, nil},
+		{"empty not in", &Filter{Field: "code", Operator: OperatorNotIn, Value: []string{}}, []string{"A-1", "A-2", "B_1"}},
+		{"not in skips NULL", &Filter{Field: "stock", Operator: OperatorNotIn, Value: []int{5}}, []string{"A-2"}},
+		{"like", &Filter{Field: "code", Operator: OperatorLike, Value: "A-_"}, []string{"A-1", "A-2"}},
+		{"like escaped wildcard", &Filter{Field: "code", Operator: OperatorLike, Value: `%\_%`}, []string{"B_1"}},
+		{"not like", &Filter{Field: "code", Operator: OperatorNotLike, Value: "A%"}, []string{"B_1"}},
+		{"regex", &Filter{Field: "code", Operator: OperatorRegex, Value: "^[AB].1$"}, []string{"A-1", "B_1"}},
+		{"is not null", &Filter{Field: "expires_at", Operator: OperatorIsNotNull}, []string{"A-1"}},
+		{"between", &Filter{Field: "stock", Operator: OperatorBetween, Value: Range{Lower: 0, Upper: 3}}, []string{"A-2"}},
+		{"array contains", &Filter{Field: "tags", Operator: OperatorArrayContains, Value: []string{"sale", "red"}}, []string{"A-1"}},
+		{"array overlaps", &Filter{Field: "tags", Operator: OperatorArrayOverlaps, Value: []string{"blue", "green"}}, []string{"A-2"}},
+		{"qualified column", &Filter{Field: "memory_items.code", Operator: OperatorEqual, Value: "A-1"}, []string{"A-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := repo.FindAll(ctx, &TestFilter{filters: []*Filter{tt.filter}}, WithSort("code", Asc))
+			require.NoError(t, err)
+			var codes []string
+			for _, item := range items {
+				codes = append(codes, item.Code)
+			}
+			assert.Equal(t, tt.expected, codes)
+		})
+	}
+
+	sorted, err := repo.FindAll(ctx, NewTestFilter(), WithSort("stock", Asc))
+	require.NoError(t, err)
+	assert.Equal(t, "B_1", sorted[0].Code, "NULLs sort first")
+}
+
+func TestMemoryRepository_Errors(t *testing.T) {
+	repo := NewMemoryRepository[MemoryItem, *TestFilter, *TestUpdater]()
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		filter   *Filter
+		expected error
+	}{
+		{"empty field", &Filter{Operator: OperatorEqual, Value: 1}, ErrEmptyFieldName},
+		{"unknown field", &Filter{Field: "colour", Operator: OperatorEqual, Value: 1}, ErrUnknownField},
+		{"unknown operator", &Filter{Field: "code", Operator: "~~", Value: 1}, ErrUnknownOperator},
+		{"database operator", &Filter{Field: "code", Operator: OperatorFullText, Value: "a"}, ErrUnsupportedDialect},
+		{"raw sql", &Filter{Operator: OperatorRaw, Value: RawSQL{SQL: "1 = 1"}}, ErrUnsupportedDialect},
+		{"wrong value shape", &Filter{Field: "stock", Operator: OperatorIn, Value: 1}, ErrInvalidFilterValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := repo.Count(ctx, &TestFilter{filters: []*Filter{tt.filter}})
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+
+	require.NoError(t, repo.Create(ctx, &MemoryItem{Code: "A-1"}))
+	_, err := repo.Count(ctx, &TestFilter{filters: []*Filter{{Field: "code", Operator: OperatorGreaterThan, Value: 1}}})
+	assert.ErrorIs(t, err, ErrInvalidFilterValue, "strings don't compare with numbers")
+}
@@ -0,0 +1,581 @@
+// Package mongorepo runs the filters and updaters of the repository package on MongoDB
+// collections, so that only programs using MongoDB depend on its driver.
+package mongorepo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dchlong/querybuilder/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"gorm.io/gorm/schema"
+)
+
+// dialect names MongoDB in repository.ErrUnsupportedDialect errors
+const dialect = "mongodb"
+
+// Repository implements the filter and updater based operations on a MongoDB collection.
+// Filter fields and change set keys are the generated column names; they are mapped to document
+// keys through the entity's bson tags, or the driver's lowercased field names for untagged fields.
+// Names that are not columns, such as "address.city", are used as document keys unchanged.
+type Repository[Entity any, Filter repository.EntityFilter, Updater repository.EntityUpdater] struct {
+	collection *mongo.Collection
+
+	schemaOnce sync.Once
+	schema     *schema.Schema
+	keys       map[string]string
+	schemaErr  error
+}
+
+// NewRepository creates a repository reading and writing the documents of collection
+func NewRepository[Entity any, Filter repository.EntityFilter, Updater repository.EntityUpdater](
+	collection *mongo.Collection,
+) *Repository[Entity, Filter, Updater] {
+	return &Repository[Entity, Filter, Updater]{
+		collection: collection,
+	}
+}
+
+// Create inserts the records in one InsertMany. Zero CreatedAt and UpdatedAt fields are set to
+// the current time, as GORM does.
+func (r *Repository[Entity, Filter, Updater]) Create(ctx context.Context, records ...*Entity) error {
+	if len(records) == 0 {
+		return repository.ErrNoRecordsProvided
+	}
+
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	documents := make([]interface{}, len(records))
+	for i, record := range records {
+		value := reflect.ValueOf(record).Elem()
+		for _, field := range entitySchema.Fields {
+			if field.AutoCreateTime == 0 && field.AutoUpdateTime == 0 {
+				continue
+			}
+			if _, isZero := field.ValueOf(ctx, value); isZero {
+				if err := field.Set(ctx, value, now); err != nil {
+					return fmt.Errorf("create records: %w", err)
+				}
+			}
+		}
+		documents[i] = record
+	}
+
+	if _, err := r.collection.InsertMany(ctx, documents); err != nil {
+		return fmt.Errorf("create records: %w", err)
+	}
+	return nil
+}
+
+// FindOne implements single record lookup with filters
+func (r *Repository[Entity, Filter, Updater]) FindOne(
+	ctx context.Context,
+	filter Filter,
+	options ...repository.OptionFunc,
+) (*Entity, bool, error) {
+	records, err := r.FindAll(ctx, filter, append(slices.Clip(options), repository.WithLimit(1))...)
+	if err != nil {
+		return nil, false, fmt.Errorf("FindOne: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, false, nil
+	}
+	return records[0], true, nil
+}
+
+// FindAll implements multiple record lookup with filters. Of the options, limits, offsets, sorting,
+// field selection and WithTimeout are supported; the others return repository.ErrUnsupportedOption.
+func (r *Repository[Entity, Filter, Updater]) FindAll(
+	ctx context.Context,
+	filter Filter,
+	options ...repository.OptionFunc,
+) ([]*Entity, error) {
+	opts := newOptions(options...)
+	if err := checkOptions(opts); err != nil {
+		return nil, fmt.Errorf("FindAll: %w", err)
+	}
+
+	query, err := r.filterDocument(filter)
+	if err != nil {
+		return nil, fmt.Errorf("FindAll build query: %w", err)
+	}
+	findOptions, err := r.findOptions(opts)
+	if err != nil {
+		return nil, fmt.Errorf("FindAll: %w", err)
+	}
+
+	ctx, cancel := timeoutContext(ctx, opts.Timeout)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("find all records: %w", err)
+	}
+
+	var result []*Entity
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, fmt.Errorf("find all records: %w", err)
+	}
+	return result, nil
+}
+
+// Count implements record counting. Of the options only WithTimeout applies.
+func (r *Repository[Entity, Filter, Updater]) Count(
+	ctx context.Context,
+	filter Filter,
+	options ...repository.OptionFunc,
+) (int64, error) {
+	query, err := r.filterDocument(filter)
+	if err != nil {
+		return 0, fmt.Errorf("count build query: %w", err)
+	}
+
+	ctx, cancel := timeoutContext(ctx, newOptions(options...).Timeout)
+	defer cancel()
+
+	count, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("count records: %w", err)
+	}
+	return count, nil
+}
+
+// Exists checks if any documents match the filter, counting at most one
+func (r *Repository[Entity, Filter, Updater]) Exists(
+	ctx context.Context,
+	filter Filter,
+) (bool, error) {
+	query, err := r.filterDocument(filter)
+	if err != nil {
+		return false, fmt.Errorf("exists build query: %w", err)
+	}
+
+	count, err := r.collection.CountDocuments(ctx, query, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("exists check: %w", err)
+	}
+	return count > 0, nil
+}
+
+// UpdateWithFilter sets the change set on every matching document with $set and returns the
+// number of matched documents. Like GORM, an UpdatedAt field missing from the change set is set
+// to the current time.
+func (r *Repository[Entity, Filter, Updater]) UpdateWithFilter(
+	ctx context.Context,
+	filter Filter,
+	updater Updater,
+) (int64, error) {
+	changeSet := updater.GetChangeSet()
+	if len(changeSet) == 0 {
+		return 0, nil
+	}
+
+	update, err := r.setDocument(changeSet)
+	if err != nil {
+		return 0, err
+	}
+	query, err := r.filterDocument(filter)
+	if err != nil {
+		return 0, fmt.Errorf("UpdateWithFilter build query: %w", err)
+	}
+
+	result, err := r.collection.UpdateMany(ctx, query, update)
+	if err != nil {
+		return 0, fmt.Errorf("update records with filter: %w", err)
+	}
+	return result.MatchedCount, nil
+}
+
+// Update sets the change set on the record's document, matched by its primary key, with $set.
+// Like GORM, an UpdatedAt field missing from the change set is set to the current time.
+func (r *Repository[Entity, Filter, Updater]) Update(
+	ctx context.Context,
+	record *Entity,
+	updater Updater,
+) error {
+	changeSet := updater.GetChangeSet()
+	if len(changeSet) == 0 {
+		return nil // No changes to apply
+	}
+
+	query, err := r.keyDocument(ctx, record)
+	if err != nil {
+		return err
+	}
+	update, err := r.setDocument(changeSet)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.collection.UpdateOne(ctx, query, update); err != nil {
+		return fmt.Errorf("update record: %w", err)
+	}
+	return nil
+}
+
+// DeleteWithFilter deletes every matching document
+func (r *Repository[Entity, Filter, Updater]) DeleteWithFilter(
+	ctx context.Context,
+	filter Filter,
+) (int64, error) {
+	query, err := r.filterDocument(filter)
+	if err != nil {
+		return 0, fmt.Errorf("DeleteWithFilter build query: %w", err)
+	}
+
+	result, err := r.collection.DeleteMany(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("delete records with filter: %w", err)
+	}
+	return result.DeletedCount, nil
+}
+
+// GetCollection returns the underlying collection for operations the repository doesn't cover
+func (r *Repository[Entity, Filter, Updater]) GetCollection() *mongo.Collection {
+	return r.collection
+}
+
+// filterDocument returns the query document of the filter, with columns mapped to document keys
+func (r *Repository[Entity, Filter, Updater]) filterDocument(filter Filter) (bson.M, error) {
+	if errorer, ok := any(filter).(repository.FilterErrorer); ok {
+		if err := errorer.Err(); err != nil {
+			return nil, fmt.Errorf("%w: %w", repository.ErrInvalidFilterValue, err)
+		}
+	}
+
+	keys, err := r.documentKeys()
+	if err != nil {
+		return nil, err
+	}
+	return compileFilters(filter.ListFilters(), keys)
+}
+
+// keyDocument returns the query document matching the record's primary key, such as {"_id": id}
+func (r *Repository[Entity, Filter, Updater]) keyDocument(ctx context.Context, record *Entity) (bson.M, error) {
+	keys, err := r.documentKeys()
+	if err != nil {
+		return nil, err
+	}
+	if len(r.schema.PrimaryFields) == 0 {
+		return nil, fmt.Errorf("%w: %s has no primary key", repository.ErrInvalidPrimaryKey, r.schema.Name)
+	}
+
+	value := reflect.ValueOf(record).Elem()
+	query := make(bson.M, len(r.schema.PrimaryFields))
+	for _, field := range r.schema.PrimaryFields {
+		key, isZero := field.ValueOf(ctx, value)
+		if isZero {
+			return nil, fmt.Errorf("%w: %s.%s is not set", repository.ErrInvalidPrimaryKey, r.schema.Name, field.Name)
+		}
+		query[documentKey(keys, field.DBName)] = key
+	}
+	return query, nil
+}
+
+// setDocument returns the $set update of a change set, with columns mapped to document keys
+func (r *Repository[Entity, Filter, Updater]) setDocument(changeSet map[string]interface{}) (bson.M, error) {
+	keys, err := r.documentKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	set := make(bson.M, len(changeSet)+1)
+	for column, value := range changeSet {
+		set[documentKey(keys, column)] = value
+	}
+	for _, field := range r.schema.Fields {
+		if _, ok := changeSet[field.DBName]; !ok && field.DBName != "" && field.AutoUpdateTime > 0 {
+			set[documentKey(keys, field.DBName)] = time.Now()
+		}
+	}
+	return bson.M{"$set": set}, nil
+}
+
+// findOptions translates limits, offsets, sorting and field selection
+func (r *Repository[Entity, Filter, Updater]) findOptions(opts *repository.Options) (*options.FindOptionsBuilder, error) {
+	keys, err := r.documentKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	findOptions := options.Find()
+	if opts.Limit != nil {
+		findOptions.SetLimit(int64(*opts.Limit))
+	}
+	if opts.Offset != nil {
+		findOptions.SetSkip(int64(*opts.Offset))
+	}
+
+	if len(opts.SortFields) > 0 {
+		sort := make(bson.D, 0, len(opts.SortFields))
+		for _, field := range opts.SortFields {
+			direction, err := repository.ParseSortDirection(string(field.Direction))
+			if err != nil {
+				return nil, err
+			}
+			if field.Field == "" {
+				return nil, repository.ErrEmptyFieldName
+			}
+			order := 1
+			if direction == repository.Desc {
+				order = -1
+			}
+			sort = append(sort, bson.E{Key: documentKey(keys, field.Field), Value: order})
+		}
+		findOptions.SetSort(sort)
+	}
+
+	// _id is returned unless excluded, so the documents can still be identified
+	if len(opts.SelectFields) > 0 {
+		projection := make(bson.M, len(opts.SelectFields))
+		for _, field := range opts.SelectFields {
+			projection[documentKey(keys, field)] = 1
+		}
+		findOptions.SetProjection(projection)
+	}
+	return findOptions, nil
+}
+
+// documentKeys maps the entity's column names to document keys, parsing the entity once
+func (r *Repository[Entity, Filter, Updater]) documentKeys() (map[string]string, error) {
+	r.schemaOnce.Do(func() {
+		r.schema, r.schemaErr = schema.Parse(new(Entity), &sync.Map{}, schema.NamingStrategy{})
+		if r.schemaErr != nil {
+			r.schemaErr = fmt.Errorf("parse entity schema: %w", r.schemaErr)
+			return
+		}
+
+		r.keys = make(map[string]string, len(r.schema.Fields))
+		for _, field := range r.schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			key, _, _ := strings.Cut(field.StructField.Tag.Get("bson"), ",")
+			switch key {
+			case "-":
+				continue
+			case "":
+				key = strings.ToLower(field.Name)
+			}
+			r.keys[field.DBName] = key
+		}
+	})
+	return r.keys, r.schemaErr
+}
+
+// entitySchema returns the entity's parsed schema
+func (r *Repository[Entity, Filter, Updater]) entitySchema() (*schema.Schema, error) {
+	if _, err := r.documentKeys(); err != nil {
+		return nil, err
+	}
+	return r.schema, nil
+}
+
+// checkOptions returns repository.ErrUnsupportedOption for options Repository cannot apply
+func checkOptions(opts *repository.Options) error {
+	var unsupported []string
+	if len(opts.Preloads) > 0 {
+		unsupported = append(unsupported, "preloads")
+	}
+	if len(opts.Joins) > 0 {
+		unsupported = append(unsupported, "joins")
+	}
+	if opts.Lock != nil {
+		unsupported = append(unsupported, "locking")
+	}
+	if len(opts.GroupBy) > 0 || len(opts.Having) > 0 || len(opts.Aggregates) > 0 {
+		unsupported = append(unsupported, "grouping")
+	}
+	if opts.Cursor != nil || opts.CursorToken != "" {
+		unsupported = append(unsupported, "cursors")
+	}
+	if opts.SortStable {
+		unsupported = append(unsupported, "stable sorting")
+	}
+	if opts.Distinct || len(opts.DistinctOn) > 0 {
+		unsupported = append(unsupported, "distinct")
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("%w: %s", repository.ErrUnsupportedOption, strings.Join(unsupported, ", "))
+	}
+	return nil
+}
+
+// newOptions collects the configured options
+func newOptions(options ...repository.OptionFunc) *repository.Options {
+	opts := &repository.Options{}
+	for _, opt := range options {
+		opt.Apply(opts)
+	}
+	return opts
+}
+
+// timeoutContext bounds ctx by timeout; a timeout of zero or less leaves it unbounded
+func timeoutContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// BuildFilter renders filters as a MongoDB query document, joining them with $and. Fields are
+// used as document keys unchanged; Repository maps column names to keys first. Raw SQL, related
+// counts and registered operators have no document form and return repository.ErrUnsupportedDialect.
+func BuildFilter(filters []*repository.Filter) (bson.M, error) {
+	return compileFilters(filters, nil)
+}
+
+// compileFilters is BuildFilter with columns mapped through keys
+func compileFilters(filters []*repository.Filter, keys map[string]string) (bson.M, error) {
+	conditions := make(bson.A, 0, len(filters))
+	for _, filter := range filters {
+		if filter.Field == "" && filter.Operator != repository.OperatorRaw {
+			return nil, repository.ErrEmptyFieldName
+		}
+
+		condition, err := queryCondition(documentKey(keys, filter.Field), filter)
+		if err != nil {
+			return nil, err
+		}
+		if condition != nil {
+			conditions = append(conditions, condition)
+		}
+	}
+
+	switch len(conditions) {
+	case 0:
+		return bson.M{}, nil
+	case 1:
+		return conditions[0].(bson.M), nil
+	default:
+		return bson.M{"$and": conditions}, nil
+	}
+}
+
+// queryCondition returns the query document of a filter on key. A nil document means the filter
+// restricts nothing.
+func queryCondition(key string, filter *repository.Filter) (bson.M, error) {
+	operator, match, err := repository.ResolveFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	switch match {
+	case repository.MatchAll:
+		return nil, nil
+	case repository.MatchNone:
+		return bson.M{key: bson.M{"$in": bson.A{}}}, nil
+	}
+
+	value := filter.Value
+	switch operator {
+	case repository.OperatorEqual:
+		return bson.M{key: bson.M{"$eq": value}}, nil
+	case repository.OperatorNotEqual:
+		return bson.M{key: bson.M{"$ne": value}}, nil
+	case repository.OperatorLessThan:
+		return bson.M{key: bson.M{"$lt": value}}, nil
+	case repository.OperatorLessThanOrEqual:
+		return bson.M{key: bson.M{"$lte": value}}, nil
+	case repository.OperatorGreaterThan:
+		return bson.M{key: bson.M{"$gt": value}}, nil
+	case repository.OperatorGreaterThanOrEqual:
+		return bson.M{key: bson.M{"$gte": value}}, nil
+	case repository.OperatorLike:
+		return bson.M{key: bson.Regex{Pattern: repository.LikeRegex(reflect.ValueOf(value).String()), Options: "s"}}, nil
+	case repository.OperatorNotLike:
+		return bson.M{key: bson.M{"$not": bson.Regex{Pattern: repository.LikeRegex(reflect.ValueOf(value).String()), Options: "s"}}}, nil
+	case repository.OperatorILike:
+		return bson.M{key: bson.Regex{Pattern: repository.LikeRegex(reflect.ValueOf(value).String()), Options: "is"}}, nil
+	case repository.OperatorRegex:
+		return bson.M{key: bson.Regex{Pattern: reflect.ValueOf(value).String()}}, nil
+	case repository.OperatorFullText:
+		// $text searches the collection's text index, whichever fields it covers
+		search, ok := value.(repository.TextSearch)
+		if !ok {
+			query, isString := value.(string)
+			if !isString {
+				return nil, fmt.Errorf("%s expects a string or repository.TextSearch, got %T: %w", operator, value, repository.ErrInvalidFilterValue)
+			}
+			search = repository.TextSearch{Query: query}
+		}
+		return bson.M{"$text": bson.M{"$search": search.Query}}, nil
+	case repository.OperatorIsNull:
+		// Matches documents without the field as well, as a NULL column would
+		return bson.M{key: bson.M{"$eq": nil}}, nil
+	case repository.OperatorIsNotNull:
+		return bson.M{key: bson.M{"$ne": nil}}, nil
+	case repository.OperatorIn:
+		return bson.M{key: bson.M{"$in": listValues(value)}}, nil
+	case repository.OperatorNotIn:
+		return bson.M{key: bson.M{"$nin": listValues(value)}}, nil
+	case repository.OperatorHStoreHasKey, repository.OperatorJSONHasKey:
+		name, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s expects a string key, got %T: %w", operator, value, repository.ErrInvalidFilterValue)
+		}
+		return bson.M{key + "." + name: bson.M{"$exists": true}}, nil
+	case repository.OperatorHStoreGet, repository.OperatorJSONEqual:
+		kv, ok := value.(repository.KeyValue)
+		if !ok {
+			return nil, fmt.Errorf("%s expects repository.KeyValue, got %T: %w", operator, value, repository.ErrInvalidFilterValue)
+		}
+		return bson.M{key + "." + kv.Key: bson.M{"$eq": kv.Value}}, nil
+	case repository.OperatorJSONContains:
+		return bson.M{key: bson.M{"$elemMatch": bson.M{"$eq": value}}}, nil
+	case repository.OperatorArrayContains:
+		values := listValues(value)
+		if len(values) == 0 {
+			// Every array contains the empty array, while $all of nothing matches nothing
+			return nil, nil
+		}
+		return bson.M{key: bson.M{"$all": values}}, nil
+	case repository.OperatorArrayOverlaps:
+		return bson.M{key: bson.M{"$in": listValues(value)}}, nil
+	case repository.OperatorBetween, repository.OperatorNotBetween:
+		bounds, ok := value.(repository.Range)
+		if !ok {
+			return nil, fmt.Errorf("%s expects repository.Range, got %T: %w", operator, value, repository.ErrInvalidFilterValue)
+		}
+		between := bson.M{"$gte": bounds.Lower, "$lte": bounds.Upper}
+		if operator == repository.OperatorNotBetween {
+			return bson.M{key: bson.M{"$not": between}}, nil
+		}
+		return bson.M{key: between}, nil
+	default:
+		return nil, repository.UnsupportedOperator(dialect, operator)
+	}
+}
+
+// documentKey maps the column of a possibly dotted name to its document key
+func documentKey(keys map[string]string, name string) string {
+	column, path, dotted := strings.Cut(name, ".")
+	key, ok := keys[column]
+	if !ok {
+		return name
+	}
+	if dotted {
+		return key + "." + path
+	}
+	return key
+}
+
+// listValues returns the elements of an IN list as a BSON array
+func listValues(value interface{}) bson.A {
+	v := reflect.ValueOf(value)
+	values := make(bson.A, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Interface()
+	}
+	return values
+}
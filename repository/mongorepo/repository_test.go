@@ -0,0 +1,217 @@
+package mongorepo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dchlong/querybuilder/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type MongoUser struct {
+	ID        bson.ObjectID `bson:"_id,omitempty" gorm:"primaryKey"`
+	Name      string        `bson:"full_name"`
+	Email     string
+	Address   map[string]string `bson:"address" gorm:"serializer:json"`
+	UpdatedAt time.Time         `bson:"updated_at"`
+}
+
+// testFilter is a minimal EntityFilter, with the error a generated filter reports
+type testFilter struct {
+	filters []*repository.Filter
+	err     error
+}
+
+func (f *testFilter) ListFilters() []*repository.Filter {
+	return f.filters
+}
+
+func (f *testFilter) Err() error {
+	return f.err
+}
+
+// testUpdater is a minimal EntityUpdater over a change set
+type testUpdater struct {
+	fields map[string]interface{}
+}
+
+func (u *testUpdater) GetChangeSet() map[string]interface{} {
+	return u.fields
+}
+
+func newMongoUserRepository() *Repository[MongoUser, *testFilter, *testUpdater] {
+	return NewRepository[MongoUser, *testFilter, *testUpdater](nil)
+}
+
+func TestBuildFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   *repository.Filter
+		expected bson.M
+	}{
+		{"eq", &repository.Filter{Field: "age", Operator: repository.OperatorEqual, Value: 30}, bson.M{"age": bson.M{"$eq": 30}}},
+		{"eq nil", &repository.Filter{Field: "deleted_at", Operator: repository.OperatorEqual, Value: nil}, bson.M{"deleted_at": bson.M{"$eq": nil}}},
+		{"ne", &repository.Filter{Field: "age", Operator: repository.OperatorNotEqual, Value: 30}, bson.M{"age": bson.M{"$ne": 30}}},
+		{"gt", &repository.Filter{Field: "age", Operator: repository.OperatorGreaterThan, Value: 18}, bson.M{"age": bson.M{"$gt": 18}}},
+		{"lte", &repository.Filter{Field: "age", Operator: repository.OperatorLessThanOrEqual, Value: 65}, bson.M{"age": bson.M{"$lte": 65}}},
+		{"in", &repository.Filter{Field: "age", Operator: repository.OperatorIn, Value: []int{25, 30}}, bson.M{"age": bson.M{"$in": bson.A{25, 30}}}},
+		{"empty in", &repository.Filter{Field: "age", Operator: repository.OperatorIn, Value: []int{}}, bson.M{"age": bson.M{"$in": bson.A{}}}},
+		{"not in", &repository.Filter{Field: "age", Operator: repository.OperatorNotIn, Value: []int{25}}, bson.M{"age": bson.M{"$nin": bson.A{25}}}},
+		{"like", &repository.Filter{Field: "name", Operator: repository.OperatorLike, Value: "A_i%"},
+			bson.M{"name": bson.Regex{Pattern: "^A.i.*$", Options: "s"}}},
+		{"like escapes", &repository.Filter{Field: "name", Operator: repository.OperatorLike, Value: `100\%.`},
+			bson.M{"name": bson.Regex{Pattern: `^100%\.$`, Options: "s"}}},
+		{"ilike", &repository.Filter{Field: "name", Operator: repository.OperatorILike, Value: "%ali%"},
+			bson.M{"name": bson.Regex{Pattern: "^.*ali.*$", Options: "is"}}},
+		{"not like", &repository.Filter{Field: "name", Operator: repository.OperatorNotLike, Value: "A%"},
+			bson.M{"name": bson.M{"$not": bson.Regex{Pattern: "^A.*$", Options: "s"}}}},
+		{"is null", &repository.Filter{Field: "email", Operator: repository.OperatorIsNull}, bson.M{"email": bson.M{"$eq": nil}}},
+		{"is not null", &repository.Filter{Field: "email", Operator: repository.OperatorIsNotNull}, bson.M{"email": bson.M{"$ne": nil}}},
+		{"between", &repository.Filter{Field: "age", Operator: repository.OperatorBetween, Value: repository.Range{Lower: 18, Upper: 65}},
+			bson.M{"age": bson.M{"$gte": 18, "$lte": 65}}},
+		{"has key", &repository.Filter{Field: "attrs", Operator: repository.OperatorJSONHasKey, Value: "color"},
+			bson.M{"attrs.color": bson.M{"$exists": true}}},
+		{"array contains", &repository.Filter{Field: "tags", Operator: repository.OperatorArrayContains, Value: []string{"a", "b"}},
+			bson.M{"tags": bson.M{"$all": bson.A{"a", "b"}}}},
+		{"full text", &repository.Filter{Field: "body", Operator: repository.OperatorFullText, Value: "mongo"},
+			bson.M{"$text": bson.M{"$search": "mongo"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := BuildFilter([]*repository.Filter{tt.filter})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, query)
+		})
+	}
+}
+
+func TestBuildFilter_Combined(t *testing.T) {
+	query, err := BuildFilter(nil)
+	require.NoError(t, err)
+	assert.Equal(t, bson.M{}, query)
+
+	query, err = BuildFilter([]*repository.Filter{
+		{Field: "age", Operator: repository.OperatorGreaterThanOrEqual, Value: 18},
+		{Field: "tags", Operator: repository.OperatorNotIn, Value: []string{}},
+		{Field: "name", Operator: repository.OperatorEqual, Value: "Alice"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, bson.M{"$and": bson.A{
+		bson.M{"age": bson.M{"$gte": 18}},
+		bson.M{"name": bson.M{"$eq": "Alice"}},
+	}}, query)
+}
+
+func TestBuildFilter_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   *repository.Filter
+		expected error
+	}{
+		{"empty field", &repository.Filter{Operator: repository.OperatorEqual, Value: 1}, repository.ErrEmptyFieldName},
+		{"unknown operator", &repository.Filter{Field: "age", Operator: "~~", Value: 1}, repository.ErrUnknownOperator},
+		{"raw sql", &repository.Filter{Operator: repository.OperatorRaw, Value: repository.RawSQL{SQL: "age > ?", Args: []interface{}{18}}}, repository.ErrUnsupportedDialect},
+		{"wrong value shape", &repository.Filter{Field: "age", Operator: repository.OperatorBetween, Value: 1}, repository.ErrInvalidFilterValue},
+		{"list operator", &repository.Filter{Field: "age", Operator: repository.OperatorIn, Value: 1}, repository.ErrInvalidFilterValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := BuildFilter([]*repository.Filter{tt.filter})
+			assert.True(t, errors.Is(err, tt.expected), "got %v", err)
+		})
+	}
+}
+
+func TestRepository_DocumentKeys(t *testing.T) {
+	repo := newMongoUserRepository()
+
+	query, err := repo.filterDocument(&testFilter{filters: []*repository.Filter{
+		{Field: "id", Operator: repository.OperatorEqual, Value: "1"},
+		{Field: "name", Operator: repository.OperatorEqual, Value: "Alice"},
+		{Field: "email", Operator: repository.OperatorIsNotNull},
+		{Field: "address.city", Operator: repository.OperatorEqual, Value: "Hanoi"},
+		{Field: "unknown", Operator: repository.OperatorEqual, Value: 1},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, bson.M{"$and": bson.A{
+		bson.M{"_id": bson.M{"$eq": "1"}},
+		bson.M{"full_name": bson.M{"$eq": "Alice"}},
+		bson.M{"email": bson.M{"$ne": nil}},
+		bson.M{"address.city": bson.M{"$eq": "Hanoi"}},
+		bson.M{"unknown": bson.M{"$eq": 1}},
+	}}, query)
+
+	findOptions, err := repo.findOptions(newOptions(
+		repository.WithSort("name", repository.Desc),
+		repository.WithSelect("name", "email"),
+		repository.WithLimit(10),
+	))
+	require.NoError(t, err)
+	var applied options.FindOptions
+	for _, set := range findOptions.Opts {
+		require.NoError(t, set(&applied))
+	}
+	assert.Equal(t, bson.D{{Key: "full_name", Value: -1}}, applied.Sort)
+	assert.Equal(t, bson.M{"full_name": 1, "email": 1}, applied.Projection)
+	assert.Equal(t, int64(10), *applied.Limit)
+}
+
+func TestRepository_SetDocument(t *testing.T) {
+	repo := newMongoUserRepository()
+
+	update, err := repo.setDocument(map[string]interface{}{"name": "Bob"})
+	require.NoError(t, err)
+
+	set := update["$set"].(bson.M)
+	assert.Equal(t, "Bob", set["full_name"])
+	assert.IsType(t, time.Time{}, set["updated_at"])
+	assert.Len(t, set, 2)
+}
+
+func TestRepository_Update(t *testing.T) {
+	repo := newMongoUserRepository()
+	ctx := context.Background()
+	id := bson.NewObjectID()
+
+	query, err := repo.keyDocument(ctx, &MongoUser{ID: id, Name: "Alice"})
+	require.NoError(t, err)
+	assert.Equal(t, bson.M{"_id": id}, query)
+
+	err = repo.Update(ctx, &MongoUser{Name: "Alice"}, &testUpdater{fields: map[string]interface{}{"name": "Bob"}})
+	assert.ErrorIs(t, err, repository.ErrInvalidPrimaryKey)
+
+	// Nothing to change needs no round trip, so the nil collection is never used
+	require.NoError(t, repo.Update(ctx, &MongoUser{ID: id}, &testUpdater{fields: map[string]interface{}{}}))
+}
+
+func TestRepository_Errors(t *testing.T) {
+	repo := newMongoUserRepository()
+	ctx := context.Background()
+
+	assert.ErrorIs(t, repo.Create(ctx), repository.ErrNoRecordsProvided)
+
+	_, err := repo.FindAll(ctx, &testFilter{}, repository.WithPreload("Orders"))
+	assert.ErrorIs(t, err, repository.ErrUnsupportedOption)
+
+	filterErr := errors.New("bad cursor")
+	_, err = repo.Count(ctx, &testFilter{err: filterErr})
+	assert.ErrorIs(t, err, repository.ErrInvalidFilterValue)
+	assert.ErrorIs(t, err, filterErr)
+
+	options := make([]repository.OptionFunc, 1, 2)
+	options[0] = repository.WithSort("name", repository.Desc)
+	_, _, err = repo.FindOne(ctx, &testFilter{err: filterErr}, options...)
+	assert.ErrorIs(t, err, filterErr)
+	assert.Nil(t, options[:2][1], "FindOne leaves the caller's options untouched")
+
+	updated, err := repo.UpdateWithFilter(ctx, &testFilter{}, &testUpdater{fields: map[string]interface{}{}})
+	require.NoError(t, err)
+	assert.Zero(t, updated)
+}
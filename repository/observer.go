@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// QueryEvent describes a finished repository call
+type QueryEvent struct {
+	Operation string        // Repository method, e.g. "FindAll"
+	Filters   []*Filter     // Conditions of the call's filter; nil for calls without one
+	Options   *Options      // Options the call was given
+	Duration  time.Duration // Time spent in the call, retries included
+	Err       error         // Error returned by the call
+}
+
+// QueryObserver is notified after repository calls, e.g. to record metrics or traces around
+// the generated filters. Set it as RepoConfig.Observer.
+type QueryObserver interface {
+	ObserveQuery(ctx context.Context, event QueryEvent)
+}
+
+// QueryObserverFunc adapts a function to a QueryObserver
+type QueryObserverFunc func(ctx context.Context, event QueryEvent)
+
+// ObserveQuery calls f(ctx, event)
+func (f QueryObserverFunc) ObserveQuery(ctx context.Context, event QueryEvent) {
+	f(ctx, event)
+}
@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGormRepository_QueryObserver(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	var events []QueryEvent
+	observer := QueryObserverFunc(func(_ context.Context, event QueryEvent) {
+		events = append(events, event)
+	})
+	repo := NewGormRepositoryWithConfig[TestEntity, *TestFilter, *TestUpdater](db, RepoConfig{Observer: observer})
+	entities := createTestEntities()
+	require.NoError(t, repo.Create(ctx, entities...))
+	require.Len(t, events, 1)
+	assert.Equal(t, "Create", events[0].Operation)
+	events = nil
+
+	_, err := repo.FindAll(ctx, NewTestFilter().NameEq("Alice").AgeGte(18), WithLimit(5))
+	require.NoError(t, err)
+	_, _, err = repo.FindOne(ctx, NewTestFilter().NameEq("Bob"))
+	require.NoError(t, err)
+	_, err = repo.Count(ctx, NewTestFilter())
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(ctx, entities[0], NewTestUpdater().SetName("Alicia")))
+	_, err = repo.UpdateWithFilter(ctx, NewTestFilter().NameEq("Bob"), NewTestUpdater().SetEmail("bob@example.org"))
+	require.NoError(t, err)
+	_, err = repo.DeleteWithFilter(ctx, NewTestFilter().NameEq("Charlie"))
+	require.NoError(t, err)
+	_, err = repo.HardDelete(ctx, NewTestFilter().NameEq("David"))
+	require.NoError(t, err)
+
+	var operations []string
+	for _, event := range events {
+		operations = append(operations, event.Operation)
+		assert.NoError(t, event.Err)
+		assert.Positive(t, event.Duration)
+		assert.NotNil(t, event.Options)
+	}
+	assert.Equal(t, []string{"FindAll", "FindOne", "Count", "Update", "UpdateWithFilter", "DeleteWithFilter", "HardDelete"}, operations)
+
+	findAll := events[0]
+	require.Len(t, findAll.Filters, 2)
+	assert.Equal(t, "name", findAll.Filters[0].Field)
+	assert.Equal(t, OperatorGreaterThanOrEqual, findAll.Filters[1].Operator)
+	require.NotNil(t, findAll.Options.Limit)
+	assert.Equal(t, 5, *findAll.Options.Limit)
+	assert.Nil(t, events[3].Filters, "Update has no filter")
+
+	t.Run("reports errors", func(t *testing.T) {
+		events = nil
+		invalid := NewTestFilter()
+		invalid.err = errors.New("bad value")
+		_, err := repo.FindAll(ctx, invalid)
+		require.Error(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, err, events[0].Err)
+	})
+
+	t.Run("within transactions", func(t *testing.T) {
+		events = nil
+		err := repo.WithTransaction(ctx, func(tx *GormRepository[TestEntity, *TestFilter, *TestUpdater]) error {
+			_, err := tx.Count(ctx, NewTestFilter())
+			return err
+		})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, "Count", events[0].Operation)
+	})
+}
+
+func TestGormRepository_QueryObserverEveryMethod(t *testing.T) {
+	type testRepo = GormRepository[TestEntity, *TestFilter, *TestUpdater]
+	ctx := context.Background()
+	active := func() *TestFilter { return NewTestFilter().IsActiveEq(true) }
+
+	tests := []struct {
+		method    string
+		operation string
+		call      func(repo *testRepo, entities []*TestEntity) error
+	}{
+		{"Create", "Create", func(repo *testRepo, _ []*TestEntity) error {
+			return repo.Create(ctx, &TestEntity{Name: "Eve"})
+		}},
+		{"CreateReturning", "CreateReturning", func(repo *testRepo, _ []*TestEntity) error {
+			return repo.CreateReturning(ctx, &TestEntity{Name: "Eve"})
+		}},
+		{"MustCreate", "Create", func(repo *testRepo, _ []*TestEntity) error {
+			repo.MustCreate(ctx, &TestEntity{Name: "Eve"})
+			return nil
+		}},
+		{"CreateInBatches", "CreateInBatches", func(repo *testRepo, _ []*TestEntity) error {
+			return repo.CreateInBatches(ctx, 1, &TestEntity{Name: "Eve"}, &TestEntity{Name: "Fay"})
+		}},
+		{"BulkCreate", "BulkCreate", func(repo *testRepo, _ []*TestEntity) error {
+			_, err := repo.BulkCreate(ctx, 1, &TestEntity{Name: "Eve"}, &TestEntity{Name: "Fay"})
+			return err
+		}},
+		{"FindOneByID", "FindByPrimaryKey", func(repo *testRepo, entities []*TestEntity) error {
+			_, _, err := repo.FindOneByID(ctx, entities[0].ID)
+			return err
+		}},
+		{"FindOneByStringID", "FindByPrimaryKey", func(repo *testRepo, _ []*TestEntity) error {
+			_, _, err := repo.FindOneByStringID(ctx, "1")
+			return err
+		}},
+		{"FindByPrimaryKey", "FindByPrimaryKey", func(repo *testRepo, entities []*TestEntity) error {
+			_, _, err := repo.FindByPrimaryKey(ctx, entities[0].ID)
+			return err
+		}},
+		{"FindByIDs", "FindByIDs", func(repo *testRepo, entities []*TestEntity) error {
+			_, err := repo.FindByIDs(ctx, entities[0].ID, entities[1].ID)
+			return err
+		}},
+		{"FindMapByIDs", "FindByIDs", func(repo *testRepo, entities []*TestEntity) error {
+			_, err := repo.FindMapByIDs(ctx, entities[0].ID, entities[1].ID)
+			return err
+		}},
+		{"FindOne", "FindOne", func(repo *testRepo, _ []*TestEntity) error {
+			_, _, err := repo.FindOne(ctx, active())
+			return err
+		}},
+		{"FindAll", "FindAll", func(repo *testRepo, _ []*TestEntity) error {
+			_, err := repo.FindAll(ctx, active())
+			return err
+		}},
+		{"FindAllAndCount", "FindAllAndCount", func(repo *testRepo, _ []*TestEntity) error {
+			_, _, err := repo.FindAllAndCount(ctx, active(), WithLimit(1))
+			return err
+		}},
+		{"FindEach", "FindEach", func(repo *testRepo, _ []*TestEntity) error {
+			return repo.FindEach(ctx, active(), func(*TestEntity) error { return nil })
+		}},
+		{"Iterate", "FindEach", func(repo *testRepo, _ []*TestEntity) error {
+			for _, err := range repo.Iterate(ctx, active()) {
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{"FindPage", "FindPage", func(repo *testRepo, _ []*TestEntity) error {
+			_, _, err := repo.FindPage(ctx, active(), 2)
+			return err
+		}},
+		{"Update", "Update", func(repo *testRepo, entities []*TestEntity) error {
+			return repo.Update(ctx, entities[0], NewTestUpdater().SetName("Alicia"))
+		}},
+		{"UpdateNonZero", "UpdateNonZero", func(repo *testRepo, entities []*TestEntity) error {
+			entities[0].Name = "Alicia"
+			return repo.UpdateNonZero(ctx, entities[0])
+		}},
+		{"UpdateWithFilter", "UpdateWithFilter", func(repo *testRepo, _ []*TestEntity) error {
+			_, err := repo.UpdateWithFilter(ctx, active(), NewTestUpdater().SetAge(40))
+			return err
+		}},
+		{"UpdateWithFilterReturning", "UpdateWithFilterReturning", func(repo *testRepo, _ []*TestEntity) error {
+			_, err := repo.UpdateWithFilterReturning(ctx, active(), NewTestUpdater().SetAge(40))
+			return err
+		}},
+		{"DeleteWithFilter", "DeleteWithFilter", func(repo *testRepo, _ []*TestEntity) error {
+			_, err := repo.DeleteWithFilter(ctx, active())
+			return err
+		}},
+		{"HardDelete", "HardDelete", func(repo *testRepo, _ []*TestEntity) error {
+			_, err := repo.HardDelete(ctx, active())
+			return err
+		}},
+		{"DeleteByID", "DeleteByID", func(repo *testRepo, entities []*TestEntity) error {
+			_, err := repo.DeleteByID(ctx, entities[0].ID)
+			return err
+		}},
+		{"DeleteByIDs", "DeleteByIDs", func(repo *testRepo, entities []*TestEntity) error {
+			_, err := repo.DeleteByIDs(ctx, entities[0].ID, entities[1].ID)
+			return err
+		}},
+		{"HardDeleteByID", "HardDeleteByID", func(repo *testRepo, entities []*TestEntity) error {
+			_, err := repo.HardDeleteByID(ctx, entities[0].ID)
+			return err
+		}},
+		{"Restore", "Restore", func(repo *testRepo, _ []*TestEntity) error {
+			_, err := repo.Restore(ctx, active())
+			if errors.Is(err, ErrNotSoftDeletable) {
+				return nil // TestEntity has no gorm.DeletedAt field; the failed call is still observed
+			}
+			return err
+		}},
+		{"Count", "Count", func(repo *testRepo, _ []*TestEntity) error {
+			_, err := repo.Count(ctx, active())
+			return err
+		}},
+		{"Exists", "Exists", func(repo *testRepo, _ []*TestEntity) error {
+			_, err := repo.Exists(ctx, active())
+			return err
+		}},
+		{"ExistsByID", "ExistsByID", func(repo *testRepo, entities []*TestEntity) error {
+			_, err := repo.ExistsByID(ctx, entities[0].ID)
+			return err
+		}},
+		{"CountDistinct", "CountDistinct", func(repo *testRepo, _ []*TestEntity) error {
+			_, err := repo.CountDistinct(ctx, active(), "age")
+			return err
+		}},
+		{"CountByMonth", "CountByMonth", func(repo *testRepo, _ []*TestEntity) error {
+			_, err := repo.CountByMonth(ctx, active(), "created_at")
+			return err
+		}},
+		{"Sum", "Sum", func(repo *testRepo, _ []*TestEntity) error {
+			_, _, err := repo.Sum(ctx, active(), "age")
+			return err
+		}},
+		{"Avg", "Avg", func(repo *testRepo, _ []*TestEntity) error {
+			_, _, err := repo.Avg(ctx, active(), "age")
+			return err
+		}},
+		{"Min", "Min", func(repo *testRepo, _ []*TestEntity) error {
+			_, _, err := repo.Min(ctx, active(), "age")
+			return err
+		}},
+		{"Max", "Max", func(repo *testRepo, _ []*TestEntity) error {
+			_, _, err := repo.Max(ctx, active(), "age")
+			return err
+		}},
+		{"FindGrouped", "FindGrouped", func(repo *testRepo, _ []*TestEntity) error {
+			var rows []struct {
+				IsActive bool
+				Count    int64
+			}
+			return repo.FindGrouped(ctx, NewTestFilter(), &rows,
+				WithGroupBy("is_active"), WithAggregate("COUNT", "*", "count"))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			var events []QueryEvent
+			observer := QueryObserverFunc(func(_ context.Context, event QueryEvent) {
+				events = append(events, event)
+			})
+			repo := NewGormRepositoryWithConfig[TestEntity, *TestFilter, *TestUpdater](setupTestDB(t), RepoConfig{Observer: observer})
+			entities := createTestEntities()
+			require.NoError(t, repo.Create(ctx, entities...))
+			events = nil
+
+			require.NoError(t, tt.call(repo, entities))
+			require.Len(t, events, 1)
+			assert.Equal(t, tt.operation, events[0].Operation)
+		})
+	}
+}
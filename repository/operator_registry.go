@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// columnPlaceholder marks the filtered column in the SQL template of a registered operator
+const columnPlaceholder = "{column}"
+
+// registeredOperators maps operators added with RegisterOperator to their SQL templates
+var registeredOperators sync.Map
+
+// RegisterOperator adds an operator to the ones filters compile, for conditions outside the
+// built-in set such as the Postgres regex match:
+//
+//	repository.RegisterOperator("SIMILAR_TO", "{column} SIMILAR TO ?")
+//
+// {column} stands for the quoted column. The ? placeholders give the operator's arity: none for
+// filters without a value, one for a single value or a list, whose elements replace the ?, and
+// two for a Range. Registering an operator again replaces its template. Like sql.Register, it is
+// meant to be called from init and panics on a built-in operator or an invalid template.
+func RegisterOperator(op Operator, sqlTemplate string) {
+	if isBuiltinOperator(op) {
+		panic(fmt.Sprintf("repository: RegisterOperator of built-in operator %s", op))
+	}
+	if !strings.Contains(sqlTemplate, columnPlaceholder) || strings.Count(sqlTemplate, "?") > 2 {
+		panic(fmt.Sprintf("repository: RegisterOperator %s needs a template with %s and at most two ?, got %q", op, columnPlaceholder, sqlTemplate))
+	}
+	registeredOperators.Store(op, sqlTemplate)
+}
+
+// registeredCondition returns the condition of a filter with a registered operator, or
+// ErrUnknownOperator if the operator isn't registered
+func registeredCondition(quotedField string, filter *Filter) (string, []interface{}, error) {
+	template, ok := registeredOperators.Load(filter.Operator)
+	if !ok {
+		return "", nil, fmt.Errorf("unknown operator %s: %w", filter.Operator, ErrUnknownOperator)
+	}
+	condition := strings.ReplaceAll(template.(string), columnPlaceholder, quotedField)
+
+	value := filter.Value
+	switch strings.Count(condition, "?") {
+	case 0:
+		if !isNilValue(value) {
+			return "", nil, fmt.Errorf("%s takes no value, got %T: %w", filter.Operator, value, ErrInvalidFilterValue)
+		}
+		return condition, nil, nil
+	case 2:
+		bounds, ok := value.(Range)
+		if !ok {
+			return "", nil, fmt.Errorf("%s expects repository.Range, got %T: %w", filter.Operator, value, ErrInvalidFilterValue)
+		}
+		return condition, []interface{}{bounds.Lower, bounds.Upper}, nil
+	}
+
+	if value != nil && isList(reflect.ValueOf(value)) {
+		if isEmptyList(value) {
+			return "", nil, fmt.Errorf("%s got an empty list: %w", filter.Operator, ErrInvalidFilterValue)
+		}
+		placeholders, args := listPlaceholders(value)
+		return strings.Replace(condition, "?", placeholders, 1), args, nil
+	}
+	return condition, []interface{}{value}, nil
+}
+
+// UnsupportedOperator returns the error of a backend that cannot translate op: ErrUnsupportedDialect
+// naming the backend for built-in and registered operators, ErrUnknownOperator for any other
+func UnsupportedOperator(backend string, op Operator) error {
+	if _, registered := registeredOperators.Load(op); registered || isBuiltinOperator(op) {
+		return requireDialect(backend, op)
+	}
+	return fmt.Errorf("unknown operator %s: %w", op, ErrUnknownOperator)
+}
+
+// isBuiltinOperator reports whether filterCondition compiles op itself
+func isBuiltinOperator(op Operator) bool {
+	switch op {
+	case OperatorEqual, OperatorNotEqual, OperatorLessThan, OperatorLessThanOrEqual,
+		OperatorGreaterThan, OperatorGreaterThanOrEqual, OperatorLike, OperatorNotLike, OperatorILike,
+		OperatorRegex, OperatorFullText, OperatorIsNull, OperatorIsNotNull, OperatorIn, OperatorNotIn, OperatorHStoreHasKey,
+		OperatorHStoreGet, OperatorBetween, OperatorNotBetween, OperatorJSONHasKey, OperatorJSONEqual,
+		OperatorJSONContains, OperatorArrayContains, OperatorArrayOverlaps, OperatorRaw, OperatorRelatedCount:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterOperator(t *testing.T) {
+	RegisterOperator("TEST_REGEX", "{column} ~ ?")
+	RegisterOperator("TEST_ANY", "{column} IN (?)")
+	RegisterOperator("TEST_EMPTY", "{column} = ''")
+	RegisterOperator("TEST_WITHIN", "{column} >= ? AND {column} < ?")
+
+	condition, args, err := BuildWhereForDialect(DialectPostgres, []*Filter{
+		{Field: "name", Operator: "TEST_REGEX", Value: "^A"},
+		{Field: "age", Operator: "TEST_ANY", Value: []int{25, 30}},
+		{Field: "email", Operator: "TEST_EMPTY"},
+		{Field: "age", Operator: "TEST_WITHIN", Value: Range{Lower: 18, Upper: 65}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `"name" ~ ? AND "age" IN (?,?) AND "email" = '' AND "age" >= ? AND "age" < ?`, condition)
+	assert.Equal(t, []interface{}{"^A", 25, 30, 18, 65}, args)
+
+	t.Run("checks the value against the arity", func(t *testing.T) {
+		for _, filter := range []*Filter{
+			{Field: "email", Operator: "TEST_EMPTY", Value: "x"},
+			{Field: "age", Operator: "TEST_WITHIN", Value: 18},
+			{Field: "age", Operator: "TEST_ANY", Value: []int{}},
+		} {
+			_, _, err := BuildWhere([]*Filter{filter})
+			assert.ErrorIs(t, err, ErrInvalidFilterValue, filter.Operator)
+		}
+	})
+
+	t.Run("applies to repository queries", func(t *testing.T) {
+		RegisterOperator("TEST_PREFIX", "{column} LIKE ? || '%'")
+		repo, _ := setupTestRepository(t)
+		require.NoError(t, repo.Create(context.Background(), createTestEntities()...))
+
+		filter := NewTestFilter()
+		filter.filters = append(filter.filters, &Filter{Field: "name", Operator: "TEST_PREFIX", Value: "Ch"})
+		results, err := repo.FindAll(context.Background(), filter)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "Charlie", results[0].Name)
+	})
+
+	t.Run("rejects built-in operators and invalid templates", func(t *testing.T) {
+		assert.Panics(t, func() { RegisterOperator(OperatorEqual, "{column} == ?") })
+		assert.Panics(t, func() { RegisterOperator("TEST_NO_COLUMN", "name ~ ?") })
+		assert.Panics(t, func() { RegisterOperator("TEST_TOO_MANY", "{column} IN (?, ?, ?)") })
+	})
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+)
+
+// Page is one page of an offset-paginated query, as FindNumberedPage returns it
+type Page[Entity any] struct {
+	// Items are the records on this page
+	Items []*Entity
+	// Total is the number of records matching the filter across all pages
+	Total int64
+	// Page is the 1-based page number and PageSize the maximum number of items per page
+	Page     int
+	PageSize int
+}
+
+// TotalPages returns the number of pages needed for Total records, zero when nothing matched
+func (p *Page[Entity]) TotalPages() int {
+	if p.PageSize <= 0 {
+		return 0
+	}
+	return int((p.Total + int64(p.PageSize) - 1) / int64(p.PageSize))
+}
+
+// HasNext reports whether a page follows this one
+func (p *Page[Entity]) HasNext() bool {
+	return p.Page < p.TotalPages()
+}
+
+// HasPrevious reports whether a page precedes this one
+func (p *Page[Entity]) HasPrevious() bool {
+	return p.Page > 1
+}
+
+// WithPage limits the query to the 1-based page of pageSize records. Invalid values are
+// reported by FindNumberedPage; other methods apply them as they would WithLimit and WithOffset.
+func WithPage(page, pageSize int) OptionFunc {
+	return &functionOption{
+		f: func(o *Options) {
+			offset := (page - 1) * pageSize
+			o.Limit = &pageSize
+			o.Offset = &offset
+		},
+	}
+}
+
+// PageCounter is implemented by repositories that can return a page of records with the total count
+type PageCounter[Entity any, Filter EntityFilter] interface {
+	FindAllAndCount(ctx context.Context, filter Filter, options ...OptionFunc) ([]*Entity, int64, error)
+}
+
+// FindNumberedPage returns the page selected by WithPage, or by WithLimit and WithOffset, with the
+// total count and page metadata. Unlike FindPage it paginates by offset, so pages can be addressed
+// by number. The limit must be positive and the offset a non-negative multiple of it.
+func FindNumberedPage[Entity any, Filter EntityFilter](
+	ctx context.Context,
+	repo PageCounter[Entity, Filter],
+	filter Filter,
+	options ...OptionFunc,
+) (*Page[Entity], error) {
+	opts := newOptions(options...)
+	pageSize := 0
+	if opts.Limit != nil {
+		pageSize = *opts.Limit
+	}
+	if pageSize <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidPageSize, pageSize)
+	}
+	offset := 0
+	if opts.Offset != nil {
+		offset = *opts.Offset
+	}
+	if offset < 0 || offset%pageSize != 0 {
+		return nil, fmt.Errorf("%w: offset %d with page size %d", ErrInvalidPage, offset, pageSize)
+	}
+
+	items, total, err := repo.FindAllAndCount(ctx, filter, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Page[Entity]{
+		Items:    items,
+		Total:    total,
+		Page:     offset/pageSize + 1,
+		PageSize: pageSize,
+	}, nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindNumberedPage(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+	ctx := context.Background()
+	require.NoError(t, repo.Create(ctx, createTestEntities()...))
+
+	page, err := FindNumberedPage[TestEntity](ctx, repo, NewTestFilter(), WithSort("age", Asc), WithPage(2, 3))
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), page.Total)
+	assert.Equal(t, 2, page.Page)
+	assert.Equal(t, 3, page.PageSize)
+	assert.Equal(t, 2, page.TotalPages())
+	assert.False(t, page.HasNext())
+	assert.True(t, page.HasPrevious())
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, "David", page.Items[0].Name)
+
+	page, err = FindNumberedPage[TestEntity](ctx, repo, NewTestFilter().IsActiveEq(true), WithLimit(2))
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Page)
+	assert.True(t, page.HasNext())
+	assert.False(t, page.HasPrevious())
+
+	page, err = FindNumberedPage[TestEntity](ctx, repo, NewTestFilter().NameEq("nobody"), WithPage(1, 10))
+	require.NoError(t, err)
+	assert.Zero(t, page.TotalPages())
+	assert.False(t, page.HasNext())
+	assert.Empty(t, page.Items)
+
+	_, err = FindNumberedPage[TestEntity](ctx, repo, NewTestFilter())
+	assert.ErrorIs(t, err, ErrInvalidPageSize)
+	_, err = FindNumberedPage[TestEntity](ctx, repo, NewTestFilter(), WithPage(1, 0))
+	assert.ErrorIs(t, err, ErrInvalidPageSize)
+	_, err = FindNumberedPage[TestEntity](ctx, repo, NewTestFilter(), WithPage(0, 10))
+	assert.ErrorIs(t, err, ErrInvalidPage)
+	_, err = FindNumberedPage[TestEntity](ctx, repo, NewTestFilter(), WithLimit(10), WithOffset(5))
+	assert.ErrorIs(t, err, ErrInvalidPage)
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy describes how a read is retried after a transient connection error.
+// Query errors (bad SQL, constraint violations, missing rows) are never retried.
+type RetryPolicy struct {
+	MaxAttempts int              // Total attempts including the first; values below 1 mean a single attempt
+	Backoff     time.Duration    // Delay before the first retry, doubled after each further attempt
+	MaxBackoff  time.Duration    // Upper bound for the delay; zero means unbounded
+	IsTransient func(error) bool // Overrides IsConnectionError when set
+}
+
+// connectionErrorMessages are fragments of driver messages reporting a lost or refused connection
+var connectionErrorMessages = []string{
+	"bad connection",
+	"broken pipe",
+	"connection refused",
+	"connection reset",
+	"server closed the connection",
+	"server has gone away",
+	"lost connection",
+	"unexpected eof",
+	"sqlstate 08", // Postgres connection exception class
+}
+
+// IsConnectionError reports whether err looks like a connection-level failure
+// that may succeed on a fresh connection. Context cancellation is never considered transient.
+func IsConnectionError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, fragment := range connectionErrorMessages {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isTransient applies the policy's classifier
+func (p *RetryPolicy) isTransient(err error) bool {
+	if p.IsTransient != nil {
+		return p.IsTransient(err)
+	}
+	return IsConnectionError(err)
+}
+
+// do runs fn until it succeeds, fails with a non-transient error, runs out of attempts
+// or the context is done. A nil policy runs fn once.
+func (p *RetryPolicy) do(ctx context.Context, fn func() error) error {
+	if p == nil {
+		return fn()
+	}
+
+	delay := p.Backoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= p.MaxAttempts || !p.isTransient(err) {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+
+		delay *= 2
+		if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+			delay = p.MaxBackoff
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// flakyConnection fails the next queries with the configured error before they reach the driver
+type flakyConnection struct {
+	failures int
+	err      error
+	calls    int
+}
+
+func installFlakyConnection(t *testing.T, db *gorm.DB, failures int, err error) *flakyConnection {
+	flaky := &flakyConnection{failures: failures, err: err}
+	require.NoError(t, db.Callback().Query().Before("gorm:query").Register("test:flaky", func(tx *gorm.DB) {
+		flaky.calls++
+		if flaky.failures > 0 {
+			flaky.failures--
+			_ = tx.AddError(flaky.err)
+		}
+	}))
+	return flaky
+}
+
+func setupRetryRepository(t *testing.T, policy *RetryPolicy) (*GormRepository[TestEntity, *TestFilter, *TestUpdater], *gorm.DB) {
+	db := setupTestDB(t)
+	require.NoError(t, db.Create(createTestEntities()).Error)
+
+	repo := NewGormRepositoryWithConfig[TestEntity, *TestFilter, *TestUpdater](db, RepoConfig{ReadRetry: policy})
+	return repo, db
+}
+
+func TestGormRepository_ReadRetry(t *testing.T) {
+	ctx := context.Background()
+	policy := &RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}
+
+	t.Run("find all recovers from transient errors", func(t *testing.T) {
+		repo, db := setupRetryRepository(t, policy)
+		flaky := installFlakyConnection(t, db, 2, driver.ErrBadConn)
+
+		entities, err := repo.FindAll(ctx, NewTestFilter().IsActiveEq(true))
+
+		require.NoError(t, err)
+		assert.Len(t, entities, 3)
+		assert.Equal(t, 3, flaky.calls)
+	})
+
+	t.Run("find one recovers from transient errors", func(t *testing.T) {
+		repo, db := setupRetryRepository(t, policy)
+		flaky := installFlakyConnection(t, db, 1, errors.New("read tcp 10.0.0.1:5432: connection reset by peer"))
+
+		entity, found, err := repo.FindOne(ctx, NewTestFilter().NameEq("Bob"))
+
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "Bob", entity.Name)
+		assert.Equal(t, 2, flaky.calls)
+	})
+
+	t.Run("count gives up after max attempts", func(t *testing.T) {
+		repo, db := setupRetryRepository(t, policy)
+		flaky := installFlakyConnection(t, db, 5, driver.ErrBadConn)
+
+		_, err := repo.Count(ctx, NewTestFilter())
+
+		assert.ErrorIs(t, err, driver.ErrBadConn)
+		assert.Equal(t, 3, flaky.calls)
+	})
+
+	t.Run("query errors are not retried", func(t *testing.T) {
+		repo, db := setupRetryRepository(t, policy)
+		flaky := installFlakyConnection(t, db, 1, errors.New("no such column: nickname"))
+
+		_, err := repo.FindAll(ctx, NewTestFilter())
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, flaky.calls)
+	})
+
+	t.Run("cancelled context stops retrying", func(t *testing.T) {
+		repo, db := setupRetryRepository(t, &RetryPolicy{MaxAttempts: 5, Backoff: time.Hour})
+		flaky := installFlakyConnection(t, db, 5, driver.ErrBadConn)
+
+		cancelCtx, cancel := context.WithCancel(ctx)
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		_, err := repo.FindAll(cancelCtx, NewTestFilter())
+
+		assert.ErrorIs(t, err, driver.ErrBadConn)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, flaky.calls)
+	})
+
+	t.Run("retries are disabled by default", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewGormRepository[TestEntity, *TestFilter, *TestUpdater](db)
+		flaky := installFlakyConnection(t, db, 1, driver.ErrBadConn)
+
+		_, err := repo.FindAll(ctx, NewTestFilter())
+
+		assert.ErrorIs(t, err, driver.ErrBadConn)
+		assert.Equal(t, 1, flaky.calls)
+	})
+}
+
+func TestIsConnectionError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"bad conn", driver.ErrBadConn, true},
+		{"wrapped bad conn", fmt.Errorf("find all records: %w", driver.ErrBadConn), true},
+		{"postgres connection exception", errors.New("FATAL: terminating connection (SQLSTATE 08006)"), true},
+		{"mysql gone away", errors.New("Error 2006: MySQL server has gone away"), true},
+		{"context cancelled", context.Canceled, false},
+		{"syntax error", errors.New(`near "SELEC": syntax error`), false},
+		{"not found", gorm.ErrRecordNotFound, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsConnectionError(tt.err))
+		})
+	}
+}
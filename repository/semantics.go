@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// FilterMatch is what a filter comes down to once the cases that read the same on every backend
+// are resolved
+type FilterMatch int
+
+const (
+	MatchOperator FilterMatch = iota // apply the resolved operator to the value
+	MatchAll                         // restricts nothing, such as NOT IN of an empty list
+	MatchNone                        // matches nothing, such as IN of an empty list
+)
+
+// ResolveFilter checks the filter's value with checkFilterValue and resolves the meaning that the
+// SQL and document backends share: Eq and Ne with a nil value are the NULL checks, since "= NULL"
+// never matches, an empty IN list matches nothing and an empty NOT IN list excludes nothing.
+// Backends, including those outside this package, then translate the returned operator.
+func ResolveFilter(filter *Filter) (Operator, FilterMatch, error) {
+	if err := checkFilterValue(filter); err != nil {
+		return "", MatchNone, err
+	}
+
+	switch filter.Operator {
+	case OperatorEqual:
+		if isNilValue(filter.Value) {
+			return OperatorIsNull, MatchOperator, nil
+		}
+	case OperatorNotEqual:
+		if isNilValue(filter.Value) {
+			return OperatorIsNotNull, MatchOperator, nil
+		}
+	case OperatorIn:
+		if isEmptyList(filter.Value) {
+			return filter.Operator, MatchNone, nil
+		}
+	case OperatorNotIn:
+		if isEmptyList(filter.Value) {
+			return filter.Operator, MatchAll, nil
+		}
+	}
+	return filter.Operator, MatchOperator, nil
+}
+
+// checkFilterValue checks that the value has the shape the operator needs: a list for IN and
+// NOT IN (nil is an empty list) and for the array operators, no value for the NULL checks and a string for the LIKE and
+// regex operators.
+// Errors wrap ErrInvalidFilterValue as well as the specific sentinel.
+func checkFilterValue(filter *Filter) error {
+	var expected error
+	switch filter.Operator {
+	case OperatorIn, OperatorNotIn:
+		if filter.Value != nil && !isList(reflect.ValueOf(filter.Value)) {
+			expected = ErrFilterValueNotList
+		}
+	case OperatorArrayContains, OperatorArrayOverlaps:
+		if filter.Value == nil || !isList(reflect.ValueOf(filter.Value)) {
+			expected = ErrFilterValueNotList
+		}
+	case OperatorIsNull, OperatorIsNotNull:
+		if !isNilValue(filter.Value) {
+			expected = ErrFilterValueNotNil
+		}
+	case OperatorLike, OperatorNotLike, OperatorILike, OperatorRegex:
+		if filter.Value == nil || reflect.TypeOf(filter.Value).Kind() != reflect.String {
+			expected = ErrFilterValueNotString
+		}
+	}
+
+	if expected == nil {
+		return nil
+	}
+	return fmt.Errorf("%s filter on %q got %T: %w: %w", filter.Operator, filter.Field, filter.Value, expected, ErrInvalidFilterValue)
+}
+
+// isList reports whether v is a slice or array. []byte counts as well: it is the []uint8 that
+// the IN filters of uint8 columns pass, as a single binary value makes no sense in a list.
+func isList(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice || v.Kind() == reflect.Array
+}
+
+// isEmptyList reports whether an IN/NOT IN value holds no elements
+func isEmptyList(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(value)
+	return isList(v) && v.Len() == 0
+}
+
+// isNilValue reports whether value is nil or a nil pointer
+func isNilValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+// LikeRegex translates a LIKE pattern, with % and _ wildcards and \ escapes, to an anchored
+// regular expression, for backends that match LIKE filters with regular expressions
+func LikeRegex(pattern string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	escaped := false
+	for _, c := range pattern {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '%':
+			b.WriteString(".*")
+		case c == '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
@@ -0,0 +1,508 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm/schema"
+)
+
+// SQLRepository implements the filter and updater based operations on database/sql, for code
+// that doesn't use GORM. Entities are mapped to tables and columns like GORM does, from gorm tags
+// and GORM's default snake_case naming, so the generated DBSchema column names apply unchanged.
+type SQLRepository[Entity any, Filter EntityFilter, Updater EntityUpdater] struct {
+	db      *sql.DB
+	dialect string
+
+	schemaOnce sync.Once
+	schema     *schema.Schema
+	schemaErr  error
+}
+
+// NewSQLRepository creates a repository executing parameterized SQL on db. dialect is one of the
+// Dialect* constants; it selects identifier quoting, placeholders and dialect-specific operators.
+func NewSQLRepository[Entity any, Filter EntityFilter, Updater EntityUpdater](
+	db *sql.DB,
+	dialect string,
+) *SQLRepository[Entity, Filter, Updater] {
+	return &SQLRepository[Entity, Filter, Updater]{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+// Create inserts the records one by one. A zero auto-increment primary key is left to the database
+// and set on the record afterwards, and zero CreatedAt and UpdatedAt fields are set to the current time.
+func (r *SQLRepository[Entity, Filter, Updater]) Create(ctx context.Context, records ...*Entity) error {
+	if len(records) == 0 {
+		return ErrNoRecordsProvided
+	}
+
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		if err := r.insert(ctx, entitySchema, record); err != nil {
+			return fmt.Errorf("create records: %w", err)
+		}
+	}
+	return nil
+}
+
+// insert inserts one record, reading a generated primary key back into it
+func (r *SQLRepository[Entity, Filter, Updater]) insert(ctx context.Context, entitySchema *schema.Schema, record *Entity) error {
+	value := reflect.ValueOf(record).Elem()
+	now := time.Now()
+
+	var (
+		columns      []string
+		placeholders []string
+		args         []interface{}
+		generated    *schema.Field
+	)
+	for _, name := range entitySchema.DBNames {
+		field := entitySchema.FieldsByDBName[name]
+		if !field.Creatable {
+			continue
+		}
+
+		fieldValue, isZero := field.ValueOf(ctx, value)
+		if isZero && field.PrimaryKey && field.AutoIncrement {
+			generated = field
+			continue
+		}
+		if isZero && (field.AutoCreateTime > 0 || field.AutoUpdateTime > 0) {
+			if err := field.Set(ctx, value, now); err != nil {
+				return fmt.Errorf("set %s: %w", field.Name, err)
+			}
+			fieldValue, _ = field.ValueOf(ctx, value)
+		}
+
+		columns = append(columns, quoteIdentifier(r.dialect, name))
+		placeholders = append(placeholders, "?")
+		args = append(args, fieldValue)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		quoteIdentifier(r.dialect, entitySchema.Table), strings.Join(columns, ", "), strings.Join(placeholders, ", "))
+
+	if generated == nil {
+		_, err := r.db.ExecContext(ctx, r.rebind(query), args...)
+		return err
+	}
+
+	// Postgres drivers don't report the last insert ID; the key is returned instead
+	if r.dialect == DialectPostgres {
+		query += " RETURNING " + quoteIdentifier(r.dialect, generated.DBName)
+		return r.db.QueryRowContext(ctx, r.rebind(query), args...).Scan(generated.ReflectValueOf(ctx, value).Addr().Interface())
+	}
+
+	result, err := r.db.ExecContext(ctx, r.rebind(query), args...)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("read generated %s: %w", generated.DBName, err)
+	}
+	return generated.Set(ctx, value, id)
+}
+
+// FindOne implements single record lookup with filters
+func (r *SQLRepository[Entity, Filter, Updater]) FindOne(
+	ctx context.Context,
+	filter Filter,
+	options ...OptionFunc,
+) (*Entity, bool, error) {
+	records, err := r.FindAll(ctx, filter, append(options, WithLimit(1))...)
+	if err != nil {
+		return nil, false, fmt.Errorf("FindOne: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, false, nil
+	}
+	return records[0], true, nil
+}
+
+// FindAll implements multiple record lookup with filters. Of the options, limits, offsets, sorting,
+// column selection, WithUnscoped and WithTimeout are supported; the others return ErrUnsupportedOption.
+func (r *SQLRepository[Entity, Filter, Updater]) FindAll(
+	ctx context.Context,
+	filter Filter,
+	options ...OptionFunc,
+) ([]*Entity, error) {
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := newOptions(options...)
+	if err := checkSQLOptions(opts); err != nil {
+		return nil, fmt.Errorf("FindAll: %w", err)
+	}
+
+	ctx, cancel := timeoutContext(ctx, opts.Timeout)
+	defer cancel()
+
+	fields, err := r.selectFields(entitySchema, opts)
+	if err != nil {
+		return nil, fmt.Errorf("FindAll: %w", err)
+	}
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = quoteIdentifier(r.dialect, field.DBName)
+	}
+
+	where, args, err := r.where(entitySchema, filter, opts.Unscoped)
+	if err != nil {
+		return nil, fmt.Errorf("FindAll build query: %w", err)
+	}
+
+	var query strings.Builder
+	fmt.Fprintf(&query, "SELECT %s FROM %s%s", strings.Join(columns, ", "), quoteIdentifier(r.dialect, entitySchema.Table), where)
+
+	for i, field := range opts.SortFields {
+		// The direction is written into the SQL, so only asc and desc get through
+		direction, err := ParseSortDirection(string(field.Direction))
+		if err != nil {
+			return nil, fmt.Errorf("FindAll: %w", err)
+		}
+		if field.Field == "" {
+			return nil, fmt.Errorf("FindAll: %w", ErrEmptyFieldName)
+		}
+		separator := ", "
+		if i == 0 {
+			separator = " ORDER BY "
+		}
+		fmt.Fprintf(&query, "%s%s %s", separator, quoteIdentifier(r.dialect, field.Field), strings.ToUpper(string(direction)))
+	}
+
+	if opts.Limit != nil {
+		query.WriteString(" LIMIT " + strconv.Itoa(*opts.Limit))
+	}
+	if opts.Offset != nil {
+		if opts.Limit == nil {
+			query.WriteString(noLimit(r.dialect))
+		}
+		query.WriteString(" OFFSET " + strconv.Itoa(*opts.Offset))
+	}
+
+	rows, err := r.db.QueryContext(ctx, r.rebind(query.String()), args...)
+	if err != nil {
+		return nil, fmt.Errorf("find all records: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*Entity
+	for rows.Next() {
+		record := new(Entity)
+		value := reflect.ValueOf(record).Elem()
+		dest := make([]interface{}, len(fields))
+		for i, field := range fields {
+			dest[i] = field.ReflectValueOf(ctx, value).Addr().Interface()
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("scan record: %w", err)
+		}
+		result = append(result, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("find all records: %w", err)
+	}
+
+	return result, nil
+}
+
+// Count implements record counting.
+// Of the options only WithUnscoped applies; limits and sorting do not change a count.
+func (r *SQLRepository[Entity, Filter, Updater]) Count(
+	ctx context.Context,
+	filter Filter,
+	options ...OptionFunc,
+) (int64, error) {
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return 0, err
+	}
+
+	opts := newOptions(options...)
+	where, args, err := r.where(entitySchema, filter, opts.Unscoped)
+	if err != nil {
+		return 0, fmt.Errorf("count build query: %w", err)
+	}
+
+	ctx, cancel := timeoutContext(ctx, opts.Timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s%s", quoteIdentifier(r.dialect, entitySchema.Table), where)
+
+	var count int64
+	if err := r.db.QueryRowContext(ctx, r.rebind(query), args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count records: %w", err)
+	}
+	return count, nil
+}
+
+// Exists checks if any records match the filter, selecting at most one row
+func (r *SQLRepository[Entity, Filter, Updater]) Exists(
+	ctx context.Context,
+	filter Filter,
+) (bool, error) {
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return false, err
+	}
+
+	where, args, err := r.where(entitySchema, filter, false)
+	if err != nil {
+		return false, fmt.Errorf("exists build query: %w", err)
+	}
+
+	query := fmt.Sprintf("SELECT 1 FROM %s%s LIMIT 1", quoteIdentifier(r.dialect, entitySchema.Table), where)
+
+	var one int
+	err = r.db.QueryRowContext(ctx, r.rebind(query), args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("exists check: %w", err)
+	}
+	return true, nil
+}
+
+// UpdateWithFilter implements batch updates using filters. Like GORM, an UpdatedAt field missing
+// from the change set is set to the current time.
+func (r *SQLRepository[Entity, Filter, Updater]) UpdateWithFilter(
+	ctx context.Context,
+	filter Filter,
+	updater Updater,
+) (int64, error) {
+	changeSet := updater.GetChangeSet()
+	if len(changeSet) == 0 {
+		return 0, nil
+	}
+
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return 0, err
+	}
+
+	values := make(map[string]interface{}, len(changeSet)+1)
+	for column, value := range changeSet {
+		values[column] = value
+	}
+	for _, field := range entitySchema.Fields {
+		if _, ok := values[field.DBName]; !ok && field.DBName != "" && field.AutoUpdateTime > 0 {
+			values[field.DBName] = time.Now()
+		}
+	}
+
+	// Sorted so that the statement is the same for the same change set
+	columns := make([]string, 0, len(values))
+	for column := range values {
+		columns = append(columns, column)
+	}
+	slices.Sort(columns)
+
+	assignments := make([]string, len(columns))
+	args := make([]interface{}, 0, len(columns))
+	for i, column := range columns {
+		assignments[i] = quoteIdentifier(r.dialect, column) + " = ?"
+		args = append(args, values[column])
+	}
+
+	where, whereArgs, err := r.where(entitySchema, filter, false)
+	if err != nil {
+		return 0, fmt.Errorf("UpdateWithFilter build query: %w", err)
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET %s%s", quoteIdentifier(r.dialect, entitySchema.Table), strings.Join(assignments, ", "), where)
+	result, err := r.db.ExecContext(ctx, r.rebind(query), append(args, whereArgs...)...)
+	if err != nil {
+		return 0, fmt.Errorf("update records with filter: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// DeleteWithFilter implements batch deletion using filters.
+// Entities with a gorm.DeletedAt field are soft-deleted, as GormRepository does.
+func (r *SQLRepository[Entity, Filter, Updater]) DeleteWithFilter(
+	ctx context.Context,
+	filter Filter,
+) (int64, error) {
+	entitySchema, err := r.entitySchema()
+	if err != nil {
+		return 0, err
+	}
+
+	where, args, err := r.where(entitySchema, filter, false)
+	if err != nil {
+		return 0, fmt.Errorf("DeleteWithFilter build query: %w", err)
+	}
+
+	table := quoteIdentifier(r.dialect, entitySchema.Table)
+	query := fmt.Sprintf("DELETE FROM %s%s", table, where)
+	if field := softDeleteField(entitySchema); field != nil {
+		query = fmt.Sprintf("UPDATE %s SET %s = ?%s", table, quoteIdentifier(r.dialect, field.DBName), where)
+		args = append([]interface{}{time.Now()}, args...)
+	}
+
+	result, err := r.db.ExecContext(ctx, r.rebind(query), args...)
+	if err != nil {
+		return 0, fmt.Errorf("delete records with filter: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// GetDB returns the underlying database handle for queries the repository doesn't cover
+func (r *SQLRepository[Entity, Filter, Updater]) GetDB() *sql.DB {
+	return r.db
+}
+
+// where returns the WHERE clause of the filter, with a leading space, and its arguments.
+// Soft-deleted records are excluded unless unscoped.
+func (r *SQLRepository[Entity, Filter, Updater]) where(entitySchema *schema.Schema, filter Filter, unscoped bool) (string, []interface{}, error) {
+	if errorer, ok := any(filter).(FilterErrorer); ok {
+		if err := errorer.Err(); err != nil {
+			return "", nil, fmt.Errorf("%w: %w", ErrInvalidFilterValue, err)
+		}
+	}
+
+	condition, args, err := BuildWhereForDialect(r.dialect, filter.ListFilters())
+	if err != nil {
+		return "", nil, err
+	}
+
+	if field := softDeleteField(entitySchema); field != nil && !unscoped {
+		notDeleted := quoteIdentifier(r.dialect, field.DBName) + " IS NULL"
+		if condition == "" {
+			condition = notDeleted
+		} else {
+			condition = "(" + condition + ") AND " + notDeleted
+		}
+	}
+
+	if condition == "" {
+		return "", args, nil
+	}
+	return " WHERE " + condition, args, nil
+}
+
+// selectFields returns the fields to select: every column, or those of opts.SelectFields plus the
+// primary key
+func (r *SQLRepository[Entity, Filter, Updater]) selectFields(entitySchema *schema.Schema, opts *Options) ([]*schema.Field, error) {
+	if len(opts.SelectFields) == 0 {
+		fields := make([]*schema.Field, 0, len(entitySchema.DBNames))
+		for _, name := range entitySchema.DBNames {
+			if field := entitySchema.FieldsByDBName[name]; field.Readable {
+				fields = append(fields, field)
+			}
+		}
+		return fields, nil
+	}
+
+	var fields []*schema.Field
+	for _, name := range append(slices.Clone(opts.SelectFields), entitySchema.PrimaryFieldDBNames...) {
+		field := entitySchema.LookUpField(name)
+		if field == nil || field.DBName == "" {
+			return nil, fmt.Errorf("%w: %q is not a column of %s", ErrUnknownField, name, entitySchema.Name)
+		}
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
+// entitySchema parses the entity's table and columns once
+func (r *SQLRepository[Entity, Filter, Updater]) entitySchema() (*schema.Schema, error) {
+	r.schemaOnce.Do(func() {
+		r.schema, r.schemaErr = schema.Parse(new(Entity), &sync.Map{}, schema.NamingStrategy{})
+		if r.schemaErr != nil {
+			r.schemaErr = fmt.Errorf("parse entity schema: %w", r.schemaErr)
+		}
+	})
+	return r.schema, r.schemaErr
+}
+
+// rebind replaces ? placeholders with $1, $2, ... on Postgres, skipping quoted strings and identifiers
+func (r *SQLRepository[Entity, Filter, Updater]) rebind(query string) string {
+	if r.dialect != DialectPostgres {
+		return query
+	}
+
+	var (
+		b     strings.Builder
+		n     int
+		quote rune
+	)
+	for _, c := range query {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '?':
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// noLimit returns the LIMIT clause that MySQL and SQLite need before an OFFSET without a limit
+func noLimit(dialect string) string {
+	switch dialect {
+	case DialectMySQL:
+		return " LIMIT 18446744073709551615"
+	case DialectPostgres:
+		return ""
+	default:
+		return " LIMIT -1"
+	}
+}
+
+// checkSQLOptions returns ErrUnsupportedOption for options SQLRepository cannot apply
+func checkSQLOptions(opts *Options) error {
+	var unsupported []string
+	if len(opts.Preloads) > 0 {
+		unsupported = append(unsupported, "preloads")
+	}
+	if len(opts.Joins) > 0 {
+		unsupported = append(unsupported, "joins")
+	}
+	if opts.Lock != nil {
+		unsupported = append(unsupported, "locking")
+	}
+	if len(opts.GroupBy) > 0 || len(opts.Having) > 0 || len(opts.Aggregates) > 0 {
+		unsupported = append(unsupported, "grouping")
+	}
+	if opts.Cursor != nil || opts.CursorToken != "" {
+		unsupported = append(unsupported, "cursors")
+	}
+	if opts.SortStable {
+		unsupported = append(unsupported, "stable sorting")
+	}
+	if opts.Distinct || len(opts.DistinctOn) > 0 {
+		unsupported = append(unsupported, "distinct")
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("%w: %s", ErrUnsupportedOption, strings.Join(unsupported, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSQLRepository(t *testing.T) *SQLRepository[TestEntity, *TestFilter, *TestUpdater] {
+	gormDB := setupTestDB(t)
+	require.NoError(t, gormDB.AutoMigrate(&SoftDeleteEntity{}))
+
+	db, err := gormDB.DB()
+	require.NoError(t, err)
+	// Every connection to :memory: opens a new, empty database
+	db.SetMaxOpenConns(1)
+
+	return NewSQLRepository[TestEntity, *TestFilter, *TestUpdater](db, DialectSQLite)
+}
+
+func TestSQLRepository_CreateAndFind(t *testing.T) {
+	repo := setupSQLRepository(t)
+	ctx := context.Background()
+
+	entities := createTestEntities()
+	require.NoError(t, repo.Create(ctx, entities...))
+	for _, entity := range entities {
+		assert.NotZero(t, entity.ID)
+		assert.False(t, entity.CreatedAt.IsZero())
+	}
+
+	active, err := repo.FindAll(ctx, NewTestFilter().IsActiveEq(true).AgeGte(30), WithSort("age", Desc))
+	require.NoError(t, err)
+	require.Len(t, active, 2)
+	assert.Equal(t, "David", active[0].Name)
+	assert.Equal(t, "Bob", active[1].Name)
+	assert.Equal(t, entities[3].ID, active[0].ID)
+
+	page, err := repo.FindAll(ctx, NewTestFilter(), WithSort("name", Asc), WithLimit(2), WithOffset(1))
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, "Bob", page[0].Name)
+
+	one, found, err := repo.FindOne(ctx, NewTestFilter().NameILike("charlie"), WithSelect("name"))
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, entities[2].ID, one.ID)
+	assert.Empty(t, one.Email)
+
+	_, found, err = repo.FindOne(ctx, NewTestFilter().NameEq("Nobody"))
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	count, err := repo.Count(ctx, NewTestFilter().EmailLike("%@example.com"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+
+	_, err = repo.FindAll(ctx, NewTestFilter(), WithGroupBy("age"))
+	assert.ErrorIs(t, err, ErrUnsupportedOption)
+
+	_, err = repo.FindAll(ctx, NewTestFilter(), WithJoin("JOIN orders ON orders.user_id = users.id"))
+	assert.ErrorIs(t, err, ErrUnsupportedOption)
+
+	_, err = repo.FindAll(ctx, &TestFilter{err: assert.AnError})
+	assert.ErrorIs(t, err, ErrInvalidFilterValue)
+}
+
+func TestSQLRepository_UpdateAndDelete(t *testing.T) {
+	repo := setupSQLRepository(t)
+	ctx := context.Background()
+	require.NoError(t, repo.Create(ctx, createTestEntities()...))
+
+	updated, err := repo.UpdateWithFilter(ctx, NewTestFilter().AgeGte(30), NewTestUpdater().SetIsActive(false).SetAge(40))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated)
+
+	inactive, err := repo.Count(ctx, NewTestFilter().IsActiveEq(false))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), inactive)
+
+	deleted, err := repo.DeleteWithFilter(ctx, NewTestFilter().IsActiveEq(false))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+
+	exists, err := repo.Exists(ctx, NewTestFilter().NameEq("Alice"))
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = repo.Exists(ctx, NewTestFilter().IsActiveEq(false))
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	remaining, err := repo.Count(ctx, NewTestFilter())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), remaining)
+}
+
+func TestSQLRepository_SoftDelete(t *testing.T) {
+	base := setupSQLRepository(t)
+	repo := NewSQLRepository[SoftDeleteEntity, *TestFilter, *TestUpdater](base.GetDB(), DialectSQLite)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx, &SoftDeleteEntity{Name: "kept"}, &SoftDeleteEntity{Name: "trashed"}))
+
+	deleted, err := repo.DeleteWithFilter(ctx, NewTestFilter().NameEq("trashed"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	live, err := repo.FindAll(ctx, NewTestFilter())
+	require.NoError(t, err)
+	require.Len(t, live, 1)
+	assert.Equal(t, "kept", live[0].Name)
+
+	exists, err := repo.Exists(ctx, NewTestFilter().NameEq("trashed"))
+	require.NoError(t, err)
+	assert.False(t, exists, "soft-deleted records don't exist")
+
+	all, err := repo.FindAll(ctx, NewTestFilter(), WithUnscoped(), WithSort("name", Desc))
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.True(t, all[0].DeletedAt.Valid)
+}
+
+func TestSQLRepository_Rebind(t *testing.T) {
+	repo := NewSQLRepository[TestEntity, *TestFilter, *TestUpdater](nil, DialectPostgres)
+	assert.Equal(t, `SELECT * FROM "t" WHERE "a?" = $1 AND b = '?' AND c IN ($2,$3)`,
+		repo.rebind(`SELECT * FROM "t" WHERE "a?" = ? AND b = '?' AND c IN (?,?)`))
+
+	sqlite := NewSQLRepository[TestEntity, *TestFilter, *TestUpdater](nil, DialectSQLite)
+	assert.Equal(t, "a = ?", sqlite.rebind("a = ?"))
+}
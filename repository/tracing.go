@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// Attribute keys set on the spans of a Tracer
+const (
+	AttributeEntity  = "querybuilder.entity"  // Entity type name
+	AttributeFilters = "querybuilder.filters" // Number of filter conditions
+	AttributeRows    = "querybuilder.rows"    // Rows returned or affected, set on success
+)
+
+// Tracer starts a span around each instrumented repository call, named "querybuilder." plus
+// the method, e.g. "querybuilder.FindAll". The repository runs the call with the returned
+// context, so spans of GORM or driver instrumentation nest below it. Set it as RepoConfig.Tracer.
+//
+// The interface keeps OpenTelemetry out of this module's dependencies; adapting a
+// trace.Tracer takes a few lines, see the README.
+type Tracer interface {
+	Start(ctx context.Context, spanName string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer
+type Span interface {
+	// SetAttributes sets attributes with string, int or int64 values
+	SetAttributes(attributes ...SpanAttribute)
+	// End ends the span with the error the call returned, nil on success
+	End(err error)
+}
+
+// SpanAttribute is a key/value pair set on a Span
+type SpanAttribute struct {
+	Key   string
+	Value any
+}
+
+// instrument starts reporting a call to the configured tracer and observer, if any. The call
+// runs with the returned context and, deferred over its named results, passes its error and
+// the rows it returned or affected (-1 when that doesn't apply) to the returned function:
+//
+//	ctx, done := r.instrument(ctx, "FindAll", filter, options)
+//	defer func() { done(err, int64(len(result))) }()
+func (r *GormRepository[Entity, Filter, Updater]) instrument(
+	ctx context.Context,
+	operation string,
+	filter Filter,
+	options []OptionFunc,
+) (context.Context, func(err error, rows int64)) {
+	if r.config.Tracer == nil && r.config.Observer == nil {
+		return ctx, func(error, int64) {}
+	}
+
+	event := QueryEvent{Operation: operation, Options: newOptions(options...)}
+	if !isNilValue(filter) {
+		event.Filters = filter.ListFilters()
+	}
+
+	start := time.Now()
+	var span Span
+	if r.config.Tracer != nil {
+		ctx, span = r.config.Tracer.Start(ctx, "querybuilder."+operation)
+		span.SetAttributes(
+			SpanAttribute{Key: AttributeEntity, Value: reflect.TypeFor[Entity]().Name()},
+			SpanAttribute{Key: AttributeFilters, Value: len(event.Filters)},
+		)
+	}
+
+	return ctx, func(err error, rows int64) {
+		if span != nil {
+			if err == nil && rows >= 0 {
+				span.SetAttributes(SpanAttribute{Key: AttributeRows, Value: rows})
+			}
+			span.End(err)
+		}
+		if r.config.Observer != nil {
+			event.Duration = time.Since(start)
+			event.Err = err
+			r.config.Observer.ObserveQuery(ctx, event)
+		}
+	}
+}
+
+// boolRows is the row count reported for a call that finds at most one row
+func boolRows(found bool) int64 {
+	if found {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type spanContextKey struct{}
+
+// recordedSpan is a Span kept by recordingTracer
+type recordedSpan struct {
+	name       string
+	attributes map[string]any
+	ended      bool
+	err        error
+}
+
+func (s *recordedSpan) SetAttributes(attributes ...SpanAttribute) {
+	for _, attribute := range attributes {
+		s.attributes[attribute.Key] = attribute.Value
+	}
+}
+
+func (s *recordedSpan) End(err error) {
+	s.ended = true
+	s.err = err
+}
+
+type recordingTracer struct {
+	spans []*recordedSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, spanName string) (context.Context, Span) {
+	span := &recordedSpan{name: spanName, attributes: map[string]any{}}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, spanContextKey{}, span), span
+}
+
+func TestGormRepository_Tracer(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	tracer := &recordingTracer{}
+	var observedSpan any
+	observer := QueryObserverFunc(func(ctx context.Context, _ QueryEvent) {
+		observedSpan = ctx.Value(spanContextKey{})
+	})
+	repo := NewGormRepositoryWithConfig[TestEntity, *TestFilter, *TestUpdater](db, RepoConfig{
+		Tracer:   tracer,
+		Observer: observer,
+	})
+	require.NoError(t, repo.Create(ctx, createTestEntities()...))
+	require.Len(t, tracer.spans, 1)
+	assert.Equal(t, "querybuilder.Create", tracer.spans[0].name)
+	assert.Equal(t, int64(4), tracer.spans[0].attributes[AttributeRows])
+	tracer.spans = nil
+
+	_, err := repo.FindAll(ctx, NewTestFilter().AgeGte(25).IsActiveEq(true))
+	require.NoError(t, err)
+	_, found, err := repo.FindOne(ctx, NewTestFilter().NameEq("Nobody"))
+	require.NoError(t, err)
+	require.False(t, found)
+	_, err = repo.Count(ctx, NewTestFilter())
+	require.NoError(t, err)
+	_, err = repo.UpdateWithFilter(ctx, NewTestFilter().IsActiveEq(true), NewTestUpdater().SetEmail("x@example.com"))
+	require.NoError(t, err)
+
+	require.Len(t, tracer.spans, 4)
+	findAll := tracer.spans[0]
+	assert.Equal(t, "querybuilder.FindAll", findAll.name)
+	assert.True(t, findAll.ended)
+	assert.Equal(t, map[string]any{
+		AttributeEntity:  "TestEntity",
+		AttributeFilters: 2,
+		AttributeRows:    int64(3),
+	}, findAll.attributes)
+	assert.Equal(t, int64(0), tracer.spans[1].attributes[AttributeRows])
+	assert.NotContains(t, tracer.spans[2].attributes, AttributeRows, "Count has no rows")
+	assert.Equal(t, "querybuilder.UpdateWithFilter", tracer.spans[3].name)
+	assert.Equal(t, int64(3), tracer.spans[3].attributes[AttributeRows])
+	assert.Same(t, tracer.spans[3], observedSpan, "observers get the span's context")
+
+	t.Run("ends spans with errors", func(t *testing.T) {
+		tracer.spans = nil
+		invalid := NewTestFilter()
+		invalid.err = errors.New("bad value")
+		_, err := repo.FindAll(ctx, invalid)
+		require.Error(t, err)
+		require.Len(t, tracer.spans, 1)
+		assert.True(t, tracer.spans[0].ended)
+		assert.Equal(t, err, tracer.spans[0].err)
+		assert.NotContains(t, tracer.spans[0].attributes, AttributeRows)
+	})
+}
@@ -1,5 +1,12 @@
 package repository
 
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
 type Operator string
 
 // Enum values for Operator
@@ -12,10 +19,24 @@ const (
 	OperatorGreaterThanOrEqual Operator = ">="
 	OperatorLike               Operator = "LIKE"
 	OperatorNotLike            Operator = "NOT_LIKE"
+	OperatorILike              Operator = "ILIKE"
+	OperatorRegex              Operator = "REGEX"
+	OperatorFullText           Operator = "FULLTEXT"
 	OperatorIsNull             Operator = "IS_NULL"
 	OperatorIsNotNull          Operator = "IS_NOT_NULL"
 	OperatorIn                 Operator = "IN"
 	OperatorNotIn              Operator = "NOT_IN"
+	OperatorHStoreHasKey       Operator = "HSTORE_HAS_KEY"
+	OperatorHStoreGet          Operator = "HSTORE_GET"
+	OperatorBetween            Operator = "BETWEEN"
+	OperatorNotBetween         Operator = "NOT_BETWEEN"
+	OperatorJSONHasKey         Operator = "JSON_HAS_KEY"
+	OperatorJSONEqual          Operator = "JSON_EQ"
+	OperatorJSONContains       Operator = "JSON_CONTAINS"
+	OperatorArrayContains      Operator = "ARRAY_CONTAINS"
+	OperatorArrayOverlaps      Operator = "ARRAY_OVERLAPS"
+	OperatorRaw                Operator = "RAW"
+	OperatorRelatedCount       Operator = "RELATED_COUNT"
 )
 
 type Filter struct {
@@ -24,9 +45,79 @@ type Filter struct {
 	Value    interface{}
 }
 
+// KeyValue is the filter value for operators that address a single key
+// inside a composite column, such as a Postgres hstore or a JSON object.
+type KeyValue struct {
+	Key   string
+	Value interface{}
+}
+
+// Range is the filter value for BETWEEN operators; both bounds are inclusive
+type Range struct {
+	Lower interface{}
+	Upper interface{}
+}
+
+// TextSearch is the filter value for OperatorFullText with a Postgres text search configuration
+// such as "english"; a plain string searches with the database default. MySQL ignores Config.
+type TextSearch struct {
+	Config string
+	Query  string
+}
+
+// RawSQL is the filter value for OperatorRaw: a condition passed to the database as written,
+// with Args bound to its placeholders. Raw filters have no Field.
+type RawSQL struct {
+	SQL  string
+	Args []interface{}
+}
+
+// RelatedCount is the filter value for OperatorRelatedCount: it compares the number of rows of
+// Table whose ForeignKey references the filter's Field with Count, e.g. products with at least 3
+// order items. Field must be qualified with the entity's table, such as "products.id", since an
+// unqualified name would refer to Table inside the subquery.
+type RelatedCount struct {
+	Table      string
+	ForeignKey string
+	Operator   Operator // =, !=, <, <=, > or >=
+	Count      int64
+}
+
+// SortDirection is the order of a sort field
+type SortDirection string
+
+const (
+	Asc  SortDirection = "asc"
+	Desc SortDirection = "desc"
+)
+
+// ParseSortDirection parses "asc" or "desc" in any case, e.g. from an API query parameter.
+// An empty string is Asc.
+func ParseSortDirection(s string) (SortDirection, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", string(Asc):
+		return Asc, nil
+	case string(Desc):
+		return Desc, nil
+	}
+	return "", fmt.Errorf("%w: %q, expected asc or desc", ErrInvalidSortDirection, s)
+}
+
 type SortField struct {
 	Field     string
-	Direction string
+	Direction SortDirection
+}
+
+// Preload names an association to eager-load along with the queried records
+type Preload struct {
+	Association string
+	Conditions  []interface{}
+}
+
+// Join is a JOIN clause added to the query, such as "JOIN categories ON categories.id = products.category_id"
+type Join struct {
+	SQL  string
+	Args []interface{}
 }
 
 type OptionFunc interface {
@@ -45,6 +136,40 @@ type Options struct {
 	Limit      *int
 	Offset     *int
 	SortFields []*SortField
+	Preloads   []*Preload
+
+	// Joins are added to the FROM clause; filters may then target joined columns such as "categories.name"
+	Joins []*Join
+
+	// SelectFields restricts the selected columns; empty selects every column
+	SelectFields []string
+
+	// Unscoped includes soft-deleted records
+	Unscoped bool
+
+	// SortStable appends the primary key as the last sort field when it is not sorted on already
+	SortStable bool
+
+	// Lock adds a row-level locking clause such as FOR UPDATE
+	Lock *Lock
+
+	// Distinct removes duplicate rows, selecting only DistinctFields when given.
+	// DistinctOn keeps the first row of each group of equal DistinctOn columns (Postgres).
+	Distinct       bool
+	DistinctFields []string
+	DistinctOn     []string
+
+	// GroupBy and Having group the results; Aggregates are the computed columns FindGrouped selects
+	GroupBy    []string
+	Having     []*Filter
+	Aggregates []*Aggregate
+
+	// Cursor and CursorToken select keyset pagination; CursorToken is only read by FindPage
+	Cursor      *Cursor
+	CursorToken string
+
+	// Timeout bounds the call, overriding RepoConfig.QueryTimeout
+	Timeout time.Duration
 }
 
 func WithLimit(limit int) OptionFunc {
@@ -63,10 +188,182 @@ func WithOffset(offset int) OptionFunc {
 	}
 }
 
+// WithTimeout cancels the call, retries included, when it takes longer than d. It overrides the
+// repository's RepoConfig.QueryTimeout; the caller's context deadline still applies if earlier.
+func WithTimeout(d time.Duration) OptionFunc {
+	return &functionOption{
+		f: func(o *Options) {
+			o.Timeout = d
+		},
+	}
+}
+
+// WithSort orders the results by field in direction dir, after any sort fields already added
+func WithSort(field string, dir SortDirection) OptionFunc {
+	return &functionOption{
+		f: func(o *Options) {
+			o.SortFields = append(o.SortFields, &SortField{Field: field, Direction: dir})
+		},
+	}
+}
+
+// WithSortStable makes the order deterministic by sorting on the primary key after every other
+// sort field, unless it is sorted on already. Without it, rows with equal sort values may come
+// back in any order, which can make offset pages skip or repeat rows.
+func WithSortStable() OptionFunc {
+	return &functionOption{
+		f: func(o *Options) {
+			o.SortStable = true
+		},
+	}
+}
+
+// Lock strengths for WithLock. NO KEY UPDATE and KEY SHARE are Postgres only.
+const (
+	LockUpdate      = "UPDATE"
+	LockShare       = "SHARE"
+	LockNoKeyUpdate = "NO KEY UPDATE"
+	LockKeyShare    = "KEY SHARE"
+)
+
+// Lock is a row-level locking clause: FOR <Strength> [SKIP LOCKED]
+type Lock struct {
+	Strength   string
+	SkipLocked bool
+}
+
+// WithLock locks the selected rows with SELECT ... FOR <strength>, skipping rows locked by other
+// transactions when skipLocked is set. Locks are held until the transaction ends, so use it inside
+// WithTransaction. SQLite has no row-level locking and ignores it.
+func WithLock(strength string, skipLocked bool) OptionFunc {
+	return &functionOption{
+		f: func(o *Options) {
+			o.Lock = &Lock{Strength: strength, SkipLocked: skipLocked}
+		},
+	}
+}
+
+// WithSelect only selects the given columns instead of every column.
+// The primary key is always selected so that the records can still be identified.
+func WithSelect(fields ...string) OptionFunc {
+	return &functionOption{
+		f: func(o *Options) {
+			o.SelectFields = append(o.SelectFields, fields...)
+		},
+	}
+}
+
+// WithDistinct removes duplicate rows with SELECT DISTINCT. Without fields whole rows are
+// compared; with fields only those columns are selected and compared, so leave out WithSelect.
+func WithDistinct(fields ...string) OptionFunc {
+	return &functionOption{
+		f: func(o *Options) {
+			o.Distinct = true
+			o.DistinctFields = append(o.DistinctFields, fields...)
+		},
+	}
+}
+
+// WithDistinctOn keeps the first row of each group of rows with equal fields, using Postgres'
+// DISTINCT ON; other databases return ErrUnsupportedDialect. Postgres requires the ORDER BY to
+// start with the same fields, so sorts that don't return ErrInvalidDistinct, e.g.
+// WithDistinctOn("category_id"), WithSort("category_id", Asc), WithSort("price", Asc) for the
+// cheapest record of each category.
+func WithDistinctOn(fields ...string) OptionFunc {
+	return &functionOption{
+		f: func(o *Options) {
+			o.DistinctOn = append(o.DistinctOn, fields...)
+		},
+	}
+}
+
+// WithPreload eager-loads an association of the queried records, such as "Category" or
+// "Reviews.Author", with one extra query per association rather than one per record. Conditions
+// filter the loaded records like GORM's Preload, e.g. WithPreload("Reviews", "rating >= ?", 4).
+// Limits and offsets apply to the queried records, not to the associations.
+func WithPreload(association string, conditions ...interface{}) OptionFunc {
+	return &functionOption{
+		f: func(o *Options) {
+			o.Preloads = append(o.Preloads, &Preload{Association: association, Conditions: conditions})
+		},
+	}
+}
+
+// WithJoin joins another table into the query, e.g.
+// WithJoin("JOIN categories ON categories.id = products.category_id"), so that filters and
+// sorts can target its columns by qualified name such as "categories.name". Once a join is
+// present, unqualified filter, sort and select fields are qualified with the entity's table.
+// The joined columns are not selected, and a to-many join returns a record once per match.
+func WithJoin(sql string, args ...interface{}) OptionFunc {
+	return &functionOption{
+		f: func(o *Options) {
+			o.Joins = append(o.Joins, &Join{SQL: sql, Args: args})
+		},
+	}
+}
+
+// WithUnscoped includes soft-deleted records, those with a non-NULL gorm.DeletedAt
+func WithUnscoped() OptionFunc {
+	return &functionOption{
+		f: func(o *Options) {
+			o.Unscoped = true
+		},
+	}
+}
+
+// newOptions collects the configured options
+func newOptions(options ...OptionFunc) *Options {
+	opts := &Options{}
+	for _, opt := range options {
+		opt.Apply(opts)
+	}
+	return opts
+}
+
+// BatchUpdater is implemented by repositories that can update every record matching a filter
+type BatchUpdater[Filter EntityFilter, Updater EntityUpdater] interface {
+	UpdateWithFilter(ctx context.Context, filter Filter, updater Updater) (int64, error)
+}
+
+// PrimaryKeyFinder is implemented by repositories that can look records up by primary key
+type PrimaryKeyFinder[Entity any] interface {
+	FindByPrimaryKey(ctx context.Context, keys ...interface{}) (*Entity, bool, error)
+}
+
+// DistinctCounter is implemented by repositories that can count the distinct values of a column
+type DistinctCounter[Filter EntityFilter] interface {
+	CountDistinct(ctx context.Context, filter Filter, field string, options ...OptionFunc) (int64, error)
+}
+
+// OneFinder is implemented by repositories that can look up the first record matching a filter
+type OneFinder[Entity any, Filter EntityFilter] interface {
+	FindOne(ctx context.Context, filter Filter, options ...OptionFunc) (*Entity, bool, error)
+}
+
+// MonthlyCounter is implemented by repositories that can bucket matching rows by month
+type MonthlyCounter[Filter EntityFilter] interface {
+	CountByMonth(ctx context.Context, filter Filter, field string) (map[string]int64, error)
+}
+
+// Aggregator is implemented by repositories that can aggregate a column over matching rows.
+// hasRows is false when no non-NULL values matched, in which case the result is zero.
+type Aggregator[Filter EntityFilter] interface {
+	Sum(ctx context.Context, filter Filter, field string) (float64, bool, error)
+	Avg(ctx context.Context, filter Filter, field string) (float64, bool, error)
+	Min(ctx context.Context, filter Filter, field string) (float64, bool, error)
+	Max(ctx context.Context, filter Filter, field string) (float64, bool, error)
+}
+
 type EntityFilter interface {
 	ListFilters() []*Filter
 }
 
+// FilterErrorer is implemented by filters that collect errors while they are built,
+// such as generated filters parsing string input. Repositories check Err before querying.
+type FilterErrorer interface {
+	Err() error
+}
+
 type EntityUpdater interface {
 	GetChangeSet() map[string]interface{}
 }
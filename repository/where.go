@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// BuildWhere renders filters as a condition for a WHERE clause, without the keyword, joining them
+// with AND. Identifiers are double-quoted and values bound to ? placeholders. Operators whose SQL
+// depends on the database, such as the JSON ones, need BuildWhereForDialect.
+func BuildWhere(filters []*Filter) (string, []interface{}, error) {
+	return BuildWhereForDialect("", filters)
+}
+
+// BuildWhereForDialect is BuildWhere for one of the Dialect* databases, quoting identifiers the
+// way it does. Placeholders are always ?; Postgres drivers need them rebound to $1, $2, ...
+func BuildWhereForDialect(dialect string, filters []*Filter) (string, []interface{}, error) {
+	expressions, err := CompileFilters(dialect, filters, func(name string) string {
+		return quoteIdentifier(dialect, name)
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	conditions := make([]string, len(expressions))
+	var args []interface{}
+	for i, expression := range expressions {
+		// OR binds looser than the AND joining the conditions
+		condition := expression.SQL
+		if strings.Contains(strings.ToUpper(condition), " OR ") {
+			condition = "(" + condition + ")"
+		}
+		conditions[i] = condition
+		args = append(args, expression.Vars...)
+	}
+
+	return strings.Join(conditions, " AND "), args, nil
+}
+
+// CompileFilters turns filters into one SQL expression each, in order, with ? placeholders and the
+// fields quoted by quote. dialect is one of the Dialect* constants, or empty for operators that
+// read the same everywhere. Filters that restrict nothing, such as an empty NOT IN list, are left out.
+func CompileFilters(dialect string, filters []*Filter, quote func(string) string) ([]clause.Expr, error) {
+	expressions := make([]clause.Expr, 0, len(filters))
+	for _, filter := range filters {
+		var quotedField string
+		if filter.Operator != OperatorRaw {
+			if filter.Field == "" {
+				return nil, ErrEmptyFieldName
+			}
+			quotedField = quote(filter.Field)
+		}
+
+		condition, args, err := filterCondition(dialect, quotedField, filter)
+		if err != nil {
+			return nil, err
+		}
+		if condition != "" {
+			expressions = append(expressions, clause.Expr{SQL: condition, Vars: args})
+		}
+	}
+	return expressions, nil
+}
+
+// quoteIdentifier quotes a column name, or each part of a table-qualified one, for the dialect
+func quoteIdentifier(dialect, name string) string {
+	quote := `"`
+	if dialect == DialectMySQL {
+		quote = "`"
+	}
+
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = quote + strings.ReplaceAll(part, quote, quote+quote) + quote
+	}
+	return strings.Join(parts, ".")
+}
+
+// filterCondition returns the SQL condition and bind arguments of a filter on quotedField for the
+// dialect, for use in WHERE or HAVING. An empty condition means the filter restricts nothing.
+func filterCondition(dialect, quotedField string, repositoryFilter *Filter) (string, []interface{}, error) {
+	value := repositoryFilter.Value
+	operator, match, err := ResolveFilter(repositoryFilter)
+	if err != nil {
+		return "", nil, err
+	}
+	switch match {
+	case MatchAll:
+		return "", nil, nil
+	case MatchNone:
+		return "1 = 0", nil, nil
+	}
+
+	switch operator {
+	case OperatorEqual:
+		return quotedField + " = ?", []interface{}{value}, nil
+	case OperatorNotEqual:
+		return quotedField + " != ?", []interface{}{value}, nil
+	case OperatorLessThan:
+		return quotedField + " < ?", []interface{}{value}, nil
+	case OperatorLessThanOrEqual:
+		return quotedField + " <= ?", []interface{}{value}, nil
+	case OperatorGreaterThan:
+		return quotedField + " > ?", []interface{}{value}, nil
+	case OperatorGreaterThanOrEqual:
+		return quotedField + " >= ?", []interface{}{value}, nil
+	case OperatorLike:
+		return quotedField + " LIKE ?" + likeEscapeClause(dialect), []interface{}{value}, nil
+	case OperatorNotLike:
+		return quotedField + " NOT LIKE ?" + likeEscapeClause(dialect), []interface{}{value}, nil
+	case OperatorILike:
+		return iLikeCondition(dialect, quotedField), []interface{}{value}, nil
+	case OperatorRegex:
+		return regexCondition(dialect, quotedField, repositoryFilter.Operator, value)
+	case OperatorFullText:
+		return fullTextCondition(dialect, quotedField, repositoryFilter.Operator, value)
+	case OperatorIsNull:
+		return quotedField + " IS NULL", nil, nil
+	case OperatorIsNotNull:
+		return quotedField + " IS NOT NULL", nil, nil
+	case OperatorIn:
+		placeholders, args := listPlaceholders(value)
+		return quotedField + " IN (" + placeholders + ")", args, nil
+	case OperatorNotIn:
+		placeholders, args := listPlaceholders(value)
+		return quotedField + " NOT IN (" + placeholders + ")", args, nil
+	case OperatorHStoreHasKey:
+		if err := requireDialect(dialect, repositoryFilter.Operator, DialectPostgres); err != nil {
+			return "", nil, err
+		}
+		// exist() is used instead of the ? operator, which would clash with bind placeholders
+		return "exist(" + quotedField + ", ?)", []interface{}{value}, nil
+	case OperatorHStoreGet:
+		if err := requireDialect(dialect, repositoryFilter.Operator, DialectPostgres); err != nil {
+			return "", nil, err
+		}
+		kv, ok := value.(KeyValue)
+		if !ok {
+			return "", nil, fmt.Errorf("%s expects repository.KeyValue, got %T: %w", repositoryFilter.Operator, value, ErrInvalidFilterValue)
+		}
+		return quotedField + " -> ? = ?", []interface{}{kv.Key, kv.Value}, nil
+	case OperatorJSONHasKey:
+		key, ok := value.(string)
+		if !ok {
+			return "", nil, fmt.Errorf("%s expects a string key, got %T: %w", repositoryFilter.Operator, value, ErrInvalidFilterValue)
+		}
+		return jsonHasKeyCondition(dialect, quotedField, key)
+	case OperatorJSONEqual:
+		kv, ok := value.(KeyValue)
+		if !ok {
+			return "", nil, fmt.Errorf("%s expects repository.KeyValue, got %T: %w", repositoryFilter.Operator, value, ErrInvalidFilterValue)
+		}
+		return jsonEqualCondition(dialect, quotedField, kv)
+	case OperatorJSONContains:
+		return jsonContainsCondition(dialect, quotedField, value)
+	case OperatorArrayContains, OperatorArrayOverlaps:
+		if err := requireDialect(dialect, repositoryFilter.Operator, DialectPostgres); err != nil {
+			return "", nil, err
+		}
+		return arrayCondition(repositoryFilter.Operator, quotedField, value)
+	case OperatorBetween, OperatorNotBetween:
+		bounds, ok := value.(Range)
+		if !ok {
+			return "", nil, fmt.Errorf("%s expects repository.Range, got %T: %w", repositoryFilter.Operator, value, ErrInvalidFilterValue)
+		}
+		keyword := " BETWEEN ? AND ?"
+		if repositoryFilter.Operator == OperatorNotBetween {
+			keyword = " NOT BETWEEN ? AND ?"
+		}
+		return quotedField + keyword, []interface{}{bounds.Lower, bounds.Upper}, nil
+	case OperatorRaw:
+		raw, ok := value.(RawSQL)
+		if !ok || strings.TrimSpace(raw.SQL) == "" {
+			return "", nil, fmt.Errorf("%s expects a non-empty repository.RawSQL, got %T: %w", repositoryFilter.Operator, value, ErrInvalidFilterValue)
+		}
+		return raw.SQL, raw.Args, nil
+	case OperatorRelatedCount:
+		return relatedCountCondition(dialect, quotedField, repositoryFilter)
+	default:
+		return registeredCondition(quotedField, repositoryFilter)
+	}
+}
+
+// relatedCountCondition returns a correlated subquery counting the rows that reference quotedField,
+// such as (SELECT COUNT(*) FROM "order_items" WHERE "order_items"."product_id" = "products"."id") >= ?
+func relatedCountCondition(dialect, quotedField string, repositoryFilter *Filter) (string, []interface{}, error) {
+	related, ok := repositoryFilter.Value.(RelatedCount)
+	if !ok {
+		return "", nil, fmt.Errorf("%s expects repository.RelatedCount, got %T: %w", repositoryFilter.Operator, repositoryFilter.Value, ErrInvalidFilterValue)
+	}
+	if related.Table == "" || related.ForeignKey == "" || !strings.Contains(repositoryFilter.Field, ".") {
+		return "", nil, fmt.Errorf("%s on %q needs a table, a foreign key and a table-qualified field: %w",
+			repositoryFilter.Operator, repositoryFilter.Field, ErrInvalidFilterValue)
+	}
+
+	switch related.Operator {
+	case OperatorEqual, OperatorNotEqual, OperatorLessThan, OperatorLessThanOrEqual, OperatorGreaterThan, OperatorGreaterThanOrEqual:
+	default:
+		return "", nil, fmt.Errorf("%w: %q cannot compare a related count", ErrUnknownOperator, related.Operator)
+	}
+
+	table := quoteIdentifier(dialect, related.Table)
+	foreignKey := quoteIdentifier(dialect, related.ForeignKey)
+	condition := fmt.Sprintf("(SELECT COUNT(*) FROM %s WHERE %s.%s = %s) %s ?", table, table, foreignKey, quotedField, related.Operator)
+	return condition, []interface{}{related.Count}, nil
+}
+
+// arrayCondition returns a condition comparing a Postgres array column with the elements of value.
+// A driver.Valuer such as pq.StringArray is bound as one array; other slices are expanded into an
+// ARRAY[...] constructor, since GORM would bind them as a list.
+func arrayCondition(op Operator, quotedField string, value interface{}) (string, []interface{}, error) {
+	operator := " @> "
+	if op == OperatorArrayOverlaps {
+		operator = " && "
+	}
+	if _, ok := value.(driver.Valuer); ok {
+		return quotedField + operator + "?", []interface{}{value}, nil
+	}
+
+	if isEmptyList(value) {
+		if op == OperatorArrayContains {
+			// Every array contains the empty array
+			return "", nil, nil
+		}
+		// Nothing overlaps the empty array
+		return "1 = 0", nil, nil
+	}
+	placeholders, args := listPlaceholders(value)
+	return quotedField + operator + "ARRAY[" + placeholders + "]", args, nil
+}
+
+// listPlaceholders returns one placeholder per element of an IN/NOT IN list and the elements
+// as arguments
+func listPlaceholders(value interface{}) (string, []interface{}) {
+	v := reflect.ValueOf(value)
+	args := make([]interface{}, v.Len())
+	for i := range args {
+		args[i] = v.Index(i).Interface()
+	}
+	return strings.TrimSuffix(strings.Repeat("?,", len(args)), ","), args
+}
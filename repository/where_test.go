@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildWhere(t *testing.T) {
+	condition, args, err := BuildWhere([]*Filter{
+		{Field: "name", Operator: OperatorEqual, Value: "Alice"},
+		{Field: "age", Operator: OperatorIn, Value: []int{25, 30}},
+		{Field: "deleted_at", Operator: OperatorEqual, Value: nil},
+		{Field: "tags", Operator: OperatorNotIn, Value: []string{}},
+		{Field: "users.email", Operator: OperatorILike, Value: "%@example.com"},
+		{Operator: OperatorRaw, Value: RawSQL{SQL: "age > ? OR is_active", Args: []interface{}{18}}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, `"name" = ? AND "age" IN (?,?) AND "deleted_at" IS NULL AND LOWER("users"."email") LIKE LOWER(?) AND (age > ? OR is_active)`, condition)
+	assert.Equal(t, []interface{}{"Alice", 25, 30, "%@example.com", 18}, args)
+}
+
+func TestBuildWhereForDialect(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{DialectPostgres, `"name" ILIKE ?`},
+		{DialectMySQL, "LOWER(`name`) LIKE LOWER(?)"},
+		{DialectSQLite, `LOWER("name") LIKE LOWER(?) ESCAPE '\'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			condition, args, err := BuildWhereForDialect(tt.dialect, []*Filter{{Field: "name", Operator: OperatorILike, Value: "%a%"}})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, condition)
+			assert.Equal(t, []interface{}{"%a%"}, args)
+		})
+	}
+}
+
+func TestBuildWhere_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   *Filter
+		expected error
+	}{
+		{"empty field", &Filter{Operator: OperatorEqual, Value: 1}, ErrEmptyFieldName},
+		{"unknown operator", &Filter{Field: "age", Operator: "~~", Value: 1}, ErrUnknownOperator},
+		{"dialect-specific operator", &Filter{Field: "attrs", Operator: OperatorJSONHasKey, Value: "color"}, ErrUnsupportedDialect},
+		{"wrong value shape", &Filter{Field: "age", Operator: OperatorBetween, Value: 1}, ErrInvalidFilterValue},
+		{"unqualified related count field", &Filter{Field: "id", Operator: OperatorRelatedCount,
+			Value: RelatedCount{Table: "order_items", ForeignKey: "product_id", Operator: OperatorGreaterThan}}, ErrInvalidFilterValue},
+		{"related count comparison", &Filter{Field: "products.id", Operator: OperatorRelatedCount,
+			Value: RelatedCount{Table: "order_items", ForeignKey: "product_id", Operator: OperatorLike}}, ErrUnknownOperator},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := BuildWhere([]*Filter{tt.filter})
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestCompileFilters_Operators(t *testing.T) {
+	quote := func(name string) string { return "[" + name + "]" }
+
+	tests := []struct {
+		name     string
+		dialect  string
+		filter   *Filter
+		expected string
+		vars     []interface{}
+	}{
+		{"equal", "", &Filter{Field: "f", Operator: OperatorEqual, Value: 1}, "[f] = ?", []interface{}{1}},
+		{"equal nil", "", &Filter{Field: "f", Operator: OperatorEqual, Value: nil}, "[f] IS NULL", nil},
+		{"not equal", "", &Filter{Field: "f", Operator: OperatorNotEqual, Value: 1}, "[f] != ?", []interface{}{1}},
+		{"not equal nil", "", &Filter{Field: "f", Operator: OperatorNotEqual, Value: (*int)(nil)}, "[f] IS NOT NULL", nil},
+		{"less than", "", &Filter{Field: "f", Operator: OperatorLessThan, Value: 1}, "[f] < ?", []interface{}{1}},
+		{"less than or equal", "", &Filter{Field: "f", Operator: OperatorLessThanOrEqual, Value: 1}, "[f] <= ?", []interface{}{1}},
+		{"greater than", "", &Filter{Field: "f", Operator: OperatorGreaterThan, Value: 1}, "[f] > ?", []interface{}{1}},
+		{"greater than or equal", "", &Filter{Field: "f", Operator: OperatorGreaterThanOrEqual, Value: 1}, "[f] >= ?", []interface{}{1}},
+		{"like", DialectPostgres, &Filter{Field: "f", Operator: OperatorLike, Value: "a%"}, "[f] LIKE ?", []interface{}{"a%"}},
+		{"like sqlite", DialectSQLite, &Filter{Field: "f", Operator: OperatorLike, Value: "a%"}, `[f] LIKE ? ESCAPE '\'`, []interface{}{"a%"}},
+		{"not like", DialectMySQL, &Filter{Field: "f", Operator: OperatorNotLike, Value: "a%"}, "[f] NOT LIKE ?", []interface{}{"a%"}},
+		{"ilike", DialectPostgres, &Filter{Field: "f", Operator: OperatorILike, Value: "a%"}, "[f] ILIKE ?", []interface{}{"a%"}},
+		{"is null", "", &Filter{Field: "f", Operator: OperatorIsNull}, "[f] IS NULL", nil},
+		{"is not null", "", &Filter{Field: "f", Operator: OperatorIsNotNull}, "[f] IS NOT NULL", nil},
+		{"in", "", &Filter{Field: "f", Operator: OperatorIn, Value: []string{"a", "b"}}, "[f] IN (?,?)", []interface{}{"a", "b"}},
+		{"in empty", "", &Filter{Field: "f", Operator: OperatorIn, Value: []string{}}, "1 = 0", nil},
+		{"not in", "", &Filter{Field: "f", Operator: OperatorNotIn, Value: []int{1}}, "[f] NOT IN (?)", []interface{}{1}},
+		{"in uint8", "", &Filter{Field: "f", Operator: OperatorIn, Value: []uint8{1, 255}}, "[f] IN (?,?)", []interface{}{uint8(1), uint8(255)}},
+		{"in uint64", "", &Filter{Field: "f", Operator: OperatorIn, Value: []uint64{math.MaxUint64}}, "[f] IN (?)", []interface{}{uint64(math.MaxUint64)}},
+		{"hstore has key", DialectPostgres, &Filter{Field: "f", Operator: OperatorHStoreHasKey, Value: "k"}, "exist([f], ?)", []interface{}{"k"}},
+		{"hstore get", DialectPostgres, &Filter{Field: "f", Operator: OperatorHStoreGet, Value: KeyValue{Key: "k", Value: "v"}}, "[f] -> ? = ?", []interface{}{"k", "v"}},
+		{"json has key", DialectSQLite, &Filter{Field: "f", Operator: OperatorJSONHasKey, Value: "k"}, "json_type([f], ?) IS NOT NULL", []interface{}{`$."k"`}},
+		{"json equal", DialectPostgres, &Filter{Field: "f", Operator: OperatorJSONEqual, Value: KeyValue{Key: "k", Value: 1}}, "CAST([f] -> ? AS jsonb) = CAST(? AS jsonb)", []interface{}{"k", "1"}},
+		{"json contains", DialectMySQL, &Filter{Field: "f", Operator: OperatorJSONContains, Value: "x"}, "JSON_CONTAINS([f], ?)", []interface{}{`"x"`}},
+		{"array contains", DialectPostgres, &Filter{Field: "f", Operator: OperatorArrayContains, Value: []string{"a"}}, "[f] @> ARRAY[?]", []interface{}{"a"}},
+		{"array contains valuer", DialectPostgres, &Filter{Field: "f", Operator: OperatorArrayContains, Value: textArray{"a"}}, "[f] @> ?", []interface{}{textArray{"a"}}},
+		{"array overlaps", DialectPostgres, &Filter{Field: "f", Operator: OperatorArrayOverlaps, Value: []int64{1, 2}}, "[f] && ARRAY[?,?]", []interface{}{int64(1), int64(2)}},
+		{"array overlaps empty", DialectPostgres, &Filter{Field: "f", Operator: OperatorArrayOverlaps, Value: []int64{}}, "1 = 0", nil},
+		{"between", "", &Filter{Field: "f", Operator: OperatorBetween, Value: Range{Lower: 1, Upper: 2}}, "[f] BETWEEN ? AND ?", []interface{}{1, 2}},
+		{"not between", "", &Filter{Field: "f", Operator: OperatorNotBetween, Value: Range{Lower: 1, Upper: 2}}, "[f] NOT BETWEEN ? AND ?", []interface{}{1, 2}},
+		{"raw", "", &Filter{Operator: OperatorRaw, Value: RawSQL{SQL: "a = ? OR b", Args: []interface{}{1}}}, "a = ? OR b", []interface{}{1}},
+		{"related count", DialectMySQL, &Filter{Field: "p.id", Operator: OperatorRelatedCount, Value: RelatedCount{Table: "items", ForeignKey: "p_id", Operator: OperatorGreaterThanOrEqual, Count: 3}},
+			"(SELECT COUNT(*) FROM `items` WHERE `items`.`p_id` = [p.id]) >= ?", []interface{}{int64(3)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expressions, err := CompileFilters(tt.dialect, []*Filter{tt.filter}, quote)
+			require.NoError(t, err)
+			require.Len(t, expressions, 1)
+			assert.Equal(t, tt.expected, expressions[0].SQL)
+			assert.Equal(t, tt.vars, expressions[0].Vars)
+		})
+	}
+}
+
+// textArray stands in for a driver.Valuer array type such as pq.StringArray
+type textArray []string
+
+func (a textArray) Value() (driver.Value, error) {
+	return "{" + strings.Join(a, ",") + "}", nil
+}
+
+func TestCompileFilters_SkipsEmptyNotIn(t *testing.T) {
+	expressions, err := CompileFilters("", []*Filter{
+		{Field: "a", Operator: OperatorNotIn, Value: []int{}},
+		{Field: "b", Operator: OperatorEqual, Value: 1},
+	}, func(name string) string { return name })
+
+	require.NoError(t, err)
+	require.Len(t, expressions, 1)
+	assert.Equal(t, "b = ?", expressions[0].SQL)
+}
+
+func TestCompileFilters_ValueValidation(t *testing.T) {
+	type status string
+
+	tests := []struct {
+		name     string
+		filter   *Filter
+		expected error
+	}{
+		{"IN with a scalar", &Filter{Field: "f", Operator: OperatorIn, Value: 5}, ErrFilterValueNotList},
+		{"NOT IN with a string", &Filter{Field: "f", Operator: OperatorNotIn, Value: "a,b"}, ErrFilterValueNotList},
+		{"IS NULL with a value", &Filter{Field: "f", Operator: OperatorIsNull, Value: 0}, ErrFilterValueNotNil},
+		{"IS NOT NULL with a value", &Filter{Field: "f", Operator: OperatorIsNotNull, Value: false}, ErrFilterValueNotNil},
+		{"LIKE with a number", &Filter{Field: "f", Operator: OperatorLike, Value: 5}, ErrFilterValueNotString},
+		{"ILIKE without a value", &Filter{Field: "f", Operator: OperatorILike}, ErrFilterValueNotString},
+		{"array contains with a scalar", &Filter{Field: "f", Operator: OperatorArrayContains, Value: "a"}, ErrFilterValueNotList},
+		{"array overlaps without a value", &Filter{Field: "f", Operator: OperatorArrayOverlaps}, ErrFilterValueNotList},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CompileFilters("", []*Filter{tt.filter}, func(name string) string { return name })
+			assert.ErrorIs(t, err, tt.expected)
+			assert.ErrorIs(t, err, ErrInvalidFilterValue)
+		})
+	}
+
+	valid := []*Filter{
+		{Field: "f", Operator: OperatorIn, Value: nil},
+		{Field: "f", Operator: OperatorIn, Value: [2]int{1, 2}},
+		{Field: "f", Operator: OperatorIsNull, Value: (*int)(nil)},
+		{Field: "f", Operator: OperatorNotLike, Value: status("a%")},
+	}
+	_, err := CompileFilters("", valid, func(name string) string { return name })
+	assert.NoError(t, err)
+}
@@ -6,15 +6,18 @@ import (
 
 // QueryBuilderTemplates contains all code generation templates
 type QueryBuilderTemplates struct {
-	Main *template.Template
+	Main   *template.Template
+	Facade *template.Template
 }
 
 // NewQueryBuilderTemplates creates a new template set
 func NewQueryBuilderTemplates() *QueryBuilderTemplates {
 	main := template.Must(template.New("querybuilder").Parse(mainTemplate))
+	facade := template.Must(template.New("facade").Parse(facadeTemplate))
 
 	return &QueryBuilderTemplates{
-		Main: main,
+		Main:   main,
+		Facade: facade,
 	}
 }
 
@@ -26,9 +29,86 @@ const mainTemplate = `
 {{- $optionsTypeName := printf "%sOptions" .Name }}
 {{- $schemaTypeName := printf "%sDBSchemaField" .Name }}
 
-// {{ $filterTypeName }} provides filtering capabilities for {{ .Name }}
+{{- if eq $.FilterStorage "slice" }}
+
+// {{ $filterTypeName }} provides filtering capabilities for {{ .Name }}.
+// Filter methods modify it, so build it on one goroutine; a built filter can then be read
+// concurrently, e.g. by ListFilters. Derive variants from a shared filter with Clone.
+{{- with .Source }}
+// generated from {{ . }}
+{{- end }}
+type {{ $filterTypeName }} struct {
+	filters []*repository.Filter
+{{- if $.StringFilters }}
+	errs    []error
+{{- end }}
+}
+
+// New{{ $filterTypeName }} creates a new filter instance
+func New{{ $filterTypeName }}() *{{ $filterTypeName }} {
+	return &{{ $filterTypeName }}{}
+}
+
+// ListFilters returns all configured filters in the order they were added.
+// The result is clipped, so appending to it never writes into f.
+func (f *{{ $filterTypeName }}) ListFilters() []*repository.Filter {
+	return slices.Clip(f.filters)
+}
+
+// addFilter appends a filter
+func (f *{{ $filterTypeName }}) addFilter(_ {{ $schemaTypeName }}, filter *repository.Filter) *{{ $filterTypeName }} {
+	f.filters = append(f.filters, filter)
+	return f
+}
+
+// clearField removes the filters on field
+func (f *{{ $filterTypeName }}) clearField(field {{ $schemaTypeName }}) *{{ $filterTypeName }} {
+	var kept []*repository.Filter
+	for _, filter := range f.filters {
+		if filter.Field != string(field) {
+			kept = append(kept, filter)
+		}
+	}
+	f.filters = kept
+	return f
+}
+
+// Clone returns a copy of the filters that can be extended without changing f
+func (f *{{ $filterTypeName }}) Clone() *{{ $filterTypeName }} {
+	return &{{ $filterTypeName }}{
+		filters: append([]*repository.Filter(nil), f.filters...),
+{{- if $.StringFilters }}
+		errs:    append([]error(nil), f.errs...),
+{{- end }}
+	}
+}
+
+// Merge appends the filters of other after those of f, e.g. request filters to a shared scope,
+// and returns f. other is not changed; merge into f.Clone() to keep f as it is.
+func (f *{{ $filterTypeName }}) Merge(other *{{ $filterTypeName }}) *{{ $filterTypeName }} {
+	if other == nil {
+		return f
+	}
+	f.filters = append(f.filters, other.filters...)
+{{- if $.StringFilters }}
+	f.errs = append(f.errs, other.errs...)
+{{- end }}
+	return f
+}
+{{- else }}
+
+// {{ $filterTypeName }} provides filtering capabilities for {{ .Name }}.
+// Filter methods modify it, so build it on one goroutine; a built filter can then be read
+// concurrently, e.g. by ListFilters. Derive variants from a shared filter with Clone.
+{{- with .Source }}
+// generated from {{ . }}
+{{- end }}
 type {{ $filterTypeName }} struct {
 	filters map[{{ $schemaTypeName }}][]*repository.Filter
+	order   []*repository.Filter // every filter in call order
+{{- if $.StringFilters }}
+	errs    []error
+{{- end }}
 }
 
 // New{{ $filterTypeName }} creates a new filter instance
@@ -38,15 +118,67 @@ func New{{ $filterTypeName }}() *{{ $filterTypeName }} {
 	}
 }
 
-// ListFilters returns all configured filters
+// ListFilters returns all configured filters in the order they were added.
+// The result is clipped, so appending to it never writes into f.
 func (f *{{ $filterTypeName }}) ListFilters() []*repository.Filter {
-	var result []*repository.Filter
-	for _, filterList := range f.filters {
-		result = append(result, filterList...)
-	} 
-	return result
+	return slices.Clip(f.order)
 }
 
+// addFilter appends a filter, indexing it by field
+func (f *{{ $filterTypeName }}) addFilter(field {{ $schemaTypeName }}, filter *repository.Filter) *{{ $filterTypeName }} {
+	f.filters[field] = append(f.filters[field], filter)
+	f.order = append(f.order, filter)
+	return f
+}
+
+// clearField removes the filters on field, keeping the others in order
+func (f *{{ $filterTypeName }}) clearField(field {{ $schemaTypeName }}) *{{ $filterTypeName }} {
+	if _, exists := f.filters[field]; !exists {
+		return f
+	}
+	delete(f.filters, field)
+	var kept []*repository.Filter
+	for _, filter := range f.order {
+		if filter.Field != string(field) {
+			kept = append(kept, filter)
+		}
+	}
+	f.order = kept
+	return f
+}
+
+// Clone returns a copy of the filters that can be extended without changing f
+func (f *{{ $filterTypeName }}) Clone() *{{ $filterTypeName }} {
+	clone := &{{ $filterTypeName }}{
+		filters: make(map[{{ $schemaTypeName }}][]*repository.Filter, len(f.filters)),
+		order:   append([]*repository.Filter(nil), f.order...),
+{{- if $.StringFilters }}
+		errs:    append([]error(nil), f.errs...),
+{{- end }}
+	}
+	for field, filters := range f.filters {
+		clone.filters[field] = append([]*repository.Filter(nil), filters...)
+	}
+	return clone
+}
+
+// Merge appends the filters of other after those of f, in other's call order, e.g. request
+// filters to a shared scope, and returns f. other is not changed; merge into f.Clone() to keep
+// f as it is.
+func (f *{{ $filterTypeName }}) Merge(other *{{ $filterTypeName }}) *{{ $filterTypeName }} {
+	if other == nil {
+		return f
+	}
+	for _, filter := range other.order {
+		f.addFilter({{ $schemaTypeName }}(filter.Field), filter)
+	}
+{{- if $.StringFilters }}
+	f.errs = append(f.errs, other.errs...)
+{{- end }}
+	return f
+}
+{{- end }}
+
 {{- range .FilterMethods }}
 
 // {{ .Documentation }}
@@ -55,7 +187,56 @@ func ({{ .Receiver }}) {{ .Name }}({{ .Parameters }}) {{ .ReturnType }} {
 }
 {{- end }}
 
+// WhereRaw adds a raw SQL condition for cases the typed methods don't cover, such as subqueries.
+// Pass values as args bound to ? placeholders; never format them into condition.
+func (f *{{ $filterTypeName }}) WhereRaw(condition string, args ...interface{}) *{{ $filterTypeName }} {
+	return f.addFilter("", &repository.Filter{
+		Operator: repository.OperatorRaw,
+		Value:    repository.RawSQL{SQL: condition, Args: args},
+	})
+}
+
+// WhereColumn filters on a column outside the generated fields, such as "categories.name" of a
+// table joined with repository.WithJoin. The column is quoted, not passed through as SQL.
+func (f *{{ $filterTypeName }}) WhereColumn(column string, operator repository.Operator, value interface{}) *{{ $filterTypeName }} {
+	return f.addFilter({{ $schemaTypeName }}(column), &repository.Filter{
+		Field:    column,
+		Operator: operator,
+		Value:    value,
+	})
+}
+
+// DebugSQL returns the WHERE condition of the filters, without the keyword, and its arguments.
+// Identifiers are double-quoted; GormRepository.ExplainFilter renders the full query of a database.
+func (f *{{ $filterTypeName }}) DebugSQL() (string, []interface{}, error) {
+{{- if $.StringFilters }}
+	if err := f.Err(); err != nil {
+		return "", nil, err
+	}
+{{- end }}
+	return repository.BuildWhere(f.ListFilters())
+}
+
+{{- if $.StringFilters }}
+
+// Err returns the errors collected while parsing string filter values
+func (f *{{ $filterTypeName }}) Err() error {
+	return errors.Join(f.errs...)
+}
+
+{{- range .StringFilterMethods }}
+
+// {{ .Documentation }}
+func ({{ .Receiver }}) {{ .Name }}({{ .Parameters }}) {{ .ReturnType }} {
+	{{ .Body }}
+}
+{{- end }}
+{{- end }}
+
 // {{ $updaterTypeName }} provides update capabilities for {{ .Name }}
+{{- with .Source }}
+// generated from {{ . }}
+{{- end }}
 type {{ $updaterTypeName }} struct {
 	fields map[string]interface{}
 }
@@ -72,6 +253,15 @@ func (u *{{ $updaterTypeName }}) GetChangeSet() map[string]interface{} {
 	return u.fields
 }
 
+// Clone returns a copy of the updater that can be changed without changing u
+func (u *{{ $updaterTypeName }}) Clone() *{{ $updaterTypeName }} {
+	clone := New{{ $updaterTypeName }}()
+	for field, value := range u.fields {
+		clone.fields[field] = value
+	}
+	return clone
+}
+
 {{- range .UpdaterMethods }}
 
 // {{ .Documentation }}
@@ -80,7 +270,18 @@ func ({{ .Receiver }}) {{ .Name }}({{ .Parameters }}) {{ .ReturnType }} {
 }
 {{- end }}
 
+{{- with .ValidateMethod }}
+
+// {{ .Documentation }}
+func ({{ .Receiver }}) {{ .Name }}() {{ .ReturnType }} {
+	{{ .Body }}
+}
+{{- end }}
+
 // {{ $optionsTypeName }} provides query options for {{ .Name }}
+{{- with .Source }}
+// generated from {{ . }}
+{{- end }}
 type {{ $optionsTypeName }} struct {
 	options []func(*repository.Options)
 }
@@ -97,6 +298,72 @@ func (o *{{ $optionsTypeName }}) Apply(repoOpts *repository.Options) {
 	}
 }
 
+// Select only queries the given fields; the primary key is always selected
+func (o *{{ $optionsTypeName }}) Select(fields ...{{ $schemaTypeName }}) *{{ $optionsTypeName }} {
+	o.options = append(o.options, func(options *repository.Options) {
+		for _, field := range fields {
+			options.SelectFields = append(options.SelectFields, string(field))
+		}
+	})
+	return o
+}
+
+// Distinct removes duplicate rows; with fields only those are selected and compared
+func (o *{{ $optionsTypeName }}) Distinct(fields ...{{ $schemaTypeName }}) *{{ $optionsTypeName }} {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.Distinct = true
+		for _, field := range fields {
+			options.DistinctFields = append(options.DistinctFields, string(field))
+		}
+	})
+	return o
+}
+
+// DistinctOn keeps the first row of each group of equal fields (Postgres); order by them first
+func (o *{{ $optionsTypeName }}) DistinctOn(fields ...{{ $schemaTypeName }}) *{{ $optionsTypeName }} {
+	o.options = append(o.options, func(options *repository.Options) {
+		for _, field := range fields {
+			options.DistinctOn = append(options.DistinctOn, string(field))
+		}
+	})
+	return o
+}
+
+// OrderBy orders results by field in direction dir, e.g. when both come from API parameters
+func (o *{{ $optionsTypeName }}) OrderBy(field {{ $schemaTypeName }}, dir repository.SortDirection) *{{ $optionsTypeName }} {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.SortFields = append(options.SortFields, &repository.SortField{
+			Field:     string(field),
+			Direction: dir,
+		})
+	})
+	return o
+}
+
+// Paginate limits results to the 1-based page of pageSize rows; {{ .Name }}FindPage reports the page metadata
+func (o *{{ $optionsTypeName }}) Paginate(page, pageSize int) *{{ $optionsTypeName }} {
+	o.options = append(o.options, func(options *repository.Options) {
+		repository.WithPage(page, pageSize).Apply(options)
+	})
+	return o
+}
+
+// ForUpdate locks the selected rows until the transaction ends (SELECT ... FOR UPDATE); use it inside WithTransaction
+func (o *{{ $optionsTypeName }}) ForUpdate() *{{ $optionsTypeName }} {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.Lock = &repository.Lock{Strength: repository.LockUpdate}
+	})
+	return o
+}
+
+// ForUpdateSkipLocked locks the selected rows like ForUpdate, skipping rows other transactions have locked
+func (o *{{ $optionsTypeName }}) ForUpdateSkipLocked() *{{ $optionsTypeName }} {
+	o.options = append(o.options, func(options *repository.Options) {
+		options.Lock = &repository.Lock{Strength: repository.LockUpdate, SkipLocked: true}
+	})
+	return o
+}
+
 {{- range .OrderMethods }}
 
 // {{ .Documentation }}  
@@ -105,7 +372,104 @@ func ({{ .Receiver }}) {{ .Name }}({{ .Parameters }}) {{ .ReturnType }} {
 }
 {{- end }}
 
+{{- range .GroupByMethods }}
+
+// {{ .Documentation }}
+func ({{ .Receiver }}) {{ .Name }}({{ .Parameters }}) {{ .ReturnType }} {
+	{{ .Body }}
+}
+{{- end }}
+
+{{- range .PreloadMethods }}
+
+// {{ .Documentation }}
+func ({{ .Receiver }}) {{ .Name }}({{ .Parameters }}) {{ .ReturnType }} {
+	{{ .Body }}
+}
+{{- end }}
+
+// {{ .Name }}UpdateWhere applies the changes configured by set to every {{ .Name }} row matching the filters configured by where
+func {{ .Name }}UpdateWhere(ctx context.Context, repo repository.BatchUpdater[*{{ $filterTypeName }}, *{{ $updaterTypeName }}], where func(*{{ $filterTypeName }}), set func(*{{ $updaterTypeName }})) (int64, error) {
+	filters := New{{ $filterTypeName }}()
+	if where != nil {
+		where(filters)
+	}
+	updater := New{{ $updaterTypeName }}()
+	if set != nil {
+		set(updater)
+	}
+	return repo.UpdateWithFilter(ctx, filters, updater)
+}
+
+// {{ .Name }}CountDistinct counts the distinct non-NULL values of field among the {{ .Name }} rows matching filter
+func {{ .Name }}CountDistinct(ctx context.Context, repo repository.DistinctCounter[*{{ $filterTypeName }}], field {{ $schemaTypeName }}, filter *{{ $filterTypeName }}) (int64, error) {
+	return repo.CountDistinct(ctx, filter, string(field))
+}
+
+// {{ .Name }}Page is one page of {{ .Name }} rows with the total count and page metadata
+type {{ .Name }}Page = repository.Page[{{ .EntityType }}]
+
+// {{ .Name }}FindPage returns the page of {{ .Name }} rows matching filter selected by Paginate, with the total count
+func {{ .Name }}FindPage(ctx context.Context, repo repository.PageCounter[{{ .EntityType }}, *{{ $filterTypeName }}], filter *{{ $filterTypeName }}, options ...repository.OptionFunc) (*{{ .Name }}Page, error) {
+	return repository.FindNumberedPage(ctx, repo, filter, options...)
+}
+
+{{- with .FindByPrimaryKey }}
+
+// {{ .Documentation }}
+func {{ .Name }}({{ .Parameters }}) {{ .ReturnType }} {
+	{{ .Body }}
+}
+{{- end }}
+
+{{- range .FindByUniqueIndex }}
+
+// {{ .Documentation }}
+func {{ .Name }}({{ .Parameters }}) {{ .ReturnType }} {
+	{{ .Body }}
+}
+{{- end }}
+
+{{- if .TimeFields }}
+
+// {{ .Name }}CountByMonth counts {{ .Name }} rows matching filter grouped by the "YYYY-MM" month of field.
+// field should be a time column: {{ range $i, $f := .TimeFields }}{{ if $i }}, {{ end }}{{ $structName }}DBSchema.{{ $f.Name }}{{ end }}
+func {{ .Name }}CountByMonth(ctx context.Context, repo repository.MonthlyCounter[*{{ $filterTypeName }}], field {{ $schemaTypeName }}, filter *{{ $filterTypeName }}) (map[string]int64, error) {
+	return repo.CountByMonth(ctx, filter, string(field))
+}
+{{- end }}
+
+{{- if .NumericFields }}
+
+// {{ .Name }}Sum returns the sum of field over the {{ .Name }} rows matching filter; hasRows is false when no non-NULL values match.
+// field should be a numeric column: {{ range $i, $f := .NumericFields }}{{ if $i }}, {{ end }}{{ $structName }}DBSchema.{{ $f.Name }}{{ end }}
+func {{ .Name }}Sum(ctx context.Context, repo repository.Aggregator[*{{ $filterTypeName }}], field {{ $schemaTypeName }}, filter *{{ $filterTypeName }}) (float64, bool, error) {
+	return repo.Sum(ctx, filter, string(field))
+}
+
+// {{ .Name }}Avg returns the average of field over the {{ .Name }} rows matching filter; hasRows is false when no non-NULL values match.
+// field should be a numeric column: {{ range $i, $f := .NumericFields }}{{ if $i }}, {{ end }}{{ $structName }}DBSchema.{{ $f.Name }}{{ end }}
+func {{ .Name }}Avg(ctx context.Context, repo repository.Aggregator[*{{ $filterTypeName }}], field {{ $schemaTypeName }}, filter *{{ $filterTypeName }}) (float64, bool, error) {
+	return repo.Avg(ctx, filter, string(field))
+}
+
+// {{ .Name }}Min returns the smallest value of field over the {{ .Name }} rows matching filter; hasRows is false when no non-NULL values match.
+// field should be a numeric column: {{ range $i, $f := .NumericFields }}{{ if $i }}, {{ end }}{{ $structName }}DBSchema.{{ $f.Name }}{{ end }}
+func {{ .Name }}Min(ctx context.Context, repo repository.Aggregator[*{{ $filterTypeName }}], field {{ $schemaTypeName }}, filter *{{ $filterTypeName }}) (float64, bool, error) {
+	return repo.Min(ctx, filter, string(field))
+}
+
+// {{ .Name }}Max returns the largest value of field over the {{ .Name }} rows matching filter; hasRows is false when no non-NULL values match.
+// field should be a numeric column: {{ range $i, $f := .NumericFields }}{{ if $i }}, {{ end }}{{ $structName }}DBSchema.{{ $f.Name }}{{ end }}
+func {{ .Name }}Max(ctx context.Context, repo repository.Aggregator[*{{ $filterTypeName }}], field {{ $schemaTypeName }}, filter *{{ $filterTypeName }}) (float64, bool, error) {
+	return repo.Max(ctx, filter, string(field))
+}
+{{- end }}
+
 // {{ $schemaTypeName }} represents database field names
+{{- with .Source }}
+// generated from {{ . }}
+{{- end }}
 type {{ $schemaTypeName }} string
 
 // String returns the string representation of the field
@@ -124,5 +488,113 @@ var {{ .Name }}DBSchema = struct {
 {{- end }}
 }
 
+// {{ .Name }}ColumnToField maps the database columns of {{ .Name }} to their struct fields, for
+// repositories that read and write entities through reflection
+var {{ .Name }}ColumnToField = map[string]string{
+{{- range .Fields }}
+	"{{ .DBName }}": "{{ .Name }}",
+{{- end }}
+}
+
+// {{ .Name }}ColumnTypes maps the database columns of {{ .Name }} to the Go types of their struct fields
+var {{ .Name }}ColumnTypes = map[string]string{
+{{- range .Fields }}
+	"{{ .DBName }}": {{ printf "%q" .GoType }},
+{{- end }}
+}
+
+{{- end }}
+`
+
+// facadeTemplate re-exports the generated types of another package through aliases,
+// so the implementation can live in an internal package
+const facadeTemplate = `
+{{- range .Structs }}
+{{- $filterTypeName := printf "%sFilters" .Name }}
+{{- $updaterTypeName := printf "%sUpdater" .Name }}
+{{- $optionsTypeName := printf "%sOptions" .Name }}
+{{- $schemaTypeName := printf "%sDBSchemaField" .Name }}
+
+// {{ $filterTypeName }} provides filtering capabilities for {{ .Name }}
+type {{ $filterTypeName }} = internal.{{ $filterTypeName }}
+
+// {{ $updaterTypeName }} provides update capabilities for {{ .Name }}
+type {{ $updaterTypeName }} = internal.{{ $updaterTypeName }}
+
+// {{ $optionsTypeName }} provides query options for {{ .Name }}
+type {{ $optionsTypeName }} = internal.{{ $optionsTypeName }}
+
+// {{ $schemaTypeName }} represents database field names
+type {{ $schemaTypeName }} = internal.{{ $schemaTypeName }}
+
+// New{{ $filterTypeName }} creates a new filter instance
+func New{{ $filterTypeName }}() *{{ $filterTypeName }} {
+	return internal.New{{ $filterTypeName }}()
+}
+
+// New{{ $updaterTypeName }} creates a new updater instance
+func New{{ $updaterTypeName }}() *{{ $updaterTypeName }} {
+	return internal.New{{ $updaterTypeName }}()
+}
+
+// New{{ $optionsTypeName }} creates a new options instance
+func New{{ $optionsTypeName }}() *{{ $optionsTypeName }} {
+	return internal.New{{ $optionsTypeName }}()
+}
+
+// {{ .Name }}DBSchema contains database field mappings for {{ .Name }}
+var {{ .Name }}DBSchema = internal.{{ .Name }}DBSchema
+
+// {{ .Name }}ColumnToField maps the database columns of {{ .Name }} to their struct fields
+var {{ .Name }}ColumnToField = internal.{{ .Name }}ColumnToField
+
+// {{ .Name }}ColumnTypes maps the database columns of {{ .Name }} to the Go types of their struct fields
+var {{ .Name }}ColumnTypes = internal.{{ .Name }}ColumnTypes
+
+// {{ .Name }}UpdateWhere applies the changes configured by set to every {{ .Name }} row matching the filters configured by where
+var {{ .Name }}UpdateWhere = internal.{{ .Name }}UpdateWhere
+
+// {{ .Name }}CountDistinct counts the distinct non-NULL values of field among the {{ .Name }} rows matching filter
+var {{ .Name }}CountDistinct = internal.{{ .Name }}CountDistinct
+
+// {{ .Name }}Page is one page of {{ .Name }} rows with the total count and page metadata
+type {{ .Name }}Page = internal.{{ .Name }}Page
+
+// {{ .Name }}FindPage returns the page of {{ .Name }} rows matching filter selected by Paginate, with the total count
+var {{ .Name }}FindPage = internal.{{ .Name }}FindPage
+
+{{- with .FindByPrimaryKey }}
+
+// {{ .Name }} looks a record up by its primary key
+var {{ .Name }} = internal.{{ .Name }}
+{{- end }}
+
+{{- range .FindByUniqueIndex }}
+
+// {{ .Name }} looks a record up by the columns of a unique index
+var {{ .Name }} = internal.{{ .Name }}
+{{- end }}
+
+{{- if .TimeFields }}
+
+// {{ .Name }}CountByMonth counts {{ .Name }} rows matching filter grouped by the "YYYY-MM" month of field
+var {{ .Name }}CountByMonth = internal.{{ .Name }}CountByMonth
+{{- end }}
+
+{{- if .NumericFields }}
+
+// {{ .Name }}Sum returns the sum of field over the {{ .Name }} rows matching filter
+var {{ .Name }}Sum = internal.{{ .Name }}Sum
+
+// {{ .Name }}Avg returns the average of field over the {{ .Name }} rows matching filter
+var {{ .Name }}Avg = internal.{{ .Name }}Avg
+
+// {{ .Name }}Min returns the smallest value of field over the {{ .Name }} rows matching filter
+var {{ .Name }}Min = internal.{{ .Name }}Min
+
+// {{ .Name }}Max returns the largest value of field over the {{ .Name }} rows matching filter
+var {{ .Name }}Max = internal.{{ .Name }}Max
+{{- end }}
+
 {{- end }}
 `
@@ -197,13 +197,11 @@ func TestTemplate_MethodGeneration(t *testing.T) {
 						Receiver:   "p *ProductFilters",
 						Parameters: "price decimal.Decimal",
 						ReturnType: "*ProductFilters",
-						Body: `p.filters[ProductDBSchema.Price] = append(p.filters[ProductDBSchema.Price], 
-	&repository.Filter{
-		Field:    string(ProductDBSchema.Price),
-		Operator: repository.OperatorGreaterThan,
-		Value:    price,
-	})
-return p`,
+						Body: `return p.addFilter(ProductDBSchema.Price, &repository.Filter{
+	Field:    string(ProductDBSchema.Price),
+	Operator: repository.OperatorGreaterThan,
+	Value:    price,
+})`,
 						Documentation: "PriceGt filters by price greater than",
 					},
 				},